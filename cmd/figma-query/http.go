@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// healthResponse is the body returned by GET /health.
+type healthResponse struct {
+	Status  string `json:"status"`
+	Auth    string `json:"auth"`
+	Version string `json:"version"`
+	Error   string `json:"error,omitempty"`
+}
+
+// healthHandler reports whether the server can reach Figma. Without a client
+// it is degraded. With checkFileKey set, the token is also verified with a
+// shallow request for that file.
+func healthHandler(client *figma.Client, checkFileKey string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp := healthResponse{Status: "ok", Auth: "configured", Version: serverVersion}
+
+		switch {
+		case client == nil:
+			resp.Status, resp.Auth = "degraded", "missing"
+		case checkFileKey != "":
+			ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+			defer cancel()
+			if _, err := client.GetFile(ctx, checkFileKey, &figma.GetFileOptions{Depth: 1}); err != nil {
+				resp.Status, resp.Auth, resp.Error = "degraded", "invalid", err.Error()
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Status != "ok" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// figmaTokenHeader carries a Figma access token on the request that starts
+// an MCP session. The session then uses that token instead of the server's.
+const figmaTokenHeader = "X-Figma-Token"
+
+const (
+	// shutdownTimeout bounds how long open requests may run after a signal.
+	shutdownTimeout = 10 * time.Second
+	// sessionIdleTimeout closes sessions whose client has gone away.
+	sessionIdleTimeout = 30 * time.Minute
+)
+
+// sessionExportDir returns the export directory of sessions using token: a
+// sub-directory of exportDir named by a hash of the token. Exports of the
+// server's own token live directly in exportDir, and cache readers only look
+// one level down, so neither sees the other's exports.
+func sessionExportDir(exportDir, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return filepath.Join(exportDir, "token-"+hex.EncodeToString(sum[:])[:12])
+}
+
+// sessionServer picks the MCP server for a new session: one built by
+// newServer for the request's X-Figma-Token, or the shared server.
+func sessionServer(server *mcp.Server, newServer func(token string) *mcp.Server) func(*http.Request) *mcp.Server {
+	return func(req *http.Request) *mcp.Server {
+		if token := strings.TrimSpace(req.Header.Get(figmaTokenHeader)); token != "" {
+			return newServer(token)
+		}
+		return server
+	}
+}
+
+// requireSessionToken rejects requests that would start a session without an
+// X-Figma-Token header, which would otherwise run on the server's own token
+// and export directory. Requests of existing sessions carry Mcp-Session-Id
+// and are checked by the MCP handler.
+func requireSessionToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Mcp-Session-Id") == "" && strings.TrimSpace(req.Header.Get(figmaTokenHeader)) == "" {
+			http.Error(w, "X-Figma-Token header required: the server's own token is only used by clients on a loopback address", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local
+// connections. An empty host listens on every interface.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// runHTTP serves the MCP server over the streamable HTTP transport on addr,
+// with a health endpoint at /health, until ctx is done. Sessions started with
+// an X-Figma-Token header get their own server from newServer; sessions
+// without one get server only when addr is a loopback address. On shutdown,
+// open requests get shutdownTimeout to finish before connections are closed.
+func runHTTP(ctx context.Context, addr string, server *mcp.Server, newServer func(token string) *mcp.Server, client *figma.Client, checkFileKey string) error {
+	var handler http.Handler = mcp.NewStreamableHTTPHandler(sessionServer(server, newServer), &mcp.StreamableHTTPOptions{
+		SessionTimeout: sessionIdleTimeout,
+	})
+	if !isLoopbackAddr(addr) {
+		handler = requireSessionToken(handler)
+		debugLog.Printf("Listening on a non-loopback address: sessions must send %s", figmaTokenHeader)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /health", healthHandler(client, checkFileKey))
+	mux.Handle("/", handler)
+
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- httpServer.ListenAndServe()
+	}()
+
+	debugLog.Printf("Listening for MCP over HTTP on %s", addr)
+	select {
+	case err := <-errc:
+		return fmt.Errorf("http server: %w", err)
+	case <-ctx.Done():
+	}
+
+	debugLog.Printf("Shutting down HTTP server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		// Event streams stay open until their client disconnects
+		debugLog.Printf("Closing connections still open after %s: %v", shutdownTimeout, err)
+		httpServer.Close()
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server: %w", err)
+	}
+	return nil
+}
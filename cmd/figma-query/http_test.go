@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func init() {
+	debugLog = log.New(io.Discard, "", 0)
+}
+
+func testMCPServer() *mcp.Server {
+	return mcp.NewServer(&mcp.Implementation{Name: serverName, Version: serverVersion}, nil)
+}
+
+func TestSessionExportDir(t *testing.T) {
+	a := sessionExportDir("/exports", "figd_secret_a")
+	if filepath.Dir(a) != "/exports" || !strings.HasPrefix(filepath.Base(a), "token-") || len(filepath.Base(a)) != len("token-")+12 {
+		t.Errorf("sessionExportDir = %q, want /exports/token-<12 hex>", a)
+	}
+	if strings.Contains(a, "secret") {
+		t.Errorf("sessionExportDir %q contains the token", a)
+	}
+	if a != sessionExportDir("/exports", "figd_secret_a") {
+		t.Error("sessionExportDir is not stable for a token")
+	}
+	if a == sessionExportDir("/exports", "figd_secret_b") {
+		t.Error("different tokens share an export directory")
+	}
+}
+
+func TestSessionServer(t *testing.T) {
+	shared := testMCPServer()
+	var tokens []string
+	pick := sessionServer(shared, func(token string) *mcp.Server {
+		tokens = append(tokens, token)
+		return testMCPServer()
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	if pick(req) != shared || len(tokens) != 0 {
+		t.Error("request without X-Figma-Token did not get the shared server")
+	}
+
+	req.Header.Set(figmaTokenHeader, "  session-token ")
+	if s := pick(req); s == shared || s == nil {
+		t.Error("request with X-Figma-Token got the shared server")
+	}
+	if len(tokens) != 1 || tokens[0] != "session-token" {
+		t.Errorf("newServer tokens = %q, want [session-token]", tokens)
+	}
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:8080": true,
+		"localhost:8080": true,
+		"[::1]:8080":     true,
+		":8080":          false,
+		"0.0.0.0:8080":   false,
+		"[::]:8080":      false,
+		"10.0.0.5:8080":  false,
+		"garbage":        false,
+	}
+	for addr, want := range tests {
+		if got := isLoopbackAddr(addr); got != want {
+			t.Errorf("isLoopbackAddr(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestRequireSessionToken(t *testing.T) {
+	handler := requireSessionToken(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"no token", nil, http.StatusUnauthorized},
+		{"blank token", map[string]string{figmaTokenHeader: " "}, http.StatusUnauthorized},
+		{"token", map[string]string{figmaTokenHeader: "session-token"}, http.StatusNoContent},
+		{"existing session", map[string]string{"Mcp-Session-Id": "abc"}, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+// freeAddr returns a local address nothing is listening on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+// waitForHealth polls /health until the server answers.
+func waitForHealth(t *testing.T, addr string) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if resp, err := http.Get("http://" + addr + "/health"); err == nil {
+			resp.Body.Close()
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("server on %s never answered /health", addr)
+}
+
+func TestRunHTTP(t *testing.T) {
+	addr := freeAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var tokens []string
+	newServer := func(token string) *mcp.Server {
+		mu.Lock()
+		tokens = append(tokens, token)
+		mu.Unlock()
+		return testMCPServer()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runHTTP(ctx, addr, testMCPServer(), newServer, nil, "")
+	}()
+	waitForHealth(t, addr)
+
+	// A session started with X-Figma-Token gets a server for that token.
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`
+	req, _ := http.NewRequest(http.MethodPost, "http://"+addr+"/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	req.Header.Set(figmaTokenHeader, "session-token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Mcp-Session-Id") == "" {
+		t.Errorf("initialize = %s, session %q", resp.Status, resp.Header.Get("Mcp-Session-Id"))
+	}
+	mu.Lock()
+	if len(tokens) != 1 || tokens[0] != "session-token" {
+		t.Errorf("newServer tokens = %q, want [session-token]", tokens)
+	}
+	mu.Unlock()
+
+	// Cancelling the context shuts the server down cleanly.
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("runHTTP after shutdown = %v, want nil", err)
+		}
+	case <-time.After(shutdownTimeout + 5*time.Second):
+		t.Fatal("runHTTP did not return after the context was cancelled")
+	}
+	if _, err := http.Get("http://" + addr + "/health"); err == nil {
+		t.Error("server still answering after shutdown")
+	}
+}
+
+func TestRunHTTPListenError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// The address is taken, so runHTTP fails without waiting for ctx.
+	err = runHTTP(context.Background(), l.Addr().String(), testMCPServer(), nil, nil, "")
+	if err == nil || !strings.Contains(err.Error(), "http server") {
+		t.Errorf("runHTTP on a used address = %v, want http server error", err)
+	}
+}
@@ -3,14 +3,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/analytics"
 	"github.com/standardbeagle/figma-query/internal/figma"
 	"github.com/standardbeagle/figma-query/internal/tools"
 )
@@ -45,13 +50,73 @@ func initDebugLog() {
 	debugLog.Printf("Args: %v", os.Args)
 }
 
+// runStats implements the "stats" subcommand, summarizing an analytics log.
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	analyticsPath := fs.String("analytics-path", os.Getenv("FIGMA_ANALYTICS_PATH"), "Path to the analytics JSONL file")
+	fs.Parse(args)
+
+	if *analyticsPath == "" {
+		return fmt.Errorf("--analytics-path is required")
+	}
+
+	f, err := os.Open(*analyticsPath)
+	if err != nil {
+		return fmt.Errorf("opening analytics log: %w", err)
+	}
+	defer f.Close()
+
+	events, err := analytics.ReadEvents(f)
+	if err != nil {
+		return fmt.Errorf("reading analytics log: %w", err)
+	}
+
+	fmt.Print(analytics.FormatTable(analytics.Summarize(events)))
+	return nil
+}
+
+// parseExtraHeaders parses a comma-separated Key=Value list of HTTP headers.
+func parseExtraHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header %q, want Key=Value", pair)
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		if err := runStats(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize debug logging first (writes to ~/.figma-query-debug.log)
 	initDebugLog()
 
 	// Parse CLI flags
 	showVersion := flag.Bool("version", false, "Show version and exit")
+	versionCheck := flag.Bool("version-check", false, "Show version, check GitHub for a newer release and exit")
 	showHelp := flag.Bool("help", false, "Show help and exit")
+	analyticsPath := flag.String("analytics-path", os.Getenv("FIGMA_ANALYTICS_PATH"), "Append tool usage analytics to this JSONL file")
+	runOAuthFlow := flag.Bool("oauth", false, "Authorize with Figma OAuth, store the token in ~/.figma-query-token and exit")
+	outputDir := flag.String("output-dir", "", "Base export directory for all tools (overrides FIGMA_EXPORT_DIR)")
+	oauthPort := flag.Int("oauth-port", 8976, "Local port for the OAuth callback (redirect URI http://localhost:<port>/callback)")
+	transport := flag.String("transport", "stdio", "MCP transport: stdio or http (streamable HTTP)")
+	listenAddr := flag.String("listen", "127.0.0.1:8080", "Address to listen on with --transport=http; off loopback, sessions must send X-Figma-Token")
+	httpAddr := flag.String("http-addr", "", "Serve MCP over streamable HTTP on this address (same as --transport=http --listen <addr>)")
+	profileDir := flag.String("profile", "", "Write pprof CPU and heap profiles (cpu.prof, mem.prof) to this directory on exit")
 	flag.Parse()
 
 	debugLog.Printf("Flags parsed: version=%v, help=%v", *showVersion, *showHelp)
@@ -60,17 +125,34 @@ func main() {
 		fmt.Printf(`%s v%s - Token-efficient MCP server for Figma
 
 Usage: %s [options]
+       %s stats --analytics-path <file>
+       %s --oauth [--oauth-port <port>]
 
-This server runs on stdio transport for MCP clients.
+This server runs on stdio transport for MCP clients, or over streamable
+HTTP with --transport=http --listen <addr> (GET /health reports readiness).
+In HTTP mode a session started with an X-Figma-Token header uses that token
+for Figma requests instead of the server's. The server's own token is only
+offered to sessions without the header when listening on a loopback address
+(the default, 127.0.0.1:8080).
+The stats subcommand summarizes an analytics log by call frequency.
+--oauth runs the OAuth authorization flow and stores the token for later runs.
 
 Environment Variables:
   FIGMA_ACCESS_TOKEN          Figma personal access token (required for API)
   FIGMA_TOKEN                 Alternative name for access token
   FIGMA_PERSONAL_ACCESS_TOKEN Alternative name for access token
-  FIGMA_EXPORT_DIR            Directory for file exports (default: ./figma-export)
+  FIGMA_EXPORT_DIR            Directory for file exports (default: ./figma-export, overridden by --output-dir)
+  FIGMA_ANALYTICS_PATH        Default for --analytics-path
+  FIGMA_CLIENT_ID             OAuth app client ID (for --oauth and token refresh)
+  FIGMA_CLIENT_SECRET         OAuth app client secret
+  FIGMA_HEALTH_FILE_KEY       File fetched by /health to verify the token (HTTP mode)
+  FIGMA_TEAM_ID               Team whose files are offered when a tool call omits file_key
+  FIGMA_PROJECT_ID            Project whose files are offered instead of the whole team's
+  FIGMA_DEFAULT_FILE_KEY      File used when a tool call omits file_key
+  FIGMA_EXTRA_HEADERS         Extra headers for every API request, e.g. X-Proxy-Authorization=Basic abc,X-Team=design
 
 Options:
-`, serverName, serverVersion, os.Args[0])
+`, serverName, serverVersion, os.Args[0], os.Args[0], os.Args[0])
 		flag.PrintDefaults()
 		os.Exit(0)
 	}
@@ -80,6 +162,46 @@ Options:
 		os.Exit(0)
 	}
 
+	if *versionCheck {
+		printVersionCheck()
+		os.Exit(0)
+	}
+
+	// Both transports stop on SIGINT or SIGTERM and return from main, so
+	// deferred cleanup such as writing profiles and closing the analytics log
+	// still runs.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *profileDir != "" {
+		stopProfiling, err := startProfiling(*profileDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer stopProfiling()
+	}
+
+	addr := *httpAddr
+	switch *transport {
+	case "stdio":
+	case "http":
+		if addr == "" {
+			addr = *listenAddr
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown transport %q, want stdio or http\n", *transport)
+		os.Exit(1)
+	}
+
+	if *runOAuthFlow {
+		if err := runOAuth(*oauthPort); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Get Figma access token from environment
 	accessToken := os.Getenv("FIGMA_ACCESS_TOKEN")
 	if accessToken == "" {
@@ -95,12 +217,29 @@ Options:
 	if accessToken != "" {
 		figmaClient = figma.NewClient(accessToken)
 		debugLog.Printf("Figma client created with token")
+	} else if figmaClient = loadOAuthClient(*oauthPort); figmaClient != nil {
+		debugLog.Printf("Figma client created with stored OAuth token")
 	} else {
 		debugLog.Printf("No Figma token - client will be nil")
 	}
+	var extraHeaders map[string]string
+	if extra := os.Getenv("FIGMA_EXTRA_HEADERS"); extra != "" {
+		headers, err := parseExtraHeaders(extra)
+		if err != nil {
+			log.Fatalf("FIGMA_EXTRA_HEADERS: %v", err)
+		}
+		extraHeaders = headers
+	}
+	if extraHeaders != nil && figmaClient != nil {
+		figmaClient.WithCustomHeaders(extraHeaders)
+		debugLog.Printf("Sending %d extra headers with API requests", len(extraHeaders))
+	}
 
-	// Get export directory from environment or use default
-	exportDir := os.Getenv("FIGMA_EXPORT_DIR")
+	// Get export directory from flag, environment or use default
+	exportDir := *outputDir
+	if exportDir == "" {
+		exportDir = os.Getenv("FIGMA_EXPORT_DIR")
+	}
 	if exportDir == "" {
 		exportDir = "./figma-export"
 	}
@@ -109,14 +248,34 @@ Options:
 	// Create tool registry
 	debugLog.Printf("Creating tool registry...")
 	registry := tools.NewRegistry(figmaClient, exportDir)
+	registry.SetServerVersion(serverVersion)
+	registry.SetFileDiscovery(tools.FileDiscovery{
+		TeamID:         os.Getenv("FIGMA_TEAM_ID"),
+		ProjectID:      os.Getenv("FIGMA_PROJECT_ID"),
+		DefaultFileKey: os.Getenv("FIGMA_DEFAULT_FILE_KEY"),
+	})
+	registry.SetProgressCallback(func(page string, nodesExported, totalEstimate int) {
+		debugLog.Printf("sync progress: page %q done, %d/%d nodes", page, nodesExported, totalEstimate)
+	})
+
+	if *analyticsPath != "" {
+		writer, err := analytics.NewJSONLWriter(*analyticsPath)
+		if err != nil {
+			log.Fatalf("Analytics error: %v", err)
+		}
+		defer writer.Close()
+		registry.SetAnalytics(writer)
+		debugLog.Printf("Analytics enabled: %s", *analyticsPath)
+	}
 
 	// Create MCP server
 	// Using nil ServerOptions like test-mcp which works with Claude Code
 	debugLog.Printf("Creating MCP server with nil ServerOptions")
-	server := mcp.NewServer(&mcp.Implementation{
+	impl := &mcp.Implementation{
 		Name:    serverName,
 		Version: serverVersion,
-	}, nil)
+	}
+	server := mcp.NewServer(impl, nil)
 	debugLog.Printf("MCP server created")
 
 	// Register all tools
@@ -124,9 +283,31 @@ Options:
 	registry.RegisterTools(server)
 	debugLog.Printf("Tools registered")
 
+	if addr != "" {
+		// HTTP sessions may bring their own token; each gets a server whose
+		// registry uses a client and an export directory for that token.
+		newServer := func(token string) *mcp.Server {
+			client := figma.NewClient(token)
+			if extraHeaders != nil {
+				client.WithCustomHeaders(extraHeaders)
+			}
+			s := mcp.NewServer(impl, nil)
+			registry.WithClient(client, sessionExportDir(exportDir, token)).RegisterTools(s)
+			debugLog.Printf("Created server for a session with its own Figma token")
+			return s
+		}
+
+		if err := runHTTP(ctx, addr, server, newServer, figmaClient, os.Getenv("FIGMA_HEALTH_FILE_KEY")); err != nil {
+			debugLog.Printf("Server error: %v", err)
+			log.Fatalf("Server error: %v", err)
+		}
+		debugLog.Printf("Server stopped")
+		return
+	}
+
 	// Run server on stdio transport
 	debugLog.Printf("Starting server on stdio transport...")
-	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && !errors.Is(err, context.Canceled) {
 		debugLog.Printf("Server error: %v", err)
 		log.Fatalf("Server error: %v", err)
 	}
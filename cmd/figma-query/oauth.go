@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// oauthTimeout bounds how long --oauth waits for the browser callback.
+const oauthTimeout = 5 * time.Minute
+
+// oauthTokenPath returns where --oauth stores the token (~/.figma-query-token).
+func oauthTokenPath() string {
+	home := os.Getenv("HOME")
+	if home == "" {
+		home = "/tmp"
+	}
+	return filepath.Join(home, ".figma-query-token")
+}
+
+// oauthConfig builds the OAuth app config from FIGMA_CLIENT_ID and
+// FIGMA_CLIENT_SECRET, with the redirect URI on the local callback port.
+func oauthConfig(port int) figma.OAuth2Config {
+	return figma.OAuth2Config{
+		ClientID:     os.Getenv("FIGMA_CLIENT_ID"),
+		ClientSecret: os.Getenv("FIGMA_CLIENT_SECRET"),
+		RedirectURI:  fmt.Sprintf("http://localhost:%d/callback", port),
+	}
+}
+
+// runOAuth implements --oauth: it prints the authorization URL, waits for
+// Figma to redirect back to a local callback server, exchanges the code and
+// stores the token.
+func runOAuth(port int) error {
+	cfg := oauthConfig(port)
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return fmt.Errorf("FIGMA_CLIENT_ID and FIGMA_CLIENT_SECRET are required for --oauth")
+	}
+
+	stateBytes := make([]byte, 16)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return fmt.Errorf("generating state: %w", err)
+	}
+	state := hex.EncodeToString(stateBytes)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return fmt.Errorf("starting callback server: %w", err)
+	}
+
+	type callback struct {
+		code string
+		err  error
+	}
+	done := make(chan callback, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		var cb callback
+		switch {
+		case q.Get("state") != state:
+			cb.err = fmt.Errorf("state mismatch in OAuth callback")
+		case q.Get("error") != "":
+			cb.err = fmt.Errorf("authorization denied: %s", q.Get("error"))
+		case q.Get("code") == "":
+			cb.err = fmt.Errorf("OAuth callback has no code")
+		default:
+			cb.code = q.Get("code")
+		}
+
+		if cb.err != nil {
+			http.Error(w, cb.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "figma-query is authorized. You can close this window.")
+		}
+		select {
+		case done <- cb:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	fmt.Fprintf(os.Stderr, "Open this URL to authorize figma-query:\n\n  %s\n\nWaiting for callback on %s ...\n", cfg.AuthCodeURL(state), cfg.RedirectURI)
+
+	var cb callback
+	select {
+	case cb = <-done:
+	case <-time.After(oauthTimeout):
+		return fmt.Errorf("timed out waiting for OAuth callback")
+	}
+	if cb.err != nil {
+		return cb.err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), figma.DefaultTimeout)
+	defer cancel()
+	token, err := cfg.ExchangeCode(ctx, cb.code)
+	if err != nil {
+		return fmt.Errorf("exchanging code: %w", err)
+	}
+
+	path := oauthTokenPath()
+	if err := figma.SaveOAuthToken(path, token); err != nil {
+		return fmt.Errorf("saving token: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Token saved to %s\n", path)
+	return nil
+}
+
+// loadOAuthClient returns a client for a token stored by --oauth, or nil if
+// there is none. Refreshed tokens are written back to the token file.
+func loadOAuthClient(port int) *figma.Client {
+	path := oauthTokenPath()
+	token, err := figma.LoadOAuthToken(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			debugLog.Printf("Ignoring OAuth token: %v", err)
+		}
+		return nil
+	}
+
+	cfg := oauthConfig(port)
+	if cfg.ClientID == "" {
+		debugLog.Printf("FIGMA_CLIENT_ID not set - OAuth token cannot be refreshed")
+	}
+	cfg.OnTokenRefresh = func(t *figma.OAuthToken) {
+		if err := figma.SaveOAuthToken(path, t); err != nil {
+			debugLog.Printf("Saving refreshed OAuth token: %v", err)
+		}
+	}
+	return figma.NewOAuthClient(cfg, token)
+}
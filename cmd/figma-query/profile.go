@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+)
+
+// Profile file names written to the --profile directory.
+const (
+	cpuProfileFile = "cpu.prof"
+	memProfileFile = "mem.prof"
+)
+
+// startProfiling starts a CPU profile in dir and returns a function that
+// stops it and writes a heap profile next to it. main defers the returned
+// function, so the profiles are complete once the server shuts down,
+// including after SIGINT or SIGTERM.
+func startProfiling(dir string) (func(), error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating profile directory: %w", err)
+	}
+
+	cpuPath := filepath.Join(dir, cpuProfileFile)
+	cpuFile, err := os.Create(cpuPath)
+	if err != nil {
+		return nil, fmt.Errorf("creating CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		cpuFile.Close()
+		return nil, fmt.Errorf("starting CPU profile: %w", err)
+	}
+	debugLog.Printf("Writing CPU and memory profiles to %s", dir)
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			pprof.StopCPUProfile()
+			cpuFile.Close()
+			if err := writeHeapProfile(filepath.Join(dir, memProfileFile)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		})
+	}
+	return stop, nil
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating memory profile: %w", err)
+	}
+	defer f.Close()
+
+	runtime.GC() // report live objects, not garbage awaiting collection
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return fmt.Errorf("writing memory profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStartProfiling(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+
+	stop, err := startProfiling(dir)
+	if err != nil {
+		t.Fatalf("startProfiling: %v", err)
+	}
+	stop()
+	stop() // a second call must not write again or panic
+
+	for _, name := range []string{cpuProfileFile, memProfileFile} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		} else if info.Size() == 0 {
+			t.Errorf("%s is empty", name)
+		}
+	}
+}
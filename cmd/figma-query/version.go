@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// latestReleaseURL is the GitHub API endpoint for the newest release.
+const latestReleaseURL = "https://api.github.com/repos/standardbeagle/figma-query/releases/latest"
+
+// versionCheckTimeout bounds the release lookup so --version-check never hangs.
+const versionCheckTimeout = 3 * time.Second
+
+// printVersionCheck prints the server version and whether a newer release
+// exists. If the latest release cannot be fetched, only the version is
+// printed.
+func printVersionCheck() {
+	fmt.Printf("%s v%s\n", serverName, serverVersion)
+
+	ctx, cancel := context.WithTimeout(context.Background(), versionCheckTimeout)
+	defer cancel()
+
+	latest, releaseURL, err := latestRelease(ctx)
+	if err != nil {
+		debugLog.Printf("Version check failed: %v", err)
+		return
+	}
+
+	if compareVersions(latest, serverVersion) > 0 {
+		fmt.Printf("A newer version is available: %s (%s)\n", latest, releaseURL)
+	} else {
+		fmt.Printf("Up to date (latest release: %s)\n", latest)
+	}
+}
+
+// latestRelease returns the tag name and page URL of the latest GitHub release.
+func latestRelease(ctx context.Context) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", serverName+"/"+serverVersion)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("GitHub API returned %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", "", fmt.Errorf("parsing release: %w", err)
+	}
+	if release.TagName == "" {
+		return "", "", fmt.Errorf("release has no tag_name")
+	}
+	return release.TagName, release.HTMLURL, nil
+}
+
+// compareVersions compares two semantic versions such as v1.2.3 or
+// 1.2.3-beta.1 and returns -1, 0 or 1. A pre-release sorts before the
+// release of the same version; pre-release identifiers are compared as
+// strings.
+func compareVersions(a, b string) int {
+	aCore, aPre := splitVersion(a)
+	bCore, bPre := splitVersion(b)
+
+	for i := 0; i < 3; i++ {
+		if aCore[i] != bCore[i] {
+			if aCore[i] < bCore[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	case aPre < bPre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// splitVersion parses "v1.2.3-rc.1+build" into [1 2 3] and "rc.1". Missing or
+// malformed numbers count as 0.
+func splitVersion(v string) ([3]int, string) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	var pre string
+	if i := strings.Index(v, "-"); i >= 0 {
+		v, pre = v[:i], v[i+1:]
+	}
+
+	var core [3]int
+	for i, part := range strings.SplitN(v, ".", 3) {
+		core[i], _ = strconv.Atoi(part)
+	}
+	return core, pre
+}
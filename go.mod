@@ -2,10 +2,14 @@ module github.com/standardbeagle/figma-query
 
 go 1.24.2
 
-require github.com/modelcontextprotocol/go-sdk v1.1.0
+require (
+	github.com/google/jsonschema-go v0.3.0
+	github.com/modelcontextprotocol/go-sdk v1.1.0
+	go.etcd.io/bbolt v1.4.3
+)
 
 require (
-	github.com/google/jsonschema-go v0.3.0 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 	golang.org/x/oauth2 v0.30.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
 )
@@ -0,0 +1,160 @@
+// Package analytics records tool usage for figma-query operators.
+package analytics
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event is a single tool invocation.
+type Event struct {
+	Tool       string    `json:"tool"`
+	Timestamp  time.Time `json:"timestamp"`
+	DurationMS int64     `json:"duration_ms"`
+	Error      bool      `json:"error"`
+}
+
+// Writer records tool invocation events.
+type Writer interface {
+	Record(event Event) error
+}
+
+// JSONLWriter appends events to a JSON Lines file.
+type JSONLWriter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewJSONLWriter opens (or creates) the analytics log at path for appending.
+func NewJSONLWriter(path string) (*JSONLWriter, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening analytics log: %w", err)
+	}
+	return &JSONLWriter{file: f}, nil
+}
+
+// Record appends an event as a single JSON line.
+func (w *JSONLWriter) Record(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.file.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the underlying file.
+func (w *JSONLWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// ToolStats summarizes invocations of a single tool.
+type ToolStats struct {
+	Tool      string
+	Calls     int
+	Errors    int
+	TotalMS   int64
+	MaxMS     int64
+	FirstCall time.Time
+	LastCall  time.Time
+}
+
+// AvgMS returns the mean invocation duration in milliseconds.
+func (s ToolStats) AvgMS() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.TotalMS) / float64(s.Calls)
+}
+
+// ReadEvents parses a JSONL analytics log. Malformed lines are skipped.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+// Summarize aggregates events per tool, sorted by call count (descending).
+func Summarize(events []Event) []ToolStats {
+	byTool := make(map[string]*ToolStats)
+	for _, e := range events {
+		s, ok := byTool[e.Tool]
+		if !ok {
+			s = &ToolStats{Tool: e.Tool, FirstCall: e.Timestamp, LastCall: e.Timestamp}
+			byTool[e.Tool] = s
+		}
+		s.Calls++
+		if e.Error {
+			s.Errors++
+		}
+		s.TotalMS += e.DurationMS
+		if e.DurationMS > s.MaxMS {
+			s.MaxMS = e.DurationMS
+		}
+		if e.Timestamp.Before(s.FirstCall) {
+			s.FirstCall = e.Timestamp
+		}
+		if e.Timestamp.After(s.LastCall) {
+			s.LastCall = e.Timestamp
+		}
+	}
+
+	stats := make([]ToolStats, 0, len(byTool))
+	for _, s := range byTool {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Calls != stats[j].Calls {
+			return stats[i].Calls > stats[j].Calls
+		}
+		return stats[i].Tool < stats[j].Tool
+	})
+	return stats
+}
+
+// FormatTable renders a summary table sorted by call frequency.
+func FormatTable(stats []ToolStats) string {
+	var sb strings.Builder
+
+	total := 0
+	for _, s := range stats {
+		total += s.Calls
+	}
+
+	sb.WriteString(fmt.Sprintf("%-20s %8s %8s %10s %10s %8s\n", "TOOL", "CALLS", "ERRORS", "AVG_MS", "MAX_MS", "SHARE"))
+	for _, s := range stats {
+		share := 0.0
+		if total > 0 {
+			share = float64(s.Calls) / float64(total) * 100
+		}
+		sb.WriteString(fmt.Sprintf("%-20s %8d %8d %10.1f %10d %7.1f%%\n",
+			s.Tool, s.Calls, s.Errors, s.AvgMS(), s.MaxMS, share))
+	}
+	sb.WriteString(fmt.Sprintf("\nTotal: %d calls across %d tools\n", total, len(stats)))
+
+	return sb.String()
+}
@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONLWriterRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics.jsonl")
+
+	w, err := NewJSONLWriter(path)
+	if err != nil {
+		t.Fatalf("NewJSONLWriter failed: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	events := []Event{
+		{Tool: "query", Timestamp: now, DurationMS: 10},
+		{Tool: "info", Timestamp: now.Add(time.Second), DurationMS: 2},
+		{Tool: "query", Timestamp: now.Add(2 * time.Second), DurationMS: 30, Error: true},
+	}
+	for _, e := range events {
+		if err := w.Record(e); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer f.Close()
+
+	got, err := ReadEvents(f)
+	if err != nil {
+		t.Fatalf("ReadEvents failed: %v", err)
+	}
+	if len(got) != len(events) {
+		t.Fatalf("expected %d events, got %d", len(events), len(got))
+	}
+	if !got[0].Timestamp.Equal(now) {
+		t.Errorf("timestamp = %v, want %v", got[0].Timestamp, now)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	input := `{"tool":"info","timestamp":"2025-01-01T00:00:00Z","duration_ms":1,"error":false}
+{"tool":"query","timestamp":"2025-01-01T00:00:01Z","duration_ms":10,"error":false}
+not json
+{"tool":"query","timestamp":"2025-01-01T00:00:02Z","duration_ms":30,"error":true}
+`
+	events, err := ReadEvents(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadEvents failed: %v", err)
+	}
+
+	stats := Summarize(events)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(stats))
+	}
+
+	q := stats[0]
+	if q.Tool != "query" || q.Calls != 2 || q.Errors != 1 || q.MaxMS != 30 || q.AvgMS() != 20 {
+		t.Errorf("unexpected query stats: %+v", q)
+	}
+	if stats[1].Tool != "info" {
+		t.Errorf("expected info second, got %s", stats[1].Tool)
+	}
+
+	table := FormatTable(stats)
+	if !strings.Contains(table, "Total: 3 calls across 2 tools") {
+		t.Errorf("unexpected table:\n%s", table)
+	}
+}
@@ -2,6 +2,7 @@
 package figma
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -22,39 +23,107 @@ type Client struct {
 	httpClient  *http.Client
 	accessToken string
 	baseURL     string
+	oauth       *oauthSession     // set for OAuth clients instead of accessToken
+	headers     map[string]string // extra headers sent with every API request
+	timeout     time.Duration     // default request timeout
+	timeouts    TimeoutConfig
+}
+
+// TimeoutConfig sets the timeouts of individual operations. A zero duration
+// uses the client's timeout. Timeouts only apply when the context passed to
+// a method has no deadline of its own.
+type TimeoutConfig struct {
+	GetFile      time.Duration // GetFile and GetFileNodes
+	GetImages    time.Duration // GetImages, GetImageFills and DownloadImage
+	GetVariables time.Duration // GetLocalVariables and PostVariables
 }
 
 // NewClient creates a new Figma API client.
 func NewClient(accessToken string) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
+		httpClient:  &http.Client{},
 		accessToken: accessToken,
 		baseURL:     BaseURL,
+		timeout:     DefaultTimeout,
 	}
 }
 
 // WithTimeout sets a custom timeout for the client.
 func (c *Client) WithTimeout(timeout time.Duration) *Client {
-	c.httpClient.Timeout = timeout
+	c.timeout = timeout
+	return c
+}
+
+// WithTimeouts sets per-operation timeouts, e.g. a long GetFile timeout for
+// large files alongside a short GetVariables timeout.
+func (c *Client) WithTimeouts(cfg TimeoutConfig) *Client {
+	c.timeouts = cfg
+	return c
+}
+
+// requestContext bounds ctx by timeout, or by the client's timeout when
+// timeout is zero. A context that already has a deadline is returned as is.
+func (c *Client) requestContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		timeout = c.timeout
+	}
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// WithCustomHeaders adds headers to every API request, such as the
+// authorization header of an enterprise proxy. They are set after the Figma
+// authentication headers.
+func (c *Client) WithCustomHeaders(headers map[string]string) *Client {
+	if c.headers == nil {
+		c.headers = make(map[string]string, len(headers))
+	}
+	for k, v := range headers {
+		c.headers[k] = v
+	}
 	return c
 }
 
 // doRequest performs an authenticated HTTP request.
 func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values) ([]byte, error) {
+	return c.doRequestBody(ctx, method, path, query, nil)
+}
+
+// doRequestBody performs an authenticated HTTP request with a JSON body.
+func (c *Client) doRequestBody(ctx context.Context, method, path string, query url.Values, body []byte) ([]byte, error) {
+	ctx, cancel := c.requestContext(ctx, 0)
+	defer cancel()
+
 	u := c.baseURL + path
 	if len(query) > 0 {
 		u += "?" + query.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("X-Figma-Token", c.accessToken)
+	if c.oauth != nil {
+		token, err := c.oauth.accessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		req.Header.Set("X-Figma-Token", c.accessToken)
+	}
 	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -62,7 +131,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, query url.V
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("reading response: %w", err)
 	}
@@ -75,17 +144,20 @@ func (c *Client) doRequest(ctx context.Context, method, path string, query url.V
 
 	if resp.StatusCode != http.StatusOK {
 		var apiErr APIError
-		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Err != "" {
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Err != "" {
 			return nil, &apiErr
 		}
-		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(respBody))
 	}
 
-	return body, nil
+	return respBody, nil
 }
 
 // GetFile retrieves a Figma file by its key.
 func (c *Client) GetFile(ctx context.Context, fileKey string, opts *GetFileOptions) (*File, error) {
+	ctx, cancel := c.requestContext(ctx, c.timeouts.GetFile)
+	defer cancel()
+
 	query := url.Values{}
 	if opts != nil {
 		if opts.Version != "" {
@@ -120,6 +192,9 @@ func (c *Client) GetFile(ctx context.Context, fileKey string, opts *GetFileOptio
 
 // GetFileNodes retrieves specific nodes from a Figma file.
 func (c *Client) GetFileNodes(ctx context.Context, fileKey string, nodeIDs []string, opts *GetFileOptions) (*FileNodes, error) {
+	ctx, cancel := c.requestContext(ctx, c.timeouts.GetFile)
+	defer cancel()
+
 	query := url.Values{}
 	query.Set("ids", strings.Join(nodeIDs, ","))
 
@@ -151,8 +226,12 @@ func (c *Client) GetFileNodes(ctx context.Context, fileKey string, nodeIDs []str
 	return &nodes, nil
 }
 
-// GetImages exports images from a Figma file.
+// GetImages exports images from a Figma file. opts are sent as given; callers
+// apply NormalizeImageExportOptions first to report adjusted values.
 func (c *Client) GetImages(ctx context.Context, fileKey string, nodeIDs []string, opts *ImageExportOptions) (*ImageExport, error) {
+	ctx, cancel := c.requestContext(ctx, c.timeouts.GetImages)
+	defer cancel()
+
 	query := url.Values{}
 	query.Set("ids", strings.Join(nodeIDs, ","))
 
@@ -187,6 +266,23 @@ func (c *Client) GetImages(ctx context.Context, fileKey string, nodeIDs []string
 	return &export, nil
 }
 
+// NormalizeImageExportOptions applies the API's per-format constraints to opts
+// and returns a warning for each value it changed. PDF exports are always
+// rendered at scale 1; the API ignores any other scale.
+func NormalizeImageExportOptions(opts *ImageExportOptions) []string {
+	var warnings []string
+	if opts == nil {
+		return warnings
+	}
+
+	if opts.Format == "pdf" && opts.Scale != 0 && opts.Scale != 1 {
+		warnings = append(warnings, fmt.Sprintf("pdf exports only support scale 1; scale %g ignored", opts.Scale))
+		opts.Scale = 1
+	}
+
+	return warnings
+}
+
 // GetFileStyles retrieves styles from a Figma file.
 func (c *Client) GetFileStyles(ctx context.Context, fileKey string) (*FileStyles, error) {
 	body, err := c.doRequest(ctx, http.MethodGet, "/files/"+fileKey+"/styles", nil)
@@ -219,6 +315,9 @@ func (c *Client) GetFileComponents(ctx context.Context, fileKey string) (*FileCo
 
 // GetLocalVariables retrieves local variables from a Figma file.
 func (c *Client) GetLocalVariables(ctx context.Context, fileKey string) (*LocalVariables, error) {
+	ctx, cancel := c.requestContext(ctx, c.timeouts.GetVariables)
+	defer cancel()
+
 	body, err := c.doRequest(ctx, http.MethodGet, "/files/"+fileKey+"/variables/local", nil)
 	if err != nil {
 		return nil, err
@@ -232,9 +331,198 @@ func (c *Client) GetLocalVariables(ctx context.Context, fileKey string) (*LocalV
 	return &vars, nil
 }
 
+// PostVariables creates, updates or deletes variables in a file. payload is
+// sent as-is, e.g. {"variableModeValues": [{"variableId", "modeId", "value"}]}.
+// The token needs the file_variables:write scope.
+func (c *Client) PostVariables(ctx context.Context, fileKey string, payload interface{}) error {
+	ctx, cancel := c.requestContext(ctx, c.timeouts.GetVariables)
+	defer cancel()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding variables payload: %w", err)
+	}
+
+	_, err = c.doRequestBody(ctx, http.MethodPost, "/files/"+fileKey+"/variables", nil, body)
+	return err
+}
+
+// GetCurrentUser retrieves the user the access token belongs to.
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/me", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("parsing user response: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetFileVersions retrieves one page of a file's version history, newest
+// first. Pass the last version's ID as Before to fetch the next page.
+func (c *Client) GetFileVersions(ctx context.Context, fileKey string, opts *GetVersionsOptions) (*FileVersions, error) {
+	query := url.Values{}
+	if opts != nil {
+		if opts.PageSize > 0 {
+			query.Set("page_size", fmt.Sprintf("%d", opts.PageSize))
+		}
+		if opts.Before != "" {
+			query.Set("before", opts.Before)
+		}
+		if opts.After != "" {
+			query.Set("after", opts.After)
+		}
+	}
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/files/"+fileKey+"/versions", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions FileVersions
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, fmt.Errorf("parsing versions response: %w", err)
+	}
+
+	return &versions, nil
+}
+
+// GetComments retrieves all comments and replies on a file.
+func (c *Client) GetComments(ctx context.Context, fileKey string) (*FileComments, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/files/"+fileKey+"/comments", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments FileComments
+	if err := json.Unmarshal(body, &comments); err != nil {
+		return nil, fmt.Errorf("parsing comments response: %w", err)
+	}
+
+	return &comments, nil
+}
+
+// PostComment adds a comment to a file, or a reply when req.CommentID names
+// the root comment of a thread. The token needs the file_comments:write scope.
+func (c *Client) PostComment(ctx context.Context, fileKey string, req *CommentRequest) (*Comment, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encoding comment: %w", err)
+	}
+
+	body, err := c.doRequestBody(ctx, http.MethodPost, "/files/"+fileKey+"/comments", nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("parsing comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment deletes a comment the token's user posted.
+func (c *Client) DeleteComment(ctx context.Context, fileKey, commentID string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, "/files/"+fileKey+"/comments/"+url.PathEscape(commentID), nil)
+	return err
+}
+
+// GetTeamProjects lists the projects of a team the token can see.
+func (c *Client) GetTeamProjects(ctx context.Context, teamID string) (*TeamProjects, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/teams/"+teamID+"/projects", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects TeamProjects
+	if err := json.Unmarshal(body, &projects); err != nil {
+		return nil, fmt.Errorf("parsing projects response: %w", err)
+	}
+
+	return &projects, nil
+}
+
+// GetProjectFiles lists the files of a project.
+func (c *Client) GetProjectFiles(ctx context.Context, projectID string) (*ProjectFiles, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/projects/"+projectID+"/files", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var files ProjectFiles
+	if err := json.Unmarshal(body, &files); err != nil {
+		return nil, fmt.Errorf("parsing project files response: %w", err)
+	}
+
+	return &files, nil
+}
+
+// GetLibraryAnalytics retrieves one page of weekly insertions and
+// detachments of a library file's components. It requires an Enterprise
+// plan and the library_analytics:read scope.
+func (c *Client) GetLibraryAnalytics(ctx context.Context, fileKey string, opts *LibraryAnalyticsOptions) (*LibraryActions, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/analytics/libraries/"+fileKey+"/component/actions", libraryAnalyticsQuery(opts, true))
+	if err != nil {
+		return nil, err
+	}
+
+	var actions LibraryActions
+	if err := json.Unmarshal(body, &actions); err != nil {
+		return nil, fmt.Errorf("parsing library actions response: %w", err)
+	}
+
+	return &actions, nil
+}
+
+// GetLibraryUsages retrieves one page of how often a library file's
+// components are used across the organization.
+func (c *Client) GetLibraryUsages(ctx context.Context, fileKey string, opts *LibraryAnalyticsOptions) (*LibraryUsages, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/analytics/libraries/"+fileKey+"/component/usages", libraryAnalyticsQuery(opts, false))
+	if err != nil {
+		return nil, err
+	}
+
+	var usages LibraryUsages
+	if err := json.Unmarshal(body, &usages); err != nil {
+		return nil, fmt.Errorf("parsing library usages response: %w", err)
+	}
+
+	return &usages, nil
+}
+
+func libraryAnalyticsQuery(opts *LibraryAnalyticsOptions, dates bool) url.Values {
+	query := url.Values{}
+	groupBy := "component"
+	if opts != nil {
+		if opts.GroupBy != "" {
+			groupBy = opts.GroupBy
+		}
+		if dates && opts.StartDate != "" {
+			query.Set("start_date", opts.StartDate)
+		}
+		if dates && opts.EndDate != "" {
+			query.Set("end_date", opts.EndDate)
+		}
+		if opts.Cursor != "" {
+			query.Set("cursor", opts.Cursor)
+		}
+	}
+	query.Set("group_by", groupBy)
+	return query
+}
+
 // GetImageFills retrieves URLs for all image fills used in a Figma file.
 // Returns a map of imageRef -> URL for all images used in fills, strokes, and backgrounds.
 func (c *Client) GetImageFills(ctx context.Context, fileKey string) (map[string]string, error) {
+	ctx, cancel := c.requestContext(ctx, c.timeouts.GetImages)
+	defer cancel()
+
 	body, err := c.doRequest(ctx, http.MethodGet, "/files/"+fileKey+"/images", nil)
 	if err != nil {
 		return nil, err
@@ -260,6 +548,9 @@ func (c *Client) GetImageFills(ctx context.Context, fileKey string) (map[string]
 
 // DownloadImage downloads an image from a URL.
 func (c *Client) DownloadImage(ctx context.Context, imageURL string) ([]byte, error) {
+	ctx, cancel := c.requestContext(ctx, c.timeouts.GetImages)
+	defer cancel()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating download request: %w", err)
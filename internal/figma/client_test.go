@@ -1,7 +1,13 @@
 package figma
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -31,3 +37,320 @@ func TestRateLimitError(t *testing.T) {
 		t.Errorf("unexpected error message: %s", err.Error())
 	}
 }
+
+func TestNormalizeImageExportOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          ImageExportOptions
+		expectedScale float64
+		warnings      int
+	}{
+		{"pdf scale clamped", ImageExportOptions{Format: "pdf", Scale: 2}, 1, 1},
+		{"pdf scale 1 unchanged", ImageExportOptions{Format: "pdf", Scale: 1}, 1, 0},
+		{"pdf default scale", ImageExportOptions{Format: "pdf"}, 0, 0},
+		{"png scale kept", ImageExportOptions{Format: "png", Scale: 3}, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			warnings := NormalizeImageExportOptions(&opts)
+			if opts.Scale != tt.expectedScale {
+				t.Errorf("scale = %g, want %g", opts.Scale, tt.expectedScale)
+			}
+			if len(warnings) != tt.warnings {
+				t.Errorf("got %d warnings, want %d: %v", len(warnings), tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestGetImagesSendsOptionsAsGiven(t *testing.T) {
+	var gotScale string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotScale = r.URL.Query().Get("scale")
+		w.Write([]byte(`{"images":{"1:2":"https://example.com/a.pdf"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	opts := &ImageExportOptions{Format: "pdf", Scale: 2}
+	if _, err := client.GetImages(context.Background(), "abc", []string{"1:2"}, opts); err != nil {
+		t.Fatalf("GetImages: %v", err)
+	}
+	if opts.Scale != 2 {
+		t.Errorf("GetImages changed opts.Scale to %g; normalizing is left to the caller", opts.Scale)
+	}
+	if gotScale != "2" {
+		t.Errorf("scale query = %q, want 2", gotScale)
+	}
+}
+
+func TestPostVariables(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &gotBody)
+		if r.Header.Get("X-Figma-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"status":200,"error":false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	payload := map[string]any{
+		"variableModeValues": []map[string]any{{"variableId": "VariableID:1:2", "modeId": "1:0", "value": 8}},
+	}
+	if err := client.PostVariables(context.Background(), "abc", payload); err != nil {
+		t.Fatalf("PostVariables: %v", err)
+	}
+
+	if gotMethod != http.MethodPost || gotPath != "/files/abc/variables" {
+		t.Errorf("request = %s %s, want POST /files/abc/variables", gotMethod, gotPath)
+	}
+	if _, ok := gotBody["variableModeValues"]; !ok {
+		t.Errorf("expected variableModeValues in body, got %v", gotBody)
+	}
+
+	client.accessToken = "wrong"
+	if err := client.PostVariables(context.Background(), "abc", payload); err == nil {
+		t.Error("expected error for rejected request")
+	}
+}
+
+func TestWithCustomHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"styles":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token").WithCustomHeaders(map[string]string{
+		"X-Proxy-Authorization": "Basic abc",
+		"X-Forwarded-For":       "10.0.0.1",
+	})
+	client.baseURL = server.URL
+
+	if _, err := client.GetFileStyles(context.Background(), "abc"); err != nil {
+		t.Fatalf("GetFileStyles: %v", err)
+	}
+	if got.Get("X-Proxy-Authorization") != "Basic abc" || got.Get("X-Forwarded-For") != "10.0.0.1" {
+		t.Errorf("custom headers missing: %v", got)
+	}
+	if got.Get("X-Figma-Token") != "test-token" {
+		t.Errorf("X-Figma-Token = %q, want test-token", got.Get("X-Figma-Token"))
+	}
+}
+
+func TestGetFileVersions(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files/abc/versions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotQuery = r.URL.RawQuery
+		w.Write([]byte(`{"versions":[{"id":"2","created_at":"2026-01-02T00:00:00Z","user":{"id":"7","handle":"ana"}}],"pagination":{"next_page":"https://api.figma.com/v1/files/abc/versions?before=2"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	versions, err := client.GetFileVersions(context.Background(), "abc", &GetVersionsOptions{PageSize: 50, Before: "9"})
+	if err != nil {
+		t.Fatalf("GetFileVersions: %v", err)
+	}
+	if gotQuery != "before=9&page_size=50" {
+		t.Errorf("query = %q, want before=9&page_size=50", gotQuery)
+	}
+	if len(versions.Versions) != 1 || versions.Versions[0].User.Handle != "ana" || versions.Pagination.NextPage == "" {
+		t.Errorf("unexpected versions: %+v", versions)
+	}
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/me" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"42","handle":"ana","img_url":"https://example.com/a.png","email":"ana@example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser: %v", err)
+	}
+	if user.ID != "42" || user.Handle != "ana" || user.Email != "ana@example.com" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+}
+
+func TestGetTeamProjectsAndFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/teams/42/projects":
+			w.Write([]byte(`{"name":"Design","projects":[{"id":"7","name":"Web"}]}`))
+		case "/projects/7/files":
+			w.Write([]byte(`{"name":"Web","files":[{"key":"abc","name":"Marketing Site","last_modified":"2026-01-02T00:00:00Z"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	projects, err := client.GetTeamProjects(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("GetTeamProjects: %v", err)
+	}
+	if len(projects.Projects) != 1 || projects.Projects[0].ID != "7" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+
+	files, err := client.GetProjectFiles(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("GetProjectFiles: %v", err)
+	}
+	if len(files.Files) != 1 || files.Files[0].Key != "abc" || files.Files[0].Name != "Marketing Site" {
+		t.Errorf("unexpected files: %+v", files)
+	}
+}
+
+func TestGetLibraryAnalyticsAndUsages(t *testing.T) {
+	queries := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries[r.URL.Path] = r.URL.RawQuery
+		switch r.URL.Path {
+		case "/analytics/libraries/abc/component/actions":
+			w.Write([]byte(`{"rows":[{"week":"2026-01-05","component_key":"k1","component_name":"Primary","component_set_name":"Button","insertions":12,"detachments":1}],"next_page":true,"cursor":"c2"}`))
+		case "/analytics/libraries/abc/component/usages":
+			w.Write([]byte(`{"rows":[{"component_key":"k1","component_name":"Primary","usages":340,"teams_using":4,"files_using":52}],"next_page":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	actions, err := client.GetLibraryAnalytics(context.Background(), "abc", &LibraryAnalyticsOptions{StartDate: "2026-01-01", Cursor: "c1"})
+	if err != nil {
+		t.Fatalf("GetLibraryAnalytics: %v", err)
+	}
+	if got := queries["/analytics/libraries/abc/component/actions"]; got != "cursor=c1&group_by=component&start_date=2026-01-01" {
+		t.Errorf("actions query = %q", got)
+	}
+	if len(actions.Rows) != 1 || actions.Rows[0].Insertions != 12 || !actions.NextPage || actions.Cursor != "c2" {
+		t.Errorf("unexpected actions: %+v", actions)
+	}
+
+	usages, err := client.GetLibraryUsages(context.Background(), "abc", &LibraryAnalyticsOptions{StartDate: "2026-01-01"})
+	if err != nil {
+		t.Fatalf("GetLibraryUsages: %v", err)
+	}
+	if got := queries["/analytics/libraries/abc/component/usages"]; got != "group_by=component" {
+		t.Errorf("usages query = %q", got)
+	}
+	if len(usages.Rows) != 1 || usages.Rows[0].Usages != 340 || usages.Rows[0].FilesUsing != 52 {
+		t.Errorf("unexpected usages: %+v", usages)
+	}
+}
+
+func TestWithTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{"name":"File"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token").WithTimeouts(TimeoutConfig{
+		GetFile:      time.Second,
+		GetVariables: 20 * time.Millisecond,
+	})
+	client.baseURL = server.URL
+
+	if _, err := client.GetLocalVariables(context.Background(), "KEY"); err == nil {
+		t.Error("expected GetLocalVariables to time out")
+	}
+	if _, err := client.GetFile(context.Background(), "KEY", nil); err != nil {
+		t.Errorf("GetFile with a longer timeout: %v", err)
+	}
+
+	// A caller's deadline takes precedence over the configured timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.GetLocalVariables(ctx, "KEY"); err != nil {
+		t.Errorf("GetLocalVariables with caller deadline: %v", err)
+	}
+}
+
+func TestPostAndDeleteComment(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		gotBody = nil
+		json.Unmarshal(data, &gotBody)
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"id":"12","file_key":"abc","parent_id":"10","message":"Fixed","user":{"id":"7","handle":"ana"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":200,"error":false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = server.URL
+
+	comment, err := client.PostComment(context.Background(), "abc", &CommentRequest{Message: "Fixed", CommentID: "10"})
+	if err != nil {
+		t.Fatalf("PostComment: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/files/abc/comments" {
+		t.Errorf("request = %s %s, want POST /files/abc/comments", gotMethod, gotPath)
+	}
+	if gotBody["message"] != "Fixed" || gotBody["comment_id"] != "10" || gotBody["client_meta"] != nil {
+		t.Errorf("body = %v", gotBody)
+	}
+	if comment.ID != "12" || comment.ParentID != "10" {
+		t.Errorf("comment = %+v", comment)
+	}
+
+	anchor := &CommentAnchor{NodeID: "1:2", NodeOffset: Vector{X: 4, Y: 8}}
+	if _, err := client.PostComment(context.Background(), "abc", &CommentRequest{Message: "Too tight", ClientMeta: anchor}); err != nil {
+		t.Fatalf("PostComment with anchor: %v", err)
+	}
+	if meta, _ := gotBody["client_meta"].(map[string]any); meta["node_id"] != "1:2" {
+		t.Errorf("client_meta = %v, want node 1:2", gotBody["client_meta"])
+	}
+
+	if err := client.DeleteComment(context.Background(), "abc", "12"); err != nil {
+		t.Fatalf("DeleteComment: %v", err)
+	}
+	if gotMethod != http.MethodDelete || gotPath != "/files/abc/comments/12" {
+		t.Errorf("request = %s %s, want DELETE /files/abc/comments/12", gotMethod, gotPath)
+	}
+}
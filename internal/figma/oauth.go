@@ -0,0 +1,197 @@
+package figma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	OAuthAuthorizeURL = "https://www.figma.com/oauth"
+	OAuthTokenURL     = "https://api.figma.com/v1/oauth/token"
+	OAuthRefreshURL   = "https://api.figma.com/v1/oauth/refresh"
+
+	// refreshSkew refreshes tokens slightly before they expire.
+	refreshSkew = time.Minute
+)
+
+// OAuth2Config identifies a Figma OAuth app.
+type OAuth2Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	Scopes       []string // default: file_content:read, file_variables:read
+
+	// Endpoint overrides, mainly for tests. Empty means the Figma defaults.
+	AuthURL    string
+	TokenURL   string
+	RefreshURL string
+
+	// OnTokenRefresh is called after a refresh so the new token can be
+	// persisted. Figma may rotate the refresh token as well.
+	OnTokenRefresh func(*OAuthToken)
+}
+
+// OAuthToken is an OAuth access token with its refresh token.
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresIn    int       `json:"expires_in,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	UserID       any       `json:"user_id,omitempty"`
+}
+
+// expired reports whether the token should be refreshed before use.
+func (t *OAuthToken) expired() bool {
+	return !t.ExpiresAt.IsZero() && time.Now().Add(refreshSkew).After(t.ExpiresAt)
+}
+
+func (cfg OAuth2Config) scopes() []string {
+	if len(cfg.Scopes) > 0 {
+		return cfg.Scopes
+	}
+	return []string{"file_content:read", "file_variables:read"}
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// AuthCodeURL returns the URL the user visits to authorize the app.
+func (cfg OAuth2Config) AuthCodeURL(state string) string {
+	q := url.Values{
+		"client_id":     {cfg.ClientID},
+		"redirect_uri":  {cfg.RedirectURI},
+		"scope":         {strings.Join(cfg.scopes(), ",")},
+		"state":         {state},
+		"response_type": {"code"},
+	}
+	return orDefault(cfg.AuthURL, OAuthAuthorizeURL) + "?" + q.Encode()
+}
+
+// ExchangeCode trades an authorization code for an access token.
+func (cfg OAuth2Config) ExchangeCode(ctx context.Context, code string) (*OAuthToken, error) {
+	return cfg.postToken(ctx, orDefault(cfg.TokenURL, OAuthTokenURL), url.Values{
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"redirect_uri":  {cfg.RedirectURI},
+		"code":          {code},
+		"grant_type":    {"authorization_code"},
+	})
+}
+
+// Refresh obtains a new access token. If the response has no refresh
+// token, the existing one is kept.
+func (cfg OAuth2Config) Refresh(ctx context.Context, refreshToken string) (*OAuthToken, error) {
+	token, err := cfg.postToken(ctx, orDefault(cfg.RefreshURL, OAuthRefreshURL), url.Values{
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+func (cfg OAuth2Config) postToken(ctx context.Context, endpoint string, form url.Values) (*OAuthToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := (&http.Client{Timeout: DefaultTimeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var token OAuthToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("parsing token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+	if token.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return &token, nil
+}
+
+// oauthSession holds the token of an OAuth client and refreshes it on demand.
+type oauthSession struct {
+	mu    sync.Mutex
+	cfg   OAuth2Config
+	token *OAuthToken
+}
+
+// accessToken returns a valid access token, refreshing it if it has expired.
+func (s *oauthSession) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.expired() && s.token.RefreshToken != "" {
+		token, err := s.cfg.Refresh(ctx, s.token.RefreshToken)
+		if err != nil {
+			return "", fmt.Errorf("refreshing OAuth token: %w", err)
+		}
+		s.token = token
+		if s.cfg.OnTokenRefresh != nil {
+			s.cfg.OnTokenRefresh(token)
+		}
+	}
+	return s.token.AccessToken, nil
+}
+
+// NewOAuthClient creates a client that authenticates with an OAuth token
+// obtained from cfg.ExchangeCode, refreshing it when it expires.
+func NewOAuthClient(cfg OAuth2Config, token *OAuthToken) *Client {
+	c := NewClient("")
+	c.oauth = &oauthSession{cfg: cfg, token: token}
+	return c
+}
+
+// LoadOAuthToken reads a token saved by SaveOAuthToken.
+func LoadOAuthToken(path string) (*OAuthToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var token OAuthToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &token, nil
+}
+
+// SaveOAuthToken writes a token readable only by the current user.
+func SaveOAuthToken(path string, token *OAuthToken) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,112 @@
+package figma
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOAuth2ConfigAuthCodeURL(t *testing.T) {
+	cfg := OAuth2Config{ClientID: "abc", RedirectURI: "http://localhost:8976/callback"}
+	u, err := url.Parse(cfg.AuthCodeURL("xyz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("client_id") != "abc" || q.Get("state") != "xyz" || q.Get("response_type") != "code" {
+		t.Errorf("unexpected query: %v", q)
+	}
+	if q.Get("redirect_uri") != cfg.RedirectURI {
+		t.Errorf("redirect_uri = %q", q.Get("redirect_uri"))
+	}
+}
+
+func TestOAuth2ConfigExchangeCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "the-code" || r.Form.Get("grant_type") != "authorization_code" {
+			t.Errorf("unexpected form: %v", r.Form)
+		}
+		w.Write([]byte(`{"access_token":"at","refresh_token":"rt","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	cfg := OAuth2Config{ClientID: "id", ClientSecret: "secret", TokenURL: srv.URL}
+	token, err := cfg.ExchangeCode(context.Background(), "the-code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "at" || token.RefreshToken != "rt" {
+		t.Errorf("token = %+v", token)
+	}
+	if token.ExpiresAt.Before(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("ExpiresAt not set from expires_in: %v", token.ExpiresAt)
+	}
+}
+
+func TestOAuthClientRefreshesExpiredToken(t *testing.T) {
+	refresh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("refresh_token") != "old-rt" {
+			t.Errorf("refresh_token = %q", r.Form.Get("refresh_token"))
+		}
+		w.Write([]byte(`{"access_token":"new-at","refresh_token":"new-rt","expires_in":3600}`))
+	}))
+	defer refresh.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer new-at" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if r.Header.Get("X-Figma-Token") != "" {
+			t.Error("OAuth client sent X-Figma-Token")
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+
+	var saved *OAuthToken
+	cfg := OAuth2Config{
+		ClientID:       "id",
+		ClientSecret:   "secret",
+		RefreshURL:     refresh.URL,
+		OnTokenRefresh: func(t *OAuthToken) { saved = t },
+	}
+	c := NewOAuthClient(cfg, &OAuthToken{
+		AccessToken:  "old-at",
+		RefreshToken: "old-rt",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	})
+	c.baseURL = api.URL
+
+	if _, err := c.doRequest(context.Background(), http.MethodGet, "/me", nil); err != nil {
+		t.Fatal(err)
+	}
+	if saved == nil || saved.RefreshToken != "new-rt" {
+		t.Errorf("rotated refresh token not reported: %+v", saved)
+	}
+}
+
+func TestSaveLoadOAuthToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	want := &OAuthToken{AccessToken: "at", RefreshToken: "rt", ExpiresAt: time.Now().Truncate(time.Second)}
+	if err := SaveOAuthToken(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadOAuthToken(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken || !got.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+
+	if _, err := LoadOAuthToken(filepath.Join(t.TempDir(), "missing")); err == nil || !strings.Contains(err.Error(), "no such file") {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package figma
+
+import "encoding/json"
+
+// Reaction is a prototype interaction on a node: a trigger and the actions
+// it runs.
+type Reaction struct {
+	Trigger *Trigger `json:"trigger"`
+	Actions []Action `json:"actions,omitempty"`
+	// Action is the single action of reactions saved before Figma supported
+	// several actions per trigger. Newer files use Actions.
+	Action *Action `json:"action,omitempty"`
+}
+
+// AllActions returns the reaction's actions, falling back to the deprecated
+// single Action.
+func (r *Reaction) AllActions() []Action {
+	if len(r.Actions) > 0 || r.Action == nil {
+		return r.Actions
+	}
+	return []Action{*r.Action}
+}
+
+// Trigger is the event that starts a reaction.
+type Trigger struct {
+	// Type is ON_CLICK, ON_HOVER, ON_PRESS, ON_DRAG, AFTER_TIMEOUT,
+	// MOUSE_ENTER, MOUSE_LEAVE, MOUSE_UP, MOUSE_DOWN, ON_KEY_DOWN,
+	// ON_MEDIA_HIT or ON_MEDIA_END.
+	Type string `json:"type"`
+
+	Timeout      float64 `json:"timeout,omitempty"` // AFTER_TIMEOUT, seconds
+	Delay        float64 `json:"delay,omitempty"`   // MOUSE_ENTER, MOUSE_LEAVE, MOUSE_UP, MOUSE_DOWN, seconds
+	Device       string  `json:"device,omitempty"`  // ON_KEY_DOWN: KEYBOARD, XBOX_ONE, PS4, SWITCH_PRO or UNKNOWN_CONTROLLER
+	KeyCodes     []int   `json:"keyCodes,omitempty"`
+	MediaHitTime float64 `json:"mediaHitTime,omitempty"` // ON_MEDIA_HIT, seconds
+}
+
+// Action is what a reaction does when triggered.
+type Action struct {
+	// Type is BACK, CLOSE, URL, NODE, SET_VARIABLE, SET_VARIABLE_MODE,
+	// CONDITIONAL or UPDATE_MEDIA_RUNTIME.
+	Type string `json:"type"`
+
+	// URL
+	URL          string `json:"url,omitempty"`
+	OpenInNewTab bool   `json:"openInNewTab,omitempty"`
+
+	// NODE
+	DestinationID              string      `json:"destinationId,omitempty"`
+	Navigation                 string      `json:"navigation,omitempty"` // NAVIGATE, SWAP, OVERLAY, SCROLL_TO or CHANGE_TO
+	Transition                 *Transition `json:"transition,omitempty"`
+	PreserveScrollPosition     bool        `json:"preserveScrollPosition,omitempty"`
+	OverlayRelativePosition    *Vector     `json:"overlayRelativePosition,omitempty"`
+	ResetVideoPosition         bool        `json:"resetVideoPosition,omitempty"`
+	ResetScrollPosition        bool        `json:"resetScrollPosition,omitempty"`
+	ResetInteractiveComponents bool        `json:"resetInteractiveComponents,omitempty"`
+
+	// SET_VARIABLE and SET_VARIABLE_MODE
+	VariableID           string          `json:"variableId,omitempty"`
+	VariableValue        json.RawMessage `json:"variableValue,omitempty"`
+	VariableCollectionID string          `json:"variableCollectionId,omitempty"`
+	VariableModeID       string          `json:"variableModeId,omitempty"`
+
+	// CONDITIONAL
+	ConditionalBlocks []ConditionalBlock `json:"conditionalBlocks,omitempty"`
+
+	// UPDATE_MEDIA_RUNTIME
+	MediaAction  string  `json:"mediaAction,omitempty"` // PLAY, PAUSE, TOGGLE_PLAY_PAUSE, MUTE, UNMUTE, TOGGLE_MUTE_UNMUTE, SKIP_FORWARD, SKIP_BACKWARD or SKIP_TO
+	AmountToSkip float64 `json:"amountToSkip,omitempty"`
+	NewTimestamp float64 `json:"newTimestamp,omitempty"`
+}
+
+// ConditionalBlock is one branch of a CONDITIONAL action. A block without a
+// condition is the else branch.
+type ConditionalBlock struct {
+	Condition json.RawMessage `json:"condition,omitempty"`
+	Actions   []Action        `json:"actions"`
+}
+
+// Transition describes the animation of a NODE action.
+type Transition struct {
+	// Type is DISSOLVE, SMART_ANIMATE, SCROLL_ANIMATE, MOVE_IN, MOVE_OUT,
+	// PUSH, SLIDE_IN or SLIDE_OUT.
+	Type        string  `json:"type"`
+	Duration    float64 `json:"duration"` // seconds
+	Easing      *Easing `json:"easing,omitempty"`
+	Direction   string  `json:"direction,omitempty"` // LEFT, RIGHT, TOP or BOTTOM
+	MatchLayers bool    `json:"matchLayers,omitempty"`
+}
+
+// Easing is the timing curve of a transition.
+type Easing struct {
+	// Type is EASE_IN, EASE_OUT, EASE_IN_AND_OUT, LINEAR, EASE_IN_BACK,
+	// EASE_OUT_BACK, EASE_IN_AND_OUT_BACK, CUSTOM_CUBIC_BEZIER, GENTLE,
+	// QUICK, BOUNCY, SLOW or CUSTOM_SPRING.
+	Type                      string       `json:"type"`
+	EasingFunctionCubicBezier *CubicBezier `json:"easingFunctionCubicBezier,omitempty"`
+	EasingFunctionSpring      *Spring      `json:"easingFunctionSpring,omitempty"`
+}
+
+// CubicBezier holds the control points of a CUSTOM_CUBIC_BEZIER easing.
+type CubicBezier struct {
+	X1 float64 `json:"x1"`
+	Y1 float64 `json:"y1"`
+	X2 float64 `json:"x2"`
+	Y2 float64 `json:"y2"`
+}
+
+// Spring holds the parameters of a spring easing.
+type Spring struct {
+	Mass      float64 `json:"mass"`
+	Stiffness float64 `json:"stiffness"`
+	Damping   float64 `json:"damping"`
+}
+
+// ParsedReactions decodes the node's prototype reactions. It returns nil for
+// nodes without reactions.
+func (n *Node) ParsedReactions() ([]Reaction, error) {
+	if len(n.Reactions) == 0 || string(n.Reactions) == "null" {
+		return nil, nil
+	}
+	var reactions []Reaction
+	if err := json.Unmarshal(n.Reactions, &reactions); err != nil {
+		return nil, err
+	}
+	return reactions, nil
+}
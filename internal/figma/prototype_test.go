@@ -0,0 +1,70 @@
+package figma
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsedReactions(t *testing.T) {
+	data := `{
+		"id": "1:2",
+		"type": "FRAME",
+		"reactions": [
+			{
+				"trigger": {"type": "ON_CLICK"},
+				"actions": [{
+					"type": "NODE",
+					"destinationId": "3:4",
+					"navigation": "NAVIGATE",
+					"transition": {
+						"type": "SMART_ANIMATE",
+						"duration": 0.3,
+						"easing": {"type": "CUSTOM_CUBIC_BEZIER", "easingFunctionCubicBezier": {"x1": 0.4, "y1": 0, "x2": 0.2, "y2": 1}}
+					}
+				}]
+			},
+			{
+				"trigger": {"type": "AFTER_TIMEOUT", "timeout": 2},
+				"action": {"type": "URL", "url": "https://example.com", "openInNewTab": true}
+			}
+		]
+	}`
+
+	var node Node
+	if err := json.Unmarshal([]byte(data), &node); err != nil {
+		t.Fatal(err)
+	}
+	reactions, err := node.ParsedReactions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reactions) != 2 {
+		t.Fatalf("got %d reactions, want 2", len(reactions))
+	}
+
+	click := reactions[0]
+	if click.Trigger.Type != "ON_CLICK" || len(click.AllActions()) != 1 {
+		t.Fatalf("unexpected first reaction: %+v", click)
+	}
+	action := click.AllActions()[0]
+	if action.DestinationID != "3:4" || action.Transition == nil || action.Transition.Duration != 0.3 {
+		t.Errorf("unexpected action: %+v", action)
+	}
+	if bezier := action.Transition.Easing.EasingFunctionCubicBezier; bezier == nil || bezier.X1 != 0.4 {
+		t.Errorf("unexpected easing: %+v", action.Transition.Easing)
+	}
+
+	// The deprecated single action is returned by AllActions.
+	timeout := reactions[1]
+	if timeout.Trigger.Timeout != 2 {
+		t.Errorf("timeout = %v, want 2", timeout.Trigger.Timeout)
+	}
+	if actions := timeout.AllActions(); len(actions) != 1 || actions[0].URL != "https://example.com" || !actions[0].OpenInNewTab {
+		t.Errorf("unexpected legacy action: %+v", actions)
+	}
+
+	empty := &Node{ID: "5:6"}
+	if reactions, err := empty.ParsedReactions(); err != nil || reactions != nil {
+		t.Errorf("node without reactions: got %v, %v", reactions, err)
+	}
+}
@@ -33,6 +33,13 @@ type GetFileOptions struct {
 	BranchData bool   // Include branch metadata
 }
 
+// GetVersionsOptions contains options for paging through version history.
+type GetVersionsOptions struct {
+	PageSize int    // Versions per page (API maximum 50)
+	Before   string // Only versions older than this version ID
+	After    string // Only versions newer than this version ID
+}
+
 // ImageExportOptions contains options for image export.
 type ImageExportOptions struct {
 	Format            string  // png, jpg, svg, pdf
@@ -163,6 +170,9 @@ type Node struct {
 	EffectStyleID  string `json:"effectStyleId,omitempty"`
 	GridStyleID    string `json:"gridStyleId,omitempty"`
 	TextStyleID    string `json:"textStyleId,omitempty"`
+	// Styles maps a style kind (fill, stroke, effect, grid, text) to the ID
+	// of the style applied; this is where the REST API reports them.
+	Styles map[string]string `json:"styles,omitempty"`
 
 	// Variables
 	BoundVariables map[string]*VariableAlias `json:"boundVariables,omitempty"`
@@ -178,6 +188,7 @@ type Node struct {
 	Guides              []Guide        `json:"guides,omitempty"`
 	FlowStartingPoints  []FlowStartingPoint `json:"flowStartingPoints,omitempty"`
 	Prototypedevice     *PrototypeDevice    `json:"prototypeDevice,omitempty"`
+	Reactions           json.RawMessage     `json:"reactions,omitempty"`
 
 	// Vector
 	FillGeometry    []VectorPath `json:"fillGeometry,omitempty"`
@@ -217,6 +228,16 @@ const (
 	NodeTypeMedia         NodeType = "MEDIA"
 )
 
+// NodeTypes lists every known NodeType.
+var NodeTypes = []NodeType{
+	NodeTypeDocument, NodeTypeCanvas, NodeTypeFrame, NodeTypeGroup, NodeTypeSection,
+	NodeTypeVector, NodeTypeBooleanOperation, NodeTypeStar, NodeTypeLine, NodeTypeEllipse,
+	NodeTypeRegularPolygon, NodeTypeRectangle, NodeTypeTable, NodeTypeTableCell, NodeTypeText,
+	NodeTypeSlice, NodeTypeComponent, NodeTypeComponentSet, NodeTypeInstance, NodeTypeSticky,
+	NodeTypeShapeWithText, NodeTypeConnector, NodeTypeWashi, NodeTypeWidget, NodeTypeEmbed,
+	NodeTypeLinkUnfurl, NodeTypeMedia,
+}
+
 // Rectangle represents a bounding box.
 type Rectangle struct {
 	X      float64 `json:"x"`
@@ -568,3 +589,131 @@ type Mode struct {
 	ModeID string `json:"modeId"`
 	Name   string `json:"name"`
 }
+
+// Version represents a saved version in a file's history.
+type Version struct {
+	ID           string `json:"id"`
+	CreatedAt    string `json:"created_at"`
+	Label        string `json:"label,omitempty"`
+	Description  string `json:"description,omitempty"`
+	User         *User  `json:"user"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+// FileVersions represents a page of a file's version history, newest first.
+type FileVersions struct {
+	Versions   []Version           `json:"versions"`
+	Pagination *VersionsPagination `json:"pagination,omitempty"`
+}
+
+// VersionsPagination links to the neighbouring pages of version history.
+type VersionsPagination struct {
+	PrevPage string `json:"prev_page,omitempty"`
+	NextPage string `json:"next_page,omitempty"`
+}
+
+// Comment represents a comment or reply on a file.
+type Comment struct {
+	ID         string          `json:"id"`
+	FileKey    string          `json:"file_key"`
+	ParentID   string          `json:"parent_id,omitempty"`
+	User       *User           `json:"user"`
+	CreatedAt  string          `json:"created_at"`
+	ResolvedAt string          `json:"resolved_at,omitempty"`
+	Message    string          `json:"message"`
+	ClientMeta json.RawMessage `json:"client_meta,omitempty"`
+	OrderID    string          `json:"order_id,omitempty"`
+}
+
+// FileComments represents a file comments response.
+type FileComments struct {
+	Comments []Comment `json:"comments"`
+}
+
+// CommentAnchor pins a comment to a point of a node, relative to its
+// top-left corner.
+type CommentAnchor struct {
+	NodeID     string `json:"node_id"`
+	NodeOffset Vector `json:"node_offset"`
+}
+
+// CommentRequest is the body of a new comment or reply.
+type CommentRequest struct {
+	Message    string         `json:"message"`
+	CommentID  string         `json:"comment_id,omitempty"` // root comment replied to
+	ClientMeta *CommentAnchor `json:"client_meta,omitempty"`
+}
+
+// Project is a project of a team.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TeamProjects represents the projects of a team.
+type TeamProjects struct {
+	Name     string    `json:"name"`
+	Projects []Project `json:"projects"`
+}
+
+// ProjectFile is a file in a project.
+type ProjectFile struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	LastModified string `json:"last_modified"`
+}
+
+// ProjectFiles represents the files of a project.
+type ProjectFiles struct {
+	Name  string        `json:"name"`
+	Files []ProjectFile `json:"files"`
+}
+
+// LibraryAnalyticsOptions contains options for the library analytics endpoints.
+type LibraryAnalyticsOptions struct {
+	GroupBy   string // component (default) or team for actions; component or file for usages
+	StartDate string // YYYY-MM-DD, actions only; rounded back to the start of the week
+	EndDate   string // YYYY-MM-DD, actions only
+	Cursor    string // Cursor from the previous page
+}
+
+// LibraryActionRow is one week of insertions and detachments of a library
+// component, or of all components by one team.
+type LibraryActionRow struct {
+	Week             string `json:"week"`
+	ComponentKey     string `json:"component_key,omitempty"`
+	ComponentName    string `json:"component_name,omitempty"`
+	ComponentSetKey  string `json:"component_set_key,omitempty"`
+	ComponentSetName string `json:"component_set_name,omitempty"`
+	TeamName         string `json:"team_name,omitempty"`
+	Insertions       int    `json:"insertions"`
+	Detachments      int    `json:"detachments"`
+}
+
+// LibraryActions represents a page of library component actions.
+type LibraryActions struct {
+	Rows     []LibraryActionRow `json:"rows"`
+	NextPage bool               `json:"next_page"`
+	Cursor   string             `json:"cursor,omitempty"`
+}
+
+// LibraryUsageRow is the current use of a library component across the
+// organization, or of all components in one file.
+type LibraryUsageRow struct {
+	ComponentKey     string `json:"component_key,omitempty"`
+	ComponentName    string `json:"component_name,omitempty"`
+	ComponentSetKey  string `json:"component_set_key,omitempty"`
+	ComponentSetName string `json:"component_set_name,omitempty"`
+	FileName         string `json:"file_name,omitempty"`
+	Usages           int    `json:"usages"`
+	TeamsUsing       int    `json:"teams_using,omitempty"`
+	FilesUsing       int    `json:"files_using,omitempty"`
+}
+
+// LibraryUsages represents a page of library component usages.
+type LibraryUsages struct {
+	Rows     []LibraryUsageRow `json:"rows"`
+	NextPage bool              `json:"next_page"`
+	Cursor   string            `json:"cursor,omitempty"`
+}
@@ -0,0 +1,476 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// WCAG 2.1 minimum contrast ratios (success criteria 1.4.3 and 1.4.6).
+const (
+	contrastAANormal  = 4.5
+	contrastAALarge   = 3.0
+	contrastAAANormal = 7.0
+	contrastAAALarge  = 4.5
+)
+
+// CheckAccessibilityArgs contains arguments for the check_accessibility tool.
+type CheckAccessibilityArgs struct {
+	FileKey    string `json:"file_key" jsonschema:"Figma file key"`
+	Level      string `json:"level,omitempty" jsonschema:"WCAG level to report failures for: AA (default) or AAA"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Max failures to return (default: 100)"`
+	Format     string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	OutputFile string `json:"output_file,omitempty" jsonschema:"Write full output to file path"`
+}
+
+// ContrastPair is the foreground and effective background of a text node.
+type ContrastPair struct {
+	NodeID       string  `json:"node_id"`
+	NodeName     string  `json:"node_name"`
+	Text         string  `json:"text,omitempty"`
+	Foreground   string  `json:"foreground"`
+	Background   string  `json:"background"`
+	BackgroundID string  `json:"background_id,omitempty"` // ancestor supplying the background, empty for the page
+	LargeText    bool    `json:"large_text"`
+	Ratio        float64 `json:"ratio"`
+}
+
+// ContrastIssue is a text node whose contrast against its background is too low.
+type ContrastIssue struct {
+	ContrastPair
+	Required float64 `json:"required"`
+	PassAA   bool    `json:"pass_aa"`
+	PassAAA  bool    `json:"pass_aaa"`
+}
+
+// CheckAccessibilityResult contains the result of check_accessibility.
+type CheckAccessibilityResult struct {
+	Level    string          `json:"level"`
+	Checked  int             `json:"checked"`
+	Total    int             `json:"total"`
+	Failures []ContrastIssue `json:"failures"`
+	FilePath string          `json:"file_path,omitempty"`
+	Cached   bool            `json:"cached"`
+}
+
+func registerCheckAccessibilityTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "check_accessibility",
+		Description: "Check WCAG 2.1 contrast of every text layer against the fill of its nearest filled ancestor and list AA or AAA failures.",
+		InputSchema: inputSchema[CheckAccessibilityArgs](map[string][]string{
+			"level":  {"AA", "AAA"},
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CheckAccessibilityArgs) (*mcp.CallToolResult, *CheckAccessibilityResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		level := strings.ToUpper(args.Level)
+		if level == "" {
+			level = "AA"
+		}
+		if level != "AA" && level != "AAA" {
+			return nil, nil, fmt.Errorf("level must be AA or AAA")
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = 100
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		checked, failures := checkTextContrast(source.Nodes, level)
+
+		result := &CheckAccessibilityResult{
+			Level:    level,
+			Checked:  checked,
+			Total:    len(failures),
+			Failures: failures,
+			Cached:   source.Cached,
+		}
+		if len(result.Failures) > limit {
+			result.Failures = result.Failures[:limit]
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatCheckAccessibilityResult(result)
+		}
+
+		outputCfg := OutputConfig{
+			MaxOutputSize: DefaultMaxOutputSize,
+			OutputFile:    args.OutputFile,
+			OutputDir:     r.ExportDir(),
+			ToolName:      "check_accessibility",
+			FileKey:       args.FileKey,
+		}
+
+		outputResult, err := ProcessOutput(textOutput, result, outputCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("processing output: %w", err)
+		}
+
+		if outputResult.WasWrittenToFile {
+			result.FilePath = outputResult.FilePath
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: outputResult.Text},
+			},
+		}, result, nil
+	})
+}
+
+// GetContrastPairsArgs contains arguments for the get_contrast_pairs tool.
+type GetContrastPairsArgs struct {
+	FileKey    string `json:"file_key" jsonschema:"Figma file key"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Max pairs to return (default: 200)"`
+	Format     string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	OutputFile string `json:"output_file,omitempty" jsonschema:"Write full output to file path"`
+}
+
+// GetContrastPairsResult contains the result of get_contrast_pairs.
+type GetContrastPairsResult struct {
+	Pairs    []ContrastPair `json:"pairs"`
+	Total    int            `json:"total"`
+	Unique   int            `json:"unique"` // distinct foreground/background combinations
+	FilePath string         `json:"file_path,omitempty"`
+	Cached   bool           `json:"cached"`
+}
+
+func registerGetContrastPairsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_contrast_pairs",
+		Description: "List the text color and effective background color of every text layer with their WCAG contrast ratio.",
+		InputSchema: inputSchema[GetContrastPairsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetContrastPairsArgs) (*mcp.CallToolResult, *GetContrastPairsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = 200
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pairs := textContrastPairs(source.Nodes)
+		combos := make(map[string]bool)
+		for _, p := range pairs {
+			combos[p.Foreground+"/"+p.Background] = true
+		}
+
+		result := &GetContrastPairsResult{
+			Pairs:  pairs,
+			Total:  len(pairs),
+			Unique: len(combos),
+			Cached: source.Cached,
+		}
+		if len(result.Pairs) > limit {
+			result.Pairs = result.Pairs[:limit]
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatContrastPairsResult(result)
+		}
+
+		outputCfg := OutputConfig{
+			MaxOutputSize: DefaultMaxOutputSize,
+			OutputFile:    args.OutputFile,
+			OutputDir:     r.ExportDir(),
+			ToolName:      "get_contrast_pairs",
+			FileKey:       args.FileKey,
+		}
+
+		outputResult, err := ProcessOutput(textOutput, result, outputCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("processing output: %w", err)
+		}
+
+		if outputResult.WasWrittenToFile {
+			result.FilePath = outputResult.FilePath
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: outputResult.Text},
+			},
+		}, result, nil
+	})
+}
+
+// buildParentMap maps each child ID to its parent using Children links.
+func buildParentMap(nodes []*figma.Node) map[string]*figma.Node {
+	parents := make(map[string]*figma.Node)
+	for _, n := range nodes {
+		for _, child := range n.Children {
+			parents[child.ID] = n
+		}
+	}
+	return parents
+}
+
+// textContrastPairs pairs every visible TEXT node that has a solid fill with
+// its effective background, in document order.
+func textContrastPairs(nodes []*figma.Node) []ContrastPair {
+	parents := buildParentMap(nodes)
+	pairs := []ContrastPair{}
+
+	for _, node := range nodes {
+		if node.Type != figma.NodeTypeText || (node.Visible != nil && !*node.Visible) {
+			continue
+		}
+		fg, ok := topSolidFill(node.Fills)
+		if !ok {
+			continue
+		}
+
+		bg, bgID := backgroundBehind(node, parents)
+		fg = blendOver(fg, bg)
+
+		pairs = append(pairs, ContrastPair{
+			NodeID:       node.ID,
+			NodeName:     node.Name,
+			Text:         truncateText(node.Characters, 40),
+			Foreground:   colorKey(&fg),
+			Background:   colorKey(&bg),
+			BackgroundID: bgID,
+			LargeText:    isLargeText(node.Style),
+			// Truncated rather than rounded so a pair never rounds up to a pass.
+			Ratio: math.Floor(contrastRatio(fg, bg)*100) / 100,
+		})
+	}
+
+	return pairs
+}
+
+// checkTextContrast measures every visible TEXT node with a solid fill and
+// returns the number checked and those failing level, lowest ratio first.
+func checkTextContrast(nodes []*figma.Node, level string) (int, []ContrastIssue) {
+	pairs := textContrastPairs(nodes)
+	failures := []ContrastIssue{}
+
+	for _, pair := range pairs {
+		issue := ContrastIssue{
+			ContrastPair: pair,
+			Required:     requiredContrast(level, pair.LargeText),
+			PassAA:       pair.Ratio >= requiredContrast("AA", pair.LargeText),
+			PassAAA:      pair.Ratio >= requiredContrast("AAA", pair.LargeText),
+		}
+
+		if (level == "AA" && !issue.PassAA) || (level == "AAA" && !issue.PassAAA) {
+			failures = append(failures, issue)
+		}
+	}
+
+	sort.SliceStable(failures, func(i, j int) bool { return failures[i].Ratio < failures[j].Ratio })
+	return len(pairs), failures
+}
+
+// requiredContrast returns the WCAG minimum ratio for a level and text size.
+func requiredContrast(level string, large bool) float64 {
+	switch {
+	case level == "AAA" && large:
+		return contrastAAALarge
+	case level == "AAA":
+		return contrastAAANormal
+	case large:
+		return contrastAALarge
+	default:
+		return contrastAANormal
+	}
+}
+
+// isLargeText applies the WCAG definition of large text: at least 18pt
+// (24px), or 14pt (about 18.66px) when bold.
+func isLargeText(style *figma.TypeStyle) bool {
+	if style == nil {
+		return false
+	}
+	return style.FontSize >= 24 || (style.FontSize >= 18.66 && style.FontWeight >= 700)
+}
+
+// topSolidFill returns the topmost visible solid paint, with paint opacity
+// folded into alpha. Figma lists paints bottom to top.
+func topSolidFill(paints []figma.Paint) (figma.Color, bool) {
+	for i := len(paints) - 1; i >= 0; i-- {
+		p := paints[i]
+		if p.Type != "SOLID" || p.Color == nil || (p.Visible != nil && !*p.Visible) {
+			continue
+		}
+		c := *p.Color
+		if p.Opacity != nil {
+			c.A *= *p.Opacity
+		}
+		return c, true
+	}
+	return figma.Color{}, false
+}
+
+// backgroundBehind composites the fills of node's ancestors, nearest first,
+// until an opaque one is found. The page background, or white, is the base.
+// It also returns the ID of the nearest ancestor that contributed a fill.
+func backgroundBehind(node *figma.Node, parents map[string]*figma.Node) (figma.Color, string) {
+	base := figma.Color{R: 1, G: 1, B: 1, A: 1}
+	var layers []figma.Color
+	nearestID := ""
+
+	for p := parents[node.ID]; p != nil; p = parents[p.ID] {
+		if p.Type == figma.NodeTypeCanvas {
+			if p.BackgroundColor != nil {
+				base = *p.BackgroundColor
+				base.A = 1
+			}
+			break
+		}
+		c, ok := topSolidFill(p.Fills)
+		if !ok || c.A == 0 {
+			continue
+		}
+		if nearestID == "" {
+			nearestID = p.ID
+		}
+		layers = append(layers, c)
+		if c.A >= 1 {
+			break
+		}
+	}
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		base = blendOver(layers[i], base)
+	}
+	return base, nearestID
+}
+
+// blendOver composites fg onto an opaque bg.
+func blendOver(fg, bg figma.Color) figma.Color {
+	a := fg.A
+	return figma.Color{
+		R: fg.R*a + bg.R*(1-a),
+		G: fg.G*a + bg.G*(1-a),
+		B: fg.B*a + bg.B*(1-a),
+		A: 1,
+	}
+}
+
+// relativeLuminance implements the WCAG 2.1 relative luminance formula.
+func relativeLuminance(c figma.Color) float64 {
+	channel := func(v float64) float64 {
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// contrastRatio returns the WCAG contrast ratio of two opaque colors (1 to 21).
+func contrastRatio(a, b figma.Color) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// truncateText shortens s to n runes on its first line.
+func truncateText(s string, n int) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n]) + "…"
+	}
+	return s
+}
+
+func formatCheckAccessibilityResult(r *CheckAccessibilityResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Contrast check (WCAG %s): %d of %d text layers fail\n\n", r.Level, r.Total, r.Checked))
+
+	if r.Total == 0 {
+		sb.WriteString("All text layers meet the required contrast.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	for _, f := range r.Failures {
+		size := "normal"
+		if f.LargeText {
+			size = "large"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s  %.2f:1 < %.1f:1 (%s text)  %s on %s  AA:%s AAA:%s\n",
+			f.NodeID, f.NodeName, f.Ratio, f.Required, size, f.Foreground, f.Background, passFail(f.PassAA), passFail(f.PassAAA)))
+		if f.Text != "" {
+			sb.WriteString(fmt.Sprintf("    %q\n", f.Text))
+		}
+	}
+
+	if len(r.Failures) < r.Total {
+		sb.WriteString(fmt.Sprintf("\n... %d more (increase limit to see all)\n", r.Total-len(r.Failures)))
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
+
+func formatContrastPairsResult(r *GetContrastPairsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Contrast pairs: %d text layers, %d unique color pairs\n\n", r.Total, r.Unique))
+
+	if r.Total == 0 {
+		sb.WriteString("No text layers with solid fills found.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	for _, p := range r.Pairs {
+		sb.WriteString(fmt.Sprintf("%s %s  %s on %s  %.2f:1", p.NodeID, p.NodeName, p.Foreground, p.Background, p.Ratio))
+		if p.Text != "" {
+			sb.WriteString(fmt.Sprintf("  %q", p.Text))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(r.Pairs) < r.Total {
+		sb.WriteString(fmt.Sprintf("\n... %d more (increase limit to see all)\n", r.Total-len(r.Pairs)))
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
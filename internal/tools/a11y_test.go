@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"math"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func solid(r, g, b float64) []figma.Paint {
+	return []figma.Paint{{Type: "SOLID", Color: &figma.Color{R: r, G: g, B: b, A: 1}}}
+}
+
+func TestContrastRatio(t *testing.T) {
+	black := figma.Color{A: 1}
+	white := figma.Color{R: 1, G: 1, B: 1, A: 1}
+	if got := contrastRatio(black, white); math.Abs(got-21) > 0.01 {
+		t.Errorf("black on white = %.2f, want 21", got)
+	}
+	if got := contrastRatio(white, white); got != 1 {
+		t.Errorf("white on white = %.2f, want 1", got)
+	}
+	// #777777 on white is a well-known near miss for AA (4.48:1)
+	grey := figma.Color{R: 0x77 / 255.0, G: 0x77 / 255.0, B: 0x77 / 255.0, A: 1}
+	if got := contrastRatio(grey, white); math.Abs(got-4.48) > 0.01 {
+		t.Errorf("#777 on white = %.2f, want 4.48", got)
+	}
+}
+
+func TestCheckTextContrast(t *testing.T) {
+	grey := 0x77 / 255.0
+	lowContrast := &figma.Node{ID: "1:3", Name: "Caption", Type: figma.NodeTypeText, Characters: "Terms apply",
+		Fills: solid(grey, grey, grey), Style: &figma.TypeStyle{FontSize: 14}}
+	largeText := &figma.Node{ID: "1:4", Name: "Heading", Type: figma.NodeTypeText,
+		Fills: solid(grey, grey, grey), Style: &figma.TypeStyle{FontSize: 32}}
+	onDark := &figma.Node{ID: "1:6", Name: "Label", Type: figma.NodeTypeText,
+		Fills: solid(1, 1, 1), Style: &figma.TypeStyle{FontSize: 14}}
+
+	button := &figma.Node{ID: "1:5", Name: "Button", Type: figma.NodeTypeFrame, Fills: solid(0, 0, 0), Children: []*figma.Node{onDark}}
+	group := &figma.Node{ID: "1:2", Name: "Group", Type: figma.NodeTypeGroup, Children: []*figma.Node{lowContrast, largeText, button}}
+	card := &figma.Node{ID: "1:1", Name: "Card", Type: figma.NodeTypeFrame, Fills: solid(1, 1, 1), Children: []*figma.Node{group}}
+	page := &figma.Node{ID: "0:1", Name: "Page", Type: figma.NodeTypeCanvas, Children: []*figma.Node{card}}
+
+	nodes := []*figma.Node{page, card, group, lowContrast, largeText, button, onDark}
+
+	checked, failures := checkTextContrast(nodes, "AA")
+	if checked != 3 {
+		t.Errorf("checked = %d, want 3", checked)
+	}
+	if len(failures) != 1 || failures[0].NodeID != "1:3" {
+		t.Fatalf("AA failures = %+v, want only 1:3", failures)
+	}
+	f := failures[0]
+	if f.Required != 4.5 || f.PassAA || f.PassAAA || f.BackgroundID != "1:1" || f.Background != "#ffffff" {
+		t.Errorf("unexpected failure details: %+v", f)
+	}
+
+	_, failures = checkTextContrast(nodes, "AAA")
+	if len(failures) != 2 {
+		t.Fatalf("AAA failures = %+v, want 1:3 and 1:4", failures)
+	}
+	for _, f := range failures {
+		if f.NodeID == "1:4" && (!f.LargeText || f.Required != 4.5 || !f.PassAA) {
+			t.Errorf("large heading should need 4.5:1 at AAA and pass AA: %+v", f)
+		}
+	}
+}
+
+func TestTextContrastPairs(t *testing.T) {
+	hidden := false
+	label := &figma.Node{ID: "1:3", Name: "Label", Type: figma.NodeTypeText, Characters: "Buy now\nToday only", Fills: solid(1, 1, 1)}
+	ghost := &figma.Node{ID: "1:4", Name: "Ghost", Type: figma.NodeTypeText, Fills: solid(1, 1, 1), Visible: &hidden}
+	icon := &figma.Node{ID: "1:5", Name: "Icon", Type: figma.NodeTypeVector, Fills: solid(1, 1, 1)}
+	button := &figma.Node{ID: "1:2", Name: "Button", Type: figma.NodeTypeFrame, Fills: solid(0, 0, 1), Children: []*figma.Node{label, ghost, icon}}
+	page := &figma.Node{ID: "0:1", Name: "Page", Type: figma.NodeTypeCanvas, Children: []*figma.Node{button}}
+
+	pairs := textContrastPairs([]*figma.Node{page, button, label, ghost, icon})
+	if len(pairs) != 1 {
+		t.Fatalf("pairs = %+v, want only the visible text layer", pairs)
+	}
+	p := pairs[0]
+	if p.Foreground != "#ffffff" || p.Background != "#0000ff" || p.BackgroundID != "1:2" || p.Text != "Buy now" {
+		t.Errorf("unexpected pair: %+v", p)
+	}
+	// White on pure blue is 8.59:1.
+	if p.Ratio != 8.59 {
+		t.Errorf("ratio = %.2f, want 8.59", p.Ratio)
+	}
+}
+
+func TestBackgroundBehindTranslucentFill(t *testing.T) {
+	half := 0.5
+	text := &figma.Node{ID: "2", Type: figma.NodeTypeText}
+	overlay := &figma.Node{ID: "1", Type: figma.NodeTypeFrame,
+		Fills:    []figma.Paint{{Type: "SOLID", Color: &figma.Color{A: 1}, Opacity: &half}},
+		Children: []*figma.Node{text}}
+	page := &figma.Node{ID: "0", Type: figma.NodeTypeCanvas, BackgroundColor: &figma.Color{R: 1, G: 1, B: 1, A: 1},
+		Children: []*figma.Node{overlay}}
+
+	bg, id := backgroundBehind(text, buildParentMap([]*figma.Node{page, overlay, text}))
+	if id != "1" || math.Abs(bg.R-0.5) > 1e-9 {
+		t.Errorf("background = %+v from %s, want 50%% grey from 1", bg, id)
+	}
+}
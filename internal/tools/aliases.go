@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// aliasFileName is the per-user alias table, stored in the home directory.
+const aliasFileName = ".figma-query-aliases.json"
+
+// AliasStore maps short, user-defined names to Figma node IDs.
+// The table is re-read on every lookup so edits from other sessions apply.
+type AliasStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewAliasStore creates an alias store backed by the JSON file at path.
+func NewAliasStore(path string) *AliasStore {
+	return &AliasStore{path: path}
+}
+
+// defaultAliasPath returns ~/.figma-query-aliases.json.
+func defaultAliasPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = os.TempDir()
+	}
+	return filepath.Join(home, aliasFileName)
+}
+
+// Path returns the alias file location.
+func (s *AliasStore) Path() string {
+	return s.path
+}
+
+// All returns a copy of the alias table. A missing file is an empty table.
+func (s *AliasStore) All() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Set stores name → nodeID and returns the previous node ID, if any.
+func (s *AliasStore) Set(name, nodeID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aliases, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	previous := aliases[name]
+	aliases[name] = nodeID
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return "", fmt.Errorf("creating alias directory: %w", err)
+	}
+	if err := writeJSON(s.path, aliases); err != nil {
+		return "", fmt.Errorf("writing aliases: %w", err)
+	}
+	return previous, nil
+}
+
+// Resolve returns the node ID for an alias, or id unchanged if it is not one.
+func (s *AliasStore) Resolve(id string) string {
+	aliases, err := s.All()
+	if err != nil {
+		return id
+	}
+	if nodeID, ok := aliases[id]; ok {
+		return nodeID
+	}
+	return id
+}
+
+func (s *AliasStore) load() (map[string]string, error) {
+	aliases := make(map[string]string)
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return aliases, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading aliases: %w", err)
+	}
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", s.path, err)
+	}
+	return aliases, nil
+}
+
+// CreateAliasArgs contains arguments for the create_alias tool.
+type CreateAliasArgs struct {
+	Name   string `json:"name" jsonschema:"Short name to use in place of the node ID (e.g. hero-button)"`
+	NodeID string `json:"node_id" jsonschema:"Figma node ID the alias points to (e.g. 1:2345)"`
+	Format string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// CreateAliasResult contains the result of create_alias.
+type CreateAliasResult struct {
+	Name     string `json:"name"`
+	NodeID   string `json:"node_id"`
+	Previous string `json:"previous,omitempty"`
+	Path     string `json:"path"`
+}
+
+func registerCreateAliasTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_alias",
+		Description: "Save a short name for a node ID. Any node_id argument accepts the alias afterwards.",
+		InputSchema: inputSchema[CreateAliasArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CreateAliasArgs) (*mcp.CallToolResult, *CreateAliasResult, error) {
+		name := strings.TrimSpace(args.Name)
+		if name == "" {
+			return nil, nil, fmt.Errorf("name is required. Pass a short name to use in place of the node ID (e.g. \"hero-button\")")
+		}
+		if args.NodeID == "" {
+			return nil, nil, errNodeIDRequired
+		}
+		if strings.Contains(name, ":") {
+			return nil, nil, fmt.Errorf("alias %q contains ':' and would shadow node IDs", name)
+		}
+
+		// Allow aliasing an alias by storing its target
+		nodeID := r.ResolveNodeID(args.NodeID)
+
+		previous, err := r.Aliases().Set(name, nodeID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := &CreateAliasResult{
+			Name:     name,
+			NodeID:   nodeID,
+			Previous: previous,
+			Path:     r.Aliases().Path(),
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = fmt.Sprintf("Alias %s → %s", result.Name, result.NodeID)
+			if previous != "" && previous != nodeID {
+				textOutput += fmt.Sprintf(" (was %s)", previous)
+			}
+			textOutput += fmt.Sprintf("\nSaved to %s", result.Path)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+func infoListAliases(r *Registry) (string, interface{}) {
+	aliases, err := r.Aliases().All()
+	if err != nil {
+		return fmt.Sprintf("Error reading aliases: %v", err), map[string]interface{}{"error": err.Error()}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Node Aliases\n")
+	sb.WriteString("============\n\n")
+
+	if len(aliases) == 0 {
+		sb.WriteString("No aliases defined. Use create_alias(name, node_id) to add one.\n")
+	} else {
+		names := make([]string, 0, len(aliases))
+		for name := range aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sb.WriteString("Alias                | Node ID\n")
+		sb.WriteString("-------------------- | -------\n")
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("%-20s | %s\n", name, aliases[name]))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\nFile: %s\n", r.Aliases().Path()))
+
+	data := map[string]interface{}{
+		"aliases": aliases,
+		"path":    r.Aliases().Path(),
+	}
+	return sb.String(), data
+}
@@ -0,0 +1,985 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// auditSource holds the nodes an audit tool inspects.
+type auditSource struct {
+	Nodes  []*figma.Node
+	Styles map[string]*figma.Style // keyed by style ID
+	Cached bool
+}
+
+// loadAuditSource returns every node in a file, preferring the sync_file cache.
+func loadAuditSource(ctx context.Context, r *Registry, fileKey string) (*auditSource, error) {
+	if cacheDir, err := findCacheDir(r.ExportDir(), fileKey); err == nil {
+		nodes, err := readNodesFromExport(cacheDir)
+		if err == nil && len(nodes) > 0 {
+			return &auditSource{Nodes: nodes, Styles: readStylesFromCache(cacheDir), Cached: true}, nil
+		}
+	}
+
+	if !r.HasClient() {
+		return nil, errNoCacheNoClient
+	}
+
+	file, err := r.Client().GetFile(ctx, fileKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching file: %w", err)
+	}
+	if file.Document == nil {
+		return nil, fmt.Errorf("file %s has no document", fileKey)
+	}
+
+	return &auditSource{Nodes: flattenNodes(file.Document), Styles: file.Styles}, nil
+}
+
+// readStylesFromCache reads the styles/*.json files written by sync_file.
+// Missing files are skipped.
+func readStylesFromCache(cacheDir string) map[string]*figma.Style {
+	styles := make(map[string]*figma.Style)
+
+	files := map[string]figma.StyleType{
+		"colors.json":     figma.StyleTypeFill,
+		"typography.json": figma.StyleTypeText,
+		"effects.json":    figma.StyleTypeEffect,
+		"grids.json":      figma.StyleTypeGrid,
+	}
+
+	for name, styleType := range files {
+		data, err := os.ReadFile(filepath.Join(cacheDir, "styles", name))
+		if err != nil {
+			continue
+		}
+
+		var entries []struct {
+			ID          string `json:"id"`
+			Key         string `json:"key"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			continue
+		}
+
+		for _, e := range entries {
+			styles[e.ID] = &figma.Style{
+				Key:         e.Key,
+				Name:        e.Name,
+				Description: e.Description,
+				StyleType:   styleType,
+			}
+		}
+	}
+
+	return styles
+}
+
+// writeCachedNote marks text output computed from a sync_file export, which
+// may be older than the file in Figma.
+func writeCachedNote(sb *strings.Builder, cached bool) {
+	if cached {
+		sb.WriteString("\n(from cache)\n")
+	}
+}
+
+// GetSpacingScaleArgs contains arguments for the get_spacing_scale tool.
+type GetSpacingScaleArgs struct {
+	FileKey  string  `json:"file_key" jsonschema:"Figma file key"`
+	BaseUnit float64 `json:"base_unit,omitempty" jsonschema:"Grid unit values should be multiples of (default: 4)"`
+	Format   string  `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// SpacingValue is one spacing value and where it is used.
+type SpacingValue struct {
+	Value      float64        `json:"value"`
+	Count      int            `json:"count"`
+	Properties map[string]int `json:"properties"`
+	OffScale   bool           `json:"off_scale,omitempty"`
+	Examples   []string       `json:"examples,omitempty"` // node IDs
+}
+
+// GetSpacingScaleResult contains the result of get_spacing_scale.
+type GetSpacingScaleResult struct {
+	Values          []SpacingValue `json:"values"`
+	BaseUnit        float64        `json:"base_unit"`
+	AutoLayoutNodes int            `json:"auto_layout_nodes"`
+	OffScale        int            `json:"off_scale"`
+	Cached          bool           `json:"cached"`
+}
+
+// maxAuditExamples caps the example node IDs recorded per flagged value.
+const maxAuditExamples = 5
+
+func registerGetSpacingScaleTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_spacing_scale",
+		Description: "List unique padding and gap values from auto-layout nodes with usage counts, flagging values off the spacing scale.",
+		InputSchema: inputSchema[GetSpacingScaleArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetSpacingScaleArgs) (*mcp.CallToolResult, *GetSpacingScaleResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		baseUnit := args.BaseUnit
+		if baseUnit <= 0 {
+			baseUnit = 4
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := collectSpacingScale(source.Nodes, baseUnit)
+		result.Cached = source.Cached
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatSpacingScaleResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// collectSpacingScale tallies padding and spacing values on auto-layout nodes.
+func collectSpacingScale(nodes []*figma.Node, baseUnit float64) *GetSpacingScaleResult {
+	byValue := make(map[float64]*SpacingValue)
+	result := &GetSpacingScaleResult{BaseUnit: baseUnit}
+
+	for _, node := range nodes {
+		if node.LayoutMode == "" || node.LayoutMode == "NONE" {
+			continue
+		}
+		result.AutoLayoutNodes++
+
+		props := []struct {
+			name  string
+			value float64
+		}{
+			{"paddingTop", node.PaddingTop},
+			{"paddingRight", node.PaddingRight},
+			{"paddingBottom", node.PaddingBottom},
+			{"paddingLeft", node.PaddingLeft},
+			{"itemSpacing", node.ItemSpacing},
+			{"counterAxisSpacing", node.CounterAxisSpacing},
+		}
+
+		for _, p := range props {
+			if p.value == 0 {
+				continue
+			}
+			sv, ok := byValue[p.value]
+			if !ok {
+				sv = &SpacingValue{
+					Value:      p.value,
+					Properties: make(map[string]int),
+					OffScale:   !onSpacingScale(p.value, baseUnit),
+				}
+				byValue[p.value] = sv
+			}
+			sv.Count++
+			sv.Properties[p.name]++
+			if sv.OffScale && len(sv.Examples) < maxAuditExamples && !containsStr(sv.Examples, node.ID) {
+				sv.Examples = append(sv.Examples, node.ID)
+			}
+		}
+	}
+
+	for _, sv := range byValue {
+		result.Values = append(result.Values, *sv)
+		if sv.OffScale {
+			result.OffScale++
+		}
+	}
+	sort.Slice(result.Values, func(i, j int) bool {
+		return result.Values[i].Value < result.Values[j].Value
+	})
+
+	return result
+}
+
+// onSpacingScale reports whether v is a whole multiple of baseUnit.
+func onSpacingScale(v, baseUnit float64) bool {
+	ratio := v / baseUnit
+	return math.Abs(ratio-math.Round(ratio)) < 1e-6
+}
+
+func formatSpacingScaleResult(r *GetSpacingScaleResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Spacing scale: %d unique values across %d auto-layout nodes (base unit %gpx)\n\n",
+		len(r.Values), r.AutoLayoutNodes, r.BaseUnit))
+
+	if len(r.Values) == 0 {
+		sb.WriteString("No auto-layout spacing found.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	sb.WriteString("Value  | Count | Used as\n")
+	sb.WriteString("------ | ----- | -------\n")
+
+	for _, v := range r.Values {
+		props := make([]string, 0, len(v.Properties))
+		for name, count := range v.Properties {
+			props = append(props, fmt.Sprintf("%s×%d", name, count))
+		}
+		sort.Strings(props)
+
+		flag := ""
+		if v.OffScale {
+			flag = "  ⚠ off-scale: " + strings.Join(v.Examples, ", ")
+		}
+		sb.WriteString(fmt.Sprintf("%-6s | %-5d | %s%s\n", fmt.Sprintf("%gpx", v.Value), v.Count, strings.Join(props, " "), flag))
+	}
+
+	if r.OffScale > 0 {
+		sb.WriteString(fmt.Sprintf("\n%d value(s) are not multiples of %gpx and may be inconsistencies.\n", r.OffScale, r.BaseUnit))
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
+
+// ListEffectsArgs contains arguments for the list_effects tool.
+type ListEffectsArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// EffectUsage is one unique effect stack and the nodes that use it.
+type EffectUsage struct {
+	Signature string         `json:"signature"`
+	Effects   []figma.Effect `json:"effects"`
+	Count     int            `json:"count"`
+	Styles    []string       `json:"styles,omitempty"`   // effect style names backing this stack
+	Unstyled  int            `json:"unstyled,omitempty"` // nodes using it without an effect style
+	Examples  []string       `json:"examples,omitempty"` // unstyled node IDs
+}
+
+// ListEffectsResult contains the result of list_effects.
+type ListEffectsResult struct {
+	Effects  []EffectUsage `json:"effects"`
+	Nodes    int           `json:"nodes"`
+	Unstyled int           `json:"unstyled"`
+	Cached   bool          `json:"cached"`
+}
+
+func registerListEffectsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_effects",
+		Description: "Inventory unique shadow and blur configurations with usage counts, linking effect styles and flagging unstyled effects.",
+		InputSchema: inputSchema[ListEffectsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListEffectsArgs) (*mcp.CallToolResult, *ListEffectsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := collectEffects(source.Nodes, source.Styles)
+		result.Cached = source.Cached
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatListEffectsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// collectEffects groups nodes by the canonical signature of their visible effects.
+func collectEffects(nodes []*figma.Node, styles map[string]*figma.Style) *ListEffectsResult {
+	bySignature := make(map[string]*EffectUsage)
+	result := &ListEffectsResult{}
+
+	for _, node := range nodes {
+		var visible []figma.Effect
+		var parts []string
+		for _, effect := range node.Effects {
+			if effect.Visible != nil && !*effect.Visible {
+				continue
+			}
+			visible = append(visible, effect)
+			parts = append(parts, effectSignature(&effect))
+		}
+		if len(visible) == 0 {
+			continue
+		}
+		result.Nodes++
+
+		signature := strings.Join(parts, " + ")
+		usage, ok := bySignature[signature]
+		if !ok {
+			usage = &EffectUsage{Signature: signature, Effects: visible}
+			bySignature[signature] = usage
+		}
+		usage.Count++
+
+		if styleID := nodeStyleIDs(node)["effect"]; styleID != "" {
+			name := styleID
+			if style, ok := styles[styleID]; ok && style.Name != "" {
+				name = style.Name
+			}
+			if !containsStr(usage.Styles, name) {
+				usage.Styles = append(usage.Styles, name)
+			}
+			continue
+		}
+
+		usage.Unstyled++
+		result.Unstyled++
+		if len(usage.Examples) < maxAuditExamples {
+			usage.Examples = append(usage.Examples, node.ID)
+		}
+	}
+
+	for _, usage := range bySignature {
+		sort.Strings(usage.Styles)
+		result.Effects = append(result.Effects, *usage)
+	}
+	sort.Slice(result.Effects, func(i, j int) bool {
+		if result.Effects[i].Count != result.Effects[j].Count {
+			return result.Effects[i].Count > result.Effects[j].Count
+		}
+		return result.Effects[i].Signature < result.Effects[j].Signature
+	})
+
+	return result
+}
+
+// effectSignature serializes the parameters of an effect to a stable string.
+func effectSignature(e *figma.Effect) string {
+	parts := []string{e.Type}
+	if e.Offset != nil {
+		parts = append(parts, fmt.Sprintf("x=%g y=%g", e.Offset.X, e.Offset.Y))
+	}
+	parts = append(parts, fmt.Sprintf("blur=%g", e.Radius))
+	if e.Spread != 0 {
+		parts = append(parts, fmt.Sprintf("spread=%g", e.Spread))
+	}
+	if e.Color != nil {
+		parts = append(parts, colorToCSS(e.Color, nil))
+	}
+	if e.BlendMode != "" && e.BlendMode != "NORMAL" {
+		parts = append(parts, strings.ToLower(e.BlendMode))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatListEffectsResult(r *ListEffectsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Effects: %d unique configurations on %d nodes (%d without an effect style)\n\n",
+		len(r.Effects), r.Nodes, r.Unstyled))
+
+	if len(r.Effects) == 0 {
+		sb.WriteString("No visible effects found.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	for _, e := range r.Effects {
+		sb.WriteString(fmt.Sprintf("%dx  %s\n", e.Count, e.Signature))
+		if len(e.Styles) > 0 {
+			sb.WriteString(fmt.Sprintf("     style: %s\n", strings.Join(e.Styles, ", ")))
+		}
+		if e.Unstyled > 0 {
+			sb.WriteString(fmt.Sprintf("     ⚠ %d unstyled: %s\n", e.Unstyled, strings.Join(e.Examples, ", ")))
+		}
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
+
+// TokenAuditArgs contains arguments for the token_audit tool.
+type TokenAuditArgs struct {
+	FileKey    string `json:"file_key" jsonschema:"Figma file key"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Max violations to return (default: 100)"`
+	Format     string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	OutputFile string `json:"output_file,omitempty" jsonschema:"Write full output to file path"`
+}
+
+// TokenViolation is a hard-coded value that matches an existing variable.
+type TokenViolation struct {
+	NodeID    string   `json:"node_id"`
+	NodeName  string   `json:"node_name"`
+	Property  string   `json:"property"`
+	Value     string   `json:"value"`
+	Variables []string `json:"variables"` // candidate variable names
+}
+
+// TokenAuditResult contains the result of token_audit.
+type TokenAuditResult struct {
+	Violations   []TokenViolation `json:"violations"`
+	Total        int              `json:"total"`
+	NodesChecked int              `json:"nodes_checked"`
+	FilePath     string           `json:"file_path,omitempty"`
+	Cached       bool             `json:"cached"`
+}
+
+func registerTokenAuditTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "token_audit",
+		Description: "Find fills, font sizes and corner radii whose hard-coded values match an existing variable but are not bound to it.",
+		InputSchema: inputSchema[TokenAuditArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args TokenAuditArgs) (*mcp.CallToolResult, *TokenAuditResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		limit := args.Limit
+		if limit <= 0 {
+			limit = 100
+		}
+
+		vars, err := r.Client().GetLocalVariables(ctx, args.FileKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching variables: %w", err)
+		}
+		if vars.Meta == nil || len(vars.Meta.Variables) == 0 {
+			return nil, nil, fmt.Errorf("no variables found in file")
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		index := newTokenValueIndex(vars.Meta.Variables, vars.Meta.VariableCollections)
+		violations := auditTokenBindings(source.Nodes, index)
+
+		result := &TokenAuditResult{
+			Violations:   violations,
+			Total:        len(violations),
+			NodesChecked: len(source.Nodes),
+			Cached:       source.Cached,
+		}
+		if len(result.Violations) > limit {
+			result.Violations = result.Violations[:limit]
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatTokenAuditResult(result)
+		}
+
+		outputCfg := OutputConfig{
+			MaxOutputSize: DefaultMaxOutputSize,
+			OutputFile:    args.OutputFile,
+			OutputDir:     r.ExportDir(),
+			ToolName:      "token_audit",
+			FileKey:       args.FileKey,
+		}
+
+		outputResult, err := ProcessOutput(textOutput, result, outputCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("processing output: %w", err)
+		}
+
+		if outputResult.WasWrittenToFile {
+			result.FilePath = outputResult.FilePath
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: outputResult.Text},
+			},
+		}, result, nil
+	})
+}
+
+// tokenValueIndex maps resolved default-mode values to the variables holding them.
+type tokenValueIndex struct {
+	colors map[string][]*figma.Variable
+	floats map[float64][]*figma.Variable
+}
+
+func newTokenValueIndex(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection) *tokenValueIndex {
+	idx := &tokenValueIndex{
+		colors: make(map[string][]*figma.Variable),
+		floats: make(map[float64][]*figma.Variable),
+	}
+	resolver := newTokenResolver(variables, collections)
+
+	for _, v := range variables {
+		coll := collections[v.VariableCollectionID]
+		if coll == nil {
+			continue
+		}
+		value, _, err := resolver.resolve(v, coll.DefaultModeID)
+		if err != nil {
+			continue
+		}
+
+		switch v.ResolvedType {
+		case "COLOR":
+			var c figma.Color
+			if err := json.Unmarshal(value, &c); err == nil {
+				key := colorKey(&c)
+				idx.colors[key] = append(idx.colors[key], v)
+			}
+		case "FLOAT":
+			var f float64
+			if err := json.Unmarshal(value, &f); err == nil {
+				idx.floats[f] = append(idx.floats[f], v)
+			}
+		}
+	}
+
+	return idx
+}
+
+// colorKey formats a color as hex, adding alpha only when translucent.
+func colorKey(c *figma.Color) string {
+	key := fmt.Sprintf("#%02x%02x%02x", int(math.Round(c.R*255)), int(math.Round(c.G*255)), int(math.Round(c.B*255)))
+	if c.A < 1 {
+		key += fmt.Sprintf("%02x", int(math.Round(c.A*255)))
+	}
+	return key
+}
+
+// matchingVariables returns the names of candidates whose scopes allow the property.
+// Variables without scopes are treated as usable anywhere.
+func matchingVariables(candidates []*figma.Variable, scopes ...string) []string {
+	var names []string
+	for _, v := range candidates {
+		if variableScoped(v, scopes) && !containsStr(names, v.Name) {
+			names = append(names, v.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func variableScoped(v *figma.Variable, scopes []string) bool {
+	if len(v.Scopes) == 0 {
+		return true
+	}
+	for _, s := range v.Scopes {
+		if s == "ALL_SCOPES" || containsStr(scopes, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditTokenBindings lists unbound fills, font sizes and corner radii that match a variable.
+func auditTokenBindings(nodes []*figma.Node, idx *tokenValueIndex) []TokenViolation {
+	var violations []TokenViolation
+
+	add := func(node *figma.Node, property, value string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		violations = append(violations, TokenViolation{
+			NodeID:    node.ID,
+			NodeName:  node.Name,
+			Property:  property,
+			Value:     value,
+			Variables: names,
+		})
+	}
+
+	for _, node := range nodes {
+		fillScope := "SHAPE_FILL"
+		switch node.Type {
+		case figma.NodeTypeText:
+			fillScope = "TEXT_FILL"
+		case figma.NodeTypeFrame, figma.NodeTypeComponent, figma.NodeTypeInstance:
+			fillScope = "FRAME_FILL"
+		}
+
+		for i, fill := range node.Fills {
+			if fill.Type != "SOLID" || fill.Color == nil || (fill.Visible != nil && !*fill.Visible) {
+				continue
+			}
+			if _, bound := fill.BoundVariables["color"]; bound {
+				continue
+			}
+			key := colorKey(fill.Color)
+			add(node, fmt.Sprintf("fills[%d]", i), key, matchingVariables(idx.colors[key], "ALL_FILLS", fillScope))
+		}
+
+		if node.Style != nil && node.Style.FontSize > 0 {
+			if _, bound := node.BoundVariables["fontSize"]; !bound {
+				add(node, "fontSize", fmt.Sprintf("%g", node.Style.FontSize), matchingVariables(idx.floats[node.Style.FontSize], "FONT_SIZE"))
+			}
+		}
+
+		if node.CornerRadius > 0 {
+			bound := false
+			for _, key := range []string{"cornerRadius", "topLeftRadius", "topRightRadius", "bottomLeftRadius", "bottomRightRadius"} {
+				if _, ok := node.BoundVariables[key]; ok {
+					bound = true
+				}
+			}
+			if !bound {
+				add(node, "cornerRadius", fmt.Sprintf("%g", node.CornerRadius), matchingVariables(idx.floats[node.CornerRadius], "CORNER_RADIUS"))
+			}
+		}
+	}
+
+	return violations
+}
+
+func formatTokenAuditResult(r *TokenAuditResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Token audit: %d hard-coded values match existing variables (%d nodes checked)\n\n",
+		r.Total, r.NodesChecked))
+
+	if r.Total == 0 {
+		sb.WriteString("No unbound values found.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	for _, v := range r.Violations {
+		sb.WriteString(fmt.Sprintf("%s %s › %s = %s → %s\n", v.NodeID, v.NodeName, v.Property, v.Value, strings.Join(v.Variables, " | ")))
+	}
+
+	if len(r.Violations) < r.Total {
+		sb.WriteString(fmt.Sprintf("\n... %d more (increase limit to see all)\n", r.Total-len(r.Violations)))
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
+
+// FindDuplicatesArgs contains arguments for the find_duplicates tool.
+type FindDuplicatesArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// DuplicateGroup is a set of components with the same visual fingerprint.
+type DuplicateGroup struct {
+	Hash       string          `json:"hash"`
+	Components []DuplicateNode `json:"components"`
+}
+
+// DuplicateNode identifies one component in a duplicate group.
+type DuplicateNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FindDuplicatesResult contains the result of find_duplicates.
+type FindDuplicatesResult struct {
+	Groups     []DuplicateGroup `json:"groups"`
+	Components int              `json:"components"`
+	Cached     bool             `json:"cached"`
+}
+
+func registerFindDuplicatesTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_duplicates",
+		Description: "Find components that look identical (same fills, strokes, effects, radius and child structure) but exist under different names.",
+		InputSchema: inputSchema[FindDuplicatesArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args FindDuplicatesArgs) (*mcp.CallToolResult, *FindDuplicatesResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := findDuplicateComponents(source.Nodes)
+		result.Cached = source.Cached
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatFindDuplicatesResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// componentFingerprint hashes the visual properties of a component.
+// ok is false for components with nothing to compare.
+func componentFingerprint(node *figma.Node) (string, bool) {
+	if len(node.Fills) == 0 && len(node.Strokes) == 0 && len(node.Effects) == 0 && len(node.Children) == 0 {
+		return "", false
+	}
+
+	canonical, err := json.Marshal(struct {
+		Fills        []figma.Paint  `json:"fills"`
+		Strokes      []figma.Paint  `json:"strokes"`
+		Effects      []figma.Effect `json:"effects"`
+		CornerRadius float64        `json:"cornerRadius"`
+		Structure    string         `json:"structure"`
+	}{node.Fills, node.Strokes, node.Effects, node.CornerRadius, childStructure(node)})
+	if err != nil {
+		return "", false
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])[:12], true
+}
+
+// childStructure renders the descendant types of a node, e.g. "FRAME(TEXT,VECTOR)".
+func childStructure(node *figma.Node) string {
+	if len(node.Children) == 0 {
+		return ""
+	}
+	parts := make([]string, len(node.Children))
+	for i, child := range node.Children {
+		parts[i] = string(child.Type) + childStructure(child)
+	}
+	return "(" + strings.Join(parts, ",") + ")"
+}
+
+func findDuplicateComponents(nodes []*figma.Node) *FindDuplicatesResult {
+	result := &FindDuplicatesResult{Groups: []DuplicateGroup{}}
+	byHash := make(map[string][]DuplicateNode)
+	var order []string
+
+	for _, node := range nodes {
+		if node.Type != figma.NodeTypeComponent {
+			continue
+		}
+		result.Components++
+
+		hash, ok := componentFingerprint(node)
+		if !ok {
+			continue
+		}
+		if _, seen := byHash[hash]; !seen {
+			order = append(order, hash)
+		}
+		byHash[hash] = append(byHash[hash], DuplicateNode{ID: node.ID, Name: node.Name})
+	}
+
+	for _, hash := range order {
+		if members := byHash[hash]; len(members) > 1 {
+			result.Groups = append(result.Groups, DuplicateGroup{Hash: hash, Components: members})
+		}
+	}
+	sort.SliceStable(result.Groups, func(i, j int) bool {
+		return len(result.Groups[i].Components) > len(result.Groups[j].Components)
+	})
+
+	return result
+}
+
+func formatFindDuplicatesResult(r *FindDuplicatesResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Found %d duplicate groups among %d components\n\n", len(r.Groups), r.Components))
+
+	for _, g := range r.Groups {
+		sb.WriteString(fmt.Sprintf("Group %s (%d components):\n", g.Hash, len(g.Components)))
+		for _, c := range g.Components {
+			sb.WriteString(fmt.Sprintf("  [%s] %s\n", c.ID, c.Name))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
+
+// FindOrphanStylesArgs contains arguments for the find_orphan_styles tool.
+type FindOrphanStylesArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// OrphanStyle is a local style that no node references.
+type OrphanStyle struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// FindOrphanStylesResult contains the result of find_orphan_styles.
+type FindOrphanStylesResult struct {
+	Orphans     map[string][]OrphanStyle `json:"orphans"` // style type → styles
+	Total       int                      `json:"total"`
+	LocalStyles int                      `json:"local_styles"`
+	Cached      bool                     `json:"cached"`
+}
+
+func registerFindOrphanStylesTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_orphan_styles",
+		Description: "List local fill, text, effect and grid styles that no node in the file uses.",
+		InputSchema: inputSchema[FindOrphanStylesArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args FindOrphanStylesArgs) (*mcp.CallToolResult, *FindOrphanStylesResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := findOrphanStyles(source.Nodes, source.Styles)
+		result.Cached = source.Cached
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatFindOrphanStylesResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// nodeStyleIDs returns the IDs of the styles applied to node by kind (fill,
+// stroke, effect, grid, text). The REST API reports them in the node's styles
+// map, with plural keys for some kinds; the per-kind ID fields are also read.
+func nodeStyleIDs(node *figma.Node) map[string]string {
+	ids := make(map[string]string)
+	for kind, id := range map[string]string{
+		"fill":   node.FillStyleID,
+		"stroke": node.StrokeStyleID,
+		"effect": node.EffectStyleID,
+		"grid":   node.GridStyleID,
+		"text":   node.TextStyleID,
+	} {
+		if id != "" {
+			ids[kind] = id
+		}
+	}
+	for kind, id := range node.Styles {
+		if id != "" {
+			ids[strings.TrimSuffix(kind, "s")] = id
+		}
+	}
+	return ids
+}
+
+// findOrphanStyles returns the local styles whose IDs no node references.
+// Remote styles belong to libraries and are skipped.
+func findOrphanStyles(nodes []*figma.Node, styles map[string]*figma.Style) *FindOrphanStylesResult {
+	used := make(map[string]bool)
+	for _, node := range nodes {
+		for _, id := range nodeStyleIDs(node) {
+			used[id] = true
+		}
+	}
+
+	result := &FindOrphanStylesResult{Orphans: make(map[string][]OrphanStyle)}
+	for id, style := range styles {
+		if style.Remote {
+			continue
+		}
+		result.LocalStyles++
+		if used[id] {
+			continue
+		}
+		styleType := string(style.StyleType)
+		result.Orphans[styleType] = append(result.Orphans[styleType], OrphanStyle{ID: id, Name: style.Name, Description: style.Description})
+		result.Total++
+	}
+
+	for _, list := range result.Orphans {
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	}
+
+	return result
+}
+
+func formatFindOrphanStylesResult(r *FindOrphanStylesResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Found %d unused styles among %d local styles\n\n", r.Total, r.LocalStyles))
+
+	if r.Total == 0 {
+		sb.WriteString("Every local style is in use.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	types := make([]string, 0, len(r.Orphans))
+	for t := range r.Orphans {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		sb.WriteString(fmt.Sprintf("%s (%d):\n", t, len(r.Orphans[t])))
+		for _, s := range r.Orphans[t] {
+			sb.WriteString(fmt.Sprintf("  [%s] %s\n", s.ID, s.Name))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
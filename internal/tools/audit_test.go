@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCollectSpacingScale(t *testing.T) {
+	nodes := []*figma.Node{
+		{ID: "1:1", LayoutMode: "VERTICAL", PaddingTop: 16, PaddingBottom: 16, ItemSpacing: 8},
+		{ID: "1:2", LayoutMode: "HORIZONTAL", PaddingLeft: 16, PaddingRight: 13, ItemSpacing: 8},
+		{ID: "1:3", LayoutMode: "NONE", PaddingTop: 7},
+		{ID: "1:4", PaddingTop: 5},
+	}
+
+	result := collectSpacingScale(nodes, 4)
+
+	if result.AutoLayoutNodes != 2 {
+		t.Errorf("auto_layout_nodes = %d, want 2", result.AutoLayoutNodes)
+	}
+
+	expected := []struct {
+		value    float64
+		count    int
+		offScale bool
+	}{
+		{8, 2, false},
+		{13, 1, true},
+		{16, 3, false},
+	}
+
+	if len(result.Values) != len(expected) {
+		t.Fatalf("got %d values, want %d: %+v", len(result.Values), len(expected), result.Values)
+	}
+	for i, tt := range expected {
+		got := result.Values[i]
+		if got.Value != tt.value || got.Count != tt.count || got.OffScale != tt.offScale {
+			t.Errorf("values[%d] = {%g %d %v}, want {%g %d %v}", i, got.Value, got.Count, got.OffScale, tt.value, tt.count, tt.offScale)
+		}
+	}
+
+	if result.OffScale != 1 {
+		t.Errorf("off_scale = %d, want 1", result.OffScale)
+	}
+	if ex := result.Values[1].Examples; len(ex) != 1 || ex[0] != "1:2" {
+		t.Errorf("examples for 13 = %v, want [1:2]", ex)
+	}
+}
+
+func TestCollectEffects(t *testing.T) {
+	hidden := false
+	shadow := figma.Effect{Type: "DROP_SHADOW", Radius: 8, Offset: &figma.Vector{Y: 4}, Color: &figma.Color{A: 0.25}}
+	blur := figma.Effect{Type: "LAYER_BLUR", Radius: 4}
+
+	nodes := []*figma.Node{
+		{ID: "1:1", Effects: []figma.Effect{shadow}, Styles: map[string]string{"effect": "S:1"}},
+		{ID: "1:2", Effects: []figma.Effect{shadow}},
+		{ID: "1:3", Effects: []figma.Effect{shadow, {Type: "LAYER_BLUR", Radius: 2, Visible: &hidden}}},
+		{ID: "1:4", Effects: []figma.Effect{blur}},
+		{ID: "1:5", Effects: []figma.Effect{{Type: "LAYER_BLUR", Visible: &hidden}}},
+		{ID: "1:6"},
+	}
+	styles := map[string]*figma.Style{"S:1": {Name: "Elevation/1", StyleType: figma.StyleTypeEffect}}
+
+	result := collectEffects(nodes, styles)
+
+	if result.Nodes != 4 {
+		t.Errorf("nodes = %d, want 4", result.Nodes)
+	}
+	if result.Unstyled != 3 {
+		t.Errorf("unstyled = %d, want 3", result.Unstyled)
+	}
+	if len(result.Effects) != 2 {
+		t.Fatalf("got %d unique effects, want 2: %+v", len(result.Effects), result.Effects)
+	}
+
+	top := result.Effects[0]
+	if top.Count != 3 || top.Unstyled != 2 {
+		t.Errorf("shadow count/unstyled = %d/%d, want 3/2", top.Count, top.Unstyled)
+	}
+	if len(top.Styles) != 1 || top.Styles[0] != "Elevation/1" {
+		t.Errorf("shadow styles = %v, want [Elevation/1]", top.Styles)
+	}
+	if want := "DROP_SHADOW x=0 y=4 blur=8 rgba(0, 0, 0, 0.25)"; top.Signature != want {
+		t.Errorf("signature = %q, want %q", top.Signature, want)
+	}
+}
+
+func TestAuditTokenBindings(t *testing.T) {
+	collections := map[string]*figma.VariableCollection{
+		"c1": {ID: "c1", DefaultModeID: "m1"},
+	}
+	variables := map[string]*figma.Variable{
+		"v1": {ID: "v1", Name: "color/primary", VariableCollectionID: "c1", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m1": json.RawMessage(`{"r":0.2,"g":0.4,"b":1,"a":1}`)}},
+		"v2": {ID: "v2", Name: "radius/md", VariableCollectionID: "c1", ResolvedType: "FLOAT", Scopes: []string{"CORNER_RADIUS"},
+			ValuesByMode: map[string]json.RawMessage{"m1": json.RawMessage(`8`)}},
+		"v3": {ID: "v3", Name: "button/bg", VariableCollectionID: "c1", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m1": json.RawMessage(`{"type":"VARIABLE_ALIAS","id":"v1"}`)}},
+	}
+	primary := &figma.Color{R: 0.2, G: 0.4, B: 1, A: 1}
+
+	nodes := []*figma.Node{
+		{ID: "1:1", Name: "Unbound", Type: figma.NodeTypeRectangle, CornerRadius: 8,
+			Fills: []figma.Paint{{Type: "SOLID", Color: primary}}},
+		{ID: "1:2", Name: "Bound", Type: figma.NodeTypeRectangle,
+			Fills: []figma.Paint{{Type: "SOLID", Color: primary, BoundVariables: map[string]*figma.VariableAlias{"color": {ID: "v1"}}}}},
+		{ID: "1:3", Name: "Text", Type: figma.NodeTypeText, Style: &figma.TypeStyle{FontSize: 8}},
+		{ID: "1:4", Name: "Other", Type: figma.NodeTypeRectangle, CornerRadius: 6,
+			Fills: []figma.Paint{{Type: "SOLID", Color: &figma.Color{R: 1, A: 1}}}},
+	}
+
+	violations := auditTokenBindings(nodes, newTokenValueIndex(variables, collections))
+
+	if len(violations) != 2 {
+		t.Fatalf("got %d violations, want 2: %+v", len(violations), violations)
+	}
+
+	fill := violations[0]
+	if fill.NodeID != "1:1" || fill.Property != "fills[0]" || fill.Value != "#3366ff" {
+		t.Errorf("fill violation = %+v", fill)
+	}
+	if len(fill.Variables) != 2 || fill.Variables[0] != "button/bg" || fill.Variables[1] != "color/primary" {
+		t.Errorf("fill candidates = %v, want [button/bg color/primary]", fill.Variables)
+	}
+
+	// radius/md is scoped to CORNER_RADIUS, so the 8px font size is not flagged
+	radius := violations[1]
+	if radius.Property != "cornerRadius" || radius.Variables[0] != "radius/md" {
+		t.Errorf("radius violation = %+v", radius)
+	}
+}
+
+func TestFindDuplicateComponents(t *testing.T) {
+	red := []figma.Paint{{Type: "SOLID", Color: &figma.Color{R: 1, A: 1}}}
+	blue := []figma.Paint{{Type: "SOLID", Color: &figma.Color{B: 1, A: 1}}}
+	label := func() []*figma.Node {
+		return []*figma.Node{{Type: figma.NodeTypeText}, {Type: figma.NodeTypeVector}}
+	}
+
+	nodes := []*figma.Node{
+		{ID: "1:1", Name: "Button", Type: figma.NodeTypeComponent, Fills: red, CornerRadius: 4, Children: label()},
+		{ID: "1:2", Name: "Btn Copy", Type: figma.NodeTypeComponent, Fills: red, CornerRadius: 4, Children: label()},
+		{ID: "1:3", Name: "Button Blue", Type: figma.NodeTypeComponent, Fills: blue, CornerRadius: 4, Children: label()},
+		{ID: "1:4", Name: "Button Square", Type: figma.NodeTypeComponent, Fills: red, Children: label()},
+		{ID: "1:5", Name: "Frame", Type: figma.NodeTypeFrame, Fills: red, CornerRadius: 4, Children: label()},
+		{ID: "1:6", Name: "Empty A", Type: figma.NodeTypeComponent},
+		{ID: "1:7", Name: "Empty B", Type: figma.NodeTypeComponent},
+	}
+
+	result := findDuplicateComponents(nodes)
+
+	if result.Components != 6 {
+		t.Errorf("components = %d, want 6", result.Components)
+	}
+	if len(result.Groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %+v", len(result.Groups), result.Groups)
+	}
+	members := result.Groups[0].Components
+	if len(members) != 2 || members[0].ID != "1:1" || members[1].ID != "1:2" {
+		t.Errorf("group members = %+v, want 1:1 and 1:2", members)
+	}
+}
+
+func TestFindOrphanStyles(t *testing.T) {
+	styles := map[string]*figma.Style{
+		"S:used-fill":   {Name: "Brand/Primary", StyleType: figma.StyleTypeFill},
+		"S:unused-fill": {Name: "Legacy/Red", StyleType: figma.StyleTypeFill},
+		"S:used-text":   {Name: "Body", StyleType: figma.StyleTypeText},
+		"S:unused-grid": {Name: "12 col", StyleType: figma.StyleTypeGrid},
+		"S:remote":      {Name: "Library/Blue", StyleType: figma.StyleTypeFill, Remote: true},
+	}
+	// The REST API reports applied styles in each node's styles map
+	var text figma.Node
+	if err := json.Unmarshal([]byte(`{"id":"1:2","type":"TEXT","styles":{"text":"S:used-text"}}`), &text); err != nil {
+		t.Fatal(err)
+	}
+	nodes := []*figma.Node{
+		{ID: "1:1", Type: figma.NodeTypeFrame, Styles: map[string]string{"fills": "S:used-fill"}},
+		&text,
+	}
+
+	result := findOrphanStyles(nodes, styles)
+
+	if result.Total != 2 || result.LocalStyles != 4 {
+		t.Errorf("total = %d, local = %d; want 2, 4", result.Total, result.LocalStyles)
+	}
+	if fills := result.Orphans[string(figma.StyleTypeFill)]; len(fills) != 1 || fills[0].Name != "Legacy/Red" {
+		t.Errorf("fill orphans = %+v", fills)
+	}
+	if grids := result.Orphans[string(figma.StyleTypeGrid)]; len(grids) != 1 || grids[0].ID != "S:unused-grid" {
+		t.Errorf("grid orphans = %+v", grids)
+	}
+}
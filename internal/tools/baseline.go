@@ -0,0 +1,358 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// baselineManifestName lists the captured nodes in assets/baseline/.
+const baselineManifestName = "_baseline.json"
+
+// baselineChannelTolerance is how far an 8-bit color channel may drift
+// before a pixel counts as changed, absorbing anti-aliasing noise between
+// renders.
+const baselineChannelTolerance = 8
+
+// CaptureBaselineArgs contains arguments for the capture_baseline tool.
+type CaptureBaselineArgs struct {
+	FileKey   string   `json:"file_key" jsonschema:"Figma file key (must have been synced with sync_file)"`
+	NodeIDs   []string `json:"node_ids,omitempty" jsonschema:"Nodes to render (default: the nodes already in the baseline)"`
+	Scale     float64  `json:"scale,omitempty" jsonschema:"Render scale of new captures (default: 1); existing baselines are compared at the scale they were captured at"`
+	Threshold float64  `json:"threshold,omitempty" jsonschema:"Percentage of changed pixels below which a node counts as unchanged (default: 0.1)"`
+	Update    bool     `json:"update,omitempty" jsonschema:"Replace the baseline images with the new renders"`
+	Format    string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// BaselineNode is the comparison of one node's render with its baseline.
+type BaselineNode struct {
+	NodeID      string  `json:"node_id"`
+	Name        string  `json:"name,omitempty"`
+	Status      string  `json:"status"` // captured, unchanged, changed or resized
+	DiffPercent float64 `json:"diff_percent"`
+	Baseline    string  `json:"baseline"`
+	Current     string  `json:"current,omitempty"` // new render, kept when it differs from the baseline
+}
+
+// CaptureBaselineResult contains the result of capture_baseline.
+type CaptureBaselineResult struct {
+	Dir     string         `json:"dir"`
+	Nodes   []BaselineNode `json:"nodes"`
+	Changed int            `json:"changed"`
+	Failed  []string       `json:"failed,omitempty"`
+}
+
+// baselineEntry is a node in the baseline manifest.
+type baselineEntry struct {
+	Name       string    `json:"name,omitempty"`
+	File       string    `json:"file"`
+	Scale      float64   `json:"scale"`
+	CapturedAt time.Time `json:"capturedAt"`
+}
+
+func registerCaptureBaselineTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "capture_baseline",
+		Description: "Visual regression baseline: render nodes as PNG into assets/baseline/ of the sync_file export, and on later calls render them again and compare pixel by pixel. Reports each node's changed pixel percentage. Pass update=true to accept the new renders as the baseline.",
+		InputSchema: inputSchema[CaptureBaselineArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CaptureBaselineArgs) (*mcp.CallToolResult, *CaptureBaselineResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		args.NodeIDs = r.ResolveNodeIDs(args.NodeIDs)
+		threshold := args.Threshold
+		if threshold == 0 {
+			threshold = 0.1
+		}
+
+		cacheDir, err := findCacheDir(r.ExportDir(), args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		dir := filepath.Join(cacheDir, "assets", "baseline")
+		manifest, err := readBaselineManifest(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nodeIDs := args.NodeIDs
+		if len(nodeIDs) == 0 {
+			for id := range manifest {
+				nodeIDs = append(nodeIDs, id)
+			}
+			sort.Strings(nodeIDs)
+		}
+		if len(nodeIDs) == 0 {
+			return nil, nil, errNodeIDsRequired
+		}
+
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		names := make(map[string]string)
+		if nodes, err := r.Client().GetFileNodes(ctx, args.FileKey, nodeIDs, &figma.GetFileOptions{Depth: 1}); err == nil {
+			for id, wrapper := range nodes.Nodes {
+				if wrapper != nil && wrapper.Document != nil {
+					names[id] = wrapper.Document.Name
+				}
+			}
+		}
+
+		scales := baselineScales(nodeIDs, manifest, args.Scale, args.Update)
+		byScale := make(map[float64][]string)
+		for _, id := range nodeIDs {
+			byScale[scales[id]] = append(byScale[scales[id]], id)
+		}
+		imageURLs := make(map[string]string, len(nodeIDs))
+		for scale, ids := range byScale {
+			images, err := r.Client().GetImages(ctx, args.FileKey, ids, &figma.ImageExportOptions{Format: "png", Scale: scale})
+			if err != nil {
+				return nil, nil, fmt.Errorf("rendering nodes: %w", err)
+			}
+			for id, url := range images.Images {
+				imageURLs[id] = url
+			}
+		}
+
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("creating baseline directory: %w", err)
+		}
+
+		result := &CaptureBaselineResult{Dir: dir, Nodes: []BaselineNode{}}
+		for _, id := range nodeIDs {
+			imageURL := imageURLs[id]
+			if imageURL == "" {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %s", id, imageFailureReason("png")))
+				continue
+			}
+			data, err := r.Client().DownloadImage(ctx, imageURL)
+			if err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("download %s: %v", id, err))
+				continue
+			}
+
+			entry, ok := manifest[id]
+			if name := names[id]; name != "" {
+				entry.Name = name
+			}
+			node, err := compareBaseline(dir, id, entry, ok && !args.Update, data, threshold)
+			if err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+			if node.Status == "captured" {
+				manifest[id] = baselineEntry{Name: entry.Name, File: filepath.Base(node.Baseline), Scale: scales[id], CapturedAt: time.Now().UTC()}
+			}
+			if node.Status == "changed" || node.Status == "resized" {
+				result.Changed++
+			}
+			result.Nodes = append(result.Nodes, node)
+		}
+
+		if err := writeBaselineManifest(dir, manifest); err != nil {
+			return nil, nil, err
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatCaptureBaselineResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// baselineScales returns the scale to render each node at. A node compared
+// against its baseline is rendered at the scale the baseline was captured at,
+// so a different scale argument does not show up as a resize; new captures
+// and updates use the requested scale, defaulting to the stored one or 1.
+func baselineScales(nodeIDs []string, manifest map[string]baselineEntry, requested float64, update bool) map[string]float64 {
+	scales := make(map[string]float64, len(nodeIDs))
+	for _, id := range nodeIDs {
+		entry, ok := manifest[id]
+		switch {
+		case ok && entry.Scale > 0 && (!update || requested == 0):
+			scales[id] = entry.Scale
+		case requested > 0:
+			scales[id] = requested
+		default:
+			scales[id] = 1
+		}
+	}
+	return scales
+}
+
+func readBaselineManifest(dir string) (map[string]baselineEntry, error) {
+	manifest := make(map[string]baselineEntry)
+	data, err := os.ReadFile(filepath.Join(dir, baselineManifestName))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing baseline manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func writeBaselineManifest(dir string, manifest map[string]baselineEntry) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, baselineManifestName), data, 0644); err != nil {
+		return fmt.Errorf("writing baseline manifest: %w", err)
+	}
+	return nil
+}
+
+// compareBaseline compares a node's new render with its baseline image in
+// dir. Without a baseline to compare against (compare false), the render
+// becomes the baseline. A render that differs is kept next to the baseline
+// as <node>.current.png.
+func compareBaseline(dir, id string, entry baselineEntry, compare bool, render []byte, threshold float64) (BaselineNode, error) {
+	file := sanitizeID(id) + ".png"
+	node := BaselineNode{NodeID: id, Name: entry.Name, Baseline: filepath.Join(dir, file)}
+	currentPath := filepath.Join(dir, sanitizeID(id)+".current.png")
+
+	current, err := png.Decode(bytes.NewReader(render))
+	if err != nil {
+		return node, fmt.Errorf("decoding render: %w", err)
+	}
+
+	if compare {
+		baseline, err := readPNG(filepath.Join(dir, entry.File))
+		if err == nil {
+			node.DiffPercent, node.Status = pixelDiffPercent(baseline, current), "unchanged"
+			if baseline.Bounds().Size() != current.Bounds().Size() {
+				node.Status = "resized"
+			} else if node.DiffPercent >= threshold {
+				node.Status = "changed"
+			}
+			if node.Status == "unchanged" {
+				os.Remove(currentPath)
+				return node, nil
+			}
+			if err := os.WriteFile(currentPath, render, 0644); err != nil {
+				return node, fmt.Errorf("writing render: %w", err)
+			}
+			node.Current = currentPath
+			return node, nil
+		}
+		// A missing or unreadable baseline image is captured again.
+	}
+
+	if err := os.WriteFile(node.Baseline, render, 0644); err != nil {
+		return node, fmt.Errorf("writing baseline: %w", err)
+	}
+	os.Remove(currentPath)
+	node.Status = "captured"
+	return node, nil
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+// pixelDiffPercent returns the percentage of pixels whose color differs by
+// more than baselineChannelTolerance in any channel. Images of different
+// sizes are compared over the larger area, so pixels outside the smaller
+// image count as changed.
+func pixelDiffPercent(a, b image.Image) float64 {
+	ab, bb := a.Bounds(), b.Bounds()
+	width, height := ab.Dx(), ab.Dy()
+	if bb.Dx() > width {
+		width = bb.Dx()
+	}
+	if bb.Dy() > height {
+		height = bb.Dy()
+	}
+	total := width * height
+	if total == 0 {
+		return 0
+	}
+
+	changed := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x >= ab.Dx() || y >= ab.Dy() || x >= bb.Dx() || y >= bb.Dy() {
+				changed++
+				continue
+			}
+			if !similarPixels(a.At(ab.Min.X+x, ab.Min.Y+y).RGBA, b.At(bb.Min.X+x, bb.Min.Y+y).RGBA) {
+				changed++
+			}
+		}
+	}
+	return float64(changed) * 100 / float64(total)
+}
+
+func similarPixels(a, b func() (uint32, uint32, uint32, uint32)) bool {
+	ar, ag, ab, aa := a()
+	br, bg, bb, ba := b()
+	for _, pair := range [][2]uint32{{ar, br}, {ag, bg}, {ab, bb}, {aa, ba}} {
+		// RGBA returns 16-bit channels.
+		d := int(pair[0]>>8) - int(pair[1]>>8)
+		if d > baselineChannelTolerance || d < -baselineChannelTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func formatCaptureBaselineResult(r *CaptureBaselineResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Baseline: %s\n", r.Dir))
+	sb.WriteString(fmt.Sprintf("%d nodes, %d changed\n\n", len(r.Nodes), r.Changed))
+
+	for _, n := range r.Nodes {
+		label := n.NodeID
+		if n.Name != "" {
+			label = fmt.Sprintf("%s [%s]", n.Name, n.NodeID)
+		}
+		switch n.Status {
+		case "captured":
+			sb.WriteString(fmt.Sprintf("  + %s: captured\n", label))
+		case "unchanged":
+			sb.WriteString(fmt.Sprintf("    %s: unchanged (%.2f%%)\n", label, n.DiffPercent))
+		default:
+			sb.WriteString(fmt.Sprintf("  ~ %s: %s, %.2f%% of pixels differ\n", label, n.Status, n.DiffPercent))
+			sb.WriteString(fmt.Sprintf("      new render: %s\n", n.Current))
+		}
+	}
+
+	for _, f := range r.Failed {
+		sb.WriteString(fmt.Sprintf("\nFailed: %s\n", f))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+)
+
+func testPNG(t *testing.T, w, h int, changed int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < w*h; i++ {
+		c := color.RGBA{255, 255, 255, 255}
+		if i < changed {
+			c = color.RGBA{255, 0, 0, 255}
+		}
+		img.Set(i%w, i/w, c)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCompareBaseline(t *testing.T) {
+	dir := t.TempDir()
+
+	node, err := compareBaseline(dir, "1:2", baselineEntry{}, false, testPNG(t, 10, 10, 0), 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Status != "captured" {
+		t.Fatalf("status = %s, want captured", node.Status)
+	}
+	entry := baselineEntry{File: "1-2.png"}
+
+	node, err = compareBaseline(dir, "1:2", entry, true, testPNG(t, 10, 10, 0), 0.1)
+	if err != nil || node.Status != "unchanged" || node.DiffPercent != 0 {
+		t.Fatalf("identical render: %+v, %v", node, err)
+	}
+
+	node, err = compareBaseline(dir, "1:2", entry, true, testPNG(t, 10, 10, 5), 0.1)
+	if err != nil || node.Status != "changed" || node.DiffPercent != 5 {
+		t.Fatalf("changed render: %+v, %v", node, err)
+	}
+	if _, err := os.Stat(node.Current); err != nil {
+		t.Errorf("changed render not kept: %v", err)
+	}
+
+	// Changes below the threshold count as unchanged, and the stale
+	// current render is removed.
+	node, err = compareBaseline(dir, "1:2", entry, true, testPNG(t, 10, 10, 5), 10)
+	if err != nil || node.Status != "unchanged" {
+		t.Fatalf("below threshold: %+v, %v", node, err)
+	}
+	if _, err := os.Stat(dir + "/1-2.current.png"); !os.IsNotExist(err) {
+		t.Errorf("current render not removed: %v", err)
+	}
+
+	node, err = compareBaseline(dir, "1:2", entry, true, testPNG(t, 10, 20, 0), 0.1)
+	if err != nil || node.Status != "resized" || node.DiffPercent != 50 {
+		t.Fatalf("resized render: %+v, %v", node, err)
+	}
+}
+
+func TestBaselineScales(t *testing.T) {
+	manifest := map[string]baselineEntry{
+		"1:1": {File: "1-1.png", Scale: 2},
+		"1:2": {File: "1-2.png"}, // captured before scales were recorded
+	}
+	ids := []string{"1:1", "1:2", "1:3"}
+
+	tests := []struct {
+		name      string
+		requested float64
+		update    bool
+		want      map[string]float64
+	}{
+		{"compare at stored scale", 3, false, map[string]float64{"1:1": 2, "1:2": 3, "1:3": 3}},
+		{"default scale", 0, false, map[string]float64{"1:1": 2, "1:2": 1, "1:3": 1}},
+		{"update at requested scale", 3, true, map[string]float64{"1:1": 3, "1:2": 3, "1:3": 3}},
+		{"update keeps stored scale", 0, true, map[string]float64{"1:1": 2, "1:2": 1, "1:3": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := baselineScales(ids, manifest, tt.requested, tt.update)
+			for id, want := range tt.want {
+				if got[id] != want {
+					t.Errorf("scale of %s = %g, want %g", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBaselineManifestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := readBaselineManifest(dir)
+	if err != nil || len(manifest) != 0 {
+		t.Fatalf("missing manifest: %v, %v", manifest, err)
+	}
+	manifest["1:2"] = baselineEntry{Name: "Button", File: "1-2.png", Scale: 2}
+	if err := writeBaselineManifest(dir, manifest); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err = readBaselineManifest(dir)
+	if err != nil || manifest["1:2"].Name != "Button" || manifest["1:2"].Scale != 2 {
+		t.Errorf("round trip: %v, %v", manifest, err)
+	}
+}
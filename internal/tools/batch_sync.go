@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultSyncConcurrency is how many files batch_sync exports at once.
+const defaultSyncConcurrency = 3
+
+// BatchSyncArgs contains arguments for the batch_sync tool.
+type BatchSyncArgs struct {
+	FileKeys    []string     `json:"file_keys" jsonschema:"Figma file keys to sync"`
+	OutputDir   string       `json:"output_dir,omitempty" jsonschema:"Base directory for export; each file gets its own <file name>-<file key> sub-directory (default: ./figma-export)"`
+	Include     []string     `json:"include,omitempty" jsonschema:"What to export: pages components styles variables assets"`
+	Assets      AssetOptions `json:"assets,omitempty" jsonschema:"Asset export options"`
+	Concurrency int          `json:"concurrency,omitempty" jsonschema:"Number of files synced at once (default: 3)"`
+	Format      string       `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// BatchSyncFile is the outcome of syncing one file of a batch.
+type BatchSyncFile struct {
+	FileKey string          `json:"file_key"`
+	Result  *SyncFileResult `json:"result,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// BatchSyncResult contains the result of batch_sync.
+type BatchSyncResult struct {
+	Files       []BatchSyncFile `json:"files"`
+	Synced      int             `json:"synced"`
+	Failed      int             `json:"failed"`
+	TotalNodes  int             `json:"total_nodes"`
+	TotalAssets int             `json:"total_assets"`
+	DurationMS  int64           `json:"duration_ms"`
+}
+
+func registerBatchSyncTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "batch_sync",
+		Description: "Sync several Figma files in one call, exporting them concurrently. Each file is exported into its own sub-directory of output_dir named <file name>-<file key>. Repeated keys are synced once. A file that fails does not stop the others.",
+		InputSchema: inputSchema[BatchSyncArgs](map[string][]string{
+			"include":        {"pages", "components", "styles", "variables", "assets"},
+			"assets.formats": {"png", "svg", "pdf", "jpg"},
+			"format":         responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args BatchSyncArgs) (*mcp.CallToolResult, *BatchSyncResult, error) {
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+		if len(args.FileKeys) == 0 {
+			return nil, nil, fmt.Errorf("file_keys is required. Pass the keys from the Figma URLs: figma.com/design/<FILE_KEY>/... (e.g. [\"abc123\", \"def456\"])")
+		}
+
+		startTime := time.Now()
+		result := syncFiles(args.FileKeys, args.Concurrency, func(fileKey string) (*SyncFileResult, error) {
+			// Progress notifications of concurrent syncs would interleave,
+			// so files are synced without the request.
+			return r.syncFile(ctx, nil, SyncFileArgs{
+				FileKey:   fileKey,
+				OutputDir: args.OutputDir,
+				Include:   args.Include,
+				Assets:    args.Assets,
+				keyedDir:  true,
+			})
+		})
+		result.DurationMS = time.Since(startTime).Milliseconds()
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatBatchSyncResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// syncFiles runs syncOne for each file key, at most concurrency at a time, and
+// totals the results. Files are reported in the order given; a repeated key is
+// synced once, as two concurrent syncs of a file would write the same export.
+func syncFiles(fileKeys []string, concurrency int, syncOne func(fileKey string) (*SyncFileResult, error)) *BatchSyncResult {
+	if concurrency <= 0 {
+		concurrency = defaultSyncConcurrency
+	}
+
+	seen := make(map[string]bool, len(fileKeys))
+	var unique []string
+	for _, fileKey := range fileKeys {
+		if !seen[fileKey] {
+			seen[fileKey] = true
+			unique = append(unique, fileKey)
+		}
+	}
+	fileKeys = unique
+
+	files := make([]BatchSyncFile, len(fileKeys))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, fileKey := range fileKeys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			files[i].FileKey = fileKey
+			res, err := syncOne(fileKey)
+			if err != nil {
+				files[i].Error = err.Error()
+				return
+			}
+			files[i].Result = res
+		}()
+	}
+	wg.Wait()
+
+	result := &BatchSyncResult{Files: files}
+	for _, f := range files {
+		if f.Result == nil {
+			result.Failed++
+			continue
+		}
+		result.Synced++
+		result.TotalNodes += f.Result.Stats.Nodes
+		result.TotalAssets += f.Result.Stats.Assets
+	}
+	return result
+}
+
+func formatBatchSyncResult(r *BatchSyncResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Synced %d of %d files\n", r.Synced, len(r.Files)))
+	sb.WriteString(fmt.Sprintf("Nodes: %d, Assets: %d, Duration: %dms\n\n", r.TotalNodes, r.TotalAssets, r.DurationMS))
+
+	for _, f := range r.Files {
+		if f.Result == nil {
+			sb.WriteString(fmt.Sprintf("  ✗ %s: %s\n", f.FileKey, f.Error))
+			continue
+		}
+		s := f.Result.Stats
+		sb.WriteString(fmt.Sprintf("  ✓ %s → %s (%d pages, %d nodes, %d assets, %dms)\n",
+			f.FileKey, f.Result.ExportPath, s.Pages, s.Nodes, s.Assets, s.DurationMS))
+		if len(f.Result.Errors) > 0 {
+			sb.WriteString(fmt.Sprintf("      %d warnings\n", len(f.Result.Errors)))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncFiles(t *testing.T) {
+	var running, peak atomic.Int32
+	result := syncFiles([]string{"A", "B", "FAIL", "C"}, 2, func(fileKey string) (*SyncFileResult, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if fileKey == "FAIL" {
+			return nil, fmt.Errorf("file not found")
+		}
+		return &SyncFileResult{
+			ExportPath: "/export/" + fileKey,
+			Stats:      SyncStats{Nodes: 10, Assets: 2},
+		}, nil
+	})
+
+	if peak.Load() > 2 {
+		t.Errorf("ran %d syncs at once, want at most 2", peak.Load())
+	}
+	if result.Synced != 3 || result.Failed != 1 {
+		t.Errorf("synced %d, failed %d, want 3 and 1", result.Synced, result.Failed)
+	}
+	if result.TotalNodes != 30 || result.TotalAssets != 6 {
+		t.Errorf("totals = %d nodes, %d assets, want 30 and 6", result.TotalNodes, result.TotalAssets)
+	}
+
+	var keys []string
+	for _, f := range result.Files {
+		keys = append(keys, f.FileKey)
+	}
+	if got := strings.Join(keys, ","); got != "A,B,FAIL,C" {
+		t.Errorf("file order = %s, want A,B,FAIL,C", got)
+	}
+	if result.Files[2].Error != "file not found" || result.Files[2].Result != nil {
+		t.Errorf("failed file = %+v", result.Files[2])
+	}
+
+	text := formatBatchSyncResult(result)
+	if !strings.Contains(text, "Synced 3 of 4 files") || !strings.Contains(text, "✗ FAIL: file not found") {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+}
+
+func TestSyncFilesDedupesKeys(t *testing.T) {
+	var calls atomic.Int32
+	result := syncFiles([]string{"A", "B", "A"}, 2, func(fileKey string) (*SyncFileResult, error) {
+		calls.Add(1)
+		return &SyncFileResult{ExportPath: "/export/" + fileKey}, nil
+	})
+	if calls.Load() != 2 || len(result.Files) != 2 {
+		t.Errorf("synced %d times with %d files, want each key once", calls.Load(), len(result.Files))
+	}
+}
+
+func TestSyncExportPath(t *testing.T) {
+	if got := syncExportPath("/out", "Design System", "KEY1", false); got != filepath.Join("/out", sanitizeName("Design System")) {
+		t.Errorf("unkeyed path = %q", got)
+	}
+	a := syncExportPath("/out", "Design System", "KEY1", true)
+	b := syncExportPath("/out", "Design System", "KEY2", true)
+	if a == b || !strings.HasSuffix(a, "-KEY1") {
+		t.Errorf("keyed paths %q and %q should differ by file key", a, b)
+	}
+	if got := syncExportPath("/out", "X", "../up", true); filepath.Dir(got) != "/out" {
+		t.Errorf("file key escaped the output directory: %q", got)
+	}
+}
@@ -0,0 +1,323 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// bookmarkFileName is the per-user file persisted bookmarks are saved to,
+// stored in the home directory.
+const bookmarkFileName = ".figma-query-bookmarks.json"
+
+// Bookmark names a file and, optionally, a node in it.
+type Bookmark struct {
+	Name    string `json:"name"`
+	FileKey string `json:"file_key"`
+	NodeID  string `json:"node_id,omitempty"`
+}
+
+// BookmarkStore holds the bookmarks of this process. Bookmarks saved to the
+// bookmark file by earlier sessions are loaded on first use.
+type BookmarkStore struct {
+	mu        sync.Mutex
+	path      string
+	bookmarks map[string]Bookmark // nil until loaded
+}
+
+// NewBookmarkStore creates a bookmark store persisting to the JSON file at path.
+func NewBookmarkStore(path string) *BookmarkStore {
+	return &BookmarkStore{path: path}
+}
+
+// defaultBookmarkPath returns ~/.figma-query-bookmarks.json.
+func defaultBookmarkPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = os.TempDir()
+	}
+	return filepath.Join(home, bookmarkFileName)
+}
+
+// Path returns the bookmark file location.
+func (s *BookmarkStore) Path() string {
+	return s.path
+}
+
+// All returns a copy of the bookmarks.
+func (s *BookmarkStore) All() (map[string]Bookmark, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	all := make(map[string]Bookmark, len(s.bookmarks))
+	for name, b := range s.bookmarks {
+		all[name] = b
+	}
+	return all, nil
+}
+
+// Get returns the bookmark called name.
+func (s *BookmarkStore) Get(name string) (Bookmark, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return Bookmark{}, false, err
+	}
+	b, ok := s.bookmarks[name]
+	return b, ok, nil
+}
+
+// Set stores b for this process and, with persist, in the bookmark file. It
+// returns the bookmark it replaced, if any.
+func (s *BookmarkStore) Set(b Bookmark, persist bool) (*Bookmark, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	if persist {
+		saved, err := s.read()
+		if err != nil {
+			return nil, err
+		}
+		saved[b.Name] = b
+		if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+			return nil, fmt.Errorf("creating bookmark directory: %w", err)
+		}
+		if err := writeJSON(s.path, saved); err != nil {
+			return nil, fmt.Errorf("writing bookmarks: %w", err)
+		}
+	}
+
+	var previous *Bookmark
+	if old, ok := s.bookmarks[b.Name]; ok {
+		previous = &old
+	}
+	s.bookmarks[b.Name] = b
+	return previous, nil
+}
+
+// load reads the bookmark file the first time the store is used.
+func (s *BookmarkStore) load() error {
+	if s.bookmarks != nil {
+		return nil
+	}
+	saved, err := s.read()
+	if err != nil {
+		return err
+	}
+	s.bookmarks = saved
+	return nil
+}
+
+// read returns the bookmarks in the bookmark file. A missing file has none.
+func (s *BookmarkStore) read() (map[string]Bookmark, error) {
+	bookmarks := make(map[string]Bookmark)
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return bookmarks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading bookmarks: %w", err)
+	}
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", s.path, err)
+	}
+	return bookmarks, nil
+}
+
+// bookmarkMiddleware expands the bookmark argument of tools/call requests
+// into the file_key and node_id (or node_ids) arguments the tool accepts.
+// Arguments given explicitly take precedence over the bookmark.
+func (r *Registry) bookmarkMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok || params.Name == "bookmark" || len(params.Arguments) == 0 {
+			return next(ctx, method, req)
+		}
+
+		args := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			return next(ctx, method, req)
+		}
+		raw, ok := args["bookmark"]
+		if !ok {
+			return next(ctx, method, req)
+		}
+		delete(args, "bookmark")
+
+		var name string
+		json.Unmarshal(raw, &name)
+		if name != "" {
+			b, found, err := r.bookmarks.Get(name)
+			errText := ""
+			if err != nil {
+				errText = err.Error()
+			} else if !found {
+				errText = fmt.Sprintf("unknown bookmark %q. Create it with bookmark(name, file_key, node_id) or see info(topic=\"list_bookmarks\")", name)
+			}
+			if errText != "" {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: errText}},
+				}, nil
+			}
+
+			props := r.toolSchema(ctx, req, next, params.Name).properties
+			setArg := func(key string, value any) {
+				if props[key] && !hasArg(args, key) {
+					args[key], _ = json.Marshal(value)
+				}
+			}
+			setArg("file_key", b.FileKey)
+			if b.NodeID != "" {
+				if props["node_id"] {
+					setArg("node_id", b.NodeID)
+				} else {
+					setArg("node_ids", []string{b.NodeID})
+				}
+			}
+		}
+
+		params.Arguments, _ = json.Marshal(args)
+		return next(ctx, method, req)
+	}
+}
+
+// hasArg reports whether args has a non-empty value for key.
+func hasArg(args map[string]json.RawMessage, key string) bool {
+	switch v := string(args[key]); v {
+	case "", `""`, "null", "[]":
+		return false
+	default:
+		return true
+	}
+}
+
+// BookmarkArgs contains arguments for the bookmark tool.
+type BookmarkArgs struct {
+	Name    string `json:"name" jsonschema:"Bookmark name to pass as the bookmark argument of other tools (e.g. checkout-form)"`
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	NodeID  string `json:"node_id,omitempty" jsonschema:"Node ID or alias in the file"`
+	Persist bool   `json:"persist,omitempty" jsonschema:"Also save the bookmark to the bookmark file (~/.figma-query-bookmarks.json, or the export directory of a per-token HTTP session) for later sessions"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// BookmarkResult contains the result of bookmark.
+type BookmarkResult struct {
+	Bookmark
+	Previous *Bookmark `json:"previous,omitempty"`
+	Path     string    `json:"path,omitempty"` // set when persisted
+}
+
+func registerBookmarkTool(server *mcp.Server, r *Registry) {
+	schema := inputSchema[BookmarkArgs](map[string][]string{
+		"format": responseFormats,
+	})
+	delete(schema.Properties, "bookmark")
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "bookmark",
+		Description: "Save a name for a file_key and node_id. Every tool that takes file_key then accepts bookmark=<name> in place of file_key and node_id.",
+		InputSchema: schema,
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args BookmarkArgs) (*mcp.CallToolResult, *BookmarkResult, error) {
+		name := strings.TrimSpace(args.Name)
+		if name == "" {
+			return nil, nil, fmt.Errorf("name is required. Pass a short name to reuse as the bookmark argument (e.g. \"checkout-form\")")
+		}
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		b := Bookmark{Name: name, FileKey: args.FileKey, NodeID: r.ResolveNodeID(args.NodeID)}
+		previous, err := r.Bookmarks().Set(b, args.Persist)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := &BookmarkResult{Bookmark: b, Previous: previous}
+		if args.Persist {
+			result.Path = r.Bookmarks().Path()
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = fmt.Sprintf("Bookmark %s → %s", name, formatBookmarkTarget(b))
+			if previous != nil && *previous != b {
+				textOutput += fmt.Sprintf(" (was %s)", formatBookmarkTarget(*previous))
+			}
+			if result.Path != "" {
+				textOutput += fmt.Sprintf("\nSaved to %s", result.Path)
+			}
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+func formatBookmarkTarget(b Bookmark) string {
+	if b.NodeID == "" {
+		return "file " + b.FileKey
+	}
+	return fmt.Sprintf("file %s, node %s", b.FileKey, b.NodeID)
+}
+
+func infoListBookmarks(r *Registry) (string, interface{}) {
+	bookmarks, err := r.Bookmarks().All()
+	if err != nil {
+		return fmt.Sprintf("Error reading bookmarks: %v", err), map[string]interface{}{"error": err.Error()}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Bookmarks\n")
+	sb.WriteString("=========\n\n")
+
+	if len(bookmarks) == 0 {
+		sb.WriteString("No bookmarks defined. Use bookmark(name, file_key, node_id) to add one.\n")
+	} else {
+		names := make([]string, 0, len(bookmarks))
+		for name := range bookmarks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sb.WriteString("Bookmark             | File key               | Node ID\n")
+		sb.WriteString("-------------------- | ---------------------- | -------\n")
+		for _, name := range names {
+			b := bookmarks[name]
+			sb.WriteString(fmt.Sprintf("%-20s | %-22s | %s\n", name, b.FileKey, b.NodeID))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\nPersisted bookmarks: %s\n", r.Bookmarks().Path()))
+
+	data := map[string]interface{}{
+		"bookmarks": bookmarks,
+		"path":      r.Bookmarks().Path(),
+	}
+	return sb.String(), data
+}
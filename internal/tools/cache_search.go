@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// treeLineRegex parses the node lines of _tree.txt: "├── Name [1:2] TYPE".
+var treeLineRegex = regexp.MustCompile(`├── (.*) \[([^\]]+)\] (\S+)$`)
+
+// CacheSearchArgs contains arguments for the cache_search tool.
+type CacheSearchArgs struct {
+	Pattern   string `json:"pattern" jsonschema:"Search pattern (supports glob * and regex /pattern/)"`
+	SearchIn  string `json:"search_in,omitempty" jsonschema:"What to search: node-names (default), css-values or characters"`
+	ExportDir string `json:"export_dir,omitempty" jsonschema:"Export directory, or one sync_file export in it (default: the configured export directory)"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Max results (default: 50)"`
+	Format    string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// CacheMatch is a node of a sync_file export matching a cache_search pattern.
+type CacheMatch struct {
+	FileKey  string `json:"file_key"`
+	FileName string `json:"file_name"`
+	NodeID   string `json:"node_id"`
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Path     string `json:"path"`            // node directory relative to the export
+	Match    string `json:"match,omitempty"` // matching CSS declarations or text
+}
+
+// CacheSearchResult contains the result of cache_search.
+type CacheSearchResult struct {
+	ExportDir string       `json:"export_dir"`
+	SearchIn  string       `json:"search_in"`
+	Exports   int          `json:"exports"`
+	Results   []CacheMatch `json:"results"`
+	Total     int          `json:"total"`
+	HasMore   bool         `json:"has_more"`
+}
+
+func registerCacheSearchTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "cache_search",
+		Description: "Search sync_file exports on disk without the Figma API: node names from _tree.txt and _index.json, CSS values from _css.json, or text content from _node.json. Works without a Figma token.",
+		InputSchema: inputSchema[CacheSearchArgs](map[string][]string{
+			"search_in": {"node-names", "css-values", "characters"},
+			"format":    responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CacheSearchArgs) (*mcp.CallToolResult, *CacheSearchResult, error) {
+		if args.Pattern == "" {
+			return nil, nil, errPatternRequired
+		}
+		searchIn := args.SearchIn
+		if searchIn == "" {
+			searchIn = "node-names"
+		}
+		if searchIn != "node-names" && searchIn != "css-values" && searchIn != "characters" {
+			return nil, nil, fmt.Errorf("search_in must be node-names, css-values or characters, got %q", searchIn)
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = 50
+		}
+
+		re, err := buildSearchRegex(args.Pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+
+		exportDir := r.ExportDir()
+		if args.ExportDir != "" {
+			exportDir = resolveExportPath(r.ExportDir(), args.ExportDir)
+		}
+
+		result, err := searchCache(exportDir, searchIn, re, limit)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatCacheSearchResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// searchCache searches every export under dir, or dir itself when it is an
+// export.
+func searchCache(dir, searchIn string, re *regexp.Regexp, limit int) (*CacheSearchResult, error) {
+	var exports []cachedFile
+	if meta, err := readExportMeta(dir); err == nil {
+		exports = []cachedFile{{Dir: dir, FileKey: meta.FileKey, Name: filepath.Base(dir)}}
+	} else {
+		exports, _ = listCachedFiles(dir)
+	}
+	if len(exports) == 0 {
+		return nil, fmt.Errorf("no synced files found in %s (run sync_file first)", dir)
+	}
+
+	result := &CacheSearchResult{ExportDir: dir, SearchIn: searchIn, Exports: len(exports), Results: []CacheMatch{}}
+	for _, c := range exports {
+		if len(result.Results) > limit {
+			break
+		}
+
+		var matches []CacheMatch
+		switch searchIn {
+		case "node-names":
+			matches = searchCachedNames(c.Dir, re)
+		case "css-values":
+			matches = searchCachedFiles(c.Dir, "_css.json", re, cssDeclarationMatch)
+		case "characters":
+			matches = searchCachedFiles(c.Dir, "_node.json", re, characterMatch)
+		}
+		for i := range matches {
+			matches[i].FileKey = c.FileKey
+			matches[i].FileName = c.Name
+		}
+		result.Results = append(result.Results, matches...)
+	}
+
+	result.HasMore = len(result.Results) > limit
+	if result.HasMore {
+		result.Results = result.Results[:limit]
+	}
+	result.Total = len(result.Results)
+	return result, nil
+}
+
+// searchCachedNames matches the node names listed in _tree.txt and locates
+// each match's directory through _index.json.
+func searchCachedNames(dir string, re *regexp.Regexp) []CacheMatch {
+	data, err := os.ReadFile(filepath.Join(dir, "_tree.txt"))
+	if err != nil {
+		return nil
+	}
+	index, _ := readExportIndex(dir)
+
+	var matches []CacheMatch
+	for _, line := range strings.Split(string(data), "\n") {
+		m := treeLineRegex.FindStringSubmatch(line)
+		if m == nil || !re.MatchString(m[1]) {
+			continue
+		}
+		match := CacheMatch{NodeID: m[2], Name: m[1], Type: m[3]}
+		if entry, ok := index[m[2]]; ok {
+			match.Path = filepath.ToSlash(indexRelPath(entry.Path))
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// searchCachedFiles reads every file called name under dir and keeps those
+// for which matchFile returns a match. Node IDs come from _index.json.
+func searchCachedFiles(dir, name string, re *regexp.Regexp, matchFile func([]byte, *regexp.Regexp) (CacheMatch, bool)) []CacheMatch {
+	ids := make(map[string]string) // node directory → node ID
+	if index, err := readExportIndex(dir); err == nil {
+		for id, entry := range index {
+			if rel := indexRelPath(entry.Path); rel != "" {
+				ids[filepath.ToSlash(rel)] = id
+			}
+		}
+	}
+
+	var matches []CacheMatch
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || info.Name() != name {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		match, ok := matchFile(data, re)
+		if !ok {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, filepath.Dir(path))
+		match.Path = filepath.ToSlash(rel)
+		if match.NodeID == "" {
+			match.NodeID = ids[match.Path]
+		}
+		matches = append(matches, match)
+		return nil
+	})
+	return matches
+}
+
+// cssDeclarationMatch matches re against each "property: value" of a
+// _css.json file.
+func cssDeclarationMatch(data []byte, re *regexp.Regexp) (CacheMatch, bool) {
+	var props map[string]interface{}
+	if err := json.Unmarshal(data, &props); err != nil {
+		return CacheMatch{}, false
+	}
+
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var found []string
+	for _, k := range keys {
+		v, _ := json.Marshal(props[k])
+		decl := fmt.Sprintf("%s: %s", k, strings.Trim(string(v), `"`))
+		if re.MatchString(decl) {
+			found = append(found, decl)
+		}
+	}
+	if len(found) == 0 {
+		return CacheMatch{}, false
+	}
+	return CacheMatch{Match: strings.Join(found, "; ")}, true
+}
+
+// characterMatch matches re against the text of a TEXT node's _node.json.
+func characterMatch(data []byte, re *regexp.Regexp) (CacheMatch, bool) {
+	var node struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		Type       string `json:"type"`
+		Characters string `json:"characters"`
+	}
+	if err := json.Unmarshal(data, &node); err != nil || node.Characters == "" || !re.MatchString(node.Characters) {
+		return CacheMatch{}, false
+	}
+	return CacheMatch{NodeID: node.ID, Name: node.Name, Type: node.Type, Match: truncateText(node.Characters, 80)}, true
+}
+
+func formatCacheSearchResult(r *CacheSearchResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Found %d matches in %s across %d exports", r.Total, r.SearchIn, r.Exports))
+	if r.HasMore {
+		sb.WriteString(" (more available, raise limit)")
+	}
+	sb.WriteString("\n\n")
+
+	for _, m := range r.Results {
+		label := m.Name
+		if label == "" {
+			label = m.Path
+		}
+		if m.Type != "" {
+			sb.WriteString(fmt.Sprintf("[%s] %s (%s) in %s\n", m.NodeID, label, m.Type, m.FileName))
+		} else {
+			sb.WriteString(fmt.Sprintf("[%s] %s in %s\n", m.NodeID, label, m.FileName))
+		}
+		if m.Match != "" {
+			sb.WriteString(fmt.Sprintf("  %s\n", m.Match))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestSearchCache(t *testing.T) {
+	exportDir := t.TempDir()
+	page := testSyncPage()
+	page.Children[0].Fills = []figma.Paint{{Type: "SOLID", Color: &figma.Color{R: 1, A: 1}}}
+	page.Children[0].Children[0].Characters = "Add to cart"
+	writeTestExport(t, filepath.Join(exportDir, "shop"), "2026-01-01T00:00:00Z", page)
+
+	search := func(dir, searchIn, pattern string) *CacheSearchResult {
+		t.Helper()
+		re, err := buildSearchRegex(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := searchCache(dir, searchIn, re, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	result := search(exportDir, "node-names", "lab*")
+	if len(result.Results) != 1 {
+		t.Fatalf("node-names: got %d matches, want 1: %+v", len(result.Results), result.Results)
+	}
+	if m := result.Results[0]; m.NodeID != "1:2" || m.Type != "TEXT" || m.FileKey != "KEY" || m.Path == "" {
+		t.Errorf("node-names match = %+v", m)
+	}
+
+	result = search(exportDir, "css-values", `/backgroundColor: rgb\(255, 0, 0\)/`)
+	if len(result.Results) != 1 || result.Results[0].NodeID != "1:1" {
+		t.Fatalf("css-values: got %+v", result.Results)
+	}
+
+	// A single export can be searched directly
+	result = search(filepath.Join(exportDir, "shop"), "characters", "*cart")
+	if len(result.Results) != 1 || result.Results[0].NodeID != "1:2" || result.Results[0].Match != "Add to cart" {
+		t.Fatalf("characters: got %+v", result.Results)
+	}
+
+	re, _ := buildSearchRegex("*")
+	if _, err := searchCache(t.TempDir(), "node-names", re, 50); err == nil {
+		t.Error("expected error for a directory without exports")
+	}
+}
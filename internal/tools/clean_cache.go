@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// nodeJSONKeepZero lists node properties whose zero value differs from
+// their absence: a hidden node stores "visible": false, a transparent
+// one "opacity": 0.
+var nodeJSONKeepZero = map[string]bool{
+	"visible":      true,
+	"locked":       true,
+	"opacity":      true,
+	"minWidth":     true,
+	"maxWidth":     true,
+	"minHeight":    true,
+	"maxHeight":    true,
+	"clipsContent": true,
+}
+
+// nodeJSONOpaque lists properties stored verbatim; they are not cleaned.
+var nodeJSONOpaque = map[string]bool{
+	"pluginData":                  true,
+	"sharedPluginData":            true,
+	"componentPropertyReferences": true,
+}
+
+// CleanNodeJSONArgs contains arguments for the clean_node_json tool.
+type CleanNodeJSONArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key of a sync_file export"`
+	DryRun  bool   `json:"dry_run,omitempty" jsonschema:"Report the savings without rewriting files"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// CleanNodeJSONResult contains the result of clean_node_json.
+type CleanNodeJSONResult struct {
+	ExportDir   string   `json:"export_dir"`
+	Files       int      `json:"files"`
+	Cleaned     int      `json:"cleaned"`
+	Unchanged   int      `json:"unchanged"`
+	BytesBefore int64    `json:"bytes_before"`
+	BytesAfter  int64    `json:"bytes_after"`
+	DryRun      bool     `json:"dry_run,omitempty"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+func registerCleanNodeJSONTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "clean_node_json",
+		Description: "Shrink the _node.json files of a sync_file export by removing null, empty and zero-value fields. Files are only rewritten when they still decode to the same node.",
+		InputSchema: inputSchema[CleanNodeJSONArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CleanNodeJSONArgs) (*mcp.CallToolResult, *CleanNodeJSONResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		cacheDir, err := findCacheDir(r.ExportDir(), args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result, err := cleanExportNodeJSON(cacheDir, args.DryRun)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatCleanNodeJSONResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// cleanExportNodeJSON rewrites every _node.json under dir without its empty
+// fields. With dryRun the sizes are computed but nothing is written.
+func cleanExportNodeJSON(dir string, dryRun bool) (*CleanNodeJSONResult, error) {
+	result := &CleanNodeJSONResult{ExportDir: dir, DryRun: dryRun}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() != "_node.json" {
+			return nil
+		}
+		result.Files++
+
+		rel, _ := filepath.Rel(dir, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", rel, err))
+			return nil
+		}
+		result.BytesBefore += int64(len(data))
+
+		cleaned, err := cleanNodeJSON(data)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", rel, err))
+			result.BytesAfter += int64(len(data))
+			return nil
+		}
+		if len(cleaned) >= len(data) {
+			result.Unchanged++
+			result.BytesAfter += int64(len(data))
+			return nil
+		}
+
+		if !dryRun {
+			if err := os.WriteFile(path, cleaned, info.Mode().Perm()); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", rel, err))
+				result.BytesAfter += int64(len(data))
+				return nil
+			}
+		}
+		result.Cleaned++
+		result.BytesAfter += int64(len(cleaned))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", dir, err)
+	}
+
+	// The snapshot still holds the nodes as they were before cleaning.
+	if !dryRun && result.Cleaned > 0 {
+		if err := removeNodeSnapshot(dir); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("removing stale node snapshot: %v", err))
+		}
+	}
+
+	return result, nil
+}
+
+// cleanNodeJSON removes null, empty and zero-value properties from a
+// serialized node. If dropping zero values would change how the node
+// decodes, only null and empty values are removed; if even that changes
+// it, the original data is returned.
+func cleanNodeJSON(data []byte) ([]byte, error) {
+	var node figma.Node
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("parsing node: %w", err)
+	}
+	want, err := json.Marshal(&node)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing node: %w", err)
+	}
+
+	for _, dropZero := range []bool{true, false} {
+		pruned, _ := pruneJSON(raw, dropZero)
+		if pruned == nil {
+			pruned = map[string]interface{}{}
+		}
+		out, err := json.MarshalIndent(pruned, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		var check figma.Node
+		if err := json.Unmarshal(out, &check); err != nil {
+			continue
+		}
+		got, err := json.Marshal(&check)
+		if err == nil && bytes.Equal(got, want) {
+			return out, nil
+		}
+	}
+
+	return data, nil
+}
+
+// pruneJSON returns v without empty object properties, and reports whether
+// anything is left. Array elements are cleaned but never removed, as their
+// position is meaningful.
+// dropZero also removes false and 0 except for the keys in nodeJSONKeepZero.
+func pruneJSON(v interface{}, dropZero bool) (interface{}, bool) {
+	switch val := v.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return val, val != ""
+	case bool:
+		return val, val || !dropZero
+	case float64:
+		return val, val != 0 || !dropZero
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, e := range val {
+			out[i], _ = pruneJSON(e, dropZero)
+		}
+		return out, len(out) > 0
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			if nodeJSONOpaque[k] {
+				out[k] = e
+				continue
+			}
+			if nodeJSONKeepZero[k] && e != nil {
+				out[k] = e
+				continue
+			}
+			if pruned, ok := pruneJSON(e, dropZero); ok {
+				out[k] = pruned
+			}
+		}
+		return out, len(out) > 0
+	}
+	return v, true
+}
+
+func formatCleanNodeJSONResult(r *CleanNodeJSONResult) string {
+	var sb strings.Builder
+
+	if r.DryRun {
+		sb.WriteString("DRY RUN - no files were rewritten\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("Export: %s\n", r.ExportDir))
+	sb.WriteString(fmt.Sprintf("Node files: %d (%d cleaned, %d unchanged)\n", r.Files, r.Cleaned, r.Unchanged))
+
+	saved := r.BytesBefore - r.BytesAfter
+	percent := 0.0
+	if r.BytesBefore > 0 {
+		percent = float64(saved) * 100 / float64(r.BytesBefore)
+	}
+	sb.WriteString(fmt.Sprintf("Size: %s → %s (saved %s, %.1f%%)\n",
+		formatBytes(r.BytesBefore), formatBytes(r.BytesAfter), formatBytes(saved), percent))
+
+	if len(r.Errors) > 0 {
+		sb.WriteString(fmt.Sprintf("\nErrors (%d):\n", len(r.Errors)))
+		for _, e := range r.Errors {
+			sb.WriteString("  " + e + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. 1.5 MB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCleanNodeJSON(t *testing.T) {
+	data := []byte(`{
+  "id": "1:2",
+  "name": "Hidden",
+  "type": "FRAME",
+  "visible": false,
+  "opacity": 0,
+  "description": "",
+  "fills": [],
+  "strokes": null,
+  "cornerRadius": 0,
+  "isMask": false,
+  "absoluteBoundingBox": {"x": 0, "y": 10, "width": 100, "height": 0},
+  "relativeTransform": [[1, 0, 0], [0, 1, 0]],
+  "effects": [{"type": "DROP_SHADOW", "visible": true, "radius": 4, "color": {"r": 0, "g": 0, "b": 0, "a": 0.5}, "offset": {"x": 0, "y": 2}}],
+  "children": []
+}`)
+
+	cleaned, err := cleanNodeJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cleaned) >= len(data) {
+		t.Fatalf("cleaned %d bytes, want fewer than %d", len(cleaned), len(data))
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(cleaned, &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"description", "fills", "strokes", "cornerRadius", "isMask", "children"} {
+		if _, ok := got[key]; ok {
+			t.Errorf("%s kept, want removed", key)
+		}
+	}
+	if got["visible"] != false || got["opacity"] != 0.0 {
+		t.Errorf("visible = %v, opacity = %v, want false and 0 kept", got["visible"], got["opacity"])
+	}
+	if transform := got["relativeTransform"]; !reflect.DeepEqual(transform, []any{[]any{1.0, 0.0, 0.0}, []any{0.0, 1.0, 0.0}}) {
+		t.Errorf("relativeTransform = %v, want array zeros kept", transform)
+	}
+	if box := got["absoluteBoundingBox"].(map[string]any); !reflect.DeepEqual(box, map[string]any{"y": 10.0, "width": 100.0}) {
+		t.Errorf("absoluteBoundingBox = %v", box)
+	}
+}
+
+func TestCleanNodeJSON_AlreadyClean(t *testing.T) {
+	data := []byte(`{"id":"1:2","name":"Frame","type":"FRAME"}`)
+
+	cleaned, err := cleanNodeJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cleaned) < len(data) {
+		t.Errorf("cleaned = %s, want no smaller than the compact input", cleaned)
+	}
+
+	if _, err := cleanNodeJSON([]byte("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestCleanExportNodeJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeTestExport(t, dir, "2026-01-01T00:00:00Z", testSyncPage())
+	bloated := filepath.Join(dir, "pages", "extra", "_node.json")
+	if err := os.MkdirAll(filepath.Dir(bloated), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeJSON(bloated, map[string]any{"id": "9:9", "name": "Extra", "type": "FRAME", "fills": []any{}, "strokes": nil, "characters": ""}); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := readNodesFromExport(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(bloated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeNodeSnapshot(dir, []*figma.Node{testSyncPage()}); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := filepath.Join(dir, nodeSnapshotFile)
+
+	dry, err := cleanExportNodeJSON(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dry.Cleaned == 0 || dry.BytesAfter >= dry.BytesBefore {
+		t.Fatalf("dry run = %+v, want savings", dry)
+	}
+	if data, _ := os.ReadFile(bloated); string(data) != string(original) {
+		t.Fatal("dry run rewrote files")
+	}
+	if _, err := os.Stat(snapshot); err != nil {
+		t.Fatalf("dry run removed the node snapshot: %v", err)
+	}
+
+	result, err := cleanExportNodeJSON(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Files != dry.Files || result.BytesAfter != dry.BytesAfter || len(result.Errors) != 0 {
+		t.Errorf("result = %+v, want same as dry run %+v", result, dry)
+	}
+	// The snapshot no longer matches the rewritten node files.
+	if _, err := os.Stat(snapshot); !os.IsNotExist(err) {
+		t.Errorf("stale node snapshot kept after cleaning: %v", err)
+	}
+
+	after, err := readNodesFromExport(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON, _ := json.Marshal(before)
+	gotJSON, _ := json.Marshal(after)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("cleaned export decodes to different nodes:\n got %s\nwant %s", gotJSON, wantJSON)
+	}
+
+	again, err := cleanExportNodeJSON(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Cleaned != 0 {
+		t.Errorf("second run cleaned %d files, want 0", again.Cleaned)
+	}
+}
@@ -0,0 +1,251 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// GetCommentsArgs contains arguments for the get_comments tool.
+type GetCommentsArgs struct {
+	FileKey  string `json:"file_key" jsonschema:"Figma file key"`
+	NodeID   string `json:"node_id,omitempty" jsonschema:"Only threads pinned to this node"`
+	Resolved string `json:"resolved,omitempty" jsonschema:"Which threads to list: all (default), resolved or unresolved"`
+	Format   string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// CommentReply is a comment of a thread.
+type CommentReply struct {
+	ID        string `json:"id"`
+	Author    string `json:"author"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CommentThread is a root comment with its replies.
+type CommentThread struct {
+	CommentReply
+	NodeID     string         `json:"node_id,omitempty"` // node the comment is pinned to
+	Resolved   bool           `json:"resolved"`
+	ResolvedAt string         `json:"resolved_at,omitempty"`
+	Replies    []CommentReply `json:"replies,omitempty"`
+}
+
+// GetCommentsResult contains the result of get_comments.
+type GetCommentsResult struct {
+	Threads []CommentThread `json:"threads"`
+	Total   int             `json:"total"`
+}
+
+func registerGetCommentsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_comments",
+		Description: "List the comment threads of a file, newest first, with the node each is pinned to and its replies, to read designer feedback on frames. Filter by node_id and resolved state. Read-only; use post_comment to reply.",
+		InputSchema: inputSchema[GetCommentsArgs](map[string][]string{
+			"resolved": {"all", "resolved", "unresolved"},
+			"format":   responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetCommentsArgs) (*mcp.CallToolResult, *GetCommentsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		switch args.Resolved {
+		case "", "all", "resolved", "unresolved":
+		default:
+			return nil, nil, fmt.Errorf("resolved must be all, resolved or unresolved")
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+		nodeID := r.ResolveNodeID(args.NodeID)
+
+		comments, err := r.Client().GetComments(ctx, args.FileKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching comments: %w", err)
+		}
+		result := &GetCommentsResult{Threads: commentThreads(comments.Comments, nodeID, args.Resolved)}
+		result.Total = len(result.Threads)
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatGetCommentsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// PostCommentArgs contains arguments for the post_comment tool.
+type PostCommentArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	Message string `json:"message" jsonschema:"Comment text"`
+	ReplyTo string `json:"reply_to,omitempty" jsonschema:"ID of the thread (root comment) to reply to; omit to start a new thread"`
+	NodeID  string `json:"node_id,omitempty" jsonschema:"Node to pin a new thread to; ignored for replies"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// PostCommentResult contains the result of post_comment.
+type PostCommentResult struct {
+	Posted  CommentReply `json:"posted"`
+	ReplyTo string       `json:"reply_to,omitempty"`
+	NodeID  string       `json:"node_id,omitempty"`
+}
+
+func registerPostCommentTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "post_comment",
+		Description: "Publish a comment on a file, visible to everyone with access to it: a reply to the reply_to thread, or a new thread pinned to node_id if given. Needs the file_comments:write scope. List threads and their IDs with get_comments.",
+		InputSchema: inputSchema[PostCommentArgs](map[string][]string{"format": responseFormats}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args PostCommentArgs) (*mcp.CallToolResult, *PostCommentResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.Message == "" {
+			return nil, nil, fmt.Errorf("message is required. Pass the comment text as message")
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		post := &figma.CommentRequest{Message: args.Message, CommentID: args.ReplyTo}
+		result := &PostCommentResult{ReplyTo: args.ReplyTo}
+		if args.ReplyTo == "" && args.NodeID != "" {
+			result.NodeID = r.ResolveNodeID(args.NodeID)
+			post.ClientMeta = &figma.CommentAnchor{NodeID: result.NodeID}
+		}
+		posted, err := r.Client().PostComment(ctx, args.FileKey, post)
+		if err != nil {
+			return nil, nil, fmt.Errorf("posting comment: %w", err)
+		}
+		result.Posted = commentReply(posted)
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatPostCommentResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// commentThreads groups replies under their root comments and keeps the
+// threads pinned to nodeID, if set, in the given resolved state. Threads are
+// newest first and replies oldest first.
+func commentThreads(comments []figma.Comment, nodeID, resolved string) []CommentThread {
+	var roots []*CommentThread
+	byID := make(map[string]*CommentThread)
+	for _, c := range comments {
+		if c.ParentID != "" {
+			continue
+		}
+		thread := &CommentThread{
+			CommentReply: commentReply(&c),
+			NodeID:       commentNodeID(c.ClientMeta),
+			Resolved:     c.ResolvedAt != "",
+			ResolvedAt:   c.ResolvedAt,
+		}
+		roots = append(roots, thread)
+		byID[c.ID] = thread
+	}
+	for _, c := range comments {
+		if thread := byID[c.ParentID]; thread != nil {
+			thread.Replies = append(thread.Replies, commentReply(&c))
+		}
+	}
+
+	threads := []CommentThread{}
+	for _, thread := range roots {
+		if nodeID != "" && thread.NodeID != nodeID {
+			continue
+		}
+		if (resolved == "resolved" && !thread.Resolved) || (resolved == "unresolved" && thread.Resolved) {
+			continue
+		}
+		// Timestamps are ISO 8601 UTC, so they compare as strings.
+		sort.SliceStable(thread.Replies, func(i, j int) bool {
+			return thread.Replies[i].CreatedAt < thread.Replies[j].CreatedAt
+		})
+		threads = append(threads, *thread)
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].CreatedAt > threads[j].CreatedAt
+	})
+	return threads
+}
+
+func commentReply(c *figma.Comment) CommentReply {
+	reply := CommentReply{ID: c.ID, Message: c.Message, CreatedAt: c.CreatedAt}
+	if c.User != nil {
+		reply.Author = c.User.Handle
+	}
+	return reply
+}
+
+// commentNodeID returns the node a comment's client_meta pins it to, or ""
+// for comments placed on the canvas.
+func commentNodeID(meta json.RawMessage) string {
+	var anchor struct {
+		NodeID string `json:"node_id"`
+	}
+	if len(meta) == 0 || json.Unmarshal(meta, &anchor) != nil {
+		return ""
+	}
+	return anchor.NodeID
+}
+
+func formatGetCommentsResult(r *GetCommentsResult) string {
+	var sb strings.Builder
+
+	if r.Total == 0 {
+		sb.WriteString("No comments found\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%d comment threads\n", r.Total))
+	for _, t := range r.Threads {
+		sb.WriteString(fmt.Sprintf("\n[%s] %s, %s", t.ID, t.Author, t.CreatedAt))
+		if t.NodeID != "" {
+			sb.WriteString(fmt.Sprintf(" on node %s", t.NodeID))
+		}
+		if t.Resolved {
+			sb.WriteString(" (resolved)")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("  %s\n", strings.ReplaceAll(t.Message, "\n", "\n  ")))
+		for _, reply := range t.Replies {
+			sb.WriteString(fmt.Sprintf("    ↳ [%s] %s: %s\n", reply.ID, reply.Author, strings.ReplaceAll(reply.Message, "\n", "\n      ")))
+		}
+	}
+
+	return sb.String()
+}
+
+func formatPostCommentResult(r *PostCommentResult) string {
+	switch {
+	case r.ReplyTo != "":
+		return fmt.Sprintf("Posted reply %s to thread %s\n", r.Posted.ID, r.ReplyTo)
+	case r.NodeID != "":
+		return fmt.Sprintf("Posted comment %s on node %s\n", r.Posted.ID, r.NodeID)
+	default:
+		return fmt.Sprintf("Posted comment %s\n", r.Posted.ID)
+	}
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCommentThreads(t *testing.T) {
+	ana := &figma.User{ID: "7", Handle: "ana"}
+	comments := []figma.Comment{
+		{ID: "1", Message: "Padding looks off", User: ana, CreatedAt: "2026-01-01T00:00:00Z", ClientMeta: json.RawMessage(`{"node_id":"1:2","node_offset":{"x":0,"y":0}}`)},
+		{ID: "3", ParentID: "1", Message: "Fixed", CreatedAt: "2026-01-03T00:00:00Z"},
+		{ID: "2", ParentID: "1", Message: "Which side?", CreatedAt: "2026-01-02T00:00:00Z"},
+		{ID: "4", Message: "Ship it", CreatedAt: "2026-01-04T00:00:00Z", ResolvedAt: "2026-01-05T00:00:00Z", ClientMeta: json.RawMessage(`{"x":10,"y":20}`)},
+	}
+
+	threads := commentThreads(comments, "", "")
+	if len(threads) != 2 || threads[0].ID != "4" || threads[1].ID != "1" {
+		t.Fatalf("threads = %+v, want 4 then 1", threads)
+	}
+	if !threads[0].Resolved || threads[0].NodeID != "" {
+		t.Errorf("canvas thread = %+v", threads[0])
+	}
+	first := threads[1]
+	if first.NodeID != "1:2" || first.Author != "ana" || first.Resolved {
+		t.Errorf("node thread = %+v", first)
+	}
+	if len(first.Replies) != 2 || first.Replies[0].ID != "2" || first.Replies[1].ID != "3" {
+		t.Errorf("replies = %+v, want 2 then 3", first.Replies)
+	}
+
+	if got := commentThreads(comments, "1:2", ""); len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("node filter = %+v", got)
+	}
+	if got := commentThreads(comments, "", "resolved"); len(got) != 1 || got[0].ID != "4" {
+		t.Errorf("resolved filter = %+v", got)
+	}
+	if got := commentThreads(comments, "", "unresolved"); len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("unresolved filter = %+v", got)
+	}
+	if got := commentThreads(comments, "9:9", ""); got == nil || len(got) != 0 {
+		t.Errorf("no match = %#v, want empty", got)
+	}
+}
@@ -0,0 +1,395 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// CompareToCodeArgs contains arguments for the compare_to_code tool.
+type CompareToCodeArgs struct {
+	FileKey  string   `json:"file_key" jsonschema:"Figma file key"`
+	NodeIDs  []string `json:"node_ids" jsonschema:"Node IDs whose CSS is compared"`
+	CSSFile  string   `json:"css_file" jsonschema:"Path to the local CSS file implementing the nodes"`
+	Selector string   `json:"selector,omitempty" jsonschema:"Only compare rules whose selector list contains this selector, e.g. .button (default: all rules)"`
+	Format   string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+
+	LogicalProperties bool `json:"logical_properties,omitempty" jsonschema:"Compare the design as CSS logical properties, as get_css logical_properties does"`
+}
+
+// CSSPropertyDiff is a property whose design and code values differ.
+type CSSPropertyDiff struct {
+	Property string `json:"property"`
+	Design   string `json:"design"`
+	Code     string `json:"code"`
+}
+
+// NodeCodeComparison compares one node's CSS with the CSS file.
+type NodeCodeComparison struct {
+	NodeID    string            `json:"node_id"`
+	Name      string            `json:"name"`
+	Missing   map[string]string `json:"missing"`   // in the design, not the code
+	Extra     map[string]string `json:"extra"`     // in the code, not the design
+	Different []CSSPropertyDiff `json:"different"` // in both with other values
+	Matching  int               `json:"matching"`
+}
+
+// CompareToCodeResult contains the result of compare_to_code.
+type CompareToCodeResult struct {
+	CSSFile  string               `json:"css_file"`
+	Rules    int                  `json:"rules"` // rule blocks compared
+	Nodes    []NodeCodeComparison `json:"nodes"`
+	Warnings []string             `json:"warnings,omitempty"`
+}
+
+// cssRule is a rule block of a CSS file.
+type cssRule struct {
+	Selector     string
+	Declarations map[string]string
+}
+
+// designOnlyCSS are extractCSSProperties keys with no CSS equivalent.
+var designOnlyCSS = map[string]bool{
+	"strokeWeight": true,
+	"strokeAlign":  true,
+}
+
+// unitlessCSS are numeric properties written without px.
+var unitlessCSS = map[string]bool{
+	"fontWeight": true,
+	"opacity":    true,
+}
+
+func registerCompareToCodeTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "compare_to_code",
+		Description: "Check implemented CSS against the design: compares the CSS get_css extracts for nodes with the declarations in a local CSS file and lists properties missing from the code, extra in the code and with different values. var() references are resolved against custom properties in the file; colors and zero lengths are normalized before comparing.",
+		InputSchema: inputSchema[CompareToCodeArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CompareToCodeArgs) (*mcp.CallToolResult, *CompareToCodeResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if len(args.NodeIDs) == 0 {
+			return nil, nil, errNodeIDsRequired
+		}
+		if args.CSSFile == "" {
+			return nil, nil, fmt.Errorf("css_file is required. Pass the path of the local CSS file implementing the nodes (e.g. \"src/components/Button.css\")")
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		data, err := os.ReadFile(args.CSSFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading css_file: %w", err)
+		}
+		rules := parseCSSRules(string(data))
+		code, matched := codeDeclarations(rules, args.Selector)
+		if args.Selector != "" && matched == 0 {
+			return nil, nil, fmt.Errorf("no rule in %s matches selector %q", args.CSSFile, args.Selector)
+		}
+
+		nodeIDs := r.ResolveNodeIDs(args.NodeIDs)
+		nodes, err := r.Client().GetFileNodes(ctx, args.FileKey, nodeIDs, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching nodes: %w", err)
+		}
+
+		result := &CompareToCodeResult{CSSFile: args.CSSFile, Rules: matched, Nodes: []NodeCodeComparison{}}
+		for _, id := range nodeIDs {
+			wrapper, ok := nodes.Nodes[id]
+			if !ok || wrapper.Document == nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("node %s not found", id))
+				continue
+			}
+			comparison := compareCSS(designDeclarations(wrapper.Document, args.LogicalProperties), code)
+			comparison.NodeID = id
+			comparison.Name = wrapper.Document.Name
+			result.Nodes = append(result.Nodes, comparison)
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatCompareToCodeResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+var cssCommentRe = regexp.MustCompile(`(?s)/\*.*?\*/`)
+
+// parseCSSRules reads the property: value declarations of each rule block.
+// It is not a full CSS parser: at-rule blocks such as @media are descended
+// into, their conditions are ignored, and strings containing braces or
+// semicolons are not handled.
+func parseCSSRules(css string) []cssRule {
+	css = cssCommentRe.ReplaceAllString(css, "")
+
+	var rules []cssRule
+	var selectors []string // open blocks, innermost last
+	start := 0
+	flush := func(text string) {
+		if len(selectors) == 0 {
+			return
+		}
+		decls := parseCSSDeclarations(text)
+		if len(decls) == 0 {
+			return
+		}
+		rules = append(rules, cssRule{Selector: selectors[len(selectors)-1], Declarations: decls})
+	}
+
+	for i := 0; i < len(css); i++ {
+		switch css[i] {
+		case '{':
+			// Declarations before a nested block belong to the enclosing rule;
+			// the text after the last ';' is the nested block's selector.
+			text := css[start:i]
+			selector := text
+			if j := strings.LastIndex(text, ";"); j >= 0 {
+				flush(text[:j])
+				selector = text[j+1:]
+			}
+			selectors = append(selectors, strings.Join(strings.Fields(selector), " "))
+			start = i + 1
+		case '}':
+			flush(css[start:i])
+			if len(selectors) > 0 {
+				selectors = selectors[:len(selectors)-1]
+			}
+			start = i + 1
+		}
+	}
+
+	return rules
+}
+
+// parseCSSDeclarations splits "a: b; c: d" into lowercase properties and
+// their values, without !important.
+func parseCSSDeclarations(text string) map[string]string {
+	decls := make(map[string]string)
+	for _, decl := range strings.Split(text, ";") {
+		prop, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		prop = strings.TrimSpace(prop)
+		if !strings.HasPrefix(prop, "--") {
+			prop = strings.ToLower(prop)
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		if prop == "" || value == "" || strings.ContainsAny(prop, " \t\n") {
+			continue
+		}
+		decls[prop] = value
+	}
+	return decls
+}
+
+// codeDeclarations merges the declarations of the rules matching selector,
+// or of all rules, later rules winning. Custom properties are not returned
+// but var() references to them are substituted. It also returns the number
+// of rules merged.
+func codeDeclarations(rules []cssRule, selector string) (map[string]string, int) {
+	custom := make(map[string]string)
+	for _, rule := range rules {
+		for prop, value := range rule.Declarations {
+			if strings.HasPrefix(prop, "--") {
+				custom[prop] = value
+			}
+		}
+	}
+
+	decls := make(map[string]string)
+	matched := 0
+	for _, rule := range rules {
+		if selector != "" && !selectorMatches(rule.Selector, selector) {
+			continue
+		}
+		matched++
+		for prop, value := range rule.Declarations {
+			if !strings.HasPrefix(prop, "--") {
+				decls[prop] = resolveCSSVars(value, custom)
+			}
+		}
+	}
+	return decls, matched
+}
+
+// selectorMatches reports whether selector is one of the selectors of a
+// comma-separated selector list.
+func selectorMatches(list, selector string) bool {
+	want := strings.Join(strings.Fields(selector), " ")
+	for _, s := range strings.Split(list, ",") {
+		if strings.Join(strings.Fields(s), " ") == want {
+			return true
+		}
+	}
+	return false
+}
+
+var cssVarRe = regexp.MustCompile(`var\(\s*(--[\w-]+)\s*(?:,\s*([^()]*))?\)`)
+
+// resolveCSSVars substitutes var() references with the custom property
+// values, or their fallbacks. Unknown properties are left as written.
+func resolveCSSVars(value string, custom map[string]string) string {
+	for depth := 0; depth < maxAliasDepth && strings.Contains(value, "var("); depth++ {
+		next := cssVarRe.ReplaceAllStringFunc(value, func(ref string) string {
+			m := cssVarRe.FindStringSubmatch(ref)
+			if v, ok := custom[m[1]]; ok {
+				return v
+			}
+			if m[2] != "" {
+				return strings.TrimSpace(m[2])
+			}
+			return ref
+		})
+		if next == value {
+			break
+		}
+		value = next
+	}
+	return value
+}
+
+// designDeclarations returns the CSS get_css generates for node as kebab-case
+// properties and formatted values, skipping Figma-only data such as fills.
+func designDeclarations(node *figma.Node, logical bool) map[string]string {
+	props := extractCSSProperties(node)
+	if logical {
+		props = toLogicalProperties(props)
+	}
+
+	decls := make(map[string]string)
+	for key, value := range props {
+		if designOnlyCSS[key] {
+			continue
+		}
+		switch v := value.(type) {
+		case float64:
+			if unitlessCSS[key] {
+				decls[camelToKebab(key)] = strconv.FormatFloat(v, 'f', -1, 64)
+				continue
+			}
+			decls[camelToKebab(key)] = formatCSSValue(v)
+		case string:
+			decls[camelToKebab(key)] = v
+		}
+	}
+	return decls
+}
+
+// compareCSS diffs design declarations against code declarations.
+func compareCSS(design, code map[string]string) NodeCodeComparison {
+	c := NodeCodeComparison{
+		Missing:   make(map[string]string),
+		Extra:     make(map[string]string),
+		Different: []CSSPropertyDiff{},
+	}
+	for prop, value := range design {
+		codeValue, ok := code[prop]
+		switch {
+		case !ok:
+			c.Missing[prop] = value
+		case normalizeCSSValue(codeValue) != normalizeCSSValue(value):
+			c.Different = append(c.Different, CSSPropertyDiff{Property: prop, Design: value, Code: codeValue})
+		default:
+			c.Matching++
+		}
+	}
+	for prop, value := range code {
+		if _, ok := design[prop]; !ok {
+			c.Extra[prop] = value
+		}
+	}
+	sort.Slice(c.Different, func(i, j int) bool {
+		return c.Different[i].Property < c.Different[j].Property
+	})
+	return c
+}
+
+var (
+	cssHexColorRe = regexp.MustCompile(`#([0-9a-f]{8}|[0-9a-f]{6}|[0-9a-f]{3,4})\b`)
+	cssZeroRe     = regexp.MustCompile(`(^|[\s(,])0(px|rem|em|%)`)
+)
+
+// normalizeCSSValue makes equivalent spellings compare equal: case,
+// whitespace, hex versus rgb() colors, and units on zero.
+func normalizeCSSValue(value string) string {
+	v := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	v = strings.NewReplacer(", ", ",", "( ", "(", " )", ")").Replace(v)
+	v = cssHexColorRe.ReplaceAllStringFunc(v, hexToRGB)
+	v = cssZeroRe.ReplaceAllString(v, "${1}0")
+	return v
+}
+
+// hexToRGB rewrites a #rgb, #rgba, #rrggbb or #rrggbbaa color in the
+// rgb()/rgba() form colorToCSS uses.
+func hexToRGB(hex string) string {
+	h := strings.TrimPrefix(hex, "#")
+	if len(h) <= 4 {
+		var sb strings.Builder
+		for _, c := range h {
+			sb.WriteRune(c)
+			sb.WriteRune(c)
+		}
+		h = sb.String()
+	}
+
+	channel := func(i int) int64 {
+		n, _ := strconv.ParseInt(h[i:i+2], 16, 64)
+		return n
+	}
+	if len(h) == 8 && channel(6) < 255 {
+		return fmt.Sprintf("rgba(%d,%d,%d,%.2f)", channel(0), channel(2), channel(4), float64(channel(6))/255)
+	}
+	return fmt.Sprintf("rgb(%d,%d,%d)", channel(0), channel(2), channel(4))
+}
+
+func formatCompareToCodeResult(r *CompareToCodeResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Compared %d nodes with %d rules in %s\n", len(r.Nodes), r.Rules, r.CSSFile))
+
+	for _, n := range r.Nodes {
+		sb.WriteString(fmt.Sprintf("\n%s [%s]: %d matching, %d missing, %d extra, %d different\n",
+			n.Name, n.NodeID, n.Matching, len(n.Missing), len(n.Extra), len(n.Different)))
+
+		for _, prop := range sortedKeys(n.Missing) {
+			sb.WriteString(fmt.Sprintf("  - %s: %s (missing in code)\n", prop, n.Missing[prop]))
+		}
+		for _, d := range n.Different {
+			sb.WriteString(fmt.Sprintf("  ~ %s: design %s, code %s\n", d.Property, d.Design, d.Code))
+		}
+		for _, prop := range sortedKeys(n.Extra) {
+			sb.WriteString(fmt.Sprintf("  + %s: %s (not in design)\n", prop, n.Extra[prop]))
+		}
+	}
+
+	if len(r.Warnings) > 0 {
+		sb.WriteString("\nWarnings:\n")
+		for _, w := range r.Warnings {
+			sb.WriteString(fmt.Sprintf("  - %s\n", w))
+		}
+	}
+
+	return sb.String()
+}
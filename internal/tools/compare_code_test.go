@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestParseCSSRules(t *testing.T) {
+	css := `
+:root { --brand: #0066CC; --space: 16px; }
+/* button { color: red } */
+.button, .btn {
+  background-color: var(--brand);
+  padding: var(--space) var(--gap, 8px) !important;
+  border-radius: 4px
+}
+@media (min-width: 600px) {
+  .button { width: 200px; }
+}
+.link { color: blue; }
+`
+	rules := parseCSSRules(css)
+	if len(rules) != 4 {
+		t.Fatalf("got %d rules, want 4: %+v", len(rules), rules)
+	}
+	if rules[1].Selector != ".button, .btn" || rules[1].Declarations["border-radius"] != "4px" {
+		t.Errorf("rule 2 = %+v", rules[1])
+	}
+	if rules[2].Selector != ".button" || rules[2].Declarations["width"] != "200px" {
+		t.Errorf("media rule = %+v", rules[2])
+	}
+
+	decls, matched := codeDeclarations(rules, ".button")
+	if matched != 2 {
+		t.Errorf("matched %d rules, want 2", matched)
+	}
+	want := map[string]string{
+		"background-color": "#0066CC",
+		"padding":          "16px 8px",
+		"border-radius":    "4px",
+		"width":            "200px",
+	}
+	if len(decls) != len(want) {
+		t.Errorf("declarations = %v, want %v", decls, want)
+	}
+	for prop, value := range want {
+		if decls[prop] != value {
+			t.Errorf("%s = %q, want %q", prop, decls[prop], value)
+		}
+	}
+}
+
+func TestCompareCSS(t *testing.T) {
+	opacity := 0.5
+	node := &figma.Node{
+		ID:                  "1:1",
+		Name:                "Button",
+		Type:                figma.NodeTypeFrame,
+		AbsoluteBoundingBox: &figma.Rectangle{Width: 200, Height: 40},
+		Fills:               []figma.Paint{{Type: "SOLID", Color: &figma.Color{R: 0, G: 0.4, B: 0.8, A: 1}}},
+		CornerRadius:        4,
+		Opacity:             &opacity,
+	}
+	design := designDeclarations(node, false)
+	if _, ok := design["fills"]; ok {
+		t.Error("design declarations include Figma fills")
+	}
+	if design["opacity"] != "0.5" {
+		t.Errorf("opacity = %q, want unitless 0.5", design["opacity"])
+	}
+
+	code := map[string]string{
+		"width":            "200px",
+		"height":           "48px",
+		"background-color": "#0066CC",
+		"opacity":          "0.5",
+		"cursor":           "pointer",
+	}
+	c := compareCSS(design, code)
+	if c.Matching != 3 {
+		t.Errorf("matching = %d, want 3 (width, background-color, opacity)", c.Matching)
+	}
+	if len(c.Different) != 1 || c.Different[0].Property != "height" || c.Different[0].Code != "48px" {
+		t.Errorf("different = %+v", c.Different)
+	}
+	if c.Missing["border-radius"] != "4px" || len(c.Missing) != 1 {
+		t.Errorf("missing = %v", c.Missing)
+	}
+	if c.Extra["cursor"] != "pointer" || len(c.Extra) != 1 {
+		t.Errorf("extra = %v", c.Extra)
+	}
+}
+
+func TestNormalizeCSSValue(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"#fff", "rgb(255, 255, 255)"},
+		{"#FF000080", "rgba(255, 0, 0, 0.50)"},
+		{"0px", "0"},
+		{"0PX 4px", "0 4px"},
+		{"Inter,  sans-serif", "inter, sans-serif"},
+	}
+	for _, tt := range tests {
+		if got, want := normalizeCSSValue(tt.a), normalizeCSSValue(tt.b); got != want {
+			t.Errorf("normalize(%q) = %q, normalize(%q) = %q", tt.a, got, tt.b, want)
+		}
+	}
+	if normalizeCSSValue("10px") == normalizeCSSValue("1px") {
+		t.Error("10px and 1px compare equal")
+	}
+}
@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// ExportComponentDocsArgs contains arguments for the export_component_docs tool.
+type ExportComponentDocsArgs struct {
+	FileKey   string `json:"file_key" jsonschema:"Figma file key"`
+	OutputDir string `json:"output_dir" jsonschema:"Directory to write .mdx files to"`
+	Format    string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// ComponentDoc describes one generated documentation page.
+type ComponentDoc struct {
+	Name       string              `json:"name"`
+	Key        string              `json:"key"`
+	FigmaURL   string              `json:"figma_url"`
+	Properties map[string][]string `json:"properties,omitempty"`
+	Variants   int                 `json:"variants"`
+	Path       string              `json:"path"`
+}
+
+// ExportComponentDocsResult contains the result of export_component_docs.
+type ExportComponentDocsResult struct {
+	Docs      []ComponentDoc `json:"docs"`
+	OutputDir string         `json:"output_dir"`
+	Errors    []string       `json:"errors,omitempty"`
+}
+
+func registerExportComponentDocsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_component_docs",
+		Description: "Generate an MDX page per component set with frontmatter, description, variant property table and documentation links.",
+		InputSchema: inputSchema[ExportComponentDocsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExportComponentDocsArgs) (*mcp.CallToolResult, *ExportComponentDocsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.OutputDir == "" {
+			return nil, nil, errOutputDirRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		file, err := r.Client().GetFile(ctx, args.FileKey, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+
+		if err := os.MkdirAll(args.OutputDir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("creating output dir: %w", err)
+		}
+
+		result := &ExportComponentDocsResult{
+			Docs:      make([]ComponentDoc, 0, len(file.ComponentSets)),
+			OutputDir: args.OutputDir,
+		}
+
+		// Group variants under their component set
+		variants := make(map[string][]*figma.Component)
+		for _, comp := range file.Components {
+			if comp.ComponentSetID != "" {
+				variants[comp.ComponentSetID] = append(variants[comp.ComponentSetID], comp)
+			}
+		}
+
+		setIDs := make([]string, 0, len(file.ComponentSets))
+		for id := range file.ComponentSets {
+			setIDs = append(setIDs, id)
+		}
+		sort.Slice(setIDs, func(i, j int) bool {
+			return file.ComponentSets[setIDs[i]].Name < file.ComponentSets[setIDs[j]].Name
+		})
+
+		used := make(map[string]bool)
+		for _, id := range setIDs {
+			set := file.ComponentSets[id]
+			if set.Remote {
+				continue
+			}
+
+			doc := ComponentDoc{
+				Name:       set.Name,
+				Key:        set.Key,
+				FigmaURL:   figmaNodeURL(args.FileKey, id),
+				Properties: variantProperties(variants[id]),
+				Variants:   len(variants[id]),
+			}
+
+			filename := sanitizeName(set.Name)
+			if used[filename] {
+				filename += "-" + sanitizeID(id)
+			}
+			used[filename] = true
+			doc.Path = filepath.Join(args.OutputDir, filename+".mdx")
+
+			mdx := renderComponentMDX(set, &doc, propertyOrder(variants[id]))
+			if err := os.WriteFile(doc.Path, []byte(mdx), 0644); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("writing %s: %v", doc.Path, err))
+				continue
+			}
+
+			result.Docs = append(result.Docs, doc)
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatExportComponentDocsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// figmaNodeURL links to a node in the Figma editor.
+func figmaNodeURL(fileKey, nodeID string) string {
+	return fmt.Sprintf("https://www.figma.com/design/%s?node-id=%s", fileKey, sanitizeID(nodeID))
+}
+
+// parseVariantName splits "Size=Large, State=Hover" into property/value pairs.
+func parseVariantName(name string) [][2]string {
+	var pairs [][2]string
+	for _, part := range strings.Split(name, ",") {
+		prop, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		pairs = append(pairs, [2]string{strings.TrimSpace(prop), strings.TrimSpace(value)})
+	}
+	return pairs
+}
+
+// variantProperties collects the distinct values of each variant property
+// in the order they first appear.
+func variantProperties(comps []*figma.Component) map[string][]string {
+	props := make(map[string][]string)
+	for _, comp := range comps {
+		for _, pair := range parseVariantName(comp.Name) {
+			if !containsStr(props[pair[0]], pair[1]) {
+				props[pair[0]] = append(props[pair[0]], pair[1])
+			}
+		}
+	}
+	return props
+}
+
+// propertyOrder returns variant property names in first-seen order.
+func propertyOrder(comps []*figma.Component) []string {
+	var order []string
+	for _, comp := range comps {
+		for _, pair := range parseVariantName(comp.Name) {
+			if !containsStr(order, pair[0]) {
+				order = append(order, pair[0])
+			}
+		}
+	}
+	return order
+}
+
+// mdxEscape escapes characters MDX would parse as JSX or expressions.
+func mdxEscape(s string) string {
+	return strings.NewReplacer("{", "\\{", "}", "\\}", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func renderComponentMDX(set *figma.ComponentSet, doc *ComponentDoc, order []string) string {
+	var sb strings.Builder
+
+	sb.WriteString("---\n")
+	sb.WriteString(fmt.Sprintf("title: %s\n", strconv.Quote(set.Name)))
+	sb.WriteString(fmt.Sprintf("componentKey: %s\n", strconv.Quote(set.Key)))
+	sb.WriteString(fmt.Sprintf("figmaUrl: %s\n", strconv.Quote(doc.FigmaURL)))
+	sb.WriteString("---\n\n")
+
+	sb.WriteString(fmt.Sprintf("# %s\n\n", mdxEscape(set.Name)))
+
+	if desc := strings.TrimSpace(set.Description); desc != "" {
+		sb.WriteString(mdxEscape(desc))
+		sb.WriteString("\n\n")
+	}
+
+	if len(order) > 0 {
+		sb.WriteString("## Properties\n\n")
+		sb.WriteString("| Property | Values |\n")
+		sb.WriteString("| --- | --- |\n")
+		for _, prop := range order {
+			values := make([]string, len(doc.Properties[prop]))
+			for i, v := range doc.Properties[prop] {
+				values[i] = "`" + v + "`"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", mdxEscape(prop), strings.Join(values, ", ")))
+		}
+		sb.WriteString(fmt.Sprintf("\n%d variants.\n\n", doc.Variants))
+	}
+
+	if len(set.DocumentationLinks) > 0 {
+		sb.WriteString("## Resources\n\n")
+		for _, link := range set.DocumentationLinks {
+			sb.WriteString(fmt.Sprintf("- [%s](%s)\n", link.URI, link.URI))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func formatExportComponentDocsResult(r *ExportComponentDocsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Wrote %d component docs to %s\n\n", len(r.Docs), r.OutputDir))
+
+	for _, doc := range r.Docs {
+		sb.WriteString(fmt.Sprintf("  %s (%d variants) → %s\n", doc.Name, doc.Variants, filepath.Base(doc.Path)))
+	}
+
+	if len(r.Errors) > 0 {
+		sb.WriteString("\nErrors:\n")
+		for _, e := range r.Errors {
+			sb.WriteString(fmt.Sprintf("  - %s\n", e))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestVariantProperties(t *testing.T) {
+	comps := []*figma.Component{
+		{Name: "Size=Large, State=Default"},
+		{Name: "Size=Small, State=Default"},
+		{Name: "Size=Large, State=Hover"},
+		{Name: "Standalone"},
+	}
+
+	props := variantProperties(comps)
+	order := propertyOrder(comps)
+
+	if strings.Join(order, ",") != "Size,State" {
+		t.Errorf("order = %v, want [Size State]", order)
+	}
+	if got := strings.Join(props["Size"], ","); got != "Large,Small" {
+		t.Errorf("Size values = %s, want Large,Small", got)
+	}
+	if got := strings.Join(props["State"], ","); got != "Default,Hover" {
+		t.Errorf("State values = %s, want Default,Hover", got)
+	}
+}
+
+func TestRenderComponentMDX(t *testing.T) {
+	set := &figma.ComponentSet{
+		Key:                "abc123",
+		Name:               "Button",
+		Description:        "Use for primary actions. Avoid <a> wrappers.",
+		DocumentationLinks: []figma.DocumentationLink{{URI: "https://example.com/button"}},
+	}
+	doc := &ComponentDoc{
+		FigmaURL:   figmaNodeURL("FILE", "1:2"),
+		Properties: map[string][]string{"Size": {"Large", "Small"}},
+		Variants:   2,
+	}
+
+	mdx := renderComponentMDX(set, doc, []string{"Size"})
+
+	for _, want := range []string{
+		"title: \"Button\"\n",
+		"componentKey: \"abc123\"\n",
+		"figmaUrl: \"https://www.figma.com/design/FILE?node-id=1-2\"\n",
+		"Avoid &lt;a&gt; wrappers.",
+		"| Size | `Large`, `Small` |",
+		"- [https://example.com/button](https://example.com/button)",
+	} {
+		if !strings.Contains(mdx, want) {
+			t.Errorf("expected MDX to contain %q, got:\n%s", want, mdx)
+		}
+	}
+}
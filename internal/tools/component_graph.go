@@ -0,0 +1,321 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// GetComponentGraphArgs contains arguments for the get_component_graph tool.
+type GetComponentGraphArgs struct {
+	FileKey     string `json:"file_key" jsonschema:"Figma file key"`
+	ComponentID string `json:"component_id,omitempty" jsonschema:"Only show this component and the components it depends on"`
+	Format      string `json:"format,omitempty" jsonschema:"Response format: mermaid (default) or json"`
+}
+
+// GraphComponent is a component in the dependency graph.
+type GraphComponent struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	External bool   `json:"external,omitempty"` // not defined in this file (library component)
+}
+
+// ComponentGraphResult contains the result of get_component_graph.
+type ComponentGraphResult struct {
+	Components []GraphComponent    `json:"components"`
+	Edges      map[string][]string `json:"edges"` // component ID → IDs of the components it instances
+	UsedBy     []string            `json:"used_by,omitempty"`
+	Cycles     [][]string          `json:"cycles,omitempty"`
+	Errors     []string            `json:"errors,omitempty"`
+}
+
+func registerGetComponentGraphTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_component_graph",
+		Description: "Build the dependency graph of components: which components contain instances of which other components. Output as a Mermaid flowchart or a JSON adjacency list. Circular dependencies are reported as errors.",
+		InputSchema: inputSchema[GetComponentGraphArgs](map[string][]string{
+			"format": {"mermaid", "json"},
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetComponentGraphArgs) (*mcp.CallToolResult, *ComponentGraphResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.ComponentID != "" {
+			args.ComponentID = r.ResolveNodeID(args.ComponentID)
+		}
+
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		file, err := r.Client().GetFile(ctx, args.FileKey, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+		if file.Document == nil {
+			return nil, nil, fmt.Errorf("file %s has no document", args.FileKey)
+		}
+
+		result := buildComponentGraph(file.Document.Children, file.Components)
+		if args.ComponentID != "" {
+			if result, err = componentSubgraph(result, args.ComponentID); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatComponentGraphMermaid(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// buildComponentGraph finds the components under roots and links each to the
+// components its INSTANCE layers point to. Instances are not descended into:
+// their layers belong to the instanced component.
+func buildComponentGraph(roots []*figma.Node, meta map[string]*figma.Component) *ComponentGraphResult {
+	result := &ComponentGraphResult{Components: []GraphComponent{}, Edges: make(map[string][]string)}
+	known := make(map[string]bool)
+
+	var collectInstances func(n *figma.Node, deps map[string]bool)
+	collectInstances = func(n *figma.Node, deps map[string]bool) {
+		for _, child := range n.Children {
+			if child.Type == figma.NodeTypeInstance {
+				if child.ComponentID != "" {
+					deps[child.ComponentID] = true
+				}
+				continue
+			}
+			collectInstances(child, deps)
+		}
+	}
+
+	var walk func(n *figma.Node)
+	walk = func(n *figma.Node) {
+		if n.Type == figma.NodeTypeComponent {
+			known[n.ID] = true
+			result.Components = append(result.Components, GraphComponent{ID: n.ID, Name: n.Name})
+			deps := make(map[string]bool)
+			collectInstances(n, deps)
+			if len(deps) > 0 {
+				ids := make([]string, 0, len(deps))
+				for id := range deps {
+					ids = append(ids, id)
+				}
+				sort.Strings(ids)
+				result.Edges[n.ID] = ids
+			}
+			return
+		}
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+
+	// Dependencies on components from other files.
+	external := make(map[string]bool)
+	for _, deps := range result.Edges {
+		for _, id := range deps {
+			if !known[id] && !external[id] {
+				external[id] = true
+				name := id
+				if c := meta[id]; c != nil {
+					name = c.Name
+				}
+				result.Components = append(result.Components, GraphComponent{ID: id, Name: name, External: true})
+			}
+		}
+	}
+
+	sort.Slice(result.Components, func(i, j int) bool {
+		return result.Components[i].Name < result.Components[j].Name
+	})
+
+	result.Cycles = findComponentCycles(result.Components, result.Edges)
+	names := make(map[string]string, len(result.Components))
+	for _, c := range result.Components {
+		names[c.ID] = c.Name
+	}
+	for _, cycle := range result.Cycles {
+		labels := make([]string, len(cycle))
+		for i, id := range cycle {
+			labels[i] = names[id]
+		}
+		result.Errors = append(result.Errors, "circular dependency: "+strings.Join(labels, " → "))
+	}
+
+	return result
+}
+
+// findComponentCycles returns each cycle of the graph once, as the path of
+// component IDs starting and ending with the same component.
+func findComponentCycles(components []GraphComponent, edges map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, dep := range edges[id] {
+			switch state[dep] {
+			case visiting:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == dep {
+						cycle := append([]string{}, stack[i:]...)
+						cycles = append(cycles, append(cycle, dep))
+						break
+					}
+				}
+			case unvisited:
+				visit(dep)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+
+	for _, c := range components {
+		if state[c.ID] == unvisited {
+			visit(c.ID)
+		}
+	}
+	return cycles
+}
+
+// componentSubgraph keeps the component with the given ID, the components it
+// depends on directly or indirectly, and lists the components that use it.
+func componentSubgraph(graph *ComponentGraphResult, id string) (*ComponentGraphResult, error) {
+	found := false
+	for _, c := range graph.Components {
+		if c.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("component %s not found", id)
+	}
+
+	reachable := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, dep := range graph.Edges[next] {
+			if !reachable[dep] {
+				reachable[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	sub := &ComponentGraphResult{Components: []GraphComponent{}, Edges: make(map[string][]string)}
+	for _, c := range graph.Components {
+		if reachable[c.ID] {
+			sub.Components = append(sub.Components, c)
+		}
+	}
+	for from, deps := range graph.Edges {
+		if reachable[from] {
+			sub.Edges[from] = deps
+		}
+		if containsString(deps, id) {
+			sub.UsedBy = append(sub.UsedBy, from)
+		}
+	}
+	sort.Strings(sub.UsedBy)
+	for i, cycle := range graph.Cycles {
+		if reachable[cycle[0]] {
+			sub.Cycles = append(sub.Cycles, cycle)
+			sub.Errors = append(sub.Errors, graph.Errors[i])
+		}
+	}
+	return sub, nil
+}
+
+// mermaidNodeID turns a Figma node ID such as I1:2;3:4 into a Mermaid node ID.
+func mermaidNodeID(id string) string {
+	var sb strings.Builder
+	sb.WriteString("c")
+	for _, ch := range id {
+		if ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' {
+			sb.WriteRune(ch)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
+
+func formatComponentGraphMermaid(r *ComponentGraphResult) string {
+	var sb strings.Builder
+
+	for _, e := range r.Errors {
+		sb.WriteString(fmt.Sprintf("%%%% ERROR: %s\n", e))
+	}
+	if len(r.UsedBy) > 0 {
+		sb.WriteString(fmt.Sprintf("%%%% used by: %s\n", strings.Join(r.UsedBy, ", ")))
+	}
+
+	sb.WriteString("flowchart LR\n")
+	for _, c := range r.Components {
+		label := strings.ReplaceAll(c.Name, `"`, "#quot;")
+		if c.External {
+			sb.WriteString(fmt.Sprintf("  %s[/\"%s\"/]\n", mermaidNodeID(c.ID), label))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", mermaidNodeID(c.ID), label))
+		}
+	}
+
+	from := make([]string, 0, len(r.Edges))
+	for id := range r.Edges {
+		from = append(from, id)
+	}
+	sort.Strings(from)
+	for _, id := range from {
+		for _, dep := range r.Edges[id] {
+			sb.WriteString(fmt.Sprintf("  %s --> %s\n", mermaidNodeID(id), mermaidNodeID(dep)))
+		}
+	}
+
+	if len(r.Cycles) > 0 {
+		inCycle := make(map[string]bool)
+		var ids []string
+		for _, cycle := range r.Cycles {
+			for _, id := range cycle {
+				if !inCycle[id] {
+					inCycle[id] = true
+					ids = append(ids, mermaidNodeID(id))
+				}
+			}
+		}
+		sb.WriteString("  classDef cycle stroke:#d00,stroke-width:2px\n")
+		sb.WriteString(fmt.Sprintf("  class %s cycle\n", strings.Join(ids, ",")))
+	}
+
+	return sb.String()
+}
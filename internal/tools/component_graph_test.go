@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestBuildComponentGraph(t *testing.T) {
+	instance := func(id, componentID string) *figma.Node {
+		return &figma.Node{ID: id, Type: figma.NodeTypeInstance, ComponentID: componentID}
+	}
+	component := func(id, name string, children ...*figma.Node) *figma.Node {
+		return &figma.Node{ID: id, Name: name, Type: figma.NodeTypeComponent, Children: children}
+	}
+
+	page := &figma.Node{ID: "0:1", Type: figma.NodeTypeCanvas, Children: []*figma.Node{
+		component("1:1", "Card",
+			&figma.Node{ID: "1:2", Type: figma.NodeTypeFrame, Children: []*figma.Node{instance("1:3", "2:1")}},
+			instance("1:4", "9:9"),
+		),
+		component("2:1", "Button", instance("2:2", "3:1")),
+		component("3:1", "Icon", instance("3:2", "2:1")),
+	}}
+	meta := map[string]*figma.Component{"9:9": {Name: "Library/Avatar", Remote: true}}
+
+	graph := buildComponentGraph([]*figma.Node{page}, meta)
+
+	if got := strings.Join(graph.Edges["1:1"], ","); got != "2:1,9:9" {
+		t.Errorf("Card edges = %s, want 2:1,9:9", got)
+	}
+	if len(graph.Components) != 4 {
+		t.Fatalf("got %d components, want 4", len(graph.Components))
+	}
+	for _, c := range graph.Components {
+		if c.ID == "9:9" && (!c.External || c.Name != "Library/Avatar") {
+			t.Errorf("library component = %+v", c)
+		}
+	}
+	if len(graph.Cycles) != 1 || len(graph.Errors) != 1 {
+		t.Fatalf("cycles = %v, errors = %v", graph.Cycles, graph.Errors)
+	}
+	if !strings.Contains(graph.Errors[0], "Button → Icon → Button") {
+		t.Errorf("error = %q", graph.Errors[0])
+	}
+
+	sub, err := componentSubgraph(graph, "2:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sub.Components) != 2 || strings.Join(sub.UsedBy, ",") != "1:1,3:1" {
+		t.Errorf("subgraph = %+v", sub)
+	}
+	if _, err := componentSubgraph(graph, "404:1"); err == nil {
+		t.Error("expected error for unknown component")
+	}
+
+	mermaid := formatComponentGraphMermaid(graph)
+	for _, want := range []string{"flowchart LR", `c1_1["Card"]`, "c1_1 --> c2_1", "%% ERROR: circular dependency", "class c2_1,c3_1 cycle"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, mermaid)
+		}
+	}
+}
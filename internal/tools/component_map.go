@@ -0,0 +1,212 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// ComponentMap maps Figma component keys to the code files that implement
+// them, read from a JSON object such as {"abc123": "src/Button.tsx"}.
+// The file is re-read on every lookup so edits apply without reloading.
+type ComponentMap struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewComponentMap creates a component map backed by the JSON file at path.
+func NewComponentMap(path string) *ComponentMap {
+	return &ComponentMap{path: path}
+}
+
+// Path returns the mapping file location.
+func (m *ComponentMap) Path() string {
+	return m.path
+}
+
+// All returns the component key → code file table.
+func (m *ComponentMap) All() (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading component map: %w", err)
+	}
+	mapping := make(map[string]string)
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", m.path, err)
+	}
+	return mapping, nil
+}
+
+// componentKeyOf returns the component key behind a node: its main
+// component for an instance, or its own key for a component. components is
+// the component metadata returned alongside the node.
+func componentKeyOf(node *figma.Node, components map[string]*figma.Component) string {
+	var id string
+	switch node.Type {
+	case figma.NodeTypeInstance:
+		id = node.ComponentID
+	case figma.NodeTypeComponent:
+		id = node.ID
+	default:
+		return ""
+	}
+	if c := components[id]; c != nil {
+		return c.Key
+	}
+	return ""
+}
+
+// codeFiles returns the code file of every node in the tree under root, down
+// to depth levels (negative for no limit), keyed by node ID.
+func codeFiles(root *figma.Node, components map[string]*figma.Component, mapping map[string]string, depth int) map[string]string {
+	files := make(map[string]string)
+	if len(mapping) == 0 {
+		return files
+	}
+	var walk func(n *figma.Node, d int)
+	walk = func(n *figma.Node, d int) {
+		if file := mapping[componentKeyOf(n, components)]; file != "" {
+			files[n.ID] = file
+		}
+		if depth >= 0 && d >= depth {
+			return
+		}
+		for _, c := range n.Children {
+			walk(c, d+1)
+		}
+	}
+	walk(root, 0)
+	return files
+}
+
+// ComponentMapArgs contains arguments for the component_map tool.
+type ComponentMapArgs struct {
+	MappingFile string `json:"mapping_file" jsonschema:"Path to a JSON file mapping component keys to code files, e.g. {\"abc123\": \"src/components/Button.tsx\"}"`
+	FileKey     string `json:"file_key,omitempty" jsonschema:"Figma file key: also report which of its components are mapped"`
+	Format      string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// MappedComponent is a component of the file and its code file, if any.
+type MappedComponent struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	NodeID   string `json:"node_id"`
+	CodeFile string `json:"code_file,omitempty"`
+}
+
+// ComponentMapResult contains the result of component_map.
+type ComponentMapResult struct {
+	MappingFile string            `json:"mapping_file"`
+	Entries     int               `json:"entries"`
+	Mapped      []MappedComponent `json:"mapped,omitempty"`
+	Unmapped    []MappedComponent `json:"unmapped,omitempty"`
+	UnknownKeys []string          `json:"unknown_keys,omitempty"` // mapping keys not found in the file
+}
+
+func registerComponentMapTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "component_map",
+		Description: "Load a JSON file mapping component keys to code files. Afterwards get_node, get_css and wireframe report code_file for instances and components that are already implemented. With file_key, lists which components are mapped and which still need implementing.",
+		InputSchema: inputSchema[ComponentMapArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ComponentMapArgs) (*mcp.CallToolResult, *ComponentMapResult, error) {
+		if args.MappingFile == "" {
+			return nil, nil, fmt.Errorf("mapping_file is required. Create a JSON file such as {\"<component key>\": \"src/components/Button.tsx\"}; list_components shows the keys")
+		}
+		path, err := filepath.Abs(args.MappingFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolving mapping_file: %w", err)
+		}
+
+		m := NewComponentMap(path)
+		mapping, err := m.All()
+		if err != nil {
+			return nil, nil, err
+		}
+		r.SetComponentMap(m)
+
+		result := &ComponentMapResult{MappingFile: path, Entries: len(mapping)}
+
+		if args.FileKey != "" {
+			if !r.HasClient() {
+				return nil, nil, errNoClient
+			}
+			file, err := r.Client().GetFile(ctx, args.FileKey, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("fetching file: %w", err)
+			}
+
+			seen := make(map[string]bool)
+			for id, c := range file.Components {
+				mc := MappedComponent{Key: c.Key, Name: c.Name, NodeID: id, CodeFile: mapping[c.Key]}
+				seen[c.Key] = true
+				if mc.CodeFile != "" {
+					result.Mapped = append(result.Mapped, mc)
+				} else {
+					result.Unmapped = append(result.Unmapped, mc)
+				}
+			}
+			for key := range mapping {
+				if !seen[key] {
+					result.UnknownKeys = append(result.UnknownKeys, key)
+				}
+			}
+			byName := func(list []MappedComponent) {
+				sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+			}
+			byName(result.Mapped)
+			byName(result.Unmapped)
+			sort.Strings(result.UnknownKeys)
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatComponentMapResult(result, args.FileKey != "")
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+func formatComponentMapResult(r *ComponentMapResult, withFile bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Loaded %d component mappings from %s\n", r.Entries, r.MappingFile))
+	sb.WriteString("get_node, get_css and wireframe now report code_file for mapped components.\n")
+	if !withFile {
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\nImplemented (%d):\n", len(r.Mapped)))
+	for _, c := range r.Mapped {
+		sb.WriteString(fmt.Sprintf("  [%s] %s → %s\n", c.NodeID, c.Name, c.CodeFile))
+	}
+	sb.WriteString(fmt.Sprintf("\nNeeds implementation (%d):\n", len(r.Unmapped)))
+	for _, c := range r.Unmapped {
+		sb.WriteString(fmt.Sprintf("  [%s] %s (key %s)\n", c.NodeID, c.Name, c.Key))
+	}
+	if len(r.UnknownKeys) > 0 {
+		sb.WriteString(fmt.Sprintf("\nMapping keys not in this file (%d): %s\n", len(r.UnknownKeys), strings.Join(r.UnknownKeys, ", ")))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCodeFiles(t *testing.T) {
+	components := map[string]*figma.Component{
+		"5:1": {Key: "btn-key", Name: "Button"},
+		"5:2": {Key: "card-key", Name: "Card"},
+	}
+	mapping := map[string]string{
+		"btn-key":  "src/components/Button.tsx",
+		"card-key": "src/components/Card.tsx",
+	}
+	root := &figma.Node{ID: "1:1", Type: figma.NodeTypeFrame, Children: []*figma.Node{
+		{ID: "1:2", Type: figma.NodeTypeInstance, ComponentID: "5:1"},
+		{ID: "1:3", Type: figma.NodeTypeFrame, Children: []*figma.Node{
+			{ID: "1:4", Type: figma.NodeTypeInstance, ComponentID: "5:2"},
+		}},
+		{ID: "1:5", Type: figma.NodeTypeInstance, ComponentID: "9:9"},
+	}}
+
+	files := codeFiles(root, components, mapping, -1)
+	if len(files) != 2 || files["1:2"] != "src/components/Button.tsx" || files["1:4"] != "src/components/Card.tsx" {
+		t.Errorf("codeFiles = %v", files)
+	}
+	if files := codeFiles(root, components, mapping, 1); len(files) != 1 {
+		t.Errorf("depth 1 should stop before 1:4: %v", files)
+	}
+
+	component := &figma.Node{ID: "5:1", Type: figma.NodeTypeComponent}
+	if got := codeFiles(component, components, mapping, 0)["5:1"]; got != "src/components/Button.tsx" {
+		t.Errorf("component code file = %q", got)
+	}
+}
+
+func TestRegistryComponentMapping(t *testing.T) {
+	r := NewRegistry(nil, t.TempDir())
+	if r.componentMapping() != nil {
+		t.Fatal("no mapping should be loaded by default")
+	}
+
+	path := filepath.Join(t.TempDir(), "components.json")
+	if err := os.WriteFile(path, []byte(`{"btn-key": "src/Button.tsx"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r.SetComponentMap(NewComponentMap(path))
+	if got := r.componentMapping()["btn-key"]; got != "src/Button.tsx" {
+		t.Errorf("mapping[btn-key] = %q", got)
+	}
+
+	// Edits to the file apply without reloading
+	if err := os.WriteFile(path, []byte(`{"btn-key": "src/ui/Button.tsx"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.componentMapping()["btn-key"]; got != "src/ui/Button.tsx" {
+		t.Errorf("mapping[btn-key] after edit = %q", got)
+	}
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// GetContributorsArgs contains arguments for the get_contributors tool.
+type GetContributorsArgs struct {
+	FileKey     string `json:"file_key" jsonschema:"Figma file key"`
+	MaxVersions int    `json:"max_versions,omitempty" jsonschema:"Max versions of history to scan, newest first (default: 500)"`
+	Format      string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// Contributor is a person who saved a version of or commented on a file.
+type Contributor struct {
+	ID         string `json:"id"`
+	Handle     string `json:"handle"`
+	ImgURL     string `json:"img_url,omitempty"`
+	Versions   int    `json:"versions"`
+	Comments   int    `json:"comments"`
+	FirstSeen  string `json:"first_seen"`
+	LastActive string `json:"last_active"`
+}
+
+// GetContributorsResult contains the result of get_contributors.
+type GetContributorsResult struct {
+	Contributors    []Contributor `json:"contributors"`
+	VersionsScanned int           `json:"versions_scanned"`
+	Comments        int           `json:"comments"`
+	HistoryComplete bool          `json:"history_complete"` // false when max_versions stopped the scan
+}
+
+func registerGetContributorsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_contributors",
+		Description: "List everyone who saved a version of or commented on a file, with user ID, handle and avatar URL.",
+		InputSchema: inputSchema[GetContributorsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetContributorsArgs) (*mcp.CallToolResult, *GetContributorsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+		maxVersions := args.MaxVersions
+		if maxVersions == 0 {
+			maxVersions = 500
+		}
+
+		versions, complete, err := fetchVersionHistory(ctx, r.Client(), args.FileKey, maxVersions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching versions: %w", err)
+		}
+		comments, err := r.Client().GetComments(ctx, args.FileKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching comments: %w", err)
+		}
+
+		result := &GetContributorsResult{
+			Contributors:    collectContributors(versions, comments.Comments),
+			VersionsScanned: len(versions),
+			Comments:        len(comments.Comments),
+			HistoryComplete: complete,
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatContributorsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// fetchVersionHistory pages through a file's versions, newest first, until
+// the history ends or max versions have been read. It reports whether the
+// whole history was read.
+func fetchVersionHistory(ctx context.Context, client *figma.Client, fileKey string, max int) ([]figma.Version, bool, error) {
+	var versions []figma.Version
+	opts := &figma.GetVersionsOptions{PageSize: 50}
+
+	for len(versions) < max {
+		page, err := client.GetFileVersions(ctx, fileKey, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		versions = append(versions, page.Versions...)
+		if len(page.Versions) == 0 || page.Pagination == nil || page.Pagination.NextPage == "" {
+			return versions, true, nil
+		}
+		opts.Before = page.Versions[len(page.Versions)-1].ID
+	}
+
+	if len(versions) > max {
+		versions = versions[:max]
+	}
+	return versions, false, nil
+}
+
+// collectContributors de-duplicates the authors of versions and comments by
+// user ID, most recently active first.
+func collectContributors(versions []figma.Version, comments []figma.Comment) []Contributor {
+	byID := make(map[string]*Contributor)
+
+	touch := func(u *figma.User, at string) *Contributor {
+		if u == nil || u.ID == "" {
+			return nil
+		}
+		c := byID[u.ID]
+		if c == nil {
+			c = &Contributor{ID: u.ID, Handle: u.Handle, ImgURL: u.ImgURL, FirstSeen: at, LastActive: at}
+			byID[u.ID] = c
+		}
+		// Timestamps are ISO 8601 UTC, so they compare as strings.
+		if at != "" && (c.FirstSeen == "" || at < c.FirstSeen) {
+			c.FirstSeen = at
+		}
+		if at > c.LastActive {
+			c.LastActive = at
+		}
+		return c
+	}
+
+	for _, v := range versions {
+		if c := touch(v.User, v.CreatedAt); c != nil {
+			c.Versions++
+		}
+	}
+	for _, cm := range comments {
+		if c := touch(cm.User, cm.CreatedAt); c != nil {
+			c.Comments++
+		}
+	}
+
+	contributors := make([]Contributor, 0, len(byID))
+	for _, c := range byID {
+		contributors = append(contributors, *c)
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].LastActive != contributors[j].LastActive {
+			return contributors[i].LastActive > contributors[j].LastActive
+		}
+		return contributors[i].ID < contributors[j].ID
+	})
+	return contributors
+}
+
+func formatContributorsResult(r *GetContributorsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Contributors: %d people across %d versions and %d comments\n", len(r.Contributors), r.VersionsScanned, r.Comments))
+	if !r.HistoryComplete {
+		sb.WriteString("Version history truncated (increase max_versions to scan further back)\n")
+	}
+	sb.WriteString("\n")
+
+	if len(r.Contributors) == 0 {
+		sb.WriteString("No contributors found.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("User ID              | Handle               | Versions | Comments | Last active\n")
+	sb.WriteString("-------------------- | -------------------- | -------- | -------- | -----------\n")
+	for _, c := range r.Contributors {
+		handle := c.Handle
+		if len(handle) > 20 {
+			handle = handle[:17] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("%-20s | %-20s | %8d | %8d | %s\n", c.ID, handle, c.Versions, c.Comments, c.LastActive))
+	}
+
+	return sb.String()
+}
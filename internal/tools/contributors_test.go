@@ -0,0 +1,34 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCollectContributors(t *testing.T) {
+	ana := &figma.User{ID: "1", Handle: "Ana", ImgURL: "https://example.com/ana.png"}
+	bo := &figma.User{ID: "2", Handle: "Bo"}
+
+	versions := []figma.Version{
+		{ID: "v3", CreatedAt: "2026-03-01T10:00:00Z", User: ana},
+		{ID: "v2", CreatedAt: "2026-02-01T10:00:00Z", User: bo},
+		{ID: "v1", CreatedAt: "2026-01-01T10:00:00Z", User: ana},
+	}
+	comments := []figma.Comment{
+		{ID: "c1", CreatedAt: "2026-04-01T10:00:00Z", User: bo},
+		{ID: "c2", CreatedAt: "2026-01-15T10:00:00Z"},
+	}
+
+	got := collectContributors(versions, comments)
+	if len(got) != 2 {
+		t.Fatalf("got %d contributors, want 2: %+v", len(got), got)
+	}
+
+	if got[0].ID != "2" || got[0].Versions != 1 || got[0].Comments != 1 || got[0].LastActive != "2026-04-01T10:00:00Z" {
+		t.Errorf("first contributor = %+v, want Bo active most recently", got[0])
+	}
+	if got[1].ID != "1" || got[1].Versions != 2 || got[1].FirstSeen != "2026-01-01T10:00:00Z" || got[1].ImgURL == "" {
+		t.Errorf("second contributor = %+v, want Ana with 2 versions", got[1])
+	}
+}
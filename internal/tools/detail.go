@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -15,8 +16,10 @@ type GetNodeArgs struct {
 	FileKey string   `json:"file_key" jsonschema:"Figma file key"`
 	NodeID  string   `json:"node_id" jsonschema:"Node ID to retrieve"`
 	Select  []string `json:"select,omitempty" jsonschema:"Properties to include (default: @all)"`
-	Depth   int      `json:"depth,omitempty" jsonschema:"Include children to this depth (default: 0)"`
+	Depth   int      `json:"depth,omitempty" jsonschema:"Include children to this depth (default: 0, -1: all descendants)"`
 	Format  string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+
+	SummarizeLargeArrays *bool `json:"summarize_large_arrays,omitempty" jsonschema:"When the node is too large to return, replace long children arrays with {$count, $types, $sample} summaries (default: true)"`
 }
 
 // GetNodeResult contains the result of get_node.
@@ -25,19 +28,33 @@ type GetNodeResult struct {
 	Path          string         `json:"path"`
 	ParentID      string         `json:"parent_id,omitempty"`
 	ChildrenCount int            `json:"children_count"`
+	CodeFile      string         `json:"code_file,omitempty"`  // from component_map
+	Summarized    bool           `json:"summarized,omitempty"` // some children arrays were summarized
+	FilePath      string         `json:"file_path,omitempty"`
 }
 
+// largeArrayThreshold is the number of children above which get_node
+// summarizes a children array, and largeArraySample how many it keeps.
+const (
+	largeArrayThreshold = 20
+	largeArraySample    = 3
+)
+
 func registerGetNodeTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_node",
 		Description: "Get full details for a specific node by ID.",
+		InputSchema: inputSchema[GetNodeArgs](map[string][]string{
+			"format": responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetNodeArgs) (*mcp.CallToolResult, *GetNodeResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 		if args.NodeID == "" {
-			return nil, nil, fmt.Errorf("node_id is required")
+			return nil, nil, errNodeIDRequired
 		}
+		args.NodeID = r.ResolveNodeID(args.NodeID)
 
 		// Set defaults
 		selects := args.Select
@@ -47,11 +64,11 @@ func registerGetNodeTool(server *mcp.Server, r *Registry) {
 
 		// Fetch node from API
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		nodes, err := r.Client().GetFileNodes(ctx, args.FileKey, []string{args.NodeID}, &figma.GetFileOptions{
-			Depth: args.Depth,
+			Depth: apiDepth(args.Depth),
 		})
 		if err != nil {
 			return nil, nil, fmt.Errorf("fetching node: %w", err)
@@ -65,12 +82,25 @@ func registerGetNodeTool(server *mcp.Server, r *Registry) {
 		node := wrapper.Document
 
 		// Project node
-		projected := projectNode(node, selects)
+		projected := projectNodeTree(node, selects, args.Depth)
 
 		result := &GetNodeResult{
 			Node:          projected,
 			ChildrenCount: len(node.Children),
 		}
+		if mapping := r.componentMapping(); mapping != nil {
+			files := codeFiles(node, wrapper.Components, mapping, args.Depth)
+			result.CodeFile = files[node.ID]
+			addCodeFiles(projected, files)
+		}
+
+		// Summarize rather than spill to a file when the node is too large
+		summarize := args.SummarizeLargeArrays == nil || *args.SummarizeLargeArrays
+		if summarize {
+			if b, _ := json.Marshal(projected); len(b) > DefaultMaxOutputSize {
+				result.Summarized = summarizeLargeArrays(projected)
+			}
+		}
 
 		// Format output
 		var textOutput string
@@ -81,27 +111,115 @@ func registerGetNodeTool(server *mcp.Server, r *Registry) {
 			textOutput = formatNodeResult(result)
 		}
 
+		outputResult, err := ProcessOutput(textOutput, result, OutputConfig{
+			MaxOutputSize: DefaultMaxOutputSize,
+			OutputDir:     r.ExportDir(),
+			ToolName:      "get_node",
+			FileKey:       args.FileKey,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("processing output: %w", err)
+		}
+		if outputResult.WasWrittenToFile {
+			result.FilePath = outputResult.FilePath
+		}
+
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: textOutput},
+				&mcp.TextContent{Text: outputResult.Text},
 			},
 		}, result, nil
 	})
 }
 
+// projectNodeTree projects node and, down to depth levels, its children
+// under a "children" key. A negative depth includes all descendants.
+func projectNodeTree(node *figma.Node, selects []string, depth int) map[string]any {
+	projected := projectNode(node, selects)
+	if depth != 0 && len(node.Children) > 0 {
+		children := make([]map[string]any, len(node.Children))
+		for i, child := range node.Children {
+			children[i] = projectNodeTree(child, selects, depth-1)
+		}
+		projected["children"] = children
+	}
+	return projected
+}
+
+// apiDepth converts a depth argument to GetFileOptions.Depth. A negative
+// depth (-1) means unlimited, which the API expresses by omitting depth.
+func apiDepth(depth int) int {
+	if depth < 0 {
+		return 0
+	}
+	return depth
+}
+
+// addCodeFiles sets "code_file" on each projected node, including children,
+// whose ID has an entry in files.
+func addCodeFiles(projected map[string]any, files map[string]string) {
+	if file := files[fmt.Sprint(projected["id"])]; file != "" {
+		projected["code_file"] = file
+	}
+	if children, ok := projected["children"].([]map[string]any); ok {
+		for _, child := range children {
+			addCodeFiles(child, files)
+		}
+	}
+}
+
+// summarizeLargeArrays replaces every children array longer than
+// largeArrayThreshold, at any depth, with a summary of the form
+// {"$count": 200, "$types": {"TEXT": 150}, "$sample": [first items]}.
+// It reports whether anything was summarized.
+func summarizeLargeArrays(node map[string]any) bool {
+	children, ok := node["children"].([]map[string]any)
+	if !ok {
+		return false
+	}
+
+	if len(children) > largeArrayThreshold {
+		types := make(map[string]int)
+		for _, child := range children {
+			types[fmt.Sprint(child["type"])]++
+		}
+		sample := children[:largeArraySample]
+		for _, child := range sample {
+			summarizeLargeArrays(child)
+		}
+		node["children"] = map[string]any{
+			"$count":  len(children),
+			"$types":  types,
+			"$sample": sample,
+		}
+		return true
+	}
+
+	summarized := false
+	for _, child := range children {
+		if summarizeLargeArrays(child) {
+			summarized = true
+		}
+	}
+	return summarized
+}
+
 // GetCSSArgs contains arguments for the get_css tool.
 type GetCSSArgs struct {
 	FileKey string   `json:"file_key" jsonschema:"Figma file key"`
 	NodeIDs []string `json:"node_ids" jsonschema:"Node IDs to get CSS for"`
-	Style   string   `json:"style,omitempty" jsonschema:"CSS output style: vanilla (default), cssmodules, tailwind, styled-components, or tokens"`
+	Style   string   `json:"style,omitempty" jsonschema:"CSS output style: vanilla (default), cssmodules, tailwind, styled-components, tokens, or svg (text elements with tspan runs)"`
 	Include []string `json:"include,omitempty" jsonschema:"What to include: layout spacing colors typography effects all"`
 	Format  string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+
+	LogicalProperties bool `json:"logical_properties,omitempty" jsonschema:"Use CSS logical properties (padding-inline-start, text-align: start) for RTL-compatible output"`
 }
 
 // GetCSSResult contains the result of get_css.
 type GetCSSResult struct {
 	CSS       map[string]string   `json:"css"`
 	Variables map[string]string   `json:"variables,omitempty"`
+	CodeFiles map[string]string   `json:"code_files,omitempty"` // node ID → implementing file, from component_map
 	Warnings  []string            `json:"warnings,omitempty"`
 }
 
@@ -109,16 +227,22 @@ func registerGetCSSTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_css",
 		Description: "Extract CSS properties for node(s). Returns production-ready CSS.",
+		InputSchema: inputSchema[GetCSSArgs](map[string][]string{
+			"style":   {"vanilla", "cssmodules", "tailwind", "styled-components", "tokens", "svg"},
+			"include": {"layout", "spacing", "colors", "typography", "effects", "all"},
+			"format":  responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetCSSArgs) (*mcp.CallToolResult, *GetCSSResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 
 		// Parse node IDs
 		nodeIDs := args.NodeIDs
 		if len(nodeIDs) == 0 {
-			return nil, nil, fmt.Errorf("node_ids is required")
+			return nil, nil, errNodeIDsRequired
 		}
+		nodeIDs = r.ResolveNodeIDs(nodeIDs)
 
 		// Set defaults
 		style := args.Style
@@ -127,7 +251,7 @@ func registerGetCSSTool(server *mcp.Server, r *Registry) {
 		}
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Fetch nodes
@@ -147,8 +271,38 @@ func registerGetCSSTool(server *mcp.Server, r *Registry) {
 				continue
 			}
 
-			css := generateCSS(wrapper.Document, style, args.Include)
+			css := generateCSS(wrapper.Document, style, args.Include, args.LogicalProperties)
 			result.CSS[id] = css
+
+			if mapping := r.componentMapping(); mapping != nil {
+				if file := codeFiles(wrapper.Document, wrapper.Components, mapping, 0)[id]; file != "" {
+					if result.CodeFiles == nil {
+						result.CodeFiles = make(map[string]string)
+					}
+					result.CodeFiles[id] = file
+				}
+			}
+		}
+
+		// Resolve variables bound to fill and stroke colors through alias chains
+		var boundIDs []string
+		for _, wrapper := range nodes.Nodes {
+			if wrapper.Document != nil {
+				boundIDs = append(boundIDs, boundColorVariables(wrapper.Document)...)
+			}
+		}
+		if len(boundIDs) > 0 {
+			vars, err := r.Client().GetLocalVariables(ctx, args.FileKey)
+			if err != nil || vars.Meta == nil {
+				result.Warnings = append(result.Warnings, "could not fetch variables to resolve bound colors")
+			} else {
+				tr := newTokenResolver(vars.Meta.Variables, vars.Meta.VariableCollections)
+				for _, varID := range boundIDs {
+					if v := vars.Meta.Variables[varID]; v != nil {
+						result.Variables["--"+formatVarName(v.Name, "")] = tr.formatValue(v, "")
+					}
+				}
+			}
 		}
 
 		// Format output
@@ -168,6 +322,20 @@ func registerGetCSSTool(server *mcp.Server, r *Registry) {
 	})
 }
 
+// boundColorVariables returns the IDs of variables bound to the node's fill
+// and stroke colors.
+func boundColorVariables(node *figma.Node) []string {
+	var ids []string
+	for _, paints := range [][]figma.Paint{node.Fills, node.Strokes} {
+		for _, paint := range paints {
+			if ref := paint.BoundVariables["color"]; ref != nil && ref.ID != "" {
+				ids = append(ids, ref.ID)
+			}
+		}
+	}
+	return ids
+}
+
 // GetTokensArgs contains arguments for the get_tokens tool.
 type GetTokensArgs struct {
 	FileKey string   `json:"file_key" jsonschema:"Figma file key"`
@@ -188,18 +356,22 @@ func registerGetTokensTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_tokens",
 		Description: "Get design token references and resolved values for node(s).",
+		InputSchema: inputSchema[GetTokensArgs](map[string][]string{
+			"format": responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetTokensArgs) (*mcp.CallToolResult, *GetTokensResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 
 		nodeIDs := args.NodeIDs
 		if len(nodeIDs) == 0 {
-			return nil, nil, fmt.Errorf("node_ids is required")
+			return nil, nil, errNodeIDsRequired
 		}
+		nodeIDs = r.ResolveNodeIDs(nodeIDs)
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Fetch nodes
@@ -269,8 +441,11 @@ func registerGetTokensTool(server *mcp.Server, r *Registry) {
 	})
 }
 
-func generateCSS(node *figma.Node, style string, include []string) string {
+func generateCSS(node *figma.Node, style string, include []string, logical bool) string {
 	props := extractCSSProperties(node)
+	if logical {
+		props = toLogicalProperties(props)
+	}
 
 	var sb strings.Builder
 
@@ -289,6 +464,9 @@ func generateCSS(node *figma.Node, style string, include []string) string {
 		sb.WriteString(fmt.Sprintf("/* %s */\n", node.Name))
 		sb.WriteString(strings.Join(classes, " "))
 
+	case "svg":
+		sb.WriteString(generateSVGTextSnippets(node))
+
 	default:
 		sb.WriteString(fmt.Sprintf("/* %s */\n", node.Name))
 		for key, value := range props {
@@ -299,6 +477,76 @@ func generateCSS(node *figma.Node, style string, include []string) string {
 	return sb.String()
 }
 
+// physicalToLogical maps physical (directional) CSS properties to their
+// logical equivalents, assuming a horizontal writing mode.
+var physicalToLogical = map[string]string{
+	"paddingLeft":       "paddingInlineStart",
+	"paddingRight":      "paddingInlineEnd",
+	"paddingTop":        "paddingBlockStart",
+	"paddingBottom":     "paddingBlockEnd",
+	"marginLeft":        "marginInlineStart",
+	"marginRight":       "marginInlineEnd",
+	"marginTop":         "marginBlockStart",
+	"marginBottom":      "marginBlockEnd",
+	"borderLeft":        "borderInlineStart",
+	"borderRight":       "borderInlineEnd",
+	"borderTop":         "borderBlockStart",
+	"borderBottom":      "borderBlockEnd",
+	"borderLeftWidth":   "borderInlineStartWidth",
+	"borderRightWidth":  "borderInlineEndWidth",
+	"borderTopWidth":    "borderBlockStartWidth",
+	"borderBottomWidth": "borderBlockEndWidth",
+	"left":              "insetInlineStart",
+	"right":             "insetInlineEnd",
+	"top":               "insetBlockStart",
+	"bottom":            "insetBlockEnd",
+}
+
+// toLogicalProperties rewrites directional CSS properties as logical
+// properties so the output works for both LTR and RTL layouts.
+func toLogicalProperties(props map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(props))
+
+	for key, value := range props {
+		if logical, ok := physicalToLogical[key]; ok {
+			result[logical] = value
+			continue
+		}
+
+		switch key {
+		case "padding", "margin":
+			// Split the "top right bottom left" shorthand into block/inline pairs
+			parts, ok := value.(string)
+			if !ok {
+				result[key] = value
+				continue
+			}
+			sides := strings.Fields(parts)
+			if len(sides) != 4 {
+				result[key] = value
+				continue
+			}
+			result[key+"Block"] = sides[0] + " " + sides[2]
+			result[key+"Inline"] = sides[3] + " " + sides[1]
+
+		case "textAlign":
+			switch value {
+			case "left":
+				result[key] = "start"
+			case "right":
+				result[key] = "end"
+			default:
+				result[key] = value
+			}
+
+		default:
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
 func camelToKebab(s string) string {
 	var result strings.Builder
 	for i, r := range s {
@@ -362,24 +610,53 @@ func formatNodeResult(r *GetNodeResult) string {
 	if r.Path != "" {
 		sb.WriteString(fmt.Sprintf("Path: %s\n", r.Path))
 	}
+	if r.CodeFile != "" {
+		sb.WriteString(fmt.Sprintf("Code: %s\n", r.CodeFile))
+	}
 	sb.WriteString(fmt.Sprintf("Children: %d\n\n", r.ChildrenCount))
 
 	sb.WriteString("Properties:\n")
 	for key, val := range r.Node {
-		if key == "id" || key == "name" || key == "type" {
+		if key == "id" || key == "name" || key == "type" || key == "children" || key == "code_file" {
 			continue
 		}
 		sb.WriteString(fmt.Sprintf("  %s: %v\n", key, val))
 	}
 
+	if children, ok := r.Node["children"]; ok {
+		b, _ := json.MarshalIndent(children, "", "  ")
+		sb.WriteString("\nChildren:\n")
+		sb.WriteString(string(b))
+		sb.WriteString("\n")
+	}
+	if r.Summarized {
+		sb.WriteString("\nLarge children arrays were summarized as {$count, $types, $sample}. Use get_node on a child or set summarize_large_arrays=false for the full list.\n")
+	}
+
 	return sb.String()
 }
 
 func formatCSSResult(r *GetCSSResult) string {
 	var sb strings.Builder
 
+	if len(r.Variables) > 0 {
+		names := make([]string, 0, len(r.Variables))
+		for name := range r.Variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		sb.WriteString(":root {\n")
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("  %s: %s;\n", name, r.Variables[name]))
+		}
+		sb.WriteString("}\n\n")
+	}
+
 	for id, css := range r.CSS {
 		sb.WriteString(fmt.Sprintf("/* Node: %s */\n", id))
+		if file := r.CodeFiles[id]; file != "" {
+			sb.WriteString(fmt.Sprintf("/* Implemented in %s */\n", file))
+		}
 		sb.WriteString(css)
 		sb.WriteString("\n")
 	}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestToLogicalProperties(t *testing.T) {
+	props := map[string]interface{}{
+		"padding":     "8px 16px 12px 4px",
+		"paddingLeft": 4.0,
+		"marginTop":   10.0,
+		"textAlign":   "left",
+		"width":       100.0,
+	}
+
+	result := toLogicalProperties(props)
+
+	tests := []struct {
+		key      string
+		expected interface{}
+	}{
+		{"paddingBlock", "8px 12px"},
+		{"paddingInline", "4px 16px"},
+		{"paddingInlineStart", 4.0},
+		{"marginBlockStart", 10.0},
+		{"textAlign", "start"},
+		{"width", 100.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if result[tt.key] != tt.expected {
+				t.Errorf("result[%s] = %v, want %v", tt.key, result[tt.key], tt.expected)
+			}
+		})
+	}
+
+	for _, physical := range []string{"padding", "paddingLeft", "marginTop"} {
+		if _, ok := result[physical]; ok {
+			t.Errorf("expected physical property %q to be removed", physical)
+		}
+	}
+}
+
+func TestProjectNodeTreeUnlimitedDepth(t *testing.T) {
+	leaf := &figma.Node{ID: "3:1", Name: "Leaf", Type: figma.NodeTypeText}
+	mid := &figma.Node{ID: "2:1", Name: "Mid", Type: figma.NodeTypeFrame, Children: []*figma.Node{leaf}}
+	root := &figma.Node{ID: "1:1", Name: "Root", Type: figma.NodeTypeFrame, Children: []*figma.Node{mid}}
+
+	projected := projectNodeTree(root, []string{"@structure"}, -1)
+	children, ok := projected["children"].([]map[string]any)
+	if !ok || len(children) != 1 {
+		t.Fatalf("children = %#v", projected["children"])
+	}
+	grandchildren, ok := children[0]["children"].([]map[string]any)
+	if !ok || len(grandchildren) != 1 || grandchildren[0]["id"] != "3:1" {
+		t.Fatalf("grandchildren = %#v", children[0]["children"])
+	}
+
+	if got := apiDepth(-1); got != 0 {
+		t.Errorf("apiDepth(-1) = %d, want 0 (no depth parameter)", got)
+	}
+	if got := apiDepth(2); got != 2 {
+		t.Errorf("apiDepth(2) = %d, want 2", got)
+	}
+}
+
+func TestSummarizeLargeArrays(t *testing.T) {
+	table := &figma.Node{ID: "1:1", Name: "Table", Type: figma.NodeTypeFrame}
+	for i := 0; i < 30; i++ {
+		typ := figma.NodeTypeText
+		if i%3 == 0 {
+			typ = figma.NodeTypeRectangle
+		}
+		table.Children = append(table.Children, &figma.Node{ID: fmt.Sprintf("2:%d", i), Name: "Cell", Type: typ})
+	}
+	root := &figma.Node{ID: "0:1", Name: "Page", Type: figma.NodeTypeFrame, Children: []*figma.Node{table}}
+
+	projected := projectNodeTree(root, []string{"@structure"}, 2)
+	if children, ok := projected["children"].([]map[string]any); !ok || len(children) != 1 {
+		t.Fatalf("children = %#v", projected["children"])
+	}
+	if shallow := projectNodeTree(root, []string{"@structure"}, 0); shallow["children"] != nil {
+		t.Errorf("depth 0 should not include children")
+	}
+
+	if !summarizeLargeArrays(projected) {
+		t.Fatal("expected the table children to be summarized")
+	}
+	tableMap := projected["children"].([]map[string]any)[0]
+	summary, ok := tableMap["children"].(map[string]any)
+	if !ok {
+		t.Fatalf("table children = %#v", tableMap["children"])
+	}
+	if summary["$count"] != 30 || len(summary["$sample"].([]map[string]any)) != largeArraySample {
+		t.Errorf("summary = %#v", summary)
+	}
+	types := summary["$types"].(map[string]int)
+	if types["TEXT"] != 20 || types["RECTANGLE"] != 10 {
+		t.Errorf("$types = %v", types)
+	}
+
+	// Small arrays are left alone
+	small := projectNodeTree(&figma.Node{ID: "3:1", Children: table.Children[:5]}, []string{"@structure"}, 1)
+	if summarizeLargeArrays(small) {
+		t.Error("small children array should not be summarized")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -14,19 +16,63 @@ import (
 
 // DiffArgs contains arguments for the diff tool.
 type DiffArgs struct {
-	FileKey   string   `json:"file_key" jsonschema:"Figma file key"`
-	Compare   string   `json:"compare,omitempty" jsonschema:"What to compare: last_sync or version"`
-	VersionID string   `json:"version_id,omitempty" jsonschema:"Specific version ID (if compare=version)"`
-	Scope     []string `json:"scope,omitempty" jsonschema:"What to compare: structure properties styles components"`
-	Format    string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	FileKey     string   `json:"file_key" jsonschema:"Figma file key"`
+	Compare     string   `json:"compare,omitempty" jsonschema:"What to compare: last_sync or version"`
+	VersionID   string   `json:"version_id,omitempty" jsonschema:"Specific version ID (if compare=version); find IDs with list_versions"`
+	Scope       []string `json:"scope,omitempty" jsonschema:"What to compare: structure properties styles components images"`
+	Timeline    bool     `json:"timeline,omitempty" jsonschema:"List every recorded sync_file run of the file with its node count change instead of comparing"`
+	SummaryOnly bool     `json:"summary_only,omitempty" jsonschema:"Only report how many nodes were added, removed and modified"`
+	SampleLimit int      `json:"sample_limit,omitempty" jsonschema:"With summary_only, the number of changed nodes of each kind still listed in JSON output (default: 10)"`
+	Format      string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
 }
 
 // DiffResult contains the result of diff comparison.
 type DiffResult struct {
-	Added    []NodeChange `json:"added"`
-	Removed  []NodeChange `json:"removed"`
-	Modified []NodeChange `json:"modified"`
-	Summary  string       `json:"summary"`
+	Added       []NodeChange   `json:"added"`
+	Removed     []NodeChange   `json:"removed"`
+	Modified    []NodeChange   `json:"modified"`
+	Renamed     []RenameChange `json:"renamed,omitempty"`
+	Counts      DiffCounts     `json:"counts"`
+	Images      []ImageChange  `json:"images,omitempty"`
+	Timeline    []SyncEntry    `json:"timeline,omitempty"`
+	Summary     string         `json:"summary"`
+	SummaryOnly bool           `json:"summary_only,omitempty"`
+}
+
+// DiffCounts holds the number of changed nodes, including those not listed
+// when summary_only limits the Added, Removed and Modified lists.
+type DiffCounts struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+	Renamed  int `json:"renamed"`
+}
+
+// SyncEntry is one sync of a file in the diff timeline, oldest first.
+type SyncEntry struct {
+	SyncSnapshot
+	NodeDelta int `json:"nodeDelta"` // change from the previous sync with counts
+}
+
+// ImageChange lists the image fill references of a node whose image content
+// was replaced. The refs can be passed to download_image.
+type ImageChange struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Previous []string `json:"previous"`
+	Current  []string `json:"current"`
+}
+
+// RenameChange is a removed node and an added node that are likely the same
+// node recreated under a new name, e.g. after detaching and rebuilding a
+// component.
+type RenameChange struct {
+	OldID      string  `json:"oldId"`
+	OldName    string  `json:"oldName"`
+	NewID      string  `json:"newId"`
+	NewName    string  `json:"newName"`
+	Type       string  `json:"type"`
+	Confidence float64 `json:"confidence"` // 0-1
 }
 
 // NodeChange represents a change to a node.
@@ -40,10 +86,36 @@ type NodeChange struct {
 func registerDiffTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "diff",
-		Description: "Compare two exports or file versions.",
+		Description: "Compare two exports or file versions. With summary_only=true, report just the added, removed and modified counts. With timeline=true, list every recorded sync of the file with its node count change instead.",
+		InputSchema: inputSchema[DiffArgs](map[string][]string{
+			"compare": {"last_sync", "version"},
+			"scope":   {"structure", "properties", "styles", "components", "images"},
+			"format":  responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args DiffArgs) (*mcp.CallToolResult, *DiffResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
+		}
+
+		if args.Timeline {
+			result, err := buildSyncTimeline(r.ExportDir(), args.FileKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var textOutput string
+			if args.Format == "json" {
+				b, _ := json.MarshalIndent(result, "", "  ")
+				textOutput = string(b)
+			} else {
+				textOutput = formatSyncTimeline(result)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: textOutput},
+				},
+			}, result, nil
 		}
 
 		// Set defaults
@@ -58,12 +130,12 @@ func registerDiffTool(server *mcp.Server, r *Registry) {
 
 		// Get current state from API
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
-		currentFile, err := r.Client().GetFile(ctx, args.FileKey, nil)
+		current, err := r.fileNodes(ctx, args.FileKey)
 		if err != nil {
-			return nil, nil, fmt.Errorf("fetching current file: %w", err)
+			return nil, nil, err
 		}
 
 		// Get comparison state
@@ -82,27 +154,35 @@ func registerDiffTool(server *mcp.Server, r *Registry) {
 				return nil, nil, fmt.Errorf("version_id required when compare=version")
 			}
 			// Fetch specific version
-			prevFile, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{
-				Version: args.VersionID,
-			})
+			prev, err := r.versionNodes(ctx, args.FileKey, args.VersionID)
 			if err != nil {
-				return nil, nil, fmt.Errorf("fetching version %s: %w", args.VersionID, err)
+				return nil, nil, err
 			}
-			previousNodes = flattenToMap(prevFile.Document)
+			previousNodes = nodesByID(prev)
 
 		default:
 			return nil, nil, fmt.Errorf("invalid compare mode: %s", compare)
 		}
 
 		// Flatten current nodes
-		currentNodes := flattenToMap(currentFile.Document)
+		currentNodes := nodesByID(current)
 
 		// Compare
-		result := compareNodes(previousNodes, currentNodes, scope)
+		limit := 0
+		if args.SummaryOnly {
+			limit = args.SampleLimit
+			if limit <= 0 {
+				limit = 10
+			}
+		}
+		result := compareNodes(previousNodes, currentNodes, scope, limit)
+		result.SummaryOnly = args.SummaryOnly
 
 		// Build summary
-		result.Summary = fmt.Sprintf("%d added, %d removed, %d modified",
-			len(result.Added), len(result.Removed), len(result.Modified))
+		result.Summary = formatDiffCounts(result.Counts)
+		if containsString(scope, "images") {
+			result.Summary += fmt.Sprintf(", %d images replaced", len(result.Images))
+		}
 
 		// Format output
 		var textOutput string
@@ -154,30 +234,69 @@ func readCachedNodes(exportDir, fileKey string) (map[string]*figma.Node, error)
 		}
 	}
 
-	return nil, fmt.Errorf("no cache found for file %s", fileKey)
+	return nil, errNoCache(fileKey)
 }
 
-func flattenToMap(doc *figma.DocumentNode) map[string]*figma.Node {
-	nodes := make(map[string]*figma.Node)
+// buildSyncTimeline collects the sync history of every export of fileKey
+// under exportDir in chronological order.
+func buildSyncTimeline(exportDir, fileKey string) (*DiffResult, error) {
+	caches, err := listCachedFiles(exportDir)
+	if err != nil {
+		return nil, err
+	}
 
-	var walk func(*figma.Node)
-	walk = func(n *figma.Node) {
-		nodes[n.ID] = n
-		for _, child := range n.Children {
-			walk(child)
+	seen := make(map[string]bool)
+	var syncs []SyncSnapshot
+	for _, c := range caches {
+		if c.FileKey != fileKey {
+			continue
+		}
+		for _, s := range readSyncHistory(filepath.Join(c.Dir, "_meta.json")) {
+			if !seen[s.ExportedAt] {
+				seen[s.ExportedAt] = true
+				syncs = append(syncs, s)
+			}
 		}
 	}
+	if len(syncs) == 0 {
+		return nil, errNoCache(fileKey)
+	}
+	sort.SliceStable(syncs, func(i, j int) bool { return syncs[i].ExportedAt < syncs[j].ExportedAt })
 
-	if doc != nil {
-		for _, page := range doc.Children {
-			walk(page)
+	result := &DiffResult{
+		Added:    make([]NodeChange, 0),
+		Removed:  make([]NodeChange, 0),
+		Modified: make([]NodeChange, 0),
+	}
+	last := -1
+	for _, s := range syncs {
+		entry := SyncEntry{SyncSnapshot: s}
+		if s.Nodes > 0 {
+			if last >= 0 {
+				entry.NodeDelta = s.Nodes - last
+			}
+			last = s.Nodes
 		}
+		result.Timeline = append(result.Timeline, entry)
 	}
 
+	first, latest := syncs[0], syncs[len(syncs)-1]
+	result.Summary = fmt.Sprintf("%d syncs from %s to %s", len(syncs), first.ExportedAt, latest.ExportedAt)
+	return result, nil
+}
+
+func nodesByID(list []*figma.Node) map[string]*figma.Node {
+	nodes := make(map[string]*figma.Node, len(list))
+	for _, n := range list {
+		nodes[n.ID] = n
+	}
 	return nodes
 }
 
-func compareNodes(previous, current map[string]*figma.Node, scope []string) *DiffResult {
+// compareNodes lists the nodes that differ between previous and current.
+// With a limit above zero, at most limit nodes of each kind are listed;
+// Counts always holds the full numbers.
+func compareNodes(previous, current map[string]*figma.Node, scope []string, limit int) *DiffResult {
 	result := &DiffResult{
 		Added:    make([]NodeChange, 0),
 		Removed:  make([]NodeChange, 0),
@@ -186,18 +305,16 @@ func compareNodes(previous, current map[string]*figma.Node, scope []string) *Dif
 
 	includeStructure := containsString(scope, "structure")
 	includeProperties := containsString(scope, "properties")
+	includeImages := containsString(scope, "images")
 
 	// Find added and modified nodes
+	var addedIDs []string
 	for id, currNode := range current {
 		prevNode, exists := previous[id]
 
 		if !exists {
 			if includeStructure {
-				result.Added = append(result.Added, NodeChange{
-					ID:   id,
-					Name: currNode.Name,
-					Type: string(currNode.Type),
-				})
+				addedIDs = append(addedIDs, id)
 			}
 			continue
 		}
@@ -275,24 +392,80 @@ func compareNodes(previous, current map[string]*figma.Node, scope []string) *Dif
 			}
 		}
 
+		if includeImages {
+			prevRefs, currRefs := imageFillRefs(prevNode), imageFillRefs(currNode)
+			if !equalStrings(prevRefs, currRefs) {
+				changes["images"] = map[string][]string{
+					"from": prevRefs,
+					"to":   currRefs,
+				}
+				result.Images = append(result.Images, ImageChange{
+					ID:       id,
+					Name:     currNode.Name,
+					Previous: prevRefs,
+					Current:  currRefs,
+				})
+			}
+		}
+
 		if len(changes) > 0 {
-			result.Modified = append(result.Modified, NodeChange{
-				ID:      id,
-				Name:    currNode.Name,
-				Type:    string(currNode.Type),
-				Changes: changes,
-			})
+			result.Counts.Modified++
+			if limit <= 0 || len(result.Modified) < limit {
+				result.Modified = append(result.Modified, NodeChange{
+					ID:      id,
+					Name:    currNode.Name,
+					Type:    string(currNode.Type),
+					Changes: changes,
+				})
+			}
 		}
 	}
 
 	// Find removed nodes
 	if includeStructure {
-		for id, prevNode := range previous {
+		var removedIDs []string
+		for id := range previous {
 			if _, exists := current[id]; !exists {
+				removedIDs = append(removedIDs, id)
+			}
+		}
+
+		// Report likely renames instead of a removed and an added node
+		renamed := make(map[string]bool)
+		for _, rename := range detectRenames(previous, current, removedIDs, addedIDs) {
+			renamed[rename.OldID], renamed[rename.NewID] = true, true
+			result.Counts.Renamed++
+			if limit <= 0 || len(result.Renamed) < limit {
+				result.Renamed = append(result.Renamed, rename)
+			}
+		}
+
+		sort.Strings(addedIDs)
+		for _, id := range addedIDs {
+			if renamed[id] {
+				continue
+			}
+			result.Counts.Added++
+			if limit <= 0 || len(result.Added) < limit {
+				result.Added = append(result.Added, NodeChange{
+					ID:   id,
+					Name: current[id].Name,
+					Type: string(current[id].Type),
+				})
+			}
+		}
+
+		sort.Strings(removedIDs)
+		for _, id := range removedIDs {
+			if renamed[id] {
+				continue
+			}
+			result.Counts.Removed++
+			if limit <= 0 || len(result.Removed) < limit {
 				result.Removed = append(result.Removed, NodeChange{
 					ID:   id,
-					Name: prevNode.Name,
-					Type: string(prevNode.Type),
+					Name: previous[id].Name,
+					Type: string(previous[id].Type),
 				})
 			}
 		}
@@ -301,6 +474,161 @@ func compareNodes(previous, current map[string]*figma.Node, scope []string) *Dif
 	return result
 }
 
+// formatDiffCounts summarizes counts, e.g. "2 added, 1 removed, 3 modified".
+func formatDiffCounts(c DiffCounts) string {
+	summary := fmt.Sprintf("%d added, %d removed, %d modified", c.Added, c.Removed, c.Modified)
+	if c.Renamed > 0 {
+		summary += fmt.Sprintf(", %d renamed", c.Renamed)
+	}
+	return summary
+}
+
+// renameThreshold is the confidence a removed/added pair needs to be
+// reported as a rename.
+const renameThreshold = 0.6
+
+// maxRenamePairs bounds the removed × added pairs of one node type that are
+// scored. Above it, only nodes under the same parent are compared.
+const maxRenamePairs = 250000
+
+// detectRenames pairs removed nodes with added nodes of the same type that
+// are likely the same node under a new ID. A pair's confidence weighs name
+// similarity (edit distance) at 0.5, parent vicinity at 0.3 and matching
+// size and child count at 0.2. Pairs are matched greedily, most confident
+// first, so each node is in at most one rename.
+func detectRenames(previous, current map[string]*figma.Node, removedIDs, addedIDs []string) []RenameChange {
+	if len(removedIDs) == 0 || len(addedIDs) == 0 {
+		return nil
+	}
+	prevParents, currParents := parentIDs(previous), parentIDs(current)
+
+	addedByType := make(map[figma.NodeType][]string)
+	for _, id := range addedIDs {
+		addedByType[current[id].Type] = append(addedByType[current[id].Type], id)
+	}
+	removedCount := make(map[figma.NodeType]int)
+	for _, id := range removedIDs {
+		removedCount[previous[id].Type]++
+	}
+
+	var candidates []RenameChange
+	for _, oldID := range removedIDs {
+		oldNode := previous[oldID]
+		added := addedByType[oldNode.Type]
+		sameParentOnly := removedCount[oldNode.Type]*len(added) > maxRenamePairs
+		for _, newID := range added {
+			newNode := current[newID]
+			oldParent, newParent := prevParents[oldID], currParents[newID]
+			if sameParentOnly && oldParent != newParent {
+				continue
+			}
+
+			confidence := 0.5*similarity(normalizeForFuzzy(oldNode.Name), normalizeForFuzzy(newNode.Name)) +
+				0.3*parentVicinity(previous[oldParent], current[newParent]) +
+				0.2*shapeSimilarity(oldNode, newNode)
+			if confidence < renameThreshold {
+				continue
+			}
+			candidates = append(candidates, RenameChange{
+				OldID:      oldID,
+				OldName:    oldNode.Name,
+				NewID:      newID,
+				NewName:    newNode.Name,
+				Type:       string(oldNode.Type),
+				Confidence: math.Round(confidence*100) / 100,
+			})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.OldID != b.OldID {
+			return a.OldID < b.OldID
+		}
+		return a.NewID < b.NewID
+	})
+
+	var renames []RenameChange
+	used := make(map[string]bool)
+	for _, c := range candidates {
+		if used[c.OldID] || used[c.NewID] {
+			continue
+		}
+		used[c.OldID], used[c.NewID] = true, true
+		renames = append(renames, c)
+	}
+	return renames
+}
+
+// parentIDs maps the ID of each child of nodes to its parent's ID.
+func parentIDs(nodes map[string]*figma.Node) map[string]string {
+	parents := make(map[string]string)
+	for id, n := range nodes {
+		for _, child := range n.Children {
+			parents[child.ID] = id
+		}
+	}
+	return parents
+}
+
+// parentVicinity scores two parents: 1 for the same node, 0.5 for nodes of
+// the same type and name (such as a recreated container), else 0.
+func parentVicinity(oldParent, newParent *figma.Node) float64 {
+	switch {
+	case oldParent == nil || newParent == nil:
+		return 0
+	case oldParent.ID == newParent.ID:
+		return 1
+	case oldParent.Type == newParent.Type && oldParent.Name == newParent.Name:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// shapeSimilarity scores how alike two nodes are in size and child count,
+// each counting for half.
+func shapeSimilarity(a, b *figma.Node) float64 {
+	score := 0.0
+	if len(a.Children) == len(b.Children) {
+		score += 0.5
+	}
+	if a.AbsoluteBoundingBox != nil && b.AbsoluteBoundingBox != nil &&
+		math.Abs(a.AbsoluteBoundingBox.Width-b.AbsoluteBoundingBox.Width) < 1 &&
+		math.Abs(a.AbsoluteBoundingBox.Height-b.AbsoluteBoundingBox.Height) < 1 {
+		score += 0.5
+	}
+	return score
+}
+
+// imageFillRefs returns the image references of a node's IMAGE fills in
+// paint order. A fill without a reference is recorded as an empty string so
+// positions stay comparable.
+func imageFillRefs(node *figma.Node) []string {
+	refs := []string{}
+	for _, fill := range node.Fills {
+		if fill.Type == "IMAGE" {
+			refs = append(refs, fill.ImageRef)
+		}
+	}
+	return refs
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -308,9 +636,40 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// formatSyncTimeline lists syncs newest first, like git log.
+func formatSyncTimeline(r *DiffResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Sync timeline: %s\n\n", r.Summary))
+
+	for i := len(r.Timeline) - 1; i >= 0; i-- {
+		e := r.Timeline[i]
+		sb.WriteString(fmt.Sprintf("%s  version %s", e.ExportedAt, e.Version))
+		if e.LastModified != "" {
+			sb.WriteString(fmt.Sprintf(" (edited %s)", e.LastModified))
+		}
+		sb.WriteString("\n")
+
+		switch {
+		case e.Nodes == 0:
+			sb.WriteString("    node count not recorded\n")
+		case e.NodeDelta != 0:
+			sb.WriteString(fmt.Sprintf("    %d nodes (%+d), %d components, %d styles\n", e.Nodes, e.NodeDelta, e.Components, e.Styles))
+		default:
+			sb.WriteString(fmt.Sprintf("    %d nodes, %d components, %d styles\n", e.Nodes, e.Components, e.Styles))
+		}
+	}
+
+	return sb.String()
+}
+
 func formatDiffResult(r *DiffResult) string {
 	var sb strings.Builder
 
+	if r.SummaryOnly {
+		return fmt.Sprintf("Diff Summary: %s\n", r.Summary)
+	}
+
 	sb.WriteString(fmt.Sprintf("Diff Summary: %s\n\n", r.Summary))
 
 	if len(r.Added) > 0 {
@@ -335,6 +694,17 @@ func formatDiffResult(r *DiffResult) string {
 		sb.WriteString("\n")
 	}
 
+	if len(r.Renamed) > 0 {
+		sb.WriteString(fmt.Sprintf("Renamed (%d):\n", len(r.Renamed)))
+		for _, c := range r.Renamed[:min(10, len(r.Renamed))] {
+			sb.WriteString(fmt.Sprintf("  ~ [%s] %s → [%s] %s (%s, %.0f%% confidence)\n", c.OldID, c.OldName, c.NewID, c.NewName, c.Type, c.Confidence*100))
+		}
+		if len(r.Renamed) > 10 {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(r.Renamed)-10))
+		}
+		sb.WriteString("\n")
+	}
+
 	if len(r.Modified) > 0 {
 		sb.WriteString(fmt.Sprintf("Modified (%d):\n", len(r.Modified)))
 		for _, n := range r.Modified[:min(10, len(r.Modified))] {
@@ -346,6 +716,17 @@ func formatDiffResult(r *DiffResult) string {
 		if len(r.Modified) > 10 {
 			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(r.Modified)-10))
 		}
+		sb.WriteString("\n")
+	}
+
+	if len(r.Images) > 0 {
+		sb.WriteString(fmt.Sprintf("Images replaced (%d):\n", len(r.Images)))
+		for _, c := range r.Images {
+			sb.WriteString(fmt.Sprintf("  ~ [%s] %s\n", c.ID, c.Name))
+			sb.WriteString(fmt.Sprintf("      from: %s\n", strings.Join(c.Previous, ", ")))
+			sb.WriteString(fmt.Sprintf("      to:   %s\n", strings.Join(c.Current, ", ")))
+		}
+		sb.WriteString("Fetch the new images with download_image image_refs.\n")
 	}
 
 	return sb.String()
@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCompareNodesImages(t *testing.T) {
+	image := func(ref string) []figma.Paint {
+		return []figma.Paint{{Type: "IMAGE", ImageRef: ref}}
+	}
+	previous := map[string]*figma.Node{
+		"1:1": {ID: "1:1", Name: "Hero", Type: figma.NodeTypeRectangle, Fills: image("aaa")},
+		"1:2": {ID: "1:2", Name: "Avatar", Type: figma.NodeTypeRectangle, Fills: image("bbb")},
+	}
+	current := map[string]*figma.Node{
+		"1:1": {ID: "1:1", Name: "Hero", Type: figma.NodeTypeRectangle, Fills: image("ccc")},
+		"1:2": {ID: "1:2", Name: "Avatar", Type: figma.NodeTypeRectangle, Fills: image("bbb")},
+	}
+
+	// Fill counts match, so properties alone sees no change.
+	if result := compareNodes(previous, current, []string{"properties"}, 0); len(result.Modified) != 0 || len(result.Images) != 0 {
+		t.Fatalf("properties scope reported changes: %+v", result)
+	}
+
+	result := compareNodes(previous, current, []string{"images"}, 0)
+	if len(result.Images) != 1 {
+		t.Fatalf("got %d image changes, want 1", len(result.Images))
+	}
+	c := result.Images[0]
+	if c.ID != "1:1" || c.Previous[0] != "aaa" || c.Current[0] != "ccc" {
+		t.Errorf("image change = %+v", c)
+	}
+	if len(result.Modified) != 1 || result.Modified[0].Changes["images"] == nil {
+		t.Errorf("modified = %+v", result.Modified)
+	}
+}
+
+func TestCompareNodesLimit(t *testing.T) {
+	previous := map[string]*figma.Node{}
+	current := map[string]*figma.Node{}
+	for i := 0; i < 25; i++ {
+		id := fmt.Sprintf("1:%d", i)
+		current[id] = &figma.Node{ID: id, Name: "New", Type: figma.NodeTypeFrame}
+	}
+	for i := 0; i < 3; i++ {
+		id := fmt.Sprintf("2:%d", i)
+		previous[id] = &figma.Node{ID: id, Name: "Old", Type: figma.NodeTypeFrame}
+	}
+
+	result := compareNodes(previous, current, []string{"structure"}, 10)
+	if len(result.Added) != 10 || len(result.Removed) != 3 {
+		t.Errorf("listed %d added and %d removed, want 10 and 3", len(result.Added), len(result.Removed))
+	}
+	if result.Counts != (DiffCounts{Added: 25, Removed: 3}) {
+		t.Errorf("counts = %+v", result.Counts)
+	}
+
+	result.SummaryOnly = true
+	result.Summary = "25 added, 3 removed, 0 modified"
+	if got := formatDiffResult(result); got != "Diff Summary: 25 added, 3 removed, 0 modified\n" {
+		t.Errorf("summary text = %q", got)
+	}
+
+	if result := compareNodes(previous, current, []string{"structure"}, 0); len(result.Added) != 25 {
+		t.Errorf("unlimited compare listed %d added, want 25", len(result.Added))
+	}
+}
+
+func TestCompareNodesRenames(t *testing.T) {
+	box := &figma.Rectangle{Width: 320, Height: 48}
+	previous := map[string]*figma.Node{
+		"0:1": {ID: "0:1", Name: "Page", Type: figma.NodeTypeCanvas, Children: []*figma.Node{{ID: "1:1"}, {ID: "1:2"}, {ID: "1:3"}}},
+		"1:1": {ID: "1:1", Name: "Checkout Header", Type: figma.NodeTypeFrame, AbsoluteBoundingBox: box},
+		"1:2": {ID: "1:2", Name: "Promo", Type: figma.NodeTypeFrame},
+		"1:3": {ID: "1:3", Name: "Footer", Type: figma.NodeTypeText},
+	}
+	current := map[string]*figma.Node{
+		"0:1": {ID: "0:1", Name: "Page", Type: figma.NodeTypeCanvas, Children: []*figma.Node{{ID: "2:1"}, {ID: "2:2"}, {ID: "2:3"}}},
+		"2:1": {ID: "2:1", Name: "Checkout Header v2", Type: figma.NodeTypeFrame, AbsoluteBoundingBox: box},
+		"2:2": {ID: "2:2", Name: "Testimonials Carousel", Type: figma.NodeTypeFrame, Children: []*figma.Node{{ID: "2:4"}}},
+		"2:3": {ID: "2:3", Name: "Footer", Type: figma.NodeTypeFrame},
+	}
+
+	result := compareNodes(previous, current, []string{"structure"}, 0)
+	if len(result.Renamed) != 1 {
+		t.Fatalf("renamed = %+v, want one rename", result.Renamed)
+	}
+	r := result.Renamed[0]
+	if r.OldID != "1:1" || r.NewID != "2:1" || r.OldName != "Checkout Header" || r.NewName != "Checkout Header v2" {
+		t.Errorf("rename = %+v", r)
+	}
+	if r.Confidence < renameThreshold || r.Confidence > 1 {
+		t.Errorf("confidence = %v", r.Confidence)
+	}
+
+	// Dissimilar names and a changed type stay removed and added
+	if result.Counts != (DiffCounts{Added: 2, Removed: 2, Renamed: 1}) {
+		t.Errorf("counts = %+v", result.Counts)
+	}
+	for _, n := range append(result.Added, result.Removed...) {
+		if n.ID == "1:1" || n.ID == "2:1" {
+			t.Errorf("renamed node %s also listed as added or removed", n.ID)
+		}
+	}
+	if got := formatDiffCounts(result.Counts); got != "2 added, 2 removed, 0 modified, 1 renamed" {
+		t.Errorf("summary = %q", got)
+	}
+}
+
+// writeTestMeta writes the _meta.json of an export directory.
+func writeTestMeta(t *testing.T, dir string, meta map[string]any) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeJSON(filepath.Join(dir, "_meta.json"), meta); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildSyncTimeline(t *testing.T) {
+	exportDir := t.TempDir()
+
+	// An export written before sync history was recorded
+	writeTestMeta(t, filepath.Join(exportDir, "old"), map[string]any{
+		"fileKey": "KEY", "exportedAt": "2026-01-01T00:00:00Z", "version": "1",
+	})
+	history := appendSyncHistory(nil, SyncSnapshot{ExportedAt: "2026-02-01T00:00:00Z", Version: "2", Nodes: 100})
+	history = appendSyncHistory(history, SyncSnapshot{ExportedAt: "2026-03-01T00:00:00Z", Version: "3", Nodes: 120})
+	history = appendSyncHistory(history, SyncSnapshot{ExportedAt: "2026-04-01T00:00:00Z", Version: "4", Nodes: 95})
+	writeTestMeta(t, filepath.Join(exportDir, "current"), map[string]any{
+		"fileKey": "KEY", "exportedAt": "2026-04-01T00:00:00Z", "history": history,
+	})
+	writeTestMeta(t, filepath.Join(exportDir, "other"), map[string]any{
+		"fileKey": "OTHER", "exportedAt": "2026-05-01T00:00:00Z",
+	})
+
+	result, err := buildSyncTimeline(exportDir, "KEY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var versions []string
+	var deltas []int
+	for _, e := range result.Timeline {
+		versions = append(versions, e.Version)
+		deltas = append(deltas, e.NodeDelta)
+	}
+	if strings.Join(versions, ",") != "1,2,3,4" {
+		t.Errorf("versions = %v, want chronological 1,2,3,4", versions)
+	}
+	if deltas[1] != 0 || deltas[2] != 20 || deltas[3] != -25 {
+		t.Errorf("deltas = %v, want [0 0 20 -25]", deltas)
+	}
+
+	text := formatSyncTimeline(result)
+	if !strings.Contains(text, "95 nodes (-25)") || strings.Index(text, "version 4") > strings.Index(text, "version 1") {
+		t.Errorf("timeline text:\n%s", text)
+	}
+
+	if _, err := buildSyncTimeline(exportDir, "MISSING"); err == nil {
+		t.Error("expected error for a file without exports")
+	}
+}
+
+func TestAppendSyncHistoryKeepsLatest(t *testing.T) {
+	var history []SyncSnapshot
+	for i := 0; i < maxSyncHistory+5; i++ {
+		history = appendSyncHistory(history, SyncSnapshot{Nodes: i})
+	}
+	if len(history) != maxSyncHistory || history[0].Nodes != 5 {
+		t.Errorf("history has %d entries starting at %d, want %d starting at 5", len(history), history[0].Nodes, maxSyncHistory)
+	}
+}
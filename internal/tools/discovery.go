@@ -0,0 +1,233 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FileDiscovery tells tools which file to use when a call omits file_key.
+type FileDiscovery struct {
+	TeamID         string // offer the files of this team
+	ProjectID      string // offer only the files of this project
+	DefaultFileKey string // use this file without asking
+}
+
+func (d FileDiscovery) enabled() bool {
+	return d.TeamID != "" || d.ProjectID != "" || d.DefaultFileKey != ""
+}
+
+// SetFileDiscovery configures how a missing file_key is filled in. With a
+// default file key, tools use it; with a team or project, the client is
+// asked to pick one of its files, or the call fails with the list of files
+// if the client cannot be asked.
+func (r *Registry) SetFileDiscovery(d FileDiscovery) {
+	r.discovery = d
+}
+
+// discoveredFile is a file offered when file_key is omitted.
+type discoveredFile struct {
+	Key     string
+	Name    string
+	Project string
+}
+
+// fileKeyMiddleware fills in file_key on tools/call requests that require
+// one but omit it, according to the configured FileDiscovery.
+func (r *Registry) fileKeyMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || !r.discovery.enabled() {
+			return next(ctx, method, req)
+		}
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		args := make(map[string]json.RawMessage)
+		if len(params.Arguments) > 0 {
+			if err := json.Unmarshal(params.Arguments, &args); err != nil {
+				return next(ctx, method, req)
+			}
+		}
+		if v := string(args["file_key"]); v != "" && v != `""` && v != "null" {
+			return next(ctx, method, req)
+		}
+		if !r.requiresFileKey(ctx, req, next, params.Name) {
+			return next(ctx, method, req)
+		}
+
+		fileKey, errText := r.discoverFileKey(ctx, req.GetSession())
+		if errText != "" {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: errText}},
+			}, nil
+		}
+
+		args["file_key"], _ = json.Marshal(fileKey)
+		params.Arguments, _ = json.Marshal(args)
+		return next(ctx, method, req)
+	}
+}
+
+// toolInput describes the input schema of a registered tool.
+type toolInput struct {
+	properties map[string]bool
+	required   []string
+}
+
+// requiresFileKey reports whether a tool's input schema requires file_key.
+func (r *Registry) requiresFileKey(ctx context.Context, req mcp.Request, next mcp.MethodHandler, tool string) bool {
+	return containsString(r.toolSchema(ctx, req, next, tool).required, "file_key")
+}
+
+// toolSchema returns the properties and required arguments of a tool. The
+// tool list is read from the server on first use.
+func (r *Registry) toolSchema(ctx context.Context, req mcp.Request, next mcp.MethodHandler, tool string) toolInput {
+	r.toolInputsOnce.Do(func() {
+		r.toolInputs = make(map[string]toolInput)
+		ss, ok := req.GetSession().(*mcp.ServerSession)
+		if !ok {
+			return
+		}
+		listParams := &mcp.ListToolsParams{}
+		for {
+			res, err := next(ctx, "tools/list", &mcp.ListToolsRequest{Session: ss, Params: listParams})
+			if err != nil {
+				return
+			}
+			list, ok := res.(*mcp.ListToolsResult)
+			if !ok {
+				return
+			}
+			for _, t := range list.Tools {
+				b, _ := json.Marshal(t.InputSchema)
+				var schema struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+					Required   []string                   `json:"required"`
+				}
+				if json.Unmarshal(b, &schema) != nil {
+					continue
+				}
+				input := toolInput{properties: make(map[string]bool), required: schema.Required}
+				for name := range schema.Properties {
+					input.properties[name] = true
+				}
+				r.toolInputs[t.Name] = input
+			}
+			if list.NextCursor == "" {
+				return
+			}
+			listParams = &mcp.ListToolsParams{Cursor: list.NextCursor}
+		}
+	})
+	return r.toolInputs[tool]
+}
+
+// discoverFileKey returns the file to use for a call without file_key, or
+// the text of the error to return instead.
+func (r *Registry) discoverFileKey(ctx context.Context, session mcp.Session) (string, string) {
+	if r.discovery.DefaultFileKey != "" {
+		return r.discovery.DefaultFileKey, ""
+	}
+	if !r.HasClient() {
+		return "", errFileKeyRequired.Error()
+	}
+
+	files, err := r.discoverFiles(ctx)
+	if err != nil {
+		return "", fmt.Sprintf("file_key is required; listing files to choose from failed: %v", err)
+	}
+	if len(files) == 0 {
+		return "", "file_key is required; no files found in the configured team or project"
+	}
+
+	if ss, ok := session.(*mcp.ServerSession); ok && canElicit(ss) {
+		if key := elicitFileKey(ctx, ss, files); key != "" {
+			return key, ""
+		}
+	}
+
+	return "", formatDiscoveredFiles(files)
+}
+
+// discoverFiles lists the files of the configured project, or of every
+// project of the configured team.
+func (r *Registry) discoverFiles(ctx context.Context) ([]discoveredFile, error) {
+	projectIDs := []string{r.discovery.ProjectID}
+	if r.discovery.ProjectID == "" {
+		projects, err := r.Client().GetTeamProjects(ctx, r.discovery.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		projectIDs = projectIDs[:0]
+		for _, p := range projects.Projects {
+			projectIDs = append(projectIDs, p.ID)
+		}
+	}
+
+	var files []discoveredFile
+	for _, id := range projectIDs {
+		project, err := r.Client().GetProjectFiles(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range project.Files {
+			files = append(files, discoveredFile{Key: f.Key, Name: f.Name, Project: project.Name})
+		}
+	}
+	return files, nil
+}
+
+func canElicit(ss *mcp.ServerSession) bool {
+	p := ss.InitializeParams()
+	return p != nil && p.Capabilities != nil && p.Capabilities.Elicitation != nil
+}
+
+// elicitFileKey asks the user to pick one of files. It returns "" if the
+// user declines or the request fails.
+func elicitFileKey(ctx context.Context, ss *mcp.ServerSession, files []discoveredFile) string {
+	keys := make([]string, len(files))
+	names := make([]string, len(files))
+	for i, f := range files {
+		keys[i] = f.Key
+		names[i] = f.Project + " / " + f.Name
+	}
+
+	res, err := ss.Elicit(ctx, &mcp.ElicitParams{
+		Message: "No file_key was given. Which Figma file should be used?",
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"file_key": map[string]any{
+					"type":      "string",
+					"title":     "Figma file",
+					"enum":      keys,
+					"enumNames": names,
+				},
+			},
+			"required": []string{"file_key"},
+		},
+	})
+	if err != nil || res.Action != "accept" {
+		return ""
+	}
+	key, _ := res.Content["file_key"].(string)
+	return key
+}
+
+func formatDiscoveredFiles(files []discoveredFile) string {
+	var sb strings.Builder
+
+	sb.WriteString("file_key is required. Available files:\n")
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("  %s  %s / %s\n", f.Key, f.Project, f.Name))
+	}
+	sb.WriteString("\nCall the tool again with one of these file_key values.")
+
+	return sb.String()
+}
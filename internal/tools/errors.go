@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validation errors shared by the tool handlers. Each one says how to fix
+// the call, so an agent can recover without asking the user.
+var (
+	errFileKeyRequired   = errors.New("file_key is required. Extract it from the Figma URL: figma.com/file/<FILE_KEY>/... or figma.com/design/<FILE_KEY>/...")
+	errNodeIDRequired    = errors.New("node_id is required. Find node IDs with search, query or get_tree (e.g. \"1:23\")")
+	errNodeIDsRequired   = errors.New("node_ids is required. Find node IDs with search, query or get_tree (e.g. [\"1:23\"])")
+	errPatternRequired   = errors.New("pattern is required. Use a glob such as \"Button*\" or a regex such as \"/^Icon/\"")
+	errOutputDirRequired = errors.New("output_dir is required. Pass the directory to write files to (e.g. \"./figma-export/assets\"); it is created if missing")
+	errNoClient          = errors.New("Figma API not configured. Set the FIGMA_ACCESS_TOKEN environment variable or run with --help for setup instructions")
+	errNoCacheNoClient   = errors.New("no cache found and Figma API not configured. Run sync_file(file_key=...) first to create a local cache, or set the FIGMA_ACCESS_TOKEN environment variable")
+)
+
+// errNoCache reports that fileKey has no sync_file export to read from.
+func errNoCache(fileKey string) error {
+	return fmt.Errorf("no cache found for file %s. Run sync_file(file_key=%q) first to create a local cache", fileKey, fileKey)
+}
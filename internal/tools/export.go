@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -19,7 +22,7 @@ type ExportAssetsArgs struct {
 	OutputDir string    `json:"output_dir" jsonschema:"Directory to save assets"`
 	Formats   []string  `json:"formats,omitempty" jsonschema:"Image formats: png svg pdf jpg (default: svg)"`
 	Scales    []float64 `json:"scales,omitempty" jsonschema:"Export scales: 1 2 3 for @1x @2x @3x"`
-	Naming    string    `json:"naming,omitempty" jsonschema:"Naming strategy: id, name (default), or path"`
+	Naming    string    `json:"naming,omitempty" jsonschema:"Naming strategy: id, name (default), or path (page and ancestor names, e.g. components--icons-navigation--arrow)"`
 	Format    string    `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
 }
 
@@ -27,6 +30,7 @@ type ExportAssetsArgs struct {
 type ExportAssetsResult struct {
 	Exported []string          `json:"exported"`
 	Failed   []string          `json:"failed,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
 	Manifest map[string]string `json:"manifest"` // node_id -> file path
 }
 
@@ -34,19 +38,25 @@ func registerExportAssetsTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "export_assets",
 		Description: "Export images/icons for specific nodes.",
+		InputSchema: inputSchema[ExportAssetsArgs](map[string][]string{
+			"formats": {"png", "svg", "pdf", "jpg"},
+			"naming":  {"id", "name", "path"},
+			"format":  responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExportAssetsArgs) (*mcp.CallToolResult, *ExportAssetsResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 		if len(args.NodeIDs) == 0 {
-			return nil, nil, fmt.Errorf("node_ids is required")
+			return nil, nil, errNodeIDsRequired
 		}
+		args.NodeIDs = r.ResolveNodeIDs(args.NodeIDs)
 		if args.OutputDir == "" {
-			return nil, nil, fmt.Errorf("output_dir is required")
+			return nil, nil, errOutputDirRequired
 		}
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Set defaults
@@ -80,6 +90,13 @@ func registerExportAssetsTool(server *mcp.Server, r *Registry) {
 				}
 			}
 		}
+		var nodePaths map[string][]PathNode
+		if naming == "path" {
+			paths, _, err := r.nodePaths(ctx, args.FileKey, args.NodeIDs)
+			if err == nil {
+				nodePaths = paths
+			}
+		}
 
 		result := &ExportAssetsResult{
 			Exported: make([]string, 0),
@@ -88,20 +105,32 @@ func registerExportAssetsTool(server *mcp.Server, r *Registry) {
 
 		// Export each format and scale combination
 		for _, format := range formats {
+			exported := make(map[float64]bool)
 			for _, scale := range scales {
-				images, err := r.Client().GetImages(ctx, args.FileKey, args.NodeIDs, &figma.ImageExportOptions{
+				opts := &figma.ImageExportOptions{
 					Format: format,
 					Scale:  scale,
-				})
+				}
+				result.Warnings = append(result.Warnings, figma.NormalizeImageExportOptions(opts)...)
+
+				// Clamped scales can collapse onto one already exported
+				if exported[opts.Scale] {
+					continue
+				}
+				exported[opts.Scale] = true
+				scale = opts.Scale
+
+				images, err := r.Client().GetImages(ctx, args.FileKey, args.NodeIDs, opts)
 				if err != nil {
 					result.Failed = append(result.Failed, fmt.Sprintf("export error: %v", err))
 					continue
 				}
+				result.Failed = append(result.Failed, imageExportFailures(images, format, args.NodeIDs)...)
 
 				// Download each image
 				for id, imageURL := range images.Images {
 					if imageURL == "" {
-						result.Failed = append(result.Failed, fmt.Sprintf("no image for %s", id))
+						result.Failed = append(result.Failed, fmt.Sprintf("no image for %s: %s", id, imageFailureReason(format)))
 						continue
 					}
 
@@ -115,7 +144,10 @@ func registerExportAssetsTool(server *mcp.Server, r *Registry) {
 						}
 						filename = sanitizeName(name)
 					case "path":
-						filename = strings.ReplaceAll(id, ":", "-")
+						filename = pathFilename(nodePaths[id])
+						if filename == "" {
+							filename = strings.ReplaceAll(id, ":", "-")
+						}
 					default: // "id"
 						filename = strings.ReplaceAll(id, ":", "-")
 					}
@@ -164,6 +196,42 @@ func registerExportAssetsTool(server *mcp.Server, r *Registry) {
 	})
 }
 
+// pathFilename joins the sanitized names of a node's ancestor chain, e.g.
+// Components / Icons/Navigation / Arrow → components--icons-navigation--arrow.
+func pathFilename(path []PathNode) string {
+	parts := make([]string, 0, len(path))
+	for _, step := range path {
+		if name := sanitizeName(step.Name); name != "" {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, "--")
+}
+
+// imageExportFailures reports an images API error, or else each requested node
+// missing from the response. A request-level error already explains why no
+// node was rendered, so it is not repeated per node.
+func imageExportFailures(images *figma.ImageExport, format string, nodeIDs []string) []string {
+	if images.Err != "" {
+		return []string{fmt.Sprintf("export error (%s): %s", format, images.Err)}
+	}
+	var failed []string
+	for _, id := range nodeIDs {
+		if _, ok := images.Images[id]; !ok {
+			failed = append(failed, fmt.Sprintf("no image for %s: not returned by the API (check the node ID)", id))
+		}
+	}
+	return failed
+}
+
+// imageFailureReason explains why the images API returned no URL for a node.
+func imageFailureReason(format string) string {
+	if format == "pdf" {
+		return "node cannot be rendered as pdf (export frames, components, or groups instead)"
+	}
+	return fmt.Sprintf("node could not be rendered as %s (it may be invisible, empty, or have zero size)", format)
+}
+
 func formatExportResult(r *ExportAssetsResult) string {
 	var sb strings.Builder
 
@@ -180,17 +248,27 @@ func formatExportResult(r *ExportAssetsResult) string {
 		}
 	}
 
+	if len(r.Warnings) > 0 {
+		sb.WriteString("\nWarnings:\n")
+		for _, w := range r.Warnings {
+			sb.WriteString(fmt.Sprintf("  - %s\n", w))
+		}
+	}
+
 	return sb.String()
 }
 
 // ExportTokensArgs contains arguments for the export_tokens tool.
 type ExportTokensArgs struct {
 	FileKey     string   `json:"file_key" jsonschema:"Figma file key"`
-	OutputPath  string   `json:"output_path" jsonschema:"Output file path"`
-	Format      string   `json:"format" jsonschema:"Export format: css, scss, json, js, ts, or tailwind"`
+	OutputPath  string   `json:"output_path" jsonschema:"Output file path, or the output directory with split_by_collection"`
+	Format      string   `json:"format,omitempty" jsonschema:"Export format: css (default), scss, json, js, ts, tailwind, android (colors.xml resources), ios-swift (UIColor and CGFloat extensions) or open-props (CSS variables named like --color-blue-5 and --size-1)"`
 	Collections []string `json:"collections,omitempty" jsonschema:"Specific collections to export (default: all)"`
 	Modes       []string `json:"modes,omitempty" jsonschema:"Specific modes to export (default: all)"`
 	Prefix      string   `json:"prefix,omitempty" jsonschema:"Prefix for variable names"`
+
+	TypescriptTypes   bool `json:"typescript_types,omitempty" jsonschema:"With format=ts, also emit interfaces per collection and a nested tokenTree typed by them"`
+	SplitByCollection bool `json:"split_by_collection,omitempty" jsonschema:"Write one file per collection (e.g. colors.css, spacing.css) into the output_path directory"`
 }
 
 // ExportTokensResult contains the result of export_tokens.
@@ -198,25 +276,29 @@ type ExportTokensResult struct {
 	Path        string   `json:"path"`
 	TokensCount int      `json:"tokens_count"`
 	Collections []string `json:"collections"`
+	Files       []string `json:"files,omitempty"` // split_by_collection
 }
 
 func registerExportTokensTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "export_tokens",
 		Description: "Export design tokens/variables to various formats.",
+		InputSchema: inputSchema[ExportTokensArgs](map[string][]string{
+			"format": {"css", "scss", "json", "js", "ts", "tailwind", "android", "ios-swift", "open-props"},
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExportTokensArgs) (*mcp.CallToolResult, *ExportTokensResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 		if args.OutputPath == "" {
-			return nil, nil, fmt.Errorf("output_path is required")
+			return nil, nil, fmt.Errorf("output_path is required. Pass the file to write (e.g. \"tokens.css\"), or a directory with split_by_collection")
 		}
 		if args.Format == "" {
 			args.Format = "css"
 		}
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Fetch variables
@@ -246,31 +328,35 @@ func registerExportTokensTool(server *mcp.Server, r *Registry) {
 			}
 		}
 
-		// Generate output
-		var content string
-		switch args.Format {
-		case "css":
-			content = generateCSSTokens(variables, collections, args.Prefix, args.Modes)
-		case "scss":
-			content = generateSCSSTokens(variables, collections, args.Prefix, args.Modes)
-		case "json":
-			content = generateJSONTokens(variables, collections, args.Modes)
-		case "js", "ts":
-			content = generateJSTokens(variables, collections, args.Prefix, args.Modes, args.Format == "ts")
-		case "tailwind":
-			content = generateTailwindTokens(variables, collections, args.Modes)
-		default:
-			return nil, nil, fmt.Errorf("unsupported format: %s", args.Format)
-		}
+		// Aliases may point into collections that were filtered out
+		resolver := newTokenResolver(vars.Meta.Variables, vars.Meta.VariableCollections)
 
-		// Write file
-		dir := filepath.Dir(args.OutputPath)
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return nil, nil, fmt.Errorf("creating directory: %w", err)
+		result := &ExportTokensResult{
+			Path:        args.OutputPath,
+			TokensCount: len(variables),
 		}
 
-		if err := os.WriteFile(args.OutputPath, []byte(content), 0644); err != nil {
-			return nil, nil, fmt.Errorf("writing file: %w", err)
+		if args.SplitByCollection {
+			files, err := writeTokensByCollection(args, variables, collections, resolver)
+			if err != nil {
+				return nil, nil, err
+			}
+			result.Files = files
+		} else {
+			content, err := generateTokens(args, variables, collections, resolver)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Write file
+			dir := filepath.Dir(args.OutputPath)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, nil, fmt.Errorf("creating directory: %w", err)
+			}
+
+			if err := os.WriteFile(args.OutputPath, []byte(content), 0644); err != nil {
+				return nil, nil, fmt.Errorf("writing file: %w", err)
+			}
 		}
 
 		// Build result
@@ -278,15 +364,16 @@ func registerExportTokensTool(server *mcp.Server, r *Registry) {
 		for _, coll := range collections {
 			collectionNames = append(collectionNames, coll.Name)
 		}
-
-		result := &ExportTokensResult{
-			Path:        args.OutputPath,
-			TokensCount: len(variables),
-			Collections: collectionNames,
-		}
+		result.Collections = collectionNames
 
 		textOutput := fmt.Sprintf("Exported %d tokens to %s\nCollections: %s",
 			result.TokensCount, result.Path, strings.Join(result.Collections, ", "))
+		if len(result.Files) > 0 {
+			textOutput += "\nFiles:"
+			for _, f := range result.Files {
+				textOutput += "\n  " + filepath.Base(f)
+			}
+		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
@@ -296,31 +383,160 @@ func registerExportTokensTool(server *mcp.Server, r *Registry) {
 	})
 }
 
-func generateCSSTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, prefix string, modes []string) string {
+// generateTokens renders variables in args.Format.
+func generateTokens(args ExportTokensArgs, variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver) (string, error) {
+	switch args.Format {
+	case "css":
+		return generateCSSTokens(variables, collections, resolver, args.Prefix, args.Modes), nil
+	case "scss":
+		return generateSCSSTokens(variables, collections, resolver, args.Prefix, args.Modes), nil
+	case "json":
+		return generateJSONTokens(variables, collections, resolver, args.Modes), nil
+	case "js", "ts":
+		content := generateJSTokens(variables, collections, resolver, args.Prefix, args.Modes, args.Format == "ts")
+		if args.Format == "ts" && args.TypescriptTypes {
+			content += "\n" + generateTSTokenTypes(variables, collections, resolver)
+		}
+		return content, nil
+	case "tailwind":
+		return generateTailwindTokens(variables, collections, resolver, args.Modes), nil
+	case "android":
+		return generateAndroidTokens(variables, collections, resolver, args.Prefix, args.Modes), nil
+	case "ios-swift":
+		return generateSwiftTokens(variables, collections, resolver, args.Prefix, args.Modes), nil
+	case "open-props":
+		return generateOpenPropsTokens(variables, collections, resolver, args.Prefix, args.Modes), nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", args.Format)
+	}
+}
+
+// tokenFileExtensions maps export_tokens formats to the extension of the
+// files written by split_by_collection.
+var tokenFileExtensions = map[string]string{
+	"css":        ".css",
+	"scss":       ".scss",
+	"json":       ".json",
+	"js":         ".js",
+	"ts":         ".ts",
+	"tailwind":   ".js",
+	"android":    ".xml",
+	"ios-swift":  ".swift",
+	"open-props": ".css",
+}
+
+// writeTokensByCollection writes one file per collection into the directory
+// args.OutputPath, e.g. colors.css and spacing.css, and returns their paths.
+func writeTokensByCollection(args ExportTokensArgs, variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver) ([]string, error) {
+	ext, ok := tokenFileExtensions[args.Format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s", args.Format)
+	}
+	if err := os.MkdirAll(args.OutputPath, 0755); err != nil {
+		return nil, fmt.Errorf("creating directory: %w", err)
+	}
+
+	ids := make([]string, 0, len(collections))
+	for id := range collections {
+		ids = append(ids, id)
+	}
+	// Ties on ID keep same-named collections, and their -<id> file names, stable
+	sort.Slice(ids, func(i, j int) bool {
+		if a, b := collections[ids[i]].Name, collections[ids[j]].Name; a != b {
+			return a < b
+		}
+		return ids[i] < ids[j]
+	})
+
+	var files []string
+	used := make(map[string]bool)
+	for _, id := range ids {
+		coll := collections[id]
+		collVars := make(map[string]*figma.Variable)
+		for vid, v := range variables {
+			if v.VariableCollectionID == id {
+				collVars[vid] = v
+			}
+		}
+
+		content, err := generateTokens(args, collVars, map[string]*figma.VariableCollection{id: coll}, resolver)
+		if err != nil {
+			return files, err
+		}
+		content = addTokenFileHeader(args.Format, content, coll.Name)
+
+		name := sanitizeName(coll.Name)
+		if name == "" || used[name] {
+			name += "-" + sanitizeID(id)
+		}
+		used[name] = true
+
+		path := filepath.Join(args.OutputPath, name+ext)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return files, fmt.Errorf("writing file: %w", err)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// addTokenFileHeader prefixes a per-collection token file with a comment
+// naming the collection. JSON has no comments, so JSON files get a $metadata
+// entry instead. There is no export time, so re-exporting unchanged variables
+// rewrites identical files.
+func addTokenFileHeader(format, content, collection string) string {
+	switch format {
+	case "css", "open-props":
+		return fmt.Sprintf("/* Collection: %s */\n", collection) + content
+	case "android":
+		header := fmt.Sprintf("<!-- Collection: %s -->\n", strings.ReplaceAll(collection, "--", "- -"))
+		// The XML declaration must stay first.
+		if decl, rest, ok := strings.Cut(content, "\n"); ok && strings.HasPrefix(decl, "<?xml") {
+			return decl + "\n" + header + rest
+		}
+		return header + content
+	case "json":
+		var tokens map[string]interface{}
+		if err := json.Unmarshal([]byte(content), &tokens); err != nil {
+			return content
+		}
+		tokens["$metadata"] = map[string]string{"collection": collection}
+		b, _ := json.MarshalIndent(tokens, "", "  ")
+		return string(b)
+	default:
+		return fmt.Sprintf("// Collection: %s\n", collection) + content
+	}
+}
+
+// sortedVariables returns variables ordered by name, then ID, so repeated
+// exports of the same variables produce identical files.
+func sortedVariables(variables map[string]*figma.Variable) []*figma.Variable {
+	sorted := make([]*figma.Variable, 0, len(variables))
+	for _, v := range variables {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+func generateCSSTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, prefix string, modes []string) string {
 	var sb strings.Builder
 
 	sb.WriteString("/* Design Tokens - Generated by figma-query */\n\n")
 	sb.WriteString(":root {\n")
 
-	for _, v := range variables {
+	for _, v := range sortedVariables(variables) {
 		coll := collections[v.VariableCollectionID]
 		if coll == nil {
 			continue
 		}
 
-		// Use default mode or first matching mode
-		modeID := coll.DefaultModeID
-		if len(modes) > 0 {
-			for _, m := range coll.Modes {
-				if containsString(modes, m.Name) {
-					modeID = m.ModeID
-					break
-				}
-			}
-		}
-
-		value := v.ValuesByMode[modeID]
-		cssValue := formatTokenValue(v.ResolvedType, value)
+		cssValue := resolver.formatValue(v, tokenModeID(coll, modes))
 		varName := formatVarName(v.Name, prefix)
 
 		sb.WriteString(fmt.Sprintf("  --%s: %s;\n", varName, cssValue))
@@ -330,20 +546,30 @@ func generateCSSTokens(variables map[string]*figma.Variable, collections map[str
 	return sb.String()
 }
 
-func generateSCSSTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, prefix string, modes []string) string {
+// tokenModeID returns the first mode of coll named in modes, or its default
+// mode.
+func tokenModeID(coll *figma.VariableCollection, modes []string) string {
+	for _, m := range coll.Modes {
+		if containsString(modes, m.Name) {
+			return m.ModeID
+		}
+	}
+	return coll.DefaultModeID
+}
+
+func generateSCSSTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, prefix string, modes []string) string {
 	var sb strings.Builder
 
 	sb.WriteString("// Design Tokens - Generated by figma-query\n\n")
 
-	for _, v := range variables {
+	for _, v := range sortedVariables(variables) {
 		coll := collections[v.VariableCollectionID]
 		if coll == nil {
 			continue
 		}
 
 		modeID := coll.DefaultModeID
-		value := v.ValuesByMode[modeID]
-		cssValue := formatTokenValue(v.ResolvedType, value)
+		cssValue := resolver.formatValue(v, modeID)
 		varName := formatVarName(v.Name, prefix)
 
 		sb.WriteString(fmt.Sprintf("$%s: %s;\n", varName, cssValue))
@@ -352,10 +578,10 @@ func generateSCSSTokens(variables map[string]*figma.Variable, collections map[st
 	return sb.String()
 }
 
-func generateJSONTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, modes []string) string {
+func generateJSONTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, modes []string) string {
 	tokens := make(map[string]interface{})
 
-	for _, v := range variables {
+	for _, v := range sortedVariables(variables) {
 		coll := collections[v.VariableCollectionID]
 		if coll == nil {
 			continue
@@ -364,18 +590,36 @@ func generateJSONTokens(variables map[string]*figma.Variable, collections map[st
 		modeID := coll.DefaultModeID
 		value := v.ValuesByMode[modeID]
 
-		tokens[v.Name] = map[string]interface{}{
-			"value":    value,
-			"type":     v.ResolvedType,
+		token := map[string]interface{}{
+			"value":      value,
+			"type":       v.ResolvedType,
 			"collection": coll.Name,
 		}
+
+		// Keep the alias and add the value it resolves to
+		if alias, ok := parseVariableAlias(value); ok {
+			aliasInfo := map[string]interface{}{"id": alias.ID}
+			if target := resolver.variables[alias.ID]; target != nil {
+				aliasInfo["name"] = target.Name
+			}
+			token["alias"] = aliasInfo
+
+			resolved, _, err := resolver.resolve(v, modeID)
+			if err != nil {
+				token["error"] = err.Error()
+			} else {
+				token["value"] = resolved
+			}
+		}
+
+		tokens[v.Name] = token
 	}
 
 	b, _ := json.MarshalIndent(tokens, "", "  ")
 	return string(b)
 }
 
-func generateJSTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, prefix string, modes []string, typescript bool) string {
+func generateJSTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, prefix string, modes []string, typescript bool) string {
 	var sb strings.Builder
 
 	sb.WriteString("// Design Tokens - Generated by figma-query\n\n")
@@ -386,15 +630,14 @@ func generateJSTokens(variables map[string]*figma.Variable, collections map[stri
 		sb.WriteString("export const tokens = {\n")
 	}
 
-	for _, v := range variables {
+	for _, v := range sortedVariables(variables) {
 		coll := collections[v.VariableCollectionID]
 		if coll == nil {
 			continue
 		}
 
 		modeID := coll.DefaultModeID
-		value := v.ValuesByMode[modeID]
-		cssValue := formatTokenValue(v.ResolvedType, value)
+		cssValue := resolver.formatValue(v, modeID)
 		varName := formatJSVarName(v.Name)
 
 		sb.WriteString(fmt.Sprintf("  %s: '%s',\n", varName, cssValue))
@@ -409,7 +652,159 @@ func generateJSTokens(variables map[string]*figma.Variable, collections map[stri
 	return sb.String()
 }
 
-func generateTailwindTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, modes []string) string {
+// tokenTreeNode is one segment of slash-separated variable names.
+type tokenTreeNode struct {
+	value    *string
+	children map[string]*tokenTreeNode
+}
+
+func (n *tokenTreeNode) insert(path []string, value string) {
+	for _, seg := range path {
+		if n.children == nil {
+			n.children = make(map[string]*tokenTreeNode)
+		}
+		child := n.children[seg]
+		if child == nil {
+			child = &tokenTreeNode{}
+			n.children[seg] = child
+		}
+		n = child
+	}
+	n.value = &value
+}
+
+// keys returns the child keys in order.
+func (n *tokenTreeNode) keys() []string {
+	keys := make([]string, 0, len(n.children))
+	for k := range n.children {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var tsIdentifier = regexp.MustCompile(`^([A-Za-z_$][A-Za-z0-9_$]*|[0-9]+)$`)
+
+// tsKey quotes property names that are not valid identifiers.
+func tsKey(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "\\'") + "'"
+}
+
+// tokenCollectionTree groups variables by collection and splits their names
+// on / into nested trees of default-mode values.
+type tokenCollectionTree struct {
+	key  string // property name in tokenTree
+	name string // interface name
+	root *tokenTreeNode
+}
+
+func buildTokenTrees(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver) []*tokenCollectionTree {
+	byCollection := make(map[string]*tokenCollectionTree)
+	for _, v := range sortedVariables(variables) {
+		coll := collections[v.VariableCollectionID]
+		if coll == nil {
+			continue
+		}
+		tree := byCollection[coll.ID]
+		if tree == nil {
+			key := formatJSVarName(coll.Name)
+			if key == "" {
+				key = "collection"
+			}
+			tree = &tokenCollectionTree{
+				key:  key,
+				name: strings.ToUpper(key[:1]) + key[1:] + "Tokens", // Colors → ColorsTokens
+				root: &tokenTreeNode{},
+			}
+			byCollection[coll.ID] = tree
+		}
+
+		var path []string
+		for _, seg := range strings.Split(v.Name, "/") {
+			if seg = formatJSVarName(strings.TrimSpace(seg)); seg != "" {
+				path = append(path, seg)
+			}
+		}
+		tree.root.insert(path, resolver.formatValue(v, coll.DefaultModeID))
+	}
+
+	trees := make([]*tokenCollectionTree, 0, len(byCollection))
+	for _, tree := range byCollection {
+		trees = append(trees, tree)
+	}
+	sort.Slice(trees, func(i, j int) bool { return trees[i].key < trees[j].key })
+	return trees
+}
+
+// generateTSTokenTypes emits an interface per collection, a Tokens interface
+// joining them, and tokenTree holding the nested values. A name that is both
+// a value and a group (color/primary next to color/primary/light) keeps its
+// value under DEFAULT, as Tailwind does.
+func generateTSTokenTypes(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver) string {
+	trees := buildTokenTrees(variables, collections, resolver)
+	var sb strings.Builder
+
+	var writeType func(n *tokenTreeNode, indent string)
+	writeType = func(n *tokenTreeNode, indent string) {
+		for _, k := range n.keys() {
+			child := n.children[k]
+			if child.children == nil {
+				sb.WriteString(fmt.Sprintf("%s%s: string;\n", indent, tsKey(k)))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%s%s: {\n", indent, tsKey(k)))
+			if child.value != nil {
+				sb.WriteString(indent + "  DEFAULT: string;\n")
+			}
+			writeType(child, indent+"  ")
+			sb.WriteString(indent + "};\n")
+		}
+	}
+
+	var writeValue func(n *tokenTreeNode, indent string)
+	writeValue = func(n *tokenTreeNode, indent string) {
+		for _, k := range n.keys() {
+			child := n.children[k]
+			if child.children == nil {
+				sb.WriteString(fmt.Sprintf("%s%s: '%s',\n", indent, tsKey(k), *child.value))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%s%s: {\n", indent, tsKey(k)))
+			if child.value != nil {
+				sb.WriteString(fmt.Sprintf("%s  DEFAULT: '%s',\n", indent, *child.value))
+			}
+			writeValue(child, indent+"  ")
+			sb.WriteString(indent + "},\n")
+		}
+	}
+
+	for _, tree := range trees {
+		sb.WriteString(fmt.Sprintf("export interface %s {\n", tree.name))
+		writeType(tree.root, "  ")
+		sb.WriteString("}\n\n")
+	}
+
+	sb.WriteString("export interface Tokens {\n")
+	for _, tree := range trees {
+		sb.WriteString(fmt.Sprintf("  %s: %s;\n", tsKey(tree.key), tree.name))
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("export const tokenTree: Tokens = {\n")
+	for _, tree := range trees {
+		sb.WriteString(fmt.Sprintf("  %s: {\n", tsKey(tree.key)))
+		writeValue(tree.root, "    ")
+		sb.WriteString("  },\n")
+	}
+	sb.WriteString("};\n")
+
+	return sb.String()
+}
+
+func generateTailwindTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, modes []string) string {
 	config := map[string]interface{}{
 		"theme": map[string]interface{}{
 			"extend": map[string]interface{}{},
@@ -419,15 +814,14 @@ func generateTailwindTokens(variables map[string]*figma.Variable, collections ma
 	colors := make(map[string]string)
 	spacing := make(map[string]string)
 
-	for _, v := range variables {
+	for _, v := range sortedVariables(variables) {
 		coll := collections[v.VariableCollectionID]
 		if coll == nil {
 			continue
 		}
 
 		modeID := coll.DefaultModeID
-		value := v.ValuesByMode[modeID]
-		cssValue := formatTokenValue(v.ResolvedType, value)
+		cssValue := resolver.formatValue(v, modeID)
 		varName := formatVarName(v.Name, "")
 
 		switch v.ResolvedType {
@@ -450,7 +844,411 @@ func generateTailwindTokens(variables map[string]*figma.Variable, collections ma
 	return "// tailwind.config.js extend\nmodule.exports = " + string(b) + ";\n"
 }
 
+// nativeToken is a COLOR or FLOAT variable resolved for a native platform.
+type nativeToken struct {
+	name  string
+	color map[string]float64 // COLOR: r, g, b, a in 0-1
+	float float64            // FLOAT
+	isDim bool
+}
+
+// nativeTokens resolves the COLOR and FLOAT variables for the android and
+// ios-swift formats, converting variable names with name. Names that convert
+// to one already used, e.g. Color/Primary and color-primary, get a _2, _3...
+// suffix. Variables that fail to resolve are returned as problems.
+func nativeTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, modes []string, name func(string) string) ([]nativeToken, []string) {
+	var tokens []nativeToken
+	var problems []string
+	used := make(map[string]bool)
+
+	for _, v := range sortedVariables(variables) {
+		coll := collections[v.VariableCollectionID]
+		if coll == nil || (v.ResolvedType != "COLOR" && v.ResolvedType != "FLOAT") {
+			continue
+		}
+
+		value, _, err := resolver.resolve(v, tokenModeID(coll, modes))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", v.Name, err))
+			continue
+		}
+
+		token := nativeToken{name: name(v.Name)}
+		for n := 2; used[token.name]; n++ {
+			token.name = fmt.Sprintf("%s_%d", name(v.Name), n)
+		}
+		if v.ResolvedType == "COLOR" {
+			if err := json.Unmarshal(value, &token.color); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid color", v.Name))
+				continue
+			}
+		} else {
+			if err := json.Unmarshal(value, &token.float); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid number", v.Name))
+				continue
+			}
+			token.isDim = true
+		}
+		used[token.name] = true
+		tokens = append(tokens, token)
+	}
+
+	return tokens, problems
+}
+
+// androidResourceName converts a variable name such as color/primary-500 to
+// a resource name such as color_primary_500.
+func androidResourceName(name, prefix string) string {
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	var sb strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+			underscore = false
+		} else if !underscore && sb.Len() > 0 {
+			sb.WriteRune('_')
+			underscore = true
+		}
+	}
+	res := strings.TrimSuffix(sb.String(), "_")
+	if res == "" || res[0] >= '0' && res[0] <= '9' {
+		res = "_" + res
+	}
+	return res
+}
+
+// swiftIdentifier converts a variable name such as color/primary-500 to a
+// camelCase identifier such as colorPrimary500.
+func swiftIdentifier(name, prefix string) string {
+	if prefix != "" {
+		name = prefix + "/" + name
+	}
+	id := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, formatJSVarName(name))
+	if id == "" || id[0] >= '0' && id[0] <= '9' {
+		id = "_" + id
+	}
+	return id
+}
+
+// swiftKeywords are reserved words that must be escaped with backticks to be
+// used as identifiers.
+var swiftKeywords = map[string]bool{
+	"associatedtype": true, "class": true, "deinit": true, "enum": true, "extension": true,
+	"fileprivate": true, "func": true, "import": true, "init": true, "inout": true,
+	"internal": true, "let": true, "open": true, "operator": true, "private": true,
+	"precedencegroup": true, "protocol": true, "public": true, "rethrows": true, "static": true,
+	"struct": true, "subscript": true, "typealias": true, "var": true, "break": true,
+	"case": true, "catch": true, "continue": true, "default": true, "defer": true,
+	"do": true, "else": true, "fallthrough": true, "for": true, "guard": true,
+	"if": true, "in": true, "repeat": true, "return": true, "throw": true,
+	"switch": true, "where": true, "while": true, "as": true, "false": true,
+	"is": true, "nil": true, "super": true, "throws": true, "true": true, "try": true,
+}
+
+// swiftDeclName escapes an identifier that is a Swift keyword.
+func swiftDeclName(id string) string {
+	if swiftKeywords[id] {
+		return "`" + id + "`"
+	}
+	return id
+}
+
+// generateAndroidTokens writes COLOR variables as <color> and FLOAT variables
+// as <dimen> resources of an Android values XML file.
+func generateAndroidTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, prefix string, modes []string) string {
+	tokens, problems := nativeTokens(variables, collections, resolver, modes, func(name string) string {
+		return androidResourceName(name, prefix)
+	})
+
+	var sb strings.Builder
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	sb.WriteString("<!-- Design Tokens - Generated by figma-query -->\n")
+	sb.WriteString("<resources>\n")
+	for _, p := range problems {
+		sb.WriteString(fmt.Sprintf("    <!-- skipped %s -->\n", strings.ReplaceAll(p, "--", "- -")))
+	}
+	for _, t := range tokens {
+		if t.isDim {
+			sb.WriteString(fmt.Sprintf("    <dimen name=\"%s\">%sdp</dimen>\n", t.name, strconv.FormatFloat(t.float, 'f', -1, 64)))
+			continue
+		}
+		r, g, b := int(t.color["r"]*255+0.5), int(t.color["g"]*255+0.5), int(t.color["b"]*255+0.5)
+		if a, ok := t.color["a"]; ok && a < 1 {
+			sb.WriteString(fmt.Sprintf("    <color name=\"%s\">#%02X%02X%02X%02X</color>\n", t.name, int(a*255+0.5), r, g, b))
+		} else {
+			sb.WriteString(fmt.Sprintf("    <color name=\"%s\">#%02X%02X%02X</color>\n", t.name, r, g, b))
+		}
+	}
+	sb.WriteString("</resources>\n")
+	return sb.String()
+}
+
+// generateSwiftTokens writes COLOR variables as UIColor and FLOAT variables
+// as CGFloat static constants.
+func generateSwiftTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, prefix string, modes []string) string {
+	tokens, problems := nativeTokens(variables, collections, resolver, modes, func(name string) string {
+		return swiftIdentifier(name, prefix)
+	})
+
+	var colors, dimens []nativeToken
+	for _, t := range tokens {
+		if t.isDim {
+			dimens = append(dimens, t)
+		} else {
+			colors = append(colors, t)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Design Tokens - Generated by figma-query\n\n")
+	sb.WriteString("import UIKit\n")
+	for _, p := range problems {
+		sb.WriteString(fmt.Sprintf("// skipped %s\n", p))
+	}
+
+	if len(colors) > 0 {
+		sb.WriteString("\nextension UIColor {\n")
+		for _, t := range colors {
+			a, ok := t.color["a"]
+			if !ok {
+				a = 1
+			}
+			sb.WriteString(fmt.Sprintf("    static let %s = UIColor(red: %.3f, green: %.3f, blue: %.3f, alpha: %.3f)\n",
+				swiftDeclName(t.name), t.color["r"], t.color["g"], t.color["b"], a))
+		}
+		sb.WriteString("}\n")
+	}
+
+	if len(dimens) > 0 {
+		sb.WriteString("\nextension CGFloat {\n")
+		for _, t := range dimens {
+			sb.WriteString(fmt.Sprintf("    static let %s: CGFloat = %s\n", swiftDeclName(t.name), strconv.FormatFloat(t.float, 'f', -1, 64)))
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// openPropsToken is a variable renamed to Open Props conventions.
+type openPropsToken struct {
+	group  string  // color-{family}, size, font-size, or "" for other variables
+	shade  float64 // color shade, or the resolved FLOAT value of sizes
+	source string  // variable name
+	value  string  // CSS value
+}
+
+// generateOpenPropsTokens writes variables as CSS custom properties named
+// like Open Props: colors become --color-{name}-{shade}, spacing FLOATs
+// --size-N and font size FLOATs --font-size-N. Shades are numbered from 0 in
+// the order of the variable's shade number (blue/50 → --color-blue-0);
+// sizes and font sizes from 1 in ascending value. Other variables keep their
+// css name. Each property is commented with the variable it came from.
+func generateOpenPropsTokens(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, resolver *tokenResolver, prefix string, modes []string) string {
+	var tokens []openPropsToken
+	for _, v := range sortedVariables(variables) {
+		coll := collections[v.VariableCollectionID]
+		if coll == nil {
+			continue
+		}
+		modeID := tokenModeID(coll, modes)
+		token := openPropsToken{source: v.Name, value: resolver.formatValue(v, modeID)}
+
+		switch v.ResolvedType {
+		case "COLOR":
+			family, shade := openPropsColor(v.Name)
+			token.group, token.shade = "color-"+family, shade
+		case "FLOAT":
+			if group := openPropsFloatGroup(v); group != "" {
+				if value, _, err := resolver.resolve(v, modeID); err == nil && json.Unmarshal(value, &token.shade) == nil {
+					token.group = group
+				}
+			}
+		}
+		tokens = append(tokens, token)
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		a, b := tokens[i], tokens[j]
+		if (a.group == "") != (b.group == "") {
+			return b.group == ""
+		}
+		if a.group != b.group {
+			return openPropsGroupOrder(a.group) < openPropsGroupOrder(b.group) ||
+				openPropsGroupOrder(a.group) == openPropsGroupOrder(b.group) && a.group < b.group
+		}
+		return a.shade < b.shade
+	})
+
+	var sb strings.Builder
+	sb.WriteString("/* Design Tokens (Open Props naming) - Generated by figma-query */\n\n")
+	sb.WriteString(":root {\n")
+
+	index := make(map[string]int)
+	for _, t := range tokens {
+		var name string
+		switch t.group {
+		case "":
+			name = formatVarName(t.source, "")
+		case "size", "font-size":
+			index[t.group]++
+			name = fmt.Sprintf("%s-%d", t.group, index[t.group])
+		default:
+			name = t.group
+			if t.shade >= 0 {
+				name += fmt.Sprintf("-%d", index[t.group])
+				index[t.group]++
+			}
+		}
+		if prefix != "" {
+			name = prefix + "-" + name
+		}
+		sb.WriteString(fmt.Sprintf("  --%s: %s; /* %s */\n", name, t.value, t.source))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// openPropsColor splits a color variable name such as color/blue/500 into
+// its family, blue, and shade, 500. Names without a trailing number have a
+// shade of -1.
+func openPropsColor(name string) (string, float64) {
+	parts := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.')
+	})
+	if len(parts) > 1 && (parts[0] == "color" || parts[0] == "colors") {
+		parts = parts[1:]
+	}
+	shade := -1.0
+	if len(parts) > 1 {
+		if n, err := strconv.ParseFloat(parts[len(parts)-1], 64); err == nil {
+			shade = n
+			parts = parts[:len(parts)-1]
+		}
+	}
+	return strings.Join(parts, "-"), shade
+}
+
+// openPropsFloatGroup returns "font-size" or "size" for FLOAT variables
+// scoped to, or named like, font sizes or spacing, and "" otherwise.
+func openPropsFloatGroup(v *figma.Variable) string {
+	name := strings.ToLower(v.Name)
+	compact := strings.NewReplacer("/", "", "-", "", "_", "", " ", "").Replace(name)
+	switch {
+	case containsString(v.Scopes, "FONT_SIZE") || strings.Contains(compact, "fontsize") || strings.Contains(compact, "textsize"):
+		return "font-size"
+	case containsString(v.Scopes, "GAP") || containsString(v.Scopes, "WIDTH_HEIGHT"):
+		return "size"
+	}
+	for _, word := range []string{"spacing", "space", "gap", "padding", "margin", "size"} {
+		if strings.Contains(name, word) {
+			return "size"
+		}
+	}
+	return ""
+}
+
+// openPropsGroupOrder lists colors before sizes and font sizes.
+func openPropsGroupOrder(group string) int {
+	switch group {
+	case "size":
+		return 1
+	case "font-size":
+		return 2
+	default:
+		return 0
+	}
+}
+
+// maxAliasDepth bounds alias chains, e.g. button.background → color.primary.500
+// → #0066CC is a depth of 2.
+const maxAliasDepth = 16
+
+// tokenResolver follows variable aliases to their concrete values.
+type tokenResolver struct {
+	variables   map[string]*figma.Variable
+	collections map[string]*figma.VariableCollection
+}
+
+func newTokenResolver(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection) *tokenResolver {
+	return &tokenResolver{variables: variables, collections: collections}
+}
+
+// parseVariableAlias reports whether value is a VARIABLE_ALIAS reference.
+func parseVariableAlias(value json.RawMessage) (*figma.VariableAlias, bool) {
+	if len(value) == 0 || value[0] != '{' {
+		return nil, false
+	}
+	var alias figma.VariableAlias
+	if err := json.Unmarshal(value, &alias); err != nil || alias.Type != "VARIABLE_ALIAS" || alias.ID == "" {
+		return nil, false
+	}
+	return &alias, true
+}
+
+// resolve returns the concrete value of v in modeID, following alias chains.
+// It also returns the variable that holds the value. When an alias crosses
+// into another collection, that collection's default mode is used unless it
+// shares the mode ID.
+func (tr *tokenResolver) resolve(v *figma.Variable, modeID string) (json.RawMessage, *figma.Variable, error) {
+	visited := make(map[string]bool)
+
+	for depth := 0; ; depth++ {
+		if visited[v.ID] {
+			return nil, nil, fmt.Errorf("alias cycle at %s", v.Name)
+		}
+		if depth > maxAliasDepth {
+			return nil, nil, fmt.Errorf("alias chain deeper than %d at %s", maxAliasDepth, v.Name)
+		}
+		visited[v.ID] = true
+
+		value, ok := v.ValuesByMode[modeID]
+		if !ok {
+			if coll := tr.collections[v.VariableCollectionID]; coll != nil {
+				modeID = coll.DefaultModeID
+				value = v.ValuesByMode[modeID]
+			}
+		}
+
+		alias, ok := parseVariableAlias(value)
+		if !ok {
+			return value, v, nil
+		}
+
+		target := tr.variables[alias.ID]
+		if target == nil {
+			return nil, nil, fmt.Errorf("alias target %s not found", alias.ID)
+		}
+		v = target
+	}
+}
+
+// formatValue resolves v and formats it for CSS-like output.
+func (tr *tokenResolver) formatValue(v *figma.Variable, modeID string) string {
+	value, source, err := tr.resolve(v, modeID)
+	if err != nil {
+		return fmt.Sprintf("unset /* %s */", err)
+	}
+	return formatTokenValue(source.ResolvedType, value)
+}
+
+// formatTokenValue formats a concrete value. Aliases must be resolved first
+// with tokenResolver; an unresolved alias is rendered as var(--<id>) rather
+// than raw JSON.
 func formatTokenValue(resolvedType string, value json.RawMessage) string {
+	if alias, ok := parseVariableAlias(value); ok {
+		return fmt.Sprintf("var(--%s)", sanitizeID(alias.ID))
+	}
+
 	switch resolvedType {
 	case "COLOR":
 		var color map[string]float64
@@ -524,33 +1322,38 @@ type DownloadImageArgs struct {
 type DownloadImageResult struct {
 	Downloaded []DownloadedImage `json:"downloaded"`
 	Failed     []string          `json:"failed,omitempty"`
+	Warnings   []string          `json:"warnings,omitempty"`
 }
 
 // DownloadedImage represents a downloaded image file.
 type DownloadedImage struct {
-	Ref      string `json:"ref,omitempty"`      // Image ref or node ID
-	Path     string `json:"path"`               // File path where saved
-	Size     int    `json:"size"`               // File size in bytes
-	Type     string `json:"type"`               // "fill" or "render"
+	Ref  string `json:"ref,omitempty"` // Image ref or node ID
+	Path string `json:"path"`          // File path where saved
+	Size int    `json:"size"`          // File size in bytes
+	Type string `json:"type"`          // "fill" or "render"
 }
 
 func registerDownloadImageTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "download_image",
 		Description: "Download images by reference ID (from fills/strokes/backgrounds) or render nodes as images.",
+		InputSchema: inputSchema[DownloadImageArgs](map[string][]string{
+			"format": {"png", "svg", "jpg", "pdf"},
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args DownloadImageArgs) (*mcp.CallToolResult, *DownloadImageResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 		if len(args.ImageRefs) == 0 && len(args.NodeIDs) == 0 {
-			return nil, nil, fmt.Errorf("either image_refs or node_ids is required")
+			return nil, nil, fmt.Errorf("either image_refs or node_ids is required. Take image_refs from the imageRef of a node's fills (get_node with select=[\"@images\"]), or pass node_ids to render nodes")
 		}
+		args.NodeIDs = r.ResolveNodeIDs(args.NodeIDs)
 		if args.OutputDir == "" {
-			return nil, nil, fmt.Errorf("output_dir is required")
+			return nil, nil, errOutputDirRequired
 		}
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Set defaults
@@ -634,16 +1437,20 @@ func registerDownloadImageTool(server *mcp.Server, r *Registry) {
 				}
 			}
 
-			images, err := r.Client().GetImages(ctx, args.FileKey, args.NodeIDs, &figma.ImageExportOptions{
+			opts := &figma.ImageExportOptions{
 				Format: format,
 				Scale:  scale,
-			})
+			}
+			result.Warnings = append(result.Warnings, figma.NormalizeImageExportOptions(opts)...)
+			scale = opts.Scale
+
+			images, err := r.Client().GetImages(ctx, args.FileKey, args.NodeIDs, opts)
 			if err != nil {
 				result.Failed = append(result.Failed, fmt.Sprintf("rendering nodes: %v", err))
 			} else {
 				for id, imageURL := range images.Images {
 					if imageURL == "" {
-						result.Failed = append(result.Failed, fmt.Sprintf("no render for node: %s", id))
+						result.Failed = append(result.Failed, fmt.Sprintf("no render for node %s: %s", id, imageFailureReason(format)))
 						continue
 					}
 
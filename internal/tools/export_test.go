@@ -0,0 +1,430 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func aliasTo(id string) json.RawMessage {
+	return json.RawMessage(`{"type":"VARIABLE_ALIAS","id":"` + id + `"}`)
+}
+
+func testTokenVariables() (map[string]*figma.Variable, map[string]*figma.VariableCollection) {
+	collections := map[string]*figma.VariableCollection{
+		"c:primitives": {ID: "c:primitives", Name: "Primitives", DefaultModeID: "m:p"},
+		"c:semantic":   {ID: "c:semantic", Name: "Semantic", DefaultModeID: "m:s"},
+	}
+	variables := map[string]*figma.Variable{
+		"v:blue": {
+			ID: "v:blue", Name: "blue/500", VariableCollectionID: "c:primitives", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m:p": json.RawMessage(`{"r":0,"g":0,"b":1,"a":1}`)},
+		},
+		"v:primary": {
+			ID: "v:primary", Name: "color/primary", VariableCollectionID: "c:semantic", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m:s": aliasTo("v:blue")},
+		},
+		"v:link": {
+			ID: "v:link", Name: "color/link", VariableCollectionID: "c:semantic", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m:s": aliasTo("v:primary")},
+		},
+		"v:a": {
+			ID: "v:a", Name: "loop/a", VariableCollectionID: "c:semantic", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m:s": aliasTo("v:b")},
+		},
+		"v:b": {
+			ID: "v:b", Name: "loop/b", VariableCollectionID: "c:semantic", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m:s": aliasTo("v:a")},
+		},
+		"v:dangling": {
+			ID: "v:dangling", Name: "dangling", VariableCollectionID: "c:semantic", ResolvedType: "COLOR",
+			ValuesByMode: map[string]json.RawMessage{"m:s": aliasTo("v:missing")},
+		},
+	}
+	return variables, collections
+}
+
+func TestTokenResolver(t *testing.T) {
+	variables, collections := testTokenVariables()
+	resolver := newTokenResolver(variables, collections)
+
+	tests := []struct {
+		id       string
+		expected string
+		errText  string
+	}{
+		{"v:blue", "#0000ff", ""},
+		{"v:primary", "#0000ff", ""},
+		{"v:link", "#0000ff", ""},
+		{"v:a", "", "alias cycle"},
+		{"v:dangling", "", "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			v := variables[tt.id]
+			coll := collections[v.VariableCollectionID]
+
+			value, source, err := resolver.resolve(v, coll.DefaultModeID)
+			if tt.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errText) {
+					t.Fatalf("expected error containing %q, got %v", tt.errText, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := formatTokenValue(source.ResolvedType, value); got != tt.expected {
+				t.Errorf("resolved value = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateJSONTokensKeepsAlias(t *testing.T) {
+	variables, collections := testTokenVariables()
+	resolver := newTokenResolver(variables, collections)
+
+	var tokens map[string]struct {
+		Value json.RawMessage `json:"value"`
+		Alias struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"alias"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(generateJSONTokens(variables, collections, resolver, nil)), &tokens); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+
+	link := tokens["color/link"]
+	if link.Alias.ID != "v:primary" || link.Alias.Name != "color/primary" {
+		t.Errorf("expected alias to color/primary, got %+v", link.Alias)
+	}
+	if got := formatTokenValue("COLOR", link.Value); got != "#0000ff" {
+		t.Errorf("expected resolved color value #0000ff, got %s", got)
+	}
+
+	if tokens["loop/a"].Error == "" {
+		t.Error("expected error for alias cycle")
+	}
+}
+
+func TestPathFilename(t *testing.T) {
+	path := []PathNode{
+		{ID: "0:1", Name: "Components", Type: "CANVAS"},
+		{ID: "1:2", Name: "Icons/Navigation", Type: "FRAME"},
+		{ID: "1:3", Name: "Arrow", Type: "COMPONENT"},
+	}
+	if got := pathFilename(path); got != "components--icons-navigation--arrow" {
+		t.Errorf("pathFilename = %q", got)
+	}
+	if got := pathFilename(nil); got != "" {
+		t.Errorf("pathFilename(nil) = %q, want empty", got)
+	}
+}
+
+func TestImageExportFailures(t *testing.T) {
+	ids := []string{"1:2", "1:3"}
+
+	failed := imageExportFailures(&figma.ImageExport{Err: "Invalid parameter"}, "png", ids)
+	if len(failed) != 1 || !strings.Contains(failed[0], "Invalid parameter") {
+		t.Errorf("API error should be reported once, not per node: %v", failed)
+	}
+
+	failed = imageExportFailures(&figma.ImageExport{Images: map[string]string{"1:2": "https://example.com/a.png"}}, "png", ids)
+	if len(failed) != 1 || !strings.Contains(failed[0], "1:3") {
+		t.Errorf("expected one failure for the missing node 1:3, got %v", failed)
+	}
+}
+
+func TestTokenResolverDepthLimit(t *testing.T) {
+	variables := map[string]*figma.Variable{}
+	for i := 0; i <= maxAliasDepth+1; i++ {
+		id := fmt.Sprintf("v:%d", i)
+		value := aliasTo(fmt.Sprintf("v:%d", i+1))
+		if i == maxAliasDepth+1 {
+			value = json.RawMessage(`{"r":1,"g":0,"b":0,"a":1}`)
+		}
+		variables[id] = &figma.Variable{ID: id, Name: id, ResolvedType: "COLOR", ValuesByMode: map[string]json.RawMessage{"m": value}}
+	}
+	resolver := newTokenResolver(variables, nil)
+
+	if _, _, err := resolver.resolve(variables["v:0"], "m"); err == nil || !strings.Contains(err.Error(), "deeper than") {
+		t.Errorf("expected depth limit error, got %v", err)
+	}
+	if got := resolver.formatValue(variables["v:1"], "m"); got != "#ff0000" {
+		t.Errorf("chain within the limit = %q, want #ff0000", got)
+	}
+}
+
+func TestFormatTokenValueUnresolvedAlias(t *testing.T) {
+	if got := formatTokenValue("COLOR", aliasTo("VariableID:1:2")); got != "var(--VariableID-1-2)" {
+		t.Errorf("formatTokenValue(alias) = %q", got)
+	}
+}
+
+func TestGenerateTSTokenTypes(t *testing.T) {
+	collections := map[string]*figma.VariableCollection{
+		"c:1": {ID: "c:1", Name: "Colors", DefaultModeID: "m:1"},
+		"c:2": {ID: "c:2", Name: "Spacing", DefaultModeID: "m:2"},
+	}
+	color := func(id, name, hex string) *figma.Variable {
+		return &figma.Variable{ID: id, Name: name, VariableCollectionID: "c:1", ResolvedType: "STRING",
+			ValuesByMode: map[string]json.RawMessage{"m:1": json.RawMessage(`"` + hex + `"`)}}
+	}
+	variables := map[string]*figma.Variable{
+		"v:1": color("v:1", "primary/500", "#0066cc"),
+		"v:2": color("v:2", "primary", "#0055aa"),
+		"v:3": color("v:3", "text-muted", "#777777"),
+		"v:4": {ID: "v:4", Name: "md", VariableCollectionID: "c:2", ResolvedType: "FLOAT",
+			ValuesByMode: map[string]json.RawMessage{"m:2": json.RawMessage(`16`)}},
+	}
+
+	out := generateTSTokenTypes(variables, collections, newTokenResolver(variables, collections))
+
+	for _, want := range []string{
+		"export interface ColorsTokens {\n  primary: {\n    DEFAULT: string;\n    500: string;\n  };\n  textMuted: string;\n}",
+		"export interface SpacingTokens {\n  md: string;\n}",
+		"export interface Tokens {\n  colors: ColorsTokens;\n  spacing: SpacingTokens;\n}",
+		"export const tokenTree: Tokens = {\n  colors: {\n    primary: {\n      DEFAULT: '#0055aa',\n      500: '#0066cc',\n    },",
+		"md: '16px',",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateTokensDeterministic(t *testing.T) {
+	collections := map[string]*figma.VariableCollection{
+		"c:1": {ID: "c:1", Name: "Spacing", DefaultModeID: "m:1"},
+	}
+	variables := make(map[string]*figma.Variable)
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("v:%d", i)
+		variables[id] = &figma.Variable{
+			ID: id, Name: fmt.Sprintf("space/%02d", i), VariableCollectionID: "c:1", ResolvedType: "FLOAT",
+			ValuesByMode: map[string]json.RawMessage{"m:1": json.RawMessage(fmt.Sprint(i * 4))},
+		}
+	}
+	resolver := newTokenResolver(variables, collections)
+
+	generators := map[string]func() string{
+		"css":  func() string { return generateCSSTokens(variables, collections, resolver, "", nil) },
+		"scss": func() string { return generateSCSSTokens(variables, collections, resolver, "", nil) },
+		"json": func() string { return generateJSONTokens(variables, collections, resolver, nil) },
+		"js":   func() string { return generateJSTokens(variables, collections, resolver, "", nil, false) },
+		"ts":   func() string { return generateJSTokens(variables, collections, resolver, "", nil, true) },
+	}
+	for name, generate := range generators {
+		t.Run(name, func(t *testing.T) {
+			first := generate()
+			for i := 0; i < 5; i++ {
+				if got := generate(); got != first {
+					t.Fatalf("run %d differs from the first:\n%s\nvs\n%s", i+2, got, first)
+				}
+			}
+		})
+	}
+
+	css := generateCSSTokens(variables, collections, resolver, "", nil)
+	if strings.Index(css, "space-00") > strings.Index(css, "space-01") ||
+		strings.Index(css, "space-48") > strings.Index(css, "space-49") {
+		t.Errorf("expected variables in name order:\n%s", css)
+	}
+}
+
+func TestGenerateNativeTokens(t *testing.T) {
+	variables, collections := testTokenVariables()
+	variables["v:space"] = &figma.Variable{
+		ID: "v:space", Name: "spacing/md", VariableCollectionID: "c:primitives", ResolvedType: "FLOAT",
+		ValuesByMode: map[string]json.RawMessage{"m:p": json.RawMessage(`16`)},
+	}
+	variables["v:overlay"] = &figma.Variable{
+		ID: "v:overlay", Name: "Overlay Scrim", VariableCollectionID: "c:primitives", ResolvedType: "COLOR",
+		ValuesByMode: map[string]json.RawMessage{"m:p": json.RawMessage(`{"r":0,"g":0,"b":0,"a":0.5}`)},
+	}
+	resolver := newTokenResolver(variables, collections)
+
+	android := generateAndroidTokens(variables, collections, resolver, "", nil)
+	for _, want := range []string{
+		`<color name="blue_500">#0000FF</color>`,
+		`<color name="color_primary">#0000FF</color>`,
+		`<color name="overlay_scrim">#80000000</color>`,
+		`<dimen name="spacing_md">16dp</dimen>`,
+		`<!-- skipped loop/a: alias cycle`,
+	} {
+		if !strings.Contains(android, want) {
+			t.Errorf("android output missing %q:\n%s", want, android)
+		}
+	}
+
+	swift := generateSwiftTokens(variables, collections, resolver, "brand", nil)
+	for _, want := range []string{
+		"extension UIColor {",
+		"static let brandColorLink = UIColor(red: 0.000, green: 0.000, blue: 1.000, alpha: 1.000)",
+		"static let brandOverlayScrim = UIColor(red: 0.000, green: 0.000, blue: 0.000, alpha: 0.500)",
+		"extension CGFloat {",
+		"static let brandSpacingMd: CGFloat = 16",
+	} {
+		if !strings.Contains(swift, want) {
+			t.Errorf("swift output missing %q:\n%s", want, swift)
+		}
+	}
+}
+
+func TestNativeTokenNameCollisions(t *testing.T) {
+	collections := map[string]*figma.VariableCollection{
+		"c:a": {ID: "c:a", Name: "A", DefaultModeID: "m:a"},
+		"c:b": {ID: "c:b", Name: "B", DefaultModeID: "m:b"},
+	}
+	red := json.RawMessage(`{"r":1,"g":0,"b":0,"a":1}`)
+	variables := map[string]*figma.Variable{
+		"v:1": {ID: "v:1", Name: "Color/Primary", VariableCollectionID: "c:a", ResolvedType: "COLOR", ValuesByMode: map[string]json.RawMessage{"m:a": red}},
+		"v:2": {ID: "v:2", Name: "color-primary", VariableCollectionID: "c:a", ResolvedType: "COLOR", ValuesByMode: map[string]json.RawMessage{"m:a": red}},
+		"v:3": {ID: "v:3", Name: "color-primary", VariableCollectionID: "c:b", ResolvedType: "COLOR", ValuesByMode: map[string]json.RawMessage{"m:b": red}},
+		"v:4": {ID: "v:4", Name: "default", VariableCollectionID: "c:a", ResolvedType: "FLOAT", ValuesByMode: map[string]json.RawMessage{"m:a": json.RawMessage(`4`)}},
+	}
+	resolver := newTokenResolver(variables, collections)
+
+	android := generateAndroidTokens(variables, collections, resolver, "", nil)
+	for _, want := range []string{`name="color_primary"`, `name="color_primary_2"`, `name="color_primary_3"`} {
+		if strings.Count(android, want+">") != 1 {
+			t.Errorf("android output should declare %s once:\n%s", want, android)
+		}
+	}
+
+	swift := generateSwiftTokens(variables, collections, resolver, "", nil)
+	for _, want := range []string{"static let colorPrimary =", "static let colorPrimary_2 =", "static let colorPrimary_3 =", "static let `default`: CGFloat = 4"} {
+		if strings.Count(swift, want) != 1 {
+			t.Errorf("swift output should declare %q once:\n%s", want, swift)
+		}
+	}
+}
+
+func TestWriteTokensByCollection(t *testing.T) {
+	variables, collections := testTokenVariables()
+	resolver := newTokenResolver(variables, collections)
+
+	dir := t.TempDir()
+	args := ExportTokensArgs{OutputPath: dir, Format: "css"}
+	files, err := writeTokensByCollection(args, variables, collections, resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || filepath.Base(files[0]) != "primitives.css" || filepath.Base(files[1]) != "semantic.css" {
+		t.Fatalf("files = %v, want primitives.css and semantic.css", files)
+	}
+
+	data, err := os.ReadFile(files[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	css := string(data)
+	for _, want := range []string{
+		"/* Collection: Semantic */\n",
+		"--color-primary:",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("semantic.css missing %q:\n%s", want, css)
+		}
+	}
+	if strings.Contains(css, "--blue-500") {
+		t.Errorf("semantic.css contains a Primitives variable:\n%s", css)
+	}
+
+	android := addTokenFileHeader("android", generateAndroidTokens(variables, collections, resolver, "", nil), "Primitives")
+	if !strings.HasPrefix(android, "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<!-- Collection: Primitives -->\n") {
+		t.Errorf("android header misplaced:\n%s", android)
+	}
+
+	var tokens map[string]any
+	if err := json.Unmarshal([]byte(addTokenFileHeader("json", `{"a": {"value": 1}}`, "Primitives")), &tokens); err != nil {
+		t.Fatalf("json header produced invalid JSON: %v", err)
+	}
+	if meta, _ := tokens["$metadata"].(map[string]any); meta["collection"] != "Primitives" {
+		t.Errorf("$metadata = %v", tokens["$metadata"])
+	}
+}
+
+func TestWriteTokensByCollectionDeterministic(t *testing.T) {
+	variables, collections := testTokenVariables()
+	// Same-named collections are told apart by ID in their file names
+	collections["c:z-dup"] = &figma.VariableCollection{ID: "c:z-dup", Name: "Semantic", DefaultModeID: "m:s"}
+	collections["c:a-dup"] = &figma.VariableCollection{ID: "c:a-dup", Name: "Semantic", DefaultModeID: "m:s"}
+	resolver := newTokenResolver(variables, collections)
+	args := ExportTokensArgs{Format: "css"}
+
+	var runs [][]string
+	for i := 0; i < 5; i++ {
+		args.OutputPath = t.TempDir()
+		files, err := writeTokensByCollection(args, variables, collections, resolver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var run []string
+		for _, f := range files {
+			data, err := os.ReadFile(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			run = append(run, filepath.Base(f)+"\n"+string(data))
+		}
+		runs = append(runs, run)
+	}
+	for i := 1; i < len(runs); i++ {
+		if strings.Join(runs[i], "\x00") != strings.Join(runs[0], "\x00") {
+			t.Fatalf("run %d differs from run 0:\n%v\n---\n%v", i, runs[i], runs[0])
+		}
+	}
+}
+
+func TestGenerateOpenPropsTokens(t *testing.T) {
+	collections := map[string]*figma.VariableCollection{
+		"c": {ID: "c", Name: "Tokens", DefaultModeID: "m"},
+	}
+	variable := func(id, name, typ, value string, scopes ...string) *figma.Variable {
+		return &figma.Variable{
+			ID: id, Name: name, VariableCollectionID: "c", ResolvedType: typ, Scopes: scopes,
+			ValuesByMode: map[string]json.RawMessage{"m": json.RawMessage(value)},
+		}
+	}
+	variables := map[string]*figma.Variable{
+		"1": variable("1", "color/blue/500", "COLOR", `{"r":0,"g":0,"b":1,"a":1}`),
+		"2": variable("2", "color/blue/50", "COLOR", `{"r":0.9,"g":0.9,"b":1,"a":1}`),
+		"3": variable("3", "brand", "COLOR", `{"r":1,"g":0,"b":0,"a":1}`),
+		"4": variable("4", "spacing/lg", "FLOAT", `24`),
+		"5": variable("5", "spacing/sm", "FLOAT", `8`),
+		"6": variable("6", "heading", "FLOAT", `32`, "FONT_SIZE"),
+		"7": variable("7", "text/size/body", "FLOAT", `16`),
+		"8": variable("8", "opacity/disabled", "FLOAT", `0.4`),
+		"9": variable("9", "flags/beta", "BOOLEAN", `true`),
+	}
+	resolver := newTokenResolver(variables, collections)
+
+	css := generateOpenPropsTokens(variables, collections, resolver, "", nil)
+	for _, want := range []string{
+		"--color-blue-0: #e5e5ff; /* color/blue/50 */",
+		"--color-blue-1: #0000ff; /* color/blue/500 */",
+		"--color-brand: #ff0000; /* brand */",
+		"--size-1: 8px; /* spacing/sm */",
+		"--size-2: 24px; /* spacing/lg */",
+		"--font-size-1: 16px; /* text/size/body */",
+		"--font-size-2: 32px; /* heading */",
+		"--opacity-disabled:",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("open-props output missing %q:\n%s", want, css)
+		}
+	}
+	if strings.Index(css, "--size-1") > strings.Index(css, "--font-size-1") {
+		t.Errorf("sizes should precede font sizes:\n%s", css)
+	}
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// FindTextArgs contains arguments for the find_text tool.
+type FindTextArgs struct {
+	FileKey  string `json:"file_key" jsonschema:"Figma file key"`
+	Contains string `json:"contains" jsonschema:"Text to look for, e.g. Get Started. Plain text matched anywhere in a text layer, ignoring case; no wildcards or regex"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"Max results (default: 50)"`
+	Format   string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+
+	IncludeHidden bool `json:"include_hidden,omitempty" jsonschema:"Include invisible nodes (default: false)"`
+}
+
+// TextMatch is a text node whose content contains the searched text.
+type TextMatch struct {
+	NodeID     string `json:"node_id"`
+	Name       string `json:"name"`
+	Page       string `json:"page,omitempty"`
+	Characters string `json:"characters"`
+}
+
+// FindTextResult contains the result of find_text.
+type FindTextResult struct {
+	Results []TextMatch `json:"results"`
+	Total   int         `json:"total"`
+	HasMore bool        `json:"has_more"`
+}
+
+func registerFindTextTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "find_text",
+		Description: "Find text layers containing a phrase, e.g. the node labelled \"Get Started\". Case-insensitive plain substring match, no pattern syntax. Returns each node's full text, ID, name and page.",
+		InputSchema: inputSchema[FindTextArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args FindTextArgs) (*mcp.CallToolResult, *FindTextResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.Contains == "" {
+			return nil, nil, fmt.Errorf("contains is required. Pass the visible text to look for (e.g. \"Get Started\")")
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = 50
+		}
+
+		// Try cache first, then API
+		var nodes []*figma.Node
+		var pages map[string]string
+		if cacheDir, err := findCacheDir(r.ExportDir(), args.FileKey); err == nil {
+			if cached, err := readNodesFromExport(cacheDir); err == nil && len(cached) > 0 {
+				nodes = cached
+				pages = cachedNodePages(cacheDir)
+			}
+		}
+		if nodes == nil {
+			if !r.HasClient() {
+				return nil, nil, errNoCacheNoClient
+			}
+			var err error
+			nodes, err = r.fileNodes(ctx, args.FileKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			pages = nodePages(nodes)
+		}
+
+		matches := findTextNodes(nodes, pages, args.Contains, args.IncludeHidden, limit+1)
+		result := &FindTextResult{Results: matches, HasMore: len(matches) > limit}
+		if result.HasMore {
+			result.Results = matches[:limit]
+		}
+		result.Total = len(result.Results)
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatFindTextResult(result, args.Contains)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// findTextNodes returns up to limit TEXT nodes whose characters contain
+// text, ignoring case. pages maps node IDs to page names.
+func findTextNodes(nodes []*figma.Node, pages map[string]string, text string, includeHidden bool, limit int) []TextMatch {
+	needle := strings.ToLower(text)
+	matches := []TextMatch{}
+	for _, node := range nodes {
+		if node.Type != figma.NodeTypeText || node.Characters == "" {
+			continue
+		}
+		if !includeHidden && isHidden(node) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(node.Characters), needle) {
+			continue
+		}
+		matches = append(matches, TextMatch{
+			NodeID:     node.ID,
+			Name:       node.Name,
+			Page:       pages[node.ID],
+			Characters: node.Characters,
+		})
+		if len(matches) >= limit {
+			break
+		}
+	}
+	return matches
+}
+
+// nodePages maps node IDs to page names for nodes flattened from a
+// document, where each page precedes its descendants.
+func nodePages(nodes []*figma.Node) map[string]string {
+	pages := make(map[string]string, len(nodes))
+	page := ""
+	for _, node := range nodes {
+		if node.Type == figma.NodeTypeCanvas {
+			page = node.Name
+		}
+		pages[node.ID] = page
+	}
+	return pages
+}
+
+// cachedNodePages maps node IDs to page names from the _index.json of a
+// sync_file export. It returns nil when the export has no index.
+func cachedNodePages(cacheDir string) map[string]string {
+	index, err := readExportIndex(cacheDir)
+	if err != nil {
+		return nil
+	}
+	pages := make(map[string]string, len(index))
+	for id, entry := range index {
+		pages[id] = entry.Page
+	}
+	return pages
+}
+
+func formatFindTextResult(r *FindTextResult, text string) string {
+	var sb strings.Builder
+
+	if r.Total == 0 {
+		sb.WriteString(fmt.Sprintf("No text layers contain %q\n", text))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Found %d text layers containing %q", r.Total, text))
+	if r.HasMore {
+		sb.WriteString(" (more available, raise limit)")
+	}
+	sb.WriteString("\n\n")
+
+	for _, m := range r.Results {
+		location := m.Name
+		if m.Page != "" {
+			location = m.Page + " / " + m.Name
+		}
+		sb.WriteString(fmt.Sprintf("[%s] %s\n", m.NodeID, location))
+		sb.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(m.Characters, "\n", "\n    ")))
+	}
+
+	return sb.String()
+}
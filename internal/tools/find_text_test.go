@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestFindTextNodes(t *testing.T) {
+	hidden := false
+	doc := &figma.DocumentNode{Children: []*figma.Node{
+		{ID: "0:1", Name: "Landing", Type: figma.NodeTypeCanvas, Children: []*figma.Node{
+			{ID: "1:1", Name: "Hero", Type: figma.NodeTypeFrame, Children: []*figma.Node{
+				{ID: "1:2", Name: "CTA label", Type: figma.NodeTypeText, Characters: "Get Started"},
+				{ID: "1:3", Name: "Get started frame", Type: figma.NodeTypeFrame},
+				{ID: "1:4", Name: "Old CTA", Type: figma.NodeTypeText, Characters: "get started now", Visible: &hidden},
+			}},
+		}},
+		{ID: "0:2", Name: "Pricing", Type: figma.NodeTypeCanvas, Children: []*figma.Node{
+			{ID: "2:1", Name: "Footer", Type: figma.NodeTypeText, Characters: "Ready? GET STARTED (free)"},
+		}},
+	}}
+	nodes := flattenNodes(doc)
+	pages := nodePages(nodes)
+
+	matches := findTextNodes(nodes, pages, "get started", false, 10)
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2: %+v", len(matches), matches)
+	}
+	if matches[0].NodeID != "1:2" || matches[0].Page != "Landing" || matches[0].Characters != "Get Started" {
+		t.Errorf("first match = %+v", matches[0])
+	}
+	if matches[1].NodeID != "2:1" || matches[1].Page != "Pricing" {
+		t.Errorf("second match = %+v", matches[1])
+	}
+
+	// Pattern characters are matched literally
+	if got := findTextNodes(nodes, pages, "(free)", false, 10); len(got) != 1 {
+		t.Errorf("literal match of (free) = %+v", got)
+	}
+
+	if got := findTextNodes(nodes, pages, "get started", true, 10); len(got) != 3 {
+		t.Errorf("with hidden nodes got %d matches, want 3", len(got))
+	}
+	if got := findTextNodes(nodes, pages, "get started", false, 1); len(got) != 1 {
+		t.Errorf("limit 1 returned %d matches", len(got))
+	}
+}
@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// Width of the ASCII grid previews, in characters.
+const gridPreviewWidth = 48
+
+// GetGridStylesArgs contains arguments for the get_grid_styles tool.
+type GetGridStylesArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// GridStyle is a grid style and the layout grids it defines.
+type GridStyle struct {
+	ID          string     `json:"id"`
+	Key         string     `json:"key"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Remote      bool       `json:"remote,omitempty"`
+	Grids       []GridSpec `json:"grids"`
+}
+
+// GridSpec is one layout grid of a grid style with its ASCII preview.
+type GridSpec struct {
+	Pattern     string   `json:"pattern"`
+	Alignment   string   `json:"alignment,omitempty"`
+	Count       int      `json:"count,omitempty"`
+	SectionSize float64  `json:"section_size,omitempty"`
+	GutterSize  float64  `json:"gutter_size,omitempty"`
+	Offset      float64  `json:"offset,omitempty"`
+	Preview     []string `json:"preview"`
+}
+
+// GetGridStylesResult contains the result of get_grid_styles.
+type GetGridStylesResult struct {
+	Styles []GridStyle `json:"styles"`
+}
+
+func registerGetGridStylesTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_grid_styles",
+		Description: "Show the layout grids of each grid style: columns, rows or square grids with count, gutter, offset and an ASCII preview.",
+		InputSchema: inputSchema[GetGridStylesArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetGridStylesArgs) (*mcp.CallToolResult, *GetGridStylesResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		file, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{Depth: 1})
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+
+		var ids []string
+		for id, style := range file.Styles {
+			if style.StyleType == figma.StyleTypeGrid {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+
+		// Style definitions are nodes; their layout grids hold the values.
+		var nodes *figma.FileNodes
+		if len(ids) > 0 {
+			nodes, err = r.Client().GetFileNodes(ctx, args.FileKey, ids, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("fetching grid style nodes: %w", err)
+			}
+		}
+
+		result := &GetGridStylesResult{Styles: []GridStyle{}}
+		for _, id := range ids {
+			style := file.Styles[id]
+			gs := GridStyle{
+				ID:          id,
+				Key:         style.Key,
+				Name:        style.Name,
+				Description: style.Description,
+				Remote:      style.Remote,
+				Grids:       []GridSpec{},
+			}
+			if w := nodes.Nodes[id]; w != nil && w.Document != nil {
+				for _, g := range w.Document.LayoutGrids {
+					gs.Grids = append(gs.Grids, GridSpec{
+						Pattern:     g.Pattern,
+						Alignment:   g.Alignment,
+						Count:       g.Count,
+						SectionSize: g.SectionSize,
+						GutterSize:  g.GutterSize,
+						Offset:      g.Offset,
+						Preview:     renderGridPreview(g),
+					})
+				}
+			}
+			result.Styles = append(result.Styles, gs)
+		}
+		sort.SliceStable(result.Styles, func(i, j int) bool { return result.Styles[i].Name < result.Styles[j].Name })
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatGridStylesResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// renderGridPreview draws a schematic of a layout grid as ASCII art:
+// columns as vertical bars, rows as horizontal bands and square grids as a
+// crosshatch. Proportions are not to scale; gutters and offsets are shown
+// only as present or absent. Grids with more than 16 columns or 6 rows
+// show the first ones and the count of the rest.
+func renderGridPreview(g figma.LayoutGrid) []string {
+	count := g.Count
+	if count <= 0 {
+		count = 8 // auto-fill grids repeat as many sections as fit
+	}
+
+	switch g.Pattern {
+	case "COLUMNS":
+		shown := count
+		if shown > 16 {
+			shown = 16
+		}
+		gutter := ""
+		if g.GutterSize > 0 {
+			gutter = " "
+		}
+		left, right := "", ""
+		switch g.Alignment {
+		case "MIN":
+			left, right = gridMargin(g.Offset), "   "
+		case "MAX":
+			left, right = "   ", gridMargin(g.Offset)
+		case "CENTER":
+			left, right = "   ", "   "
+		default:
+			left, right = gridMargin(g.Offset), gridMargin(g.Offset)
+		}
+		inner := (gridPreviewWidth-len(left)-len(right)-(shown-1)*len(gutter))/shown - 2
+		if inner < 0 {
+			inner = 0
+		}
+		column := "|" + strings.Repeat(" ", inner) + "|"
+		cols := make([]string, shown)
+		for i := range cols {
+			cols[i] = column
+		}
+		line := strings.TrimRight(left+strings.Join(cols, gutter)+right, " ")
+		if shown < count {
+			line += fmt.Sprintf(" +%d", count-shown)
+		}
+		return []string{line, line, line, line}
+
+	case "ROWS":
+		shown := count
+		if shown > 6 {
+			shown = 6
+		}
+		band := strings.Repeat("=", gridPreviewWidth)
+		var lines []string
+		if g.Offset > 0 && g.Alignment != "MAX" {
+			lines = append(lines, "")
+		}
+		for i := 0; i < shown; i++ {
+			if i > 0 && g.GutterSize > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, band)
+		}
+		if shown < count {
+			lines = append(lines, fmt.Sprintf("+%d more rows", count-shown))
+		}
+		return lines
+
+	case "GRID":
+		border := strings.Repeat("+-----", 8) + "+"
+		cells := strings.Repeat("|     ", 8) + "|"
+		lines := []string{border}
+		for i := 0; i < 3; i++ {
+			lines = append(lines, cells, cells, border)
+		}
+		return lines
+	}
+	return nil
+}
+
+// gridMargin shows an outer offset as a gap before or after the columns.
+func gridMargin(offset float64) string {
+	if offset > 0 {
+		return "  "
+	}
+	return ""
+}
+
+func formatGridStylesResult(r *GetGridStylesResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Grid styles: %d\n\n", len(r.Styles)))
+	if len(r.Styles) == 0 {
+		sb.WriteString("No grid styles found.\n")
+		return sb.String()
+	}
+
+	for _, s := range r.Styles {
+		sb.WriteString(fmt.Sprintf("%s [%s]", s.Name, s.ID))
+		if s.Remote {
+			sb.WriteString(" (library)")
+		}
+		sb.WriteString("\n")
+		if s.Description != "" {
+			sb.WriteString(fmt.Sprintf("  %s\n", s.Description))
+		}
+		if len(s.Grids) == 0 {
+			sb.WriteString("  No layout grids (style definition not available in this file)\n\n")
+			continue
+		}
+		for _, g := range s.Grids {
+			sb.WriteString("  " + describeGrid(g) + "\n")
+			for _, line := range g.Preview {
+				sb.WriteString("  " + line + "\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// describeGrid summarises the numbers of a layout grid on one line.
+func describeGrid(g GridSpec) string {
+	switch g.Pattern {
+	case "GRID":
+		return fmt.Sprintf("GRID: %gpx squares", g.SectionSize)
+	case "COLUMNS", "ROWS":
+		count := "auto"
+		if g.Count > 0 {
+			count = fmt.Sprintf("%d", g.Count)
+		}
+		size := "stretch"
+		if g.Alignment != "STRETCH" {
+			size = fmt.Sprintf("%gpx", g.SectionSize)
+		}
+		return fmt.Sprintf("%s: count %s, size %s, gutter %gpx, offset %gpx, align %s",
+			g.Pattern, count, size, g.GutterSize, g.Offset, strings.ToLower(g.Alignment))
+	}
+	return g.Pattern
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestRenderGridPreview(t *testing.T) {
+	columns := renderGridPreview(figma.LayoutGrid{Pattern: "COLUMNS", Alignment: "STRETCH", Count: 4, GutterSize: 20, Offset: 40})
+	if len(columns) == 0 {
+		t.Fatal("no columns preview")
+	}
+	if got := strings.Count(columns[0], "|"); got != 8 {
+		t.Errorf("got %d bars, want 8: %q", got, columns[0])
+	}
+	if !strings.HasPrefix(columns[0], "  |") || !strings.Contains(columns[0], "| |") {
+		t.Errorf("offset and gutter not shown: %q", columns[0])
+	}
+
+	many := renderGridPreview(figma.LayoutGrid{Pattern: "COLUMNS", Alignment: "STRETCH", Count: 24})
+	if !strings.HasSuffix(many[0], "+8") {
+		t.Errorf("extra columns not counted: %q", many[0])
+	}
+
+	rows := renderGridPreview(figma.LayoutGrid{Pattern: "ROWS", Alignment: "STRETCH", Count: 3, GutterSize: 10})
+	bands := 0
+	for _, line := range rows {
+		if strings.HasPrefix(line, "=") {
+			bands++
+		}
+	}
+	if bands != 3 || len(rows) != 5 {
+		t.Errorf("rows preview = %q", rows)
+	}
+
+	grid := renderGridPreview(figma.LayoutGrid{Pattern: "GRID", SectionSize: 8})
+	if !strings.HasPrefix(grid[0], "+-----+") || !strings.HasPrefix(grid[1], "|     |") {
+		t.Errorf("grid preview = %q", grid)
+	}
+}
+
+func TestDescribeGrid(t *testing.T) {
+	got := describeGrid(GridSpec{Pattern: "COLUMNS", Alignment: "STRETCH", Count: 12, GutterSize: 24, Offset: 80})
+	want := "COLUMNS: count 12, size stretch, gutter 24px, offset 80px, align stretch"
+	if got != want {
+		t.Errorf("describeGrid = %q, want %q", got, want)
+	}
+	if got := describeGrid(GridSpec{Pattern: "GRID", SectionSize: 8}); got != "GRID: 8px squares" {
+		t.Errorf("describeGrid = %q", got)
+	}
+}
@@ -7,11 +7,12 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
 )
 
 // InfoArgs contains the arguments for the info tool.
 type InfoArgs struct {
-	Topic  string `json:"topic,omitempty" jsonschema:"Specific topic: tools, projections, query, operators, export, examples, status. Omit for overview."`
+	Topic  string `json:"topic,omitempty" jsonschema:"Specific topic: tools, projections, query, operators, export, examples, status, list_aliases, list_bookmarks, query_schema. Omit for overview."`
 	Format string `json:"format,omitempty" jsonschema:"Output format: text (default) or json"`
 }
 
@@ -26,6 +27,9 @@ func registerInfoTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "info",
 		Description: "List available tools, projections, query syntax, and server status. Use without arguments for overview.",
+		InputSchema: inputSchema[InfoArgs](map[string][]string{
+			"format": responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args InfoArgs) (*mcp.CallToolResult, *InfoResult, error) {
 		topic := args.Topic
 		if topic == "" {
@@ -55,9 +59,15 @@ func registerInfoTool(server *mcp.Server, r *Registry) {
 		case "examples":
 			content, data = infoExamples()
 		case "status":
-			content, data = infoStatus(r)
+			content, data = infoStatus(ctx, r)
+		case "list_aliases":
+			content, data = infoListAliases(r)
+		case "list_bookmarks":
+			content, data = infoListBookmarks(r)
+		case "query_schema":
+			content, data = infoQuerySchema()
 		default:
-			content = fmt.Sprintf("Unknown topic: %s. Available: tools, projections, query, operators, export, examples, status", topic)
+			content = fmt.Sprintf("Unknown topic: %s. Available: tools, projections, query, operators, export, examples, status, list_aliases, list_bookmarks, query_schema", topic)
 		}
 
 		result := &InfoResult{
@@ -96,12 +106,13 @@ Tool Groups
 -----------
 Group     | Count | Purpose
 --------- | ----- | --------
-discovery | 1     | info - help & status
-export    | 4     | sync_file, export_assets, export_tokens, download_image
-query     | 5     | query, search, get_tree, list_components, list_styles
-detail    | 3     | get_node, get_css, get_tokens
-render    | 1     | wireframe (ASCII/SVG with IDs)
-analysis  | 1     | diff (version comparison)
+discovery | 3     | info - help & status, create_alias, bookmark
+export    | 13    | sync_file, export_assets, export_tokens, download_image, export_component_docs, merge_exports, text_styles_to_css, clean_node_json, export_sprite, validate_export, capture_baseline, batch_sync, export_stories
+query     | 14    | query, search, get_tree, list_components, list_styles, frame_inventory, get_node_path, get_responsive_breakpoints, get_grid_styles, component_map, cache_search, get_component_graph, find_text, get_comments
+detail    | 5     | get_node, get_css, get_tokens, get_overrides, compare_to_code
+render    | 2     | wireframe (ASCII/SVG with IDs), render_all_pages
+analysis  | 18    | diff (version comparison), get_spacing_scale, list_effects, token_audit, token_diff, find_duplicates, check_accessibility, find_orphan_styles, generate_color_palette, get_contrast_pairs, get_contributors, detect_design_patterns, watch_query, check_naming_conventions, get_analytics, layout_audit, resolve_token_chain, list_versions
+write     | 3     | update_variables, search_and_replace, post_comment
 
 Quick Start
 -----------
@@ -111,20 +122,21 @@ Quick Start
 4. get_css(file_key, node_ids) - Extract CSS for implementation
 
 Use info(topic="<topic>") for detailed help on:
-  tools, projections, query, operators, export, examples, status`
+  tools, projections, query, operators, export, examples, status, list_aliases, list_bookmarks, query_schema`
 
 	data := map[string]interface{}{
 		"version":      "0.1.0",
 		"auth_status":  authStatus,
 		"export_dir":   r.ExportDir(),
-		"tool_count":   15,
+		"tool_count":   58,
 		"tool_groups": []map[string]interface{}{
-			{"name": "discovery", "count": 1, "tools": []string{"info"}},
-			{"name": "export", "count": 4, "tools": []string{"sync_file", "export_assets", "export_tokens", "download_image"}},
-			{"name": "query", "count": 5, "tools": []string{"query", "search", "get_tree", "list_components", "list_styles"}},
-			{"name": "detail", "count": 3, "tools": []string{"get_node", "get_css", "get_tokens"}},
-			{"name": "render", "count": 1, "tools": []string{"wireframe"}},
-			{"name": "analysis", "count": 1, "tools": []string{"diff"}},
+			{"name": "discovery", "count": 3, "tools": []string{"info", "create_alias", "bookmark"}},
+			{"name": "export", "count": 13, "tools": []string{"sync_file", "export_assets", "export_tokens", "download_image", "export_component_docs", "merge_exports", "text_styles_to_css", "clean_node_json", "export_sprite", "validate_export", "capture_baseline", "batch_sync", "export_stories"}},
+			{"name": "query", "count": 14, "tools": []string{"query", "search", "get_tree", "list_components", "list_styles", "frame_inventory", "get_node_path", "get_responsive_breakpoints", "get_grid_styles", "component_map", "cache_search", "get_component_graph", "find_text", "get_comments"}},
+			{"name": "detail", "count": 5, "tools": []string{"get_node", "get_css", "get_tokens", "get_overrides", "compare_to_code"}},
+			{"name": "render", "count": 2, "tools": []string{"wireframe", "render_all_pages"}},
+			{"name": "analysis", "count": 18, "tools": []string{"diff", "get_spacing_scale", "list_effects", "token_audit", "token_diff", "find_duplicates", "check_accessibility", "find_orphan_styles", "generate_color_palette", "get_contrast_pairs", "get_contributors", "detect_design_patterns", "watch_query", "check_naming_conventions", "get_analytics", "layout_audit", "resolve_token_chain", "list_versions"}},
+			{"name": "write", "count": 3, "tools": []string{"update_variables", "search_and_replace", "post_comment"}},
 		},
 	}
 
@@ -134,20 +146,63 @@ Use info(topic="<topic>") for detailed help on:
 func infoTools() (string, interface{}) {
 	tools := []map[string]string{
 		{"name": "info", "group": "discovery", "desc": "List tools, projections, query syntax, status"},
+		{"name": "create_alias", "group": "discovery", "desc": "Save a short name for a node ID"},
+		{"name": "bookmark", "group": "discovery", "desc": "Name a file_key and node_id for use as bookmark=<name> in other tools"},
 		{"name": "sync_file", "group": "export", "desc": "Export entire file to nested folders (includes assets by default)"},
 		{"name": "export_assets", "group": "export", "desc": "Export images/icons for specific nodes"},
 		{"name": "export_tokens", "group": "export", "desc": "Export design tokens to CSS/JSON/etc"},
 		{"name": "download_image", "group": "export", "desc": "Download images by ref ID or render nodes as images"},
+		{"name": "export_component_docs", "group": "export", "desc": "Generate MDX docs for component sets"},
+		{"name": "merge_exports", "group": "export", "desc": "Merge two exports of a file, keeping the newer copy of each node"},
+		{"name": "text_styles_to_css", "group": "export", "desc": "CSS typography classes from text styles"},
+		{"name": "clean_node_json", "group": "export", "desc": "Remove null, empty and zero-value fields from the _node.json files of an export"},
+		{"name": "export_sprite", "group": "export", "desc": "Combine icon nodes into one SVG sprite with a <name>.manifest.json index"},
+		{"name": "validate_export", "group": "export", "desc": "Check that a sync_file export is complete: index, node files, metadata and tree"},
+		{"name": "capture_baseline", "group": "export", "desc": "Render nodes to a PNG baseline and report pixel differences on later runs"},
+		{"name": "batch_sync", "group": "export", "desc": "Sync several files concurrently, each into its own directory"},
+		{"name": "export_stories", "group": "export", "desc": "Generate Storybook stories and MDX docs with Figma embeds"},
 		{"name": "query", "group": "query", "desc": "Query nodes with JSON DSL and data shaping"},
-		{"name": "search", "group": "query", "desc": "Full-text search across names, text, properties"},
+		{"name": "search", "group": "query", "desc": "Full-text search across names, text, properties (file_key=* searches all synced files)"},
 		{"name": "get_tree", "group": "query", "desc": "Get file structure as ASCII tree with node IDs"},
 		{"name": "list_components", "group": "query", "desc": "List all components with usage stats"},
 		{"name": "list_styles", "group": "query", "desc": "List all styles (color, text, effect, grid)"},
+		{"name": "frame_inventory", "group": "query", "desc": "Top-level frames per page with sizes and counts"},
+		{"name": "get_node_path", "group": "query", "desc": "Ancestor chain from page to node"},
+		{"name": "get_responsive_breakpoints", "group": "query", "desc": "Group frames that are the same screen at different widths"},
+		{"name": "get_grid_styles", "group": "query", "desc": "Layout grid styles with ASCII previews"},
+		{"name": "component_map", "group": "query", "desc": "Map component keys to code files"},
+		{"name": "cache_search", "group": "query", "desc": "Search names, CSS values or text in sync_file exports without API access"},
+		{"name": "get_component_graph", "group": "query", "desc": "Component dependency graph as Mermaid or JSON, with circular dependencies flagged"},
+		{"name": "find_text", "group": "query", "desc": "Find text layers containing a phrase (case-insensitive, no pattern syntax)"},
+		{"name": "get_comments", "group": "query", "desc": "Comment threads with node anchors and replies"},
 		{"name": "get_node", "group": "detail", "desc": "Get full details for a specific node"},
 		{"name": "get_css", "group": "detail", "desc": "Extract CSS properties for node(s)"},
 		{"name": "get_tokens", "group": "detail", "desc": "Get design token references and resolved values"},
+		{"name": "get_overrides", "group": "detail", "desc": "Properties a component instance overrides, with main component and instance values"},
+		{"name": "compare_to_code", "group": "detail", "desc": "Diff a node's CSS against a local CSS file"},
 		{"name": "wireframe", "group": "render", "desc": "Generate annotated wireframe with node IDs"},
+		{"name": "render_all_pages", "group": "render", "desc": "Render wireframes for every page in one call"},
 		{"name": "diff", "group": "analysis", "desc": "Compare exports or file versions"},
+		{"name": "get_spacing_scale", "group": "analysis", "desc": "Unique padding/gap values with off-scale flags"},
+		{"name": "list_effects", "group": "analysis", "desc": "Unique shadow/blur configurations and their styles"},
+		{"name": "token_audit", "group": "analysis", "desc": "Hard-coded values that should be bound to variables"},
+		{"name": "token_diff", "group": "analysis", "desc": "Variable changes since the last sync"},
+		{"name": "find_duplicates", "group": "analysis", "desc": "Visually identical components under different names"},
+		{"name": "check_accessibility", "group": "analysis", "desc": "Flag text layers failing WCAG contrast"},
+		{"name": "find_orphan_styles", "group": "analysis", "desc": "List local styles no node uses"},
+		{"name": "generate_color_palette", "group": "analysis", "desc": "Cluster unbound solid fill colors and suggest token names"},
+		{"name": "get_contrast_pairs", "group": "analysis", "desc": "Text color / background color pairs with contrast ratios"},
+		{"name": "get_contributors", "group": "analysis", "desc": "People who saved versions of or commented on a file"},
+		{"name": "detect_design_patterns", "group": "analysis", "desc": "Identify navbars, cards and forms from node structure with confidence scores"},
+		{"name": "watch_query", "group": "analysis", "desc": "Wait for nodes matching a query to change and report what changed"},
+		{"name": "check_naming_conventions", "group": "analysis", "desc": "Check component, variable, page and frame names against regex naming rules"},
+		{"name": "get_analytics", "group": "analysis", "desc": "Library component usage across the organization with weekly trends"},
+		{"name": "layout_audit", "group": "analysis", "desc": "Absolute children in auto-layout frames and fixed counter axes without max size"},
+		{"name": "resolve_token_chain", "group": "analysis", "desc": "Follow a variable's alias chain to its raw value"},
+		{"name": "list_versions", "group": "analysis", "desc": "Version history with IDs for diff compare=version"},
+		{"name": "update_variables", "group": "write", "desc": "EXPERIMENTAL: write variable values back to Figma"},
+		{"name": "search_and_replace", "group": "write", "desc": "Preview bulk text replacements across text nodes"},
+		{"name": "post_comment", "group": "write", "desc": "Publish a comment or reply on a file"},
 	}
 
 	var sb strings.Builder
@@ -165,18 +220,22 @@ func infoTools() (string, interface{}) {
 	return sb.String(), tools
 }
 
+// queryProjections describes the built-in @projections for select.
+var queryProjections = map[string][]string{
+	"@structure":  {"id", "name", "type", "visible", "locked", "parent_id"},
+	"@bounds":     {"x", "y", "width", "height", "rotation"},
+	"@css":        {"fills", "strokes", "effects", "cornerRadius", "opacity", "blendMode"},
+	"@layout":     {"layoutMode", "primaryAxisSizingMode", "counterAxisSizingMode", "padding*", "itemSpacing", "constraints"},
+	"@typography": {"fontFamily", "fontSize", "fontWeight", "lineHeight", "letterSpacing", "textAlign*"},
+	"@tokens":     {"boundVariables", "resolvedTokens"},
+	"@images":     {"imageRefs (from fills/strokes/backgrounds)", "exportSettings"},
+	"@children":   {"children (recursive with depth)"},
+	"@summary":    {"summary (one line: [TYPE] 'Name' (WxH) [N children] [fills] [text])"},
+	"@all":        {"All properties including @images"},
+}
+
 func infoProjections() (string, interface{}) {
-	projections := map[string][]string{
-		"@structure":  {"id", "name", "type", "visible", "parent_id"},
-		"@bounds":     {"x", "y", "width", "height", "rotation"},
-		"@css":        {"fills", "strokes", "effects", "cornerRadius", "opacity", "blendMode"},
-		"@layout":     {"layoutMode", "primaryAxisSizingMode", "counterAxisSizingMode", "padding*", "itemSpacing", "constraints"},
-		"@typography": {"fontFamily", "fontSize", "fontWeight", "lineHeight", "letterSpacing", "textAlign*"},
-		"@tokens":     {"boundVariables", "resolvedTokens"},
-		"@images":     {"imageRefs (from fills/strokes/backgrounds)", "exportSettings"},
-		"@children":   {"children (recursive with depth)"},
-		"@all":        {"All properties including @images"},
-	}
+	projections := queryProjections
 
 	var sb strings.Builder
 	sb.WriteString("Built-in Projections\n")
@@ -200,9 +259,10 @@ The query tool accepts a JSON query object with these fields:
 
 {
   "from": "COMPONENT",              // Node type(s) or "#node_id"
+  "path": "FRAME > TEXT",           // Ancestor/sibling selector
   "where": {"name": {"$match": "Button*"}},  // Filter conditions
   "select": ["@css", "@bounds"],    // Properties to include
-  "depth": 2,                       // Child traversal depth
+  "depth": 2,                       // Child traversal depth (-1 = unlimited)
   "limit": 50,                      // Max results
   "offset": 0                       // Skip N results
 }
@@ -212,7 +272,14 @@ FROM clause
 - Single type: "FRAME", "COMPONENT", "TEXT", "INSTANCE"
 - Multiple: ["FRAME", "GROUP"]
 - Specific node: "#1:234"
-- Path expression: "PAGE > FRAME > COMPONENT"
+
+PATH clause
+-----------
+CSS-like selectors evaluated against each node's ancestors:
+- Direct child: "FRAME > TEXT"
+- Any descendant: "PAGE COMPONENT"
+- Adjacent sibling: "FRAME + TEXT"
+- Compound steps: "*", "#1:234", "FRAME[name=Card*]"
 
 SELECT clause
 -------------
@@ -220,12 +287,22 @@ SELECT clause
 - Projections: ["@css", "@layout", "@typography"]
 - Mixed: ["@structure", "effects", "componentId"]
 
+DEPTH
+-----
+Each result includes its children down to "depth" levels:
+- 0 (default): the matched node only
+- 2: children and grandchildren
+- -1: all descendants, however deep
+get_node accepts the same depth values.
+
 See info(topic="operators") for WHERE clause operators.
-See info(topic="projections") for available @projections.`
+See info(topic="projections") for available @projections.
+See info(topic="query_schema") for the JSON Schema of the query object.`
 
 	data := map[string]interface{}{
 		"fields": map[string]string{
-			"from":   "Node type(s), '#id', or path expression",
+			"from":   "Node type(s) or '#id'",
+			"path":   "CSS-like selector with >, + and descendant combinators",
 			"where":  "Filter conditions with operators",
 			"select": "Properties or @projections to include",
 			"depth":  "Child traversal depth (0=node only, -1=unlimited)",
@@ -237,21 +314,40 @@ See info(topic="projections") for available @projections.`
 	return text, data
 }
 
-func infoOperators() (string, interface{}) {
-	operators := map[string]string{
-		"$eq":       "Exact match: {name: {$eq: 'Button'}}",
-		"$match":    "Glob pattern: {name: {$match: 'Button*'}}",
-		"$regex":    "Regex: {name: {$regex: '^Icon-.*'}}",
-		"$contains": "Substring: {name: {$contains: 'primary'}}",
-		"$in":       "Value in array: {type: {$in: ['FRAME', 'GROUP']}}",
-		"$gt":       "Greater than: {width: {$gt: 100}}",
-		"$gte":      "Greater or equal: {opacity: {$gte: 0.5}}",
-		"$lt":       "Less than: {height: {$lt: 50}}",
-		"$lte":      "Less or equal: {cornerRadius: {$lte: 8}}",
-		"$exists":   "Property exists: {fills: {$exists: true}}",
-		"$not":      "Negate: {visible: {$not: false}}",
+// queryOperators describes the WHERE clause operators.
+var queryOperators = map[string]string{
+	"$eq":       "Exact match: {name: {$eq: 'Button'}}",
+	"$match":    "Glob pattern: {name: {$match: 'Button*'}}",
+	"$regex":    "Regex: {name: {$regex: '^Icon-.*'}}",
+	"$contains": "Substring: {name: {$contains: 'primary'}}",
+	"$in":       "Value in array: {type: {$in: ['FRAME', 'GROUP']}}",
+	"$gt":       "Greater than: {width: {$gt: 100}}",
+	"$gte":      "Greater or equal: {opacity: {$gte: 0.5}}",
+	"$lt":       "Less than: {height: {$lt: 50}}",
+	"$lte":      "Less or equal: {cornerRadius: {$lte: 8}}",
+	"$exists":   "Property exists: {fills: {$exists: true}}",
+	"$not":      "Negate: {visible: {$not: false}}",
+	"$fuzzy":    "Approximate match: {name: {$fuzzy: 'Button', $threshold: 0.8}}",
+}
+
+func infoQuerySchema() (string, interface{}) {
+	b, err := json.MarshalIndent(querySchema(), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error generating schema: %v", err), nil
 	}
 
+	var data map[string]any
+	json.Unmarshal(b, &data)
+
+	text := "JSON Schema for the query tool's q argument\n" +
+		"============================================\n\n" + string(b)
+
+	return text, data
+}
+
+func infoOperators() (string, interface{}) {
+	operators := queryOperators
+
 	var sb strings.Builder
 	sb.WriteString("WHERE Clause Operators\n")
 	sb.WriteString("======================\n\n")
@@ -274,9 +370,12 @@ func infoExport() (string, interface{}) {
 sync_file creates a grep-friendly folder structure with assets (enabled by default):
 
 <export_dir>/<file-name>/
-├── _meta.json          # File metadata, export timestamp
+├── _meta.json          # File metadata, export timestamp, sync history
 ├── _tree.txt           # ASCII tree with node IDs
-├── _index.json         # Flat lookup: node_id → path
+├── _index.json         # Flat lookup: node_id → {path, parent_id, depth, page, plugin}
+├── _nodes.db           # bbolt snapshot of every node, read by query/search/diff instead of _node.json files
+├── _manifest.json      # External refs: library components/styles, fonts, image hosts
+├── _thumbnail.png      # File thumbnail (with download_thumbnail=true)
 ├── pages/
 │   └── <page-name>/
 │       └── children/
@@ -284,6 +383,7 @@ sync_file creates a grep-friendly folder structure with assets (enabled by defau
 │               ├── _node.json   # Full node data
 │               ├── _css.json    # CSS properties
 │               ├── _tokens.json # Variable refs
+│               ├── _plugin.json # Plugin data (sync_file plugin_data)
 │               └── children/
 ├── components/
 │   └── _components.json
@@ -314,7 +414,7 @@ find . -name "_css.json" -exec jq . {} \; # All CSS files
 jq '.fills' ./figma-export/**/_node.json  # Extract fills`
 
 	data := map[string]interface{}{
-		"root_files":  []string{"_meta.json", "_tree.txt", "_index.json"},
+		"root_files":  []string{"_meta.json", "_tree.txt", "_index.json", "_nodes.db", "_manifest.json"},
 		"directories": []string{"pages/", "components/", "styles/", "variables/", "assets/"},
 		"assets_subdirs": []string{"fills/", "renders/"},
 	}
@@ -373,12 +473,23 @@ jq '.fills[0].color' ./figma-export/pages/**/_node.json`
 	return text, examples
 }
 
-func infoStatus(r *Registry) (string, interface{}) {
+func infoStatus(ctx context.Context, r *Registry) (string, interface{}) {
 	authStatus := "not configured"
 	authDetail := "Set FIGMA_ACCESS_TOKEN environment variable"
+	var user *figma.User
+	var userErr error
 	if r.HasClient() {
 		authStatus = "configured"
 		authDetail = "Token loaded from environment"
+		user, userErr = r.CurrentUser(ctx)
+		switch {
+		case userErr != nil:
+			authDetail = fmt.Sprintf("Token rejected or API unreachable: %v", userErr)
+		case user.Email != "":
+			authDetail = fmt.Sprintf("Authenticated as %s <%s>", user.Handle, user.Email)
+		default:
+			authDetail = fmt.Sprintf("Authenticated as %s", user.Handle)
+		}
 	}
 
 	text := fmt.Sprintf(`Server Status
@@ -405,6 +516,12 @@ FIGMA_EXPORT_DIR       : %s`, authStatus, authDetail, r.ExportDir(),
 		"export_dir":  r.ExportDir(),
 		"ready":       r.HasClient(),
 	}
+	if user != nil {
+		data["user"] = map[string]string{"id": user.ID, "handle": user.Handle, "email": user.Email}
+	}
+	if userErr != nil {
+		data["user_error"] = userErr.Error()
+	}
 
 	return text, data
 }
@@ -3,10 +3,15 @@ package tools_test
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/analytics"
 	"github.com/standardbeagle/figma-query/internal/figma"
 	"github.com/standardbeagle/figma-query/internal/tools"
 )
@@ -80,6 +85,7 @@ func TestIntegration_ListTools(t *testing.T) {
 		"sync_file",
 		"export_assets",
 		"export_tokens",
+		"download_image",
 		"query",
 		"search",
 		"get_tree",
@@ -90,6 +96,49 @@ func TestIntegration_ListTools(t *testing.T) {
 		"get_tokens",
 		"wireframe",
 		"diff",
+		"render_all_pages",
+		"create_alias",
+		"get_spacing_scale",
+		"list_effects",
+		"token_audit",
+		"export_component_docs",
+		"frame_inventory",
+		"get_node_path",
+		"token_diff",
+		"get_responsive_breakpoints",
+		"update_variables",
+		"find_duplicates",
+		"check_accessibility",
+		"find_orphan_styles",
+		"generate_color_palette",
+		"merge_exports",
+		"get_contrast_pairs",
+		"get_contributors",
+		"search_and_replace",
+		"get_grid_styles",
+		"text_styles_to_css",
+		"component_map",
+		"clean_node_json",
+		"export_sprite",
+		"detect_design_patterns",
+		"validate_export",
+		"watch_query",
+		"check_naming_conventions",
+		"get_analytics",
+		"get_overrides",
+		"layout_audit",
+		"cache_search",
+		"get_component_graph",
+		"capture_baseline",
+		"bookmark",
+		"batch_sync",
+		"export_stories",
+		"find_text",
+		"resolve_token_chain",
+		"compare_to_code",
+		"get_comments",
+		"list_versions",
+		"post_comment",
 	}
 
 	toolNames := make(map[string]bool)
@@ -108,6 +157,51 @@ func TestIntegration_ListTools(t *testing.T) {
 	}
 }
 
+func TestIntegration_ToolInputSchemas(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.ListTools(ctx, &mcp.ListToolsParams{})
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+
+	var syncFile *mcp.Tool
+	for _, tool := range result.Tools {
+		if tool.Name == "sync_file" {
+			syncFile = tool
+		}
+	}
+	if syncFile == nil {
+		t.Fatal("sync_file tool not found")
+	}
+
+	data, err := json.Marshal(syncFile.InputSchema)
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Enum []string `json:"enum"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	if len(schema.Required) != 1 || schema.Required[0] != "file_key" {
+		t.Errorf("expected required [file_key], got %v", schema.Required)
+	}
+	if enum := schema.Properties["format"].Enum; len(enum) != 2 {
+		t.Errorf("expected format enum [text json], got %v", enum)
+	}
+}
+
 func TestIntegration_InfoTool_Overview(t *testing.T) {
 	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
 	session := testServer(t, registry)
@@ -364,6 +458,81 @@ func TestIntegration_QueryTool_MissingFileKey(t *testing.T) {
 	}
 }
 
+func TestIntegration_QueryTool_FromString(t *testing.T) {
+	exportDir := testExportDir(t)
+	cacheDir := filepath.Join(exportDir, "site")
+	writeTestJSON(t, filepath.Join(cacheDir, "_meta.json"), map[string]any{"fileKey": "KEY1", "name": "Site"})
+	writeTestJSON(t, filepath.Join(cacheDir, "pages/home/children/card", "_node.json"), map[string]any{"id": "1:1", "name": "Card", "type": "FRAME"})
+	writeTestJSON(t, filepath.Join(cacheDir, "pages/home/children/label", "_node.json"), map[string]any{"id": "1:2", "name": "Label", "type": "TEXT"})
+
+	registry := tools.NewRegistry(nil, exportDir)
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// "from" accepts a single node type as well as a list of them.
+	var outputs []string
+	for _, from := range []any{"FRAME", []string{"FRAME"}} {
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      "query",
+			Arguments: map[string]any{"file_key": "KEY1", "q": map[string]any{"from": from}, "from_cache": true, "format": "json"},
+		})
+		if err != nil {
+			t.Fatalf("CallTool(query, from=%v) failed: %v", from, err)
+		}
+		if result.IsError {
+			t.Fatalf("query(from=%v) returned error: %v", from, result.Content[0].(*mcp.TextContent).Text)
+		}
+		text := result.Content[0].(*mcp.TextContent).Text
+		if !containsSubstring(text, "1:1") || containsSubstring(text, "1:2") {
+			t.Errorf("query(from=%v) should match only the frame, got:\n%s", from, text)
+		}
+		outputs = append(outputs, text)
+	}
+	if outputs[0] != outputs[1] {
+		t.Errorf("from=\"FRAME\" and from=[\"FRAME\"] differ:\n%s\n---\n%s", outputs[0], outputs[1])
+	}
+}
+
+// TestIntegration_NegativeLimit checks that limit -1 falls back to the
+// default instead of panicking when results are truncated.
+func TestIntegration_NegativeLimit(t *testing.T) {
+	exportDir := testExportDir(t)
+	cacheDir := filepath.Join(exportDir, "site")
+	writeTestJSON(t, filepath.Join(cacheDir, "_meta.json"), map[string]any{"fileKey": "KEY1", "name": "Site"})
+	writeTestJSON(t, filepath.Join(cacheDir, "pages/home/children/label", "_node.json"), map[string]any{
+		"id": "1:2", "name": "Label", "type": "TEXT", "characters": "Label",
+	})
+
+	registry := tools.NewRegistry(nil, exportDir)
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, tt := range []struct {
+		name string
+		args map[string]any
+	}{
+		{"search", map[string]any{"file_key": "KEY1", "pattern": "Label"}},
+		{"find_text", map[string]any{"file_key": "KEY1", "contains": "label"}},
+		{"check_accessibility", map[string]any{"file_key": "KEY1"}},
+		{"get_contrast_pairs", map[string]any{"file_key": "KEY1"}},
+		{"layout_audit", map[string]any{"file_key": "KEY1"}},
+		{"cache_search", map[string]any{"pattern": "Label"}},
+	} {
+		tt.args["limit"] = -1
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tt.name, Arguments: tt.args})
+		if err != nil {
+			t.Fatalf("CallTool(%s) failed: %v", tt.name, err)
+		}
+		if result.IsError {
+			t.Errorf("%s with limit -1 returned error: %v", tt.name, result.Content[0].(*mcp.TextContent).Text)
+		}
+	}
+}
+
 func TestIntegration_ToolsRequireAPIorCache(t *testing.T) {
 	// Test that tools properly return errors when no API or cache is available
 	registry := tools.NewRegistry(nil, testExportDir(t))
@@ -408,6 +577,24 @@ func TestIntegration_ToolsRequireAPIorCache(t *testing.T) {
 				"file_key": "test123",
 			},
 		},
+		{
+			name: "get_grid_styles",
+			args: map[string]any{
+				"file_key": "test123",
+			},
+		},
+		{
+			name: "text_styles_to_css",
+			args: map[string]any{
+				"file_key": "test123",
+			},
+		},
+		{
+			name: "get_contributors",
+			args: map[string]any{
+				"file_key": "test123",
+			},
+		},
 	}
 
 	for _, tc := range toolsRequiringData {
@@ -673,24 +860,1183 @@ func TestIntegration_SyncFileTool_MissingArgs(t *testing.T) {
 	}
 }
 
-func TestIntegration_RegistryWithClient(t *testing.T) {
-	// Test that HasClient returns correct values
-	withoutClient := tools.NewRegistry(nil, testExportDir(t))
-	if withoutClient.HasClient() {
-		t.Error("expected HasClient() to return false when no client provided")
+func TestIntegration_RenderAllPagesTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "render_all_pages",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing render_all_pages arguments")
 	}
+}
 
-	// We can't easily create a real client without a token,
-	// but we can test the nil case works
-	if withoutClient.Client() != nil {
-		t.Error("expected Client() to return nil when no client provided")
+func TestIntegration_GetSpacingScaleTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_spacing_scale",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_spacing_scale arguments")
 	}
+}
 
-	// Test ExportDir returns the correct value
+func TestIntegration_GetSpacingScaleTool_Cached(t *testing.T) {
 	exportDir := testExportDir(t)
+	cacheDir := filepath.Join(exportDir, "site")
+	writeTestJSON(t, filepath.Join(cacheDir, "_meta.json"), map[string]any{"fileKey": "KEY1", "name": "Site"})
+	writeTestJSON(t, filepath.Join(cacheDir, "pages/home/children/card", "_node.json"), map[string]any{
+		"id": "1:1", "name": "Card", "type": "FRAME", "layoutMode": "VERTICAL", "itemSpacing": 8,
+	})
+
 	registry := tools.NewRegistry(nil, exportDir)
-	if registry.ExportDir() != exportDir {
-		t.Errorf("expected ExportDir() to return %q, got %q", exportDir, registry.ExportDir())
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_spacing_scale",
+		Arguments: map[string]any{"file_key": "KEY1"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(get_spacing_scale) failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("get_spacing_scale returned error: %v", result.Content)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !containsSubstring(text, "(from cache)") {
+		t.Errorf("text output should say it was read from the cache, got:\n%s", text)
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_spacing_scale",
+		Arguments: map[string]any{"file_key": "KEY1", "format": "json"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(get_spacing_scale) failed: %v", err)
+	}
+	var out struct {
+		Cached bool `json:"cached"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("parsing json output: %v", err)
+	}
+	if !out.Cached {
+		t.Error("cached = false, want true for a sync_file export")
+	}
+}
+
+func TestIntegration_ListEffectsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "list_effects",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing list_effects arguments")
+	}
+}
+
+func TestIntegration_TokenAuditTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "token_audit",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing token_audit arguments")
+	}
+}
+
+func TestIntegration_ExportComponentDocsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "export_component_docs",
+		Arguments: map[string]any{"file_key": "abc"},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing export_component_docs arguments")
+	}
+}
+
+func TestIntegration_FrameInventoryTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "frame_inventory",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing frame_inventory arguments")
+	}
+}
+
+func TestIntegration_GetNodePathTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_node_path",
+		Arguments: map[string]any{"file_key": "abc"},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_node_path arguments")
+	}
+}
+
+func TestIntegration_TokenDiffTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "token_diff",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing token_diff arguments")
+	}
+}
+
+func TestIntegration_GetResponsiveBreakpointsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_responsive_breakpoints",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_responsive_breakpoints arguments")
+	}
+}
+
+func TestIntegration_UpdateVariablesTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "update_variables",
+		Arguments: map[string]any{
+			"file_key": "abc",
+			"changes":  []map[string]any{{"variableId": "VariableID:1:2"}},
+		},
+	})
+
+	// Should return error for a change without modeId and value
+	if err == nil && !result.IsError {
+		t.Fatal("expected error for incomplete update_variables change")
+	}
+}
+
+func TestIntegration_FindDuplicatesTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "find_duplicates",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing find_duplicates arguments")
+	}
+}
+
+func TestIntegration_CheckAccessibilityTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "check_accessibility",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing check_accessibility arguments")
+	}
+}
+
+func TestIntegration_FindOrphanStylesTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "find_orphan_styles",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing find_orphan_styles arguments")
+	}
+}
+
+func TestIntegration_GenerateColorPaletteTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "generate_color_palette",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing generate_color_palette arguments")
+	}
+}
+
+func TestIntegration_MergeExportsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "merge_exports",
+		Arguments: map[string]any{"source_dir": "a"},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing merge_exports arguments")
+	}
+}
+
+func TestIntegration_GetContrastPairsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_contrast_pairs",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_contrast_pairs arguments")
+	}
+}
+
+func TestIntegration_SearchAndReplaceTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search_and_replace",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing search_and_replace arguments")
+	}
+}
+
+func TestIntegration_SearchAndReplaceTool_Alias(t *testing.T) {
+	exportDir := testExportDir(t)
+	cacheDir := filepath.Join(exportDir, "site")
+	writeTestJSON(t, filepath.Join(cacheDir, "_meta.json"), map[string]any{"fileKey": "KEY1", "name": "Site"})
+	for _, frame := range []struct{ dir, id, textID string }{
+		{"pages/home/children/hero", "1:1", "1:2"},
+		{"pages/home/children/footer", "2:1", "2:2"},
+	} {
+		writeTestJSON(t, filepath.Join(cacheDir, frame.dir, "_node.json"), map[string]any{
+			"id": frame.id, "name": "Frame", "type": "FRAME",
+			"children": []map[string]any{{"id": frame.textID, "name": "Label", "type": "TEXT", "characters": "Lorem ipsum"}},
+		})
+	}
+
+	registry := tools.NewRegistry(nil, exportDir)
+	aliases := tools.NewAliasStore(filepath.Join(t.TempDir(), "aliases.json"))
+	if _, err := aliases.Set("hero", "1:1"); err != nil {
+		t.Fatal(err)
+	}
+	registry.SetAliases(aliases)
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search_and_replace",
+		Arguments: map[string]any{"file_key": "KEY1", "pattern": "Lorem*", "replacement": "Hello", "node_ids": []string{"hero"}, "dry_run": true},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(search_and_replace) failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("search_and_replace returned error: %v", result.Content)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !containsSubstring(text, "1:2") || containsSubstring(text, "2:2") {
+		t.Errorf("alias should limit the replacement to the hero frame, got:\n%s", text)
+	}
+}
+
+func TestIntegration_ComponentMapTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "component_map",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing component_map arguments")
+	}
+}
+
+func TestIntegration_CleanNodeJSONTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "clean_node_json",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing clean_node_json arguments")
+	}
+}
+
+func TestIntegration_ExportSpriteTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "export_sprite",
+		Arguments: map[string]any{"file_key": "test"},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing export_sprite arguments")
+	}
+}
+
+func TestIntegration_DetectDesignPatternsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "detect_design_patterns",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing detect_design_patterns arguments")
+	}
+}
+
+func TestIntegration_DefaultFileKey(t *testing.T) {
+	exportDir := testExportDir(t)
+	writeTestJSON(t, filepath.Join(exportDir, "app", "_meta.json"), map[string]any{"fileKey": "KEY2", "name": "Mobile App"})
+	writeTestJSON(t, filepath.Join(exportDir, "app", "pages", "logo", "_node.json"),
+		map[string]any{"id": "5:6", "name": "Brand Logo", "type": "COMPONENT"})
+
+	registry := tools.NewRegistry(mockFigmaClient(), exportDir)
+	registry.SetFileDiscovery(tools.FileDiscovery{TeamID: "42", DefaultFileKey: "KEY2"})
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search",
+		Arguments: map[string]any{"pattern": "Brand Logo", "format": "json"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("search without file_key failed: %v %+v", err, result)
+	}
+	var out tools.SearchResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(out.Results) != 1 || out.Results[0].NodeID != "5:6" {
+		t.Errorf("expected the default file's node, got %+v", out.Results)
+	}
+
+	// Tools without file_key are left alone
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "info",
+		Arguments: map[string]any{},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("info failed: %v %+v", err, result)
+	}
+}
+
+func TestIntegration_TeamWithoutDefaultFileKey(t *testing.T) {
+	registry := tools.NewRegistry(nil, testExportDir(t))
+	registry.SetFileDiscovery(tools.FileDiscovery{TeamID: "42"})
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search",
+		Arguments: map[string]any{"pattern": "Logo"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "file_key is required") {
+		t.Errorf("expected file_key error, got %+v", result)
+	}
+}
+
+func TestIntegration_ValidateExportTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "validate_export",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing validate_export arguments")
+	}
+}
+
+func TestIntegration_WatchQueryTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "watch_query",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing watch_query arguments")
+	}
+}
+
+func TestIntegration_CheckNamingConventionsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "check_naming_conventions",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing check_naming_conventions arguments")
+	}
+}
+
+func TestIntegration_GetAnalyticsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_analytics",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_analytics arguments")
+	}
+}
+
+func TestIntegration_GetOverridesTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_overrides",
+		Arguments: map[string]any{"file_key": "test"},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_overrides arguments")
+	}
+}
+
+func TestIntegration_LayoutAuditTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "layout_audit",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing layout_audit arguments")
+	}
+}
+
+func TestIntegration_CacheSearchTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(nil, testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "cache_search",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing cache_search arguments")
+	}
+}
+
+func TestIntegration_GetComponentGraphTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_component_graph",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_component_graph arguments")
+	}
+}
+
+func TestIntegration_CaptureBaselineTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "capture_baseline",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing capture_baseline arguments")
+	}
+}
+
+func TestIntegration_BatchSyncTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "batch_sync",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing batch_sync arguments")
+	}
+}
+
+func TestIntegration_ExportStoriesTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "export_stories",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing export_stories arguments")
+	}
+}
+
+func TestIntegration_FindTextTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "find_text",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing find_text arguments")
+	}
+}
+
+func TestIntegration_ResolveTokenChainTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "resolve_token_chain",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing resolve_token_chain arguments")
+	}
+}
+
+func TestIntegration_CompareToCodeTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "compare_to_code",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing compare_to_code arguments")
+	}
+}
+
+func TestIntegration_GetCommentsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_comments",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing get_comments arguments")
+	}
+}
+
+func TestIntegration_PostCommentTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "post_comment",
+		Arguments: map[string]any{"file_key": "KEY1"},
+	})
+
+	// Should return error without a message to post
+	if err == nil {
+		t.Fatal("expected error for post_comment without message")
+	}
+}
+
+func TestIntegration_ListVersionsTool_MissingArgs(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "list_versions",
+		Arguments: map[string]any{},
+	})
+
+	// Should return error for missing required arguments
+	if err == nil {
+		t.Fatal("expected error for missing list_versions arguments")
+	}
+}
+
+func TestIntegration_SearchTool_AllCaches(t *testing.T) {
+	exportDir := testExportDir(t)
+	for _, f := range []struct{ dir, key, name, nodeID string }{
+		{"marketing", "KEY1", "Marketing Site", "1:2"},
+		{"app", "KEY2", "Mobile App", "5:6"},
+	} {
+		writeTestJSON(t, filepath.Join(exportDir, f.dir, "_meta.json"), map[string]any{"fileKey": f.key, "name": f.name})
+		writeTestJSON(t, filepath.Join(exportDir, f.dir, "pages", "logo", "_node.json"),
+			map[string]any{"id": f.nodeID, "name": "Brand Logo", "type": "COMPONENT"})
+	}
+
+	registry := tools.NewRegistry(mockFigmaClient(), exportDir)
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search",
+		Arguments: map[string]any{"file_key": "*", "pattern": "Brand Logo", "format": "json"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("search failed: %v %+v", err, result)
+	}
+
+	var out tools.SearchResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	files := map[string]string{}
+	for _, m := range out.Results {
+		files[m.FileKey] = m.FileName
+	}
+	if len(out.Results) != 2 || files["KEY1"] != "Marketing Site" || files["KEY2"] != "Mobile App" {
+		t.Errorf("expected one match per file, got %+v", out.Results)
+	}
+}
+
+func TestIntegration_SearchTool_Cursor(t *testing.T) {
+	exportDir := testExportDir(t)
+	for _, f := range []struct{ dir, key, name, nodeID string }{
+		{"marketing", "KEY1", "Marketing Site", "1:2"},
+		{"app", "KEY2", "Mobile App", "5:6"},
+	} {
+		writeTestJSON(t, filepath.Join(exportDir, f.dir, "_meta.json"), map[string]any{"fileKey": f.key, "name": f.name})
+		writeTestJSON(t, filepath.Join(exportDir, f.dir, "pages", "logo", "_node.json"),
+			map[string]any{"id": f.nodeID, "name": "Brand Logo", "type": "COMPONENT"})
+	}
+
+	registry := tools.NewRegistry(mockFigmaClient(), exportDir)
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	search := func(cursor string) tools.SearchResult {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      "search",
+			Arguments: map[string]any{"file_key": "*", "pattern": "Brand Logo", "limit": 1, "cursor": cursor, "format": "json"},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("search failed: %v %+v", err, result)
+		}
+		var out tools.SearchResult
+		if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return out
+	}
+
+	first := search("")
+	if len(first.Results) != 1 || !first.HasMore || first.NextCursor == "" {
+		t.Fatalf("first page = %+v", first)
+	}
+	second := search(first.NextCursor)
+	if len(second.Results) != 1 || second.HasMore || second.NextCursor != "" {
+		t.Fatalf("second page = %+v", second)
+	}
+	if first.Results[0].FileKey == second.Results[0].FileKey {
+		t.Errorf("pages returned the same match: %+v, %+v", first.Results[0], second.Results[0])
+	}
+}
+
+func writeTestJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(v)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIntegration_CreateAliasTool(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	registry.SetAliases(tools.NewAliasStore(filepath.Join(t.TempDir(), "aliases.json")))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "create_alias",
+		Arguments: map[string]any{"name": "hero-button", "node_id": "1:2345"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(create_alias) failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("create_alias returned error: %v", result.Content)
+	}
+
+	if got := registry.ResolveNodeID("hero-button"); got != "1:2345" {
+		t.Errorf("ResolveNodeID(hero-button) = %q, want 1:2345", got)
+	}
+	if got := registry.ResolveNodeID("9:9"); got != "9:9" {
+		t.Errorf("ResolveNodeID(9:9) = %q, want unchanged", got)
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "info",
+		Arguments: map[string]any{"topic": "list_aliases"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(info) failed: %v", err)
+	}
+
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected TextContent, got %T", result.Content[0])
+	}
+	if !containsSubstring(textContent.Text, "hero-button") || !containsSubstring(textContent.Text, "1:2345") {
+		t.Errorf("list_aliases should show the alias, got:\n%s", textContent.Text)
+	}
+}
+
+// TestIntegration_SessionPathsConfined checks that a per-token session
+// registry keeps path arguments and aliases inside its export directory.
+func TestIntegration_SessionPathsConfined(t *testing.T) {
+	exportDir := testExportDir(t)
+	sessionDir := filepath.Join(exportDir, "token-abc")
+	writeTestJSON(t, filepath.Join(sessionDir, "site", "_meta.json"), map[string]any{"fileKey": "KEY1", "name": "Site"})
+	writeTestJSON(t, filepath.Join(sessionDir, "site", "pages/home/children/label", "_node.json"), map[string]any{
+		"id": "1:2", "name": "Label", "type": "TEXT", "characters": "Sign in",
+	})
+	writeTestJSON(t, filepath.Join(exportDir, "other", "_meta.json"), map[string]any{"fileKey": "KEY2", "name": "Other"})
+
+	registry := tools.NewRegistry(nil, exportDir).WithClient(figma.NewClient("session-token"), sessionDir)
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	call := func(name string, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		if err != nil {
+			t.Fatalf("CallTool(%s) failed: %v", name, err)
+		}
+		return result
+	}
+
+	// Relative paths resolve inside the session's export directory.
+	result := call("cache_search", map[string]any{"pattern": "Sign in", "search_in": "characters", "export_dir": "site"})
+	if result.IsError {
+		t.Fatalf("cache_search in the session directory returned error: %v", result.Content)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !containsSubstring(text, "1:2") {
+		t.Errorf("cache_search should find the session's export, got:\n%s", text)
+	}
+
+	for _, tt := range []struct {
+		name string
+		args map[string]any
+	}{
+		{"cache_search", map[string]any{"pattern": "*", "export_dir": "../other"}},
+		{"compare_to_code", map[string]any{"file_key": "KEY1", "node_ids": []string{"1:2"}, "css_file": "/etc/passwd"}},
+		{"merge_exports", map[string]any{"source_dir": exportDir + "/other", "target_dir": "site"}},
+	} {
+		result := call(tt.name, tt.args)
+		if !result.IsError {
+			t.Errorf("%s with a path outside the session directory succeeded", tt.name)
+			continue
+		}
+		if text := result.Content[0].(*mcp.TextContent).Text; !containsSubstring(text, "outside the session export directory") {
+			t.Errorf("%s error = %q, want it to name the session directory", tt.name, text)
+		}
+	}
+
+	// Aliases persist to the session directory, not the home directory.
+	if result := call("create_alias", map[string]any{"name": "label", "node_id": "1:2"}); result.IsError {
+		t.Fatalf("create_alias returned error: %v", result.Content)
+	}
+	if _, err := os.Stat(registry.Aliases().Path()); err != nil || filepath.Dir(registry.Aliases().Path()) != sessionDir {
+		t.Errorf("alias file %q not written to the session directory: %v", registry.Aliases().Path(), err)
+	}
+}
+
+func TestIntegration_BookmarkTool(t *testing.T) {
+	exportDir := testExportDir(t)
+	cacheDir := filepath.Join(exportDir, "site")
+	writeTestJSON(t, filepath.Join(cacheDir, "_meta.json"), map[string]any{"fileKey": "KEY1", "name": "Site"})
+	for id, node := range map[string]struct{ dir, parent, name, typ string }{
+		"0:1": {"pages/home", "", "Home", "CANVAS"},
+		"1:2": {"pages/home/children/logo", "0:1", "Logo", "FRAME"},
+	} {
+		writeTestJSON(t, filepath.Join(cacheDir, node.dir, "_node.json"), map[string]any{"id": id, "name": node.name, "type": node.typ})
+	}
+	writeTestJSON(t, filepath.Join(cacheDir, "_index.json"), map[string]any{
+		"0:1": map[string]any{"path": filepath.Join(cacheDir, "pages/home"), "depth": 0, "page": "Home"},
+		"1:2": map[string]any{"path": filepath.Join(cacheDir, "pages/home/children/logo"), "parent_id": "0:1", "depth": 1, "page": "Home"},
+	})
+
+	registry := tools.NewRegistry(nil, exportDir)
+	bookmarkPath := filepath.Join(t.TempDir(), "bookmarks.json")
+	registry.SetBookmarks(tools.NewBookmarkStore(bookmarkPath))
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "bookmark",
+		Arguments: map[string]any{"name": "logo", "file_key": "KEY1", "node_id": "1:2", "persist": true},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(bookmark) failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("bookmark returned error: %v", result.Content)
+	}
+	if _, err := os.Stat(bookmarkPath); err != nil {
+		t.Errorf("persisted bookmark file missing: %v", err)
+	}
+
+	// The bookmark stands in for file_key and node_id.
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_node_path",
+		Arguments: map[string]any{"bookmark": "logo"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(get_node_path) failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("get_node_path returned error: %v", result.Content)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !containsSubstring(text, "Home") || !containsSubstring(text, "Logo") {
+		t.Errorf("expected path Home > Logo, got:\n%s", text)
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_node_path",
+		Arguments: map[string]any{"bookmark": "missing"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool(get_node_path) failed: %v", err)
+	}
+	if !result.IsError || !containsSubstring(result.Content[0].(*mcp.TextContent).Text, "unknown bookmark") {
+		t.Errorf("expected unknown bookmark error, got %v", result.Content)
+	}
+
+	// A new store reads the persisted bookmark.
+	b, ok, err := tools.NewBookmarkStore(bookmarkPath).Get("logo")
+	if err != nil || !ok || b.FileKey != "KEY1" || b.NodeID != "1:2" {
+		t.Errorf("persisted bookmark = %+v, %v, %v", b, ok, err)
+	}
+}
+
+func TestIntegration_RegistryWithClient(t *testing.T) {
+	// Test that HasClient returns correct values
+	withoutClient := tools.NewRegistry(nil, testExportDir(t))
+	if withoutClient.HasClient() {
+		t.Error("expected HasClient() to return false when no client provided")
+	}
+
+	// We can't easily create a real client without a token,
+	// but we can test the nil case works
+	if withoutClient.Client() != nil {
+		t.Error("expected Client() to return nil when no client provided")
+	}
+
+	// Test ExportDir returns the correct value
+	exportDir := testExportDir(t)
+	registry := tools.NewRegistry(nil, exportDir)
+	if registry.ExportDir() != exportDir {
+		t.Errorf("expected ExportDir() to return %q, got %q", exportDir, registry.ExportDir())
+	}
+}
+
+// recordingAnalytics captures analytics events in memory.
+type recordingAnalytics struct {
+	mu     sync.Mutex
+	events []analytics.Event
+}
+
+func (r *recordingAnalytics) Record(event analytics.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestIntegration_AnalyticsRecordsToolCalls(t *testing.T) {
+	registry := tools.NewRegistry(mockFigmaClient(), testExportDir(t))
+	recorder := &recordingAnalytics{}
+	registry.SetAnalytics(recorder)
+	session := testServer(t, registry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "info",
+		Arguments: map[string]any{},
+	}); err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+
+	// Handler error: no API client and no cache
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "list_components",
+		Arguments: map[string]any{"file_key": "abc123"},
+	}); err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+
+	// Non-tool requests are not recorded
+	if _, err := session.ListTools(ctx, &mcp.ListToolsParams{}); err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	if len(recorder.events) != 2 {
+		t.Fatalf("expected 2 events, got %d: %+v", len(recorder.events), recorder.events)
+	}
+	if recorder.events[0].Tool != "info" || recorder.events[0].Error {
+		t.Errorf("unexpected first event: %+v", recorder.events[0])
+	}
+	if recorder.events[1].Tool != "list_components" || !recorder.events[1].Error {
+		t.Errorf("unexpected second event: %+v", recorder.events[1])
 	}
 }
 
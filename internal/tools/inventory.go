@@ -0,0 +1,317 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// FrameInventoryArgs contains arguments for the frame_inventory tool.
+type FrameInventoryArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	SortBy  string `json:"sort_by,omitempty" jsonschema:"Row order: page (default, document order), name or width"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// FrameInfo summarizes one top-level frame.
+type FrameInfo struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Page           string  `json:"page"`
+	Width          float64 `json:"width"`
+	Height         float64 `json:"height"`
+	ComponentCount int     `json:"componentCount"`
+	TextLayerCount int     `json:"textLayerCount"`
+}
+
+// FrameInventoryResult contains the result of frame_inventory.
+type FrameInventoryResult struct {
+	Frames []FrameInfo `json:"frames"`
+	Pages  int         `json:"pages"`
+}
+
+func registerFrameInventoryTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "frame_inventory",
+		Description: "List top-level frames on every page with dimensions and counts of direct component instances and text layers.",
+		InputSchema: inputSchema[FrameInventoryArgs](map[string][]string{
+			"format":  responseFormats,
+			"sort_by": {"page", "name", "width"},
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args FrameInventoryArgs) (*mcp.CallToolResult, *FrameInventoryResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		// Pages → frames → frame children; the last level feeds the counts
+		file, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{Depth: 3})
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+		if file.Document == nil {
+			return nil, nil, fmt.Errorf("file %s has no document", args.FileKey)
+		}
+
+		result := buildFrameInventory(file.Document)
+		sortFrameInventory(result.Frames, args.SortBy)
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatFrameInventoryResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+func buildFrameInventory(doc *figma.DocumentNode) *FrameInventoryResult {
+	result := &FrameInventoryResult{Frames: []FrameInfo{}}
+
+	for _, page := range doc.Children {
+		if page.Type != figma.NodeTypeCanvas {
+			continue
+		}
+		result.Pages++
+
+		for _, frame := range page.Children {
+			if frame.Type != figma.NodeTypeFrame {
+				continue
+			}
+
+			info := FrameInfo{ID: frame.ID, Name: frame.Name, Page: page.Name}
+			if frame.AbsoluteBoundingBox != nil {
+				info.Width = frame.AbsoluteBoundingBox.Width
+				info.Height = frame.AbsoluteBoundingBox.Height
+			}
+			for _, child := range frame.Children {
+				switch child.Type {
+				case figma.NodeTypeInstance, figma.NodeTypeComponent, figma.NodeTypeComponentSet:
+					info.ComponentCount++
+				case figma.NodeTypeText:
+					info.TextLayerCount++
+				}
+			}
+
+			result.Frames = append(result.Frames, info)
+		}
+	}
+
+	return result
+}
+
+func sortFrameInventory(frames []FrameInfo, sortBy string) {
+	switch sortBy {
+	case "name":
+		sort.SliceStable(frames, func(i, j int) bool {
+			return strings.ToLower(frames[i].Name) < strings.ToLower(frames[j].Name)
+		})
+	case "width":
+		sort.SliceStable(frames, func(i, j int) bool {
+			return frames[i].Width > frames[j].Width
+		})
+	}
+}
+
+func formatFrameInventoryResult(r *FrameInventoryResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Frame inventory: %d frames on %d pages\n\n", len(r.Frames), r.Pages))
+
+	if len(r.Frames) == 0 {
+		sb.WriteString("No top-level frames found.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("ID         | Size        | Comp | Text | Page / Frame\n")
+	sb.WriteString("---------- | ----------- | ---- | ---- | ------------\n")
+
+	for _, f := range r.Frames {
+		sb.WriteString(fmt.Sprintf("%-10s | %-11s | %-4d | %-4d | %s / %s\n",
+			f.ID, fmt.Sprintf("%.0fx%.0f", f.Width, f.Height), f.ComponentCount, f.TextLayerCount, f.Page, f.Name))
+	}
+
+	return sb.String()
+}
+
+// GetResponsiveBreakpointsArgs contains arguments for the get_responsive_breakpoints tool.
+type GetResponsiveBreakpointsArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// BreakpointFrame is one frame within a responsive group.
+type BreakpointFrame struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Device string  `json:"device"` // mobile, tablet or desktop, from width
+}
+
+// BreakpointGroup is a set of frames showing one screen at different widths.
+type BreakpointGroup struct {
+	Name   string            `json:"name"`
+	Page   string            `json:"page"`
+	Frames []BreakpointFrame `json:"frames"`
+}
+
+// GetResponsiveBreakpointsResult contains the result of get_responsive_breakpoints.
+type GetResponsiveBreakpointsResult struct {
+	Groups []BreakpointGroup `json:"groups"`
+}
+
+func registerGetResponsiveBreakpointsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_responsive_breakpoints",
+		Description: "Group top-level frames that show the same screen at different widths (e.g. Login - Mobile / Tablet / Desktop).",
+		InputSchema: inputSchema[GetResponsiveBreakpointsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetResponsiveBreakpointsArgs) (*mcp.CallToolResult, *GetResponsiveBreakpointsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		// Pages and their top-level frames
+		file, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{Depth: 2})
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+		if file.Document == nil {
+			return nil, nil, fmt.Errorf("file %s has no document", args.FileKey)
+		}
+
+		result := &GetResponsiveBreakpointsResult{
+			Groups: groupBreakpoints(buildFrameInventory(file.Document).Frames),
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatBreakpointsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// breakpointSuffix matches a trailing device name or width, e.g. " - Mobile",
+// "/Desktop", " (1440)", "@768px".
+var breakpointSuffix = regexp.MustCompile(`(?i)[\s\-–—_/|@(]*\b(mobile|phone|iphone(\s*\d+)?|android|tablet|ipad|desktop|laptop|web|xs|sm|md|lg|xl|\d{3,4}\s*(px|w)?)\)?\s*$`)
+
+// breakpointPrefix matches a leading device name, e.g. "Mobile / Login".
+var breakpointPrefix = regexp.MustCompile(`(?i)^(mobile|tablet|desktop)\s*[\-–—_/|]\s*`)
+
+// breakpointBaseName strips device and width markers from a frame name.
+// ok is false when the name carries no such marker.
+func breakpointBaseName(name string) (string, bool) {
+	base := breakpointSuffix.ReplaceAllString(name, "")
+	if base == name {
+		base = breakpointPrefix.ReplaceAllString(name, "")
+	}
+	base = strings.TrimSpace(base)
+	if base == "" || base == strings.TrimSpace(name) {
+		return name, false
+	}
+	return base, true
+}
+
+// deviceForWidth classifies a frame width into a common device class.
+func deviceForWidth(width float64) string {
+	switch {
+	case width < 600:
+		return "mobile"
+	case width < 1024:
+		return "tablet"
+	default:
+		return "desktop"
+	}
+}
+
+// groupBreakpoints groups frames on the same page by base name. Only groups
+// with at least two frames are returned.
+func groupBreakpoints(frames []FrameInfo) []BreakpointGroup {
+	type groupKey struct{ page, name string }
+	groups := make(map[groupKey]*BreakpointGroup)
+	var order []groupKey
+
+	for _, f := range frames {
+		base, ok := breakpointBaseName(f.Name)
+		if !ok {
+			continue
+		}
+		key := groupKey{f.Page, strings.ToLower(base)}
+		g, exists := groups[key]
+		if !exists {
+			g = &BreakpointGroup{Name: base, Page: f.Page}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.Frames = append(g.Frames, BreakpointFrame{
+			ID:     f.ID,
+			Name:   f.Name,
+			Width:  f.Width,
+			Height: f.Height,
+			Device: deviceForWidth(f.Width),
+		})
+	}
+
+	result := []BreakpointGroup{}
+	for _, key := range order {
+		g := groups[key]
+		if len(g.Frames) < 2 {
+			continue
+		}
+		sort.SliceStable(g.Frames, func(i, j int) bool {
+			return g.Frames[i].Width < g.Frames[j].Width
+		})
+		result = append(result, *g)
+	}
+	return result
+}
+
+func formatBreakpointsResult(r *GetResponsiveBreakpointsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Found %d responsive frame groups\n\n", len(r.Groups)))
+
+	if len(r.Groups) == 0 {
+		sb.WriteString("No frames share a name across device or width suffixes.\n")
+		return sb.String()
+	}
+
+	for _, g := range r.Groups {
+		sb.WriteString(fmt.Sprintf("%s / %s\n", g.Page, g.Name))
+		for _, f := range g.Frames {
+			sb.WriteString(fmt.Sprintf("  %-8s %5.0fpx  [%s] %s\n", f.Device, f.Width, f.ID, f.Name))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
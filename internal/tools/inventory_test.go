@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestBuildFrameInventory(t *testing.T) {
+	doc := &figma.DocumentNode{
+		Children: []*figma.Node{
+			{ID: "0:1", Name: "Screens", Type: figma.NodeTypeCanvas, Children: []*figma.Node{
+				{ID: "1:1", Name: "Login", Type: figma.NodeTypeFrame,
+					AbsoluteBoundingBox: &figma.Rectangle{Width: 375, Height: 812},
+					Children: []*figma.Node{
+						{ID: "1:2", Type: figma.NodeTypeInstance},
+						{ID: "1:3", Type: figma.NodeTypeText},
+						{ID: "1:4", Type: figma.NodeTypeText},
+					}},
+				{ID: "1:5", Name: "Note", Type: figma.NodeTypeText},
+				{ID: "1:6", Name: "Dashboard", Type: figma.NodeTypeFrame,
+					AbsoluteBoundingBox: &figma.Rectangle{Width: 1440, Height: 900}},
+			}},
+		},
+	}
+
+	result := buildFrameInventory(doc)
+	if result.Pages != 1 || len(result.Frames) != 2 {
+		t.Fatalf("got %d pages / %d frames, want 1 / 2", result.Pages, len(result.Frames))
+	}
+
+	login := result.Frames[0]
+	if login.ComponentCount != 1 || login.TextLayerCount != 2 || login.Width != 375 {
+		t.Errorf("login = %+v", login)
+	}
+
+	tests := []struct {
+		sortBy string
+		first  string
+	}{
+		{"", "Login"},
+		{"name", "Dashboard"},
+		{"width", "Dashboard"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			frames := append([]FrameInfo(nil), result.Frames...)
+			sortFrameInventory(frames, tt.sortBy)
+			if frames[0].Name != tt.first {
+				t.Errorf("first frame = %s, want %s", frames[0].Name, tt.first)
+			}
+		})
+	}
+}
+
+func TestBreakpointBaseName(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		ok   bool
+	}{
+		{"Login - Mobile", "Login", true},
+		{"Login – Desktop", "Login", true},
+		{"Checkout/Tablet", "Checkout", true},
+		{"Home (1440)", "Home", true},
+		{"Home @768px", "Home", true},
+		{"Mobile / Settings", "Settings", true},
+		{"Dashboard", "Dashboard", false},
+		{"Desktop", "Desktop", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, ok := breakpointBaseName(tt.name)
+			if base != tt.base || ok != tt.ok {
+				t.Errorf("breakpointBaseName(%q) = %q, %v; want %q, %v", tt.name, base, ok, tt.base, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGroupBreakpoints(t *testing.T) {
+	frames := []FrameInfo{
+		{ID: "1:1", Name: "Login - Desktop", Page: "Auth", Width: 1440},
+		{ID: "1:2", Name: "Login - Mobile", Page: "Auth", Width: 375},
+		{ID: "1:3", Name: "login - tablet", Page: "Auth", Width: 768},
+		{ID: "1:4", Name: "Signup - Mobile", Page: "Auth", Width: 375},
+		{ID: "2:1", Name: "Login - Mobile", Page: "Archive", Width: 375},
+		{ID: "2:2", Name: "Notes", Page: "Archive", Width: 800},
+	}
+
+	groups := groupBreakpoints(frames)
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %+v", len(groups), groups)
+	}
+
+	g := groups[0]
+	if g.Name != "Login" || g.Page != "Auth" || len(g.Frames) != 3 {
+		t.Fatalf("group = %+v", g)
+	}
+	for i, want := range []string{"mobile", "tablet", "desktop"} {
+		if g.Frames[i].Device != want {
+			t.Errorf("frames[%d].device = %s, want %s", i, g.Frames[i].Device, want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// LayoutAuditArgs contains arguments for the layout_audit tool.
+type LayoutAuditArgs struct {
+	FileKey    string `json:"file_key" jsonschema:"Figma file key"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Max violations to return (default: 100)"`
+	Format     string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	OutputFile string `json:"output_file,omitempty" jsonschema:"Write full output to file path"`
+}
+
+// LayoutViolation is an auto-layout frame or child likely to be implemented
+// differently from how it looks in Figma.
+type LayoutViolation struct {
+	Rule       string `json:"rule"` // absolute_child or fixed_counter_axis
+	NodeID     string `json:"node_id"`
+	NodeName   string `json:"node_name"`
+	FrameID    string `json:"frame_id"`
+	FrameName  string `json:"frame_name"`
+	LayoutMode string `json:"layout_mode"`
+	Suggestion string `json:"suggestion"`
+}
+
+// LayoutAuditResult contains the result of layout_audit.
+type LayoutAuditResult struct {
+	Frames     int               `json:"frames"` // auto-layout frames checked
+	Total      int               `json:"total"`
+	Counts     map[string]int    `json:"counts"`
+	Violations []LayoutViolation `json:"violations"`
+	Cached     bool              `json:"cached"`
+	FilePath   string            `json:"file_path,omitempty"`
+}
+
+func registerLayoutAuditTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "layout_audit",
+		Description: "Find auto-layout problems: absolutely positioned children inside auto-layout frames, and frames with a fixed counter axis size but no max width or height. Each violation includes a suggested fix.",
+		InputSchema: inputSchema[LayoutAuditArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args LayoutAuditArgs) (*mcp.CallToolResult, *LayoutAuditResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		limit := args.Limit
+		if limit <= 0 {
+			limit = 100
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		frames, violations := auditAutoLayout(source.Nodes)
+
+		result := &LayoutAuditResult{
+			Frames:     frames,
+			Total:      len(violations),
+			Counts:     make(map[string]int),
+			Violations: violations,
+			Cached:     source.Cached,
+		}
+		for _, v := range violations {
+			result.Counts[v.Rule]++
+		}
+		if len(result.Violations) > limit {
+			result.Violations = result.Violations[:limit]
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatLayoutAuditResult(result)
+		}
+
+		outputCfg := OutputConfig{
+			MaxOutputSize: DefaultMaxOutputSize,
+			OutputFile:    args.OutputFile,
+			OutputDir:     r.ExportDir(),
+			ToolName:      "layout_audit",
+			FileKey:       args.FileKey,
+		}
+
+		outputResult, err := ProcessOutput(textOutput, result, outputCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("processing output: %w", err)
+		}
+
+		if outputResult.WasWrittenToFile {
+			result.FilePath = outputResult.FilePath
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: outputResult.Text},
+			},
+		}, result, nil
+	})
+}
+
+// auditAutoLayout checks every visible auto-layout frame and component. It
+// returns the number of frames checked and the violations, frame by frame.
+func auditAutoLayout(nodes []*figma.Node) (int, []LayoutViolation) {
+	frames := 0
+	violations := []LayoutViolation{}
+	seen := make(map[string]bool)
+
+	for _, node := range nodes {
+		if node.Type != figma.NodeTypeFrame && node.Type != figma.NodeTypeComponent {
+			continue
+		}
+		if node.LayoutMode == "" || node.LayoutMode == "NONE" || isHidden(node) || seen[node.ID] {
+			continue
+		}
+		seen[node.ID] = true
+		frames++
+
+		violation := func(rule string, n *figma.Node, suggestion string) LayoutViolation {
+			return LayoutViolation{
+				Rule:       rule,
+				NodeID:     n.ID,
+				NodeName:   n.Name,
+				FrameID:    node.ID,
+				FrameName:  node.Name,
+				LayoutMode: node.LayoutMode,
+				Suggestion: suggestion,
+			}
+		}
+
+		for _, child := range node.Children {
+			if child.LayoutPositioning == "ABSOLUTE" && !isHidden(child) {
+				violations = append(violations, violation("absolute_child", child,
+					"Set layout positioning to AUTO so the layer takes part in the auto layout, or move it out of the frame"))
+			}
+		}
+
+		if node.CounterAxisSizingMode == "FIXED" && node.MaxWidth == nil && node.MaxHeight == nil {
+			axis := "height"
+			if node.LayoutMode == "VERTICAL" {
+				axis = "width"
+			}
+			violations = append(violations, violation("fixed_counter_axis", node,
+				fmt.Sprintf("Set the %s to hug contents or fill container, or give the frame a max %s", axis, axis)))
+		}
+	}
+
+	return frames, violations
+}
+
+func formatLayoutAuditResult(r *LayoutAuditResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Layout audit: %d violations in %d auto-layout frames\n", r.Total, r.Frames))
+	if r.Total == 0 {
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("  absolute_child: %d, fixed_counter_axis: %d\n", r.Counts["absolute_child"], r.Counts["fixed_counter_axis"]))
+
+	sb.WriteString("\n")
+	for _, v := range r.Violations {
+		if v.Rule == "absolute_child" {
+			sb.WriteString(fmt.Sprintf("  [%s] %s: absolutely positioned in %s auto-layout frame %s [%s]\n",
+				v.NodeID, v.NodeName, strings.ToLower(v.LayoutMode), v.FrameName, v.FrameID))
+		} else {
+			sb.WriteString(fmt.Sprintf("  [%s] %s: fixed counter axis size in %s auto layout, no max width or height\n",
+				v.NodeID, v.NodeName, strings.ToLower(v.LayoutMode)))
+		}
+		sb.WriteString(fmt.Sprintf("      fix: %s\n", v.Suggestion))
+	}
+
+	if r.Total > len(r.Violations) {
+		sb.WriteString(fmt.Sprintf("\n... and %d more (raise limit to see them)\n", r.Total-len(r.Violations)))
+	}
+
+	return sb.String()
+}
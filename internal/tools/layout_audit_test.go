@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestAuditAutoLayout(t *testing.T) {
+	maxWidth := 640.0
+	hidden := false
+	badge := &figma.Node{ID: "1:2", Name: "Badge", Type: figma.NodeTypeFrame, LayoutPositioning: "ABSOLUTE"}
+	card := &figma.Node{
+		ID: "1:1", Name: "Card", Type: figma.NodeTypeFrame, LayoutMode: "VERTICAL", CounterAxisSizingMode: "FIXED",
+		Children: []*figma.Node{
+			{ID: "1:3", Name: "Title", Type: figma.NodeTypeText},
+			badge,
+			{ID: "1:4", Name: "Hidden", Type: figma.NodeTypeFrame, LayoutPositioning: "ABSOLUTE", Visible: &hidden},
+		},
+	}
+	bounded := &figma.Node{ID: "2:1", Name: "Row", Type: figma.NodeTypeFrame, LayoutMode: "HORIZONTAL", CounterAxisSizingMode: "FIXED", MaxWidth: &maxWidth}
+	free := &figma.Node{ID: "3:1", Name: "Canvas", Type: figma.NodeTypeFrame, Children: []*figma.Node{
+		{ID: "3:2", Name: "Sticker", Type: figma.NodeTypeFrame, LayoutPositioning: "ABSOLUTE"},
+	}}
+
+	frames, violations := auditAutoLayout([]*figma.Node{card, badge, bounded, free})
+	if frames != 2 {
+		t.Errorf("checked %d frames, want 2", frames)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("got %d violations, want 2: %+v", len(violations), violations)
+	}
+	if v := violations[0]; v.Rule != "absolute_child" || v.NodeID != "1:2" || v.FrameID != "1:1" || !strings.Contains(v.Suggestion, "AUTO") {
+		t.Errorf("absolute child violation = %+v", v)
+	}
+	if v := violations[1]; v.Rule != "fixed_counter_axis" || v.NodeID != "1:1" || !strings.Contains(v.Suggestion, "max width") {
+		t.Errorf("fixed counter axis violation = %+v", v)
+	}
+}
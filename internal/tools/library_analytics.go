@@ -0,0 +1,240 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// Pages of library analytics read per endpoint.
+const maxAnalyticsPages = 20
+
+// GetAnalyticsArgs contains arguments for the get_analytics tool.
+type GetAnalyticsArgs struct {
+	FileKey   string `json:"file_key" jsonschema:"File key of a published library"`
+	StartDate string `json:"start_date,omitempty" jsonschema:"First week of the trend, YYYY-MM-DD (default: one year ago)"`
+	EndDate   string `json:"end_date,omitempty" jsonschema:"Last week of the trend, YYYY-MM-DD (default: today)"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Max components to list, most used first (default: 20)"`
+	Format    string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// ComponentAnalytics is the use of one library component.
+type ComponentAnalytics struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	ComponentSet string `json:"component_set,omitempty"`
+	Usages       int    `json:"usages"`
+	FilesUsing   int    `json:"files_using"`
+	TeamsUsing   int    `json:"teams_using"`
+	Insertions   int    `json:"insertions"`
+	Detachments  int    `json:"detachments"`
+}
+
+// WeeklyActions totals the insertions and detachments of one week.
+type WeeklyActions struct {
+	Week        string `json:"week"`
+	Insertions  int    `json:"insertions"`
+	Detachments int    `json:"detachments"`
+}
+
+// GetAnalyticsResult contains the result of get_analytics.
+type GetAnalyticsResult struct {
+	TotalUsages      int                  `json:"total_usages"`
+	TotalInsertions  int                  `json:"total_insertions"`
+	TotalDetachments int                  `json:"total_detachments"`
+	ComponentCount   int                  `json:"component_count"`
+	Components       []ComponentAnalytics `json:"components"`
+	Weekly           []WeeklyActions      `json:"weekly"`
+	Truncated        bool                 `json:"truncated,omitempty"` // more pages than were read
+}
+
+func registerGetAnalyticsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_analytics",
+		Description: "Show how a library's components are used across the organization: total instances, the most used components, and weekly insertions and detachments. Requires an Enterprise plan and a token with the library_analytics:read scope.",
+		InputSchema: inputSchema[GetAnalyticsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetAnalyticsArgs) (*mcp.CallToolResult, *GetAnalyticsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+		limit := args.Limit
+		if limit == 0 {
+			limit = 20
+		}
+
+		usages, usagesComplete, err := fetchLibraryUsages(ctx, r.Client(), args.FileKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching library usages: %w", err)
+		}
+		actions, actionsComplete, err := fetchLibraryActions(ctx, r.Client(), args.FileKey, args.StartDate, args.EndDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching library actions: %w", err)
+		}
+
+		result := summarizeLibraryAnalytics(usages, actions, limit)
+		result.Truncated = !usagesComplete || !actionsComplete
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatAnalyticsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// fetchLibraryUsages pages through the usages of a library's components. It
+// reports whether every page was read.
+func fetchLibraryUsages(ctx context.Context, client *figma.Client, fileKey string) ([]figma.LibraryUsageRow, bool, error) {
+	var rows []figma.LibraryUsageRow
+	opts := &figma.LibraryAnalyticsOptions{}
+
+	for i := 0; i < maxAnalyticsPages; i++ {
+		page, err := client.GetLibraryUsages(ctx, fileKey, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		rows = append(rows, page.Rows...)
+		if !page.NextPage || page.Cursor == "" {
+			return rows, true, nil
+		}
+		opts.Cursor = page.Cursor
+	}
+	return rows, false, nil
+}
+
+// fetchLibraryActions pages through the weekly actions on a library's
+// components. It reports whether every page was read.
+func fetchLibraryActions(ctx context.Context, client *figma.Client, fileKey, startDate, endDate string) ([]figma.LibraryActionRow, bool, error) {
+	var rows []figma.LibraryActionRow
+	opts := &figma.LibraryAnalyticsOptions{StartDate: startDate, EndDate: endDate}
+
+	for i := 0; i < maxAnalyticsPages; i++ {
+		page, err := client.GetLibraryAnalytics(ctx, fileKey, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		rows = append(rows, page.Rows...)
+		if !page.NextPage || page.Cursor == "" {
+			return rows, true, nil
+		}
+		opts.Cursor = page.Cursor
+	}
+	return rows, false, nil
+}
+
+// summarizeLibraryAnalytics joins usages and actions by component key. The
+// limit most used components are listed; weeks are oldest first.
+func summarizeLibraryAnalytics(usages []figma.LibraryUsageRow, actions []figma.LibraryActionRow, limit int) *GetAnalyticsResult {
+	result := &GetAnalyticsResult{}
+	byKey := make(map[string]*ComponentAnalytics)
+	component := func(key, name, set string) *ComponentAnalytics {
+		c := byKey[key]
+		if c == nil {
+			c = &ComponentAnalytics{Key: key, Name: name, ComponentSet: set}
+			byKey[key] = c
+		}
+		return c
+	}
+
+	for _, u := range usages {
+		c := component(u.ComponentKey, u.ComponentName, u.ComponentSetName)
+		c.Usages += u.Usages
+		c.FilesUsing += u.FilesUsing
+		c.TeamsUsing += u.TeamsUsing
+		result.TotalUsages += u.Usages
+	}
+
+	weeks := make(map[string]*WeeklyActions)
+	for _, a := range actions {
+		c := component(a.ComponentKey, a.ComponentName, a.ComponentSetName)
+		c.Insertions += a.Insertions
+		c.Detachments += a.Detachments
+		result.TotalInsertions += a.Insertions
+		result.TotalDetachments += a.Detachments
+
+		w := weeks[a.Week]
+		if w == nil {
+			w = &WeeklyActions{Week: a.Week}
+			weeks[a.Week] = w
+		}
+		w.Insertions += a.Insertions
+		w.Detachments += a.Detachments
+	}
+
+	components := make([]ComponentAnalytics, 0, len(byKey))
+	for _, c := range byKey {
+		components = append(components, *c)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		a, b := components[i], components[j]
+		if a.Usages != b.Usages {
+			return a.Usages > b.Usages
+		}
+		if a.Insertions != b.Insertions {
+			return a.Insertions > b.Insertions
+		}
+		return a.Key < b.Key
+	})
+	result.ComponentCount = len(components)
+	if limit > 0 && len(components) > limit {
+		components = components[:limit]
+	}
+	result.Components = components
+
+	result.Weekly = make([]WeeklyActions, 0, len(weeks))
+	for _, w := range weeks {
+		result.Weekly = append(result.Weekly, *w)
+	}
+	sort.Slice(result.Weekly, func(i, j int) bool { return result.Weekly[i].Week < result.Weekly[j].Week })
+
+	return result
+}
+
+func formatAnalyticsResult(r *GetAnalyticsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Library usage: %d instances of %d components\n", r.TotalUsages, r.ComponentCount))
+	sb.WriteString(fmt.Sprintf("Insertions: %d, detachments: %d\n", r.TotalInsertions, r.TotalDetachments))
+	if r.Truncated {
+		sb.WriteString("(partial: not every page of analytics was read)\n")
+	}
+
+	if len(r.Components) > 0 {
+		sb.WriteString(fmt.Sprintf("\nMost used components (%d):\n", len(r.Components)))
+		for _, c := range r.Components {
+			name := c.Name
+			if c.ComponentSet != "" {
+				name = c.ComponentSet + " / " + c.Name
+			}
+			sb.WriteString(fmt.Sprintf("  %-40s %6d uses in %d files, %d teams (+%d/-%d)\n",
+				truncateText(name, 40), c.Usages, c.FilesUsing, c.TeamsUsing, c.Insertions, c.Detachments))
+		}
+	}
+
+	if len(r.Weekly) > 0 {
+		sb.WriteString("\nWeekly trend:\n")
+		for _, w := range r.Weekly {
+			sb.WriteString(fmt.Sprintf("  %s  +%d inserted, -%d detached\n", w.Week, w.Insertions, w.Detachments))
+		}
+	}
+
+	return sb.String()
+}
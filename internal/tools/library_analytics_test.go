@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestSummarizeLibraryAnalytics(t *testing.T) {
+	usages := []figma.LibraryUsageRow{
+		{ComponentKey: "k1", ComponentName: "Primary", ComponentSetName: "Button", Usages: 340, FilesUsing: 52, TeamsUsing: 4},
+		{ComponentKey: "k2", ComponentName: "Card", Usages: 80, FilesUsing: 9, TeamsUsing: 2},
+		{ComponentKey: "k3", ComponentName: "Badge", Usages: 5, FilesUsing: 1, TeamsUsing: 1},
+	}
+	actions := []figma.LibraryActionRow{
+		{Week: "2026-01-12", ComponentKey: "k1", ComponentName: "Primary", Insertions: 10, Detachments: 1},
+		{Week: "2026-01-05", ComponentKey: "k1", ComponentName: "Primary", Insertions: 4},
+		{Week: "2026-01-12", ComponentKey: "k2", ComponentName: "Card", Insertions: 2, Detachments: 3},
+	}
+
+	result := summarizeLibraryAnalytics(usages, actions, 2)
+	if result.TotalUsages != 425 || result.TotalInsertions != 16 || result.TotalDetachments != 4 {
+		t.Errorf("totals = %d usages, %d insertions, %d detachments", result.TotalUsages, result.TotalInsertions, result.TotalDetachments)
+	}
+	if result.ComponentCount != 3 || len(result.Components) != 2 {
+		t.Fatalf("got %d of %d components, want 2 of 3", len(result.Components), result.ComponentCount)
+	}
+	if c := result.Components[0]; c.Key != "k1" || c.Insertions != 14 || c.Detachments != 1 || c.ComponentSet != "Button" {
+		t.Errorf("most used = %+v", c)
+	}
+	if len(result.Weekly) != 2 || result.Weekly[0].Week != "2026-01-05" || result.Weekly[1].Insertions != 12 || result.Weekly[1].Detachments != 4 {
+		t.Errorf("weekly = %+v", result.Weekly)
+	}
+
+	text := formatAnalyticsResult(result)
+	if !strings.Contains(text, "Button / Primary") || !strings.Contains(text, "2026-01-12  +12 inserted, -4 detached") {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+}
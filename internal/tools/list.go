@@ -19,7 +19,7 @@ type ListComponentsArgs struct {
 	Select          []string `json:"select,omitempty" jsonschema:"Properties to return"`
 	Limit           int      `json:"limit,omitempty" jsonschema:"Max results to return (default: 100, max: 500)"`
 	Offset          int      `json:"offset,omitempty" jsonschema:"Pagination offset"`
-	Format          string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	Format          string   `json:"format,omitempty" jsonschema:"Response format: text (default), json, or markdown"`
 	OutputFile      string   `json:"output_file,omitempty" jsonschema:"Write full output to file path"`
 }
 
@@ -48,13 +48,16 @@ func registerListComponentsTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_components",
 		Description: "List all components with usage statistics.",
+		InputSchema: inputSchema[ListComponentsArgs](map[string][]string{
+			"format": tableFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListComponentsArgs) (*mcp.CallToolResult, *ListComponentsResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Set defaults
@@ -75,7 +78,9 @@ func registerListComponentsTool(server *mcp.Server, r *Registry) {
 		// Count instances if requested
 		instanceCounts := make(map[string]int)
 		if args.IncludeUsage && file.Document != nil {
-			countInstances(file.Document, instanceCounts)
+			nodeCounts := make(map[string]int)
+			countInstances(file.Document, nodeCounts)
+			instanceCounts = instancesByKey(nodeCounts, file.Components)
 		}
 
 		// Build component list
@@ -146,6 +151,8 @@ func registerListComponentsTool(server *mcp.Server, r *Registry) {
 		if args.Format == "json" {
 			b, _ := json.MarshalIndent(result, "", "  ")
 			textOutput = string(b)
+		} else if args.Format == "markdown" {
+			textOutput = formatComponentMarkdown(result, args.IncludeUsage)
 		} else {
 			textOutput = formatComponentList(result, args.IncludeUsage)
 			if truncInfo.Truncated {
@@ -179,6 +186,7 @@ func registerListComponentsTool(server *mcp.Server, r *Registry) {
 	})
 }
 
+// countInstances counts instances per component node ID.
 func countInstances(doc *figma.DocumentNode, counts map[string]int) {
 	var walk func(*figma.Node)
 	walk = func(n *figma.Node) {
@@ -195,6 +203,19 @@ func countInstances(doc *figma.DocumentNode, counts map[string]int) {
 	}
 }
 
+// instancesByKey converts per-node-ID instance counts to per-component-key
+// counts. Several node IDs can share a key when a library component is
+// imported more than once.
+func instancesByKey(counts map[string]int, components map[string]*figma.Component) map[string]int {
+	byKey := make(map[string]int)
+	for id, count := range counts {
+		if comp, ok := components[id]; ok {
+			byKey[comp.Key] += count
+		}
+	}
+	return byKey
+}
+
 func formatComponentList(r *ListComponentsResult, showUsage bool) string {
 	var sb strings.Builder
 
@@ -244,6 +265,38 @@ func formatComponentList(r *ListComponentsResult, showUsage bool) string {
 	return sb.String()
 }
 
+// markdownCell escapes a value for use inside a GFM table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func formatComponentMarkdown(r *ListComponentsResult, showUsage bool) string {
+	var sb strings.Builder
+
+	if showUsage {
+		sb.WriteString("| ID | Name | Description | Instances |\n")
+		sb.WriteString("|---|---|---|---|\n")
+	} else {
+		sb.WriteString("| ID | Name | Description |\n")
+		sb.WriteString("|---|---|---|\n")
+	}
+
+	for _, c := range r.Components {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |", c.ID, markdownCell(c.Name), markdownCell(c.Description)))
+		if showUsage {
+			sb.WriteString(fmt.Sprintf(" %d |", c.Instances))
+		}
+		sb.WriteString("\n")
+	}
+
+	if r.HasMore {
+		sb.WriteString(fmt.Sprintf("\n_Showing %d of %d components. Use offset=%d to see the next page._\n", r.Returned, r.Total, r.Offset+r.Returned))
+	}
+
+	return sb.String()
+}
+
 // ListStylesArgs contains arguments for the list_styles tool.
 type ListStylesArgs struct {
 	FileKey       string   `json:"file_key" jsonschema:"Figma file key"`
@@ -251,7 +304,7 @@ type ListStylesArgs struct {
 	IncludeValues bool     `json:"include_values,omitempty" jsonschema:"Include resolved style values (default: true)"`
 	Limit         int      `json:"limit,omitempty" jsonschema:"Max results to return (default: 100, max: 500)"`
 	Offset        int      `json:"offset,omitempty" jsonschema:"Pagination offset"`
-	Format        string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	Format        string   `json:"format,omitempty" jsonschema:"Response format: text (default), json, or markdown"`
 	OutputFile    string   `json:"output_file,omitempty" jsonschema:"Write full output to file path"`
 }
 
@@ -279,13 +332,17 @@ func registerListStylesTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_styles",
 		Description: "List all styles (color, text, effect, grid).",
+		InputSchema: inputSchema[ListStylesArgs](map[string][]string{
+			"types":  {"color", "text", "effect", "grid"},
+			"format": tableFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListStylesArgs) (*mcp.CallToolResult, *ListStylesResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Set defaults
@@ -373,6 +430,8 @@ func registerListStylesTool(server *mcp.Server, r *Registry) {
 		if args.Format == "json" {
 			b, _ := json.MarshalIndent(result, "", "  ")
 			textOutput = string(b)
+		} else if args.Format == "markdown" {
+			textOutput = formatStyleMarkdown(result)
 		} else {
 			textOutput = formatStyleList(result)
 			if truncInfo.Truncated {
@@ -435,3 +494,28 @@ func formatStyleList(r *ListStylesResult) string {
 
 	return sb.String()
 }
+
+func formatStyleMarkdown(r *ListStylesResult) string {
+	var sb strings.Builder
+
+	for _, typeName := range []string{"color", "text", "effect", "grid"} {
+		styles := r.Styles[typeName]
+		if len(styles) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("### %s Styles\n\n", strings.Title(typeName)))
+		sb.WriteString("| ID | Name | Description |\n")
+		sb.WriteString("|---|---|---|\n")
+		for _, s := range styles {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.ID, markdownCell(s.Name), markdownCell(s.Description)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if r.HasMore {
+		sb.WriteString(fmt.Sprintf("_Showing %d of %d styles. Use offset=%d to see the next page._\n", r.Returned, r.Total, r.Offset+r.Returned))
+	}
+
+	return sb.String()
+}
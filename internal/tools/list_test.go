@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestInstancesByKey(t *testing.T) {
+	doc := &figma.DocumentNode{
+		Children: []*figma.Node{
+			{ID: "0:1", Type: figma.NodeTypeCanvas, Children: []*figma.Node{
+				{ID: "2:1", Type: figma.NodeTypeInstance, ComponentID: "1:1"},
+				{ID: "2:2", Type: figma.NodeTypeInstance, ComponentID: "1:1"},
+				{ID: "2:3", Type: figma.NodeTypeFrame, Children: []*figma.Node{
+					{ID: "2:4", Type: figma.NodeTypeInstance, ComponentID: "1:2"},
+				}},
+				{ID: "2:5", Type: figma.NodeTypeInstance, ComponentID: "9:9"},
+			}},
+		},
+	}
+	components := map[string]*figma.Component{
+		"1:1": {Key: "key-button"},
+		"1:2": {Key: "key-button"}, // same library component imported twice
+		"1:3": {Key: "key-card"},
+	}
+
+	counts := make(map[string]int)
+	countInstances(doc, counts)
+	byKey := instancesByKey(counts, components)
+
+	if byKey["key-button"] != 3 {
+		t.Errorf("key-button instances = %d, want 3", byKey["key-button"])
+	}
+	if byKey["key-card"] != 0 {
+		t.Errorf("key-card instances = %d, want 0", byKey["key-card"])
+	}
+	if len(byKey) != 1 {
+		t.Errorf("expected only known components in result, got %v", byKey)
+	}
+}
+
+func TestFormatComponentMarkdown(t *testing.T) {
+	result := &ListComponentsResult{
+		Components: []ComponentInfo{
+			{ID: "1:1", Name: "Button | Primary", Description: "Main\naction", Instances: 4},
+		},
+		Total:    2,
+		Returned: 1,
+		HasMore:  true,
+	}
+
+	expected := "| ID | Name | Description | Instances |\n" +
+		"|---|---|---|---|\n" +
+		"| 1:1 | Button \\| Primary | Main action | 4 |\n" +
+		"\n_Showing 1 of 2 components. Use offset=1 to see the next page._\n"
+
+	if got := formatComponentMarkdown(result, true); got != expected {
+		t.Errorf("formatComponentMarkdown() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestFormatStyleMarkdown(t *testing.T) {
+	result := &ListStylesResult{
+		Styles: map[string][]StyleInfo{
+			"text":  {{ID: "2:1", Name: "Heading/H1", Type: "text"}},
+			"color": {{ID: "3:1", Name: "Brand|Blue", Type: "color", Description: "Primary"}},
+		},
+		Total:    2,
+		Returned: 2,
+	}
+
+	expected := "### Color Styles\n\n" +
+		"| ID | Name | Description |\n|---|---|---|\n" +
+		"| 3:1 | Brand\\|Blue | Primary |\n\n" +
+		"### Text Styles\n\n" +
+		"| ID | Name | Description |\n|---|---|---|\n" +
+		"| 2:1 | Heading/H1 |  |\n\n"
+
+	if got := formatStyleMarkdown(result); got != expected {
+		t.Errorf("formatStyleMarkdown() =\n%s\nwant\n%s", got, expected)
+	}
+}
@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"net/url"
+	"sort"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// ExportManifest is written to _manifest.json by sync_file. It lists what an
+// export depends on outside the file itself, so an export can be audited or
+// reproduced: the libraries it pulls components and styles from, the fonts
+// it needs, the hosts images were downloaded from and the tool version used.
+type ExportManifest struct {
+	FileKey          string              `json:"fileKey"`
+	FileName         string              `json:"fileName"`
+	FileVersion      string              `json:"fileVersion"`
+	MainFileKey      string              `json:"mainFileKey,omitempty"` // set when the file is a branch
+	ExportedAt       string              `json:"exportedAt"`
+	Generator        string              `json:"generator"`
+	RemoteComponents []ManifestComponent `json:"remoteComponents"`
+	RemoteStyles     []ManifestStyle     `json:"remoteStyles"`
+	Fonts            []ManifestFont      `json:"fonts"`
+	ImageHosts       []string            `json:"imageHosts"`
+}
+
+// ManifestComponent is a library component used in the file.
+type ManifestComponent struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// ManifestStyle is a library style used in the file.
+type ManifestStyle struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ManifestFont is a font family and the weights the file uses from it.
+type ManifestFont struct {
+	Family          string    `json:"family"`
+	Weights         []float64 `json:"weights"`
+	PostScriptNames []string  `json:"postScriptNames,omitempty"`
+}
+
+// buildExportManifest collects the external references of a file. imageURLs
+// are the URLs assets were downloaded from; only their hosts are recorded.
+func buildExportManifest(file *figma.File, fileKey, exportedAt, version string, imageURLs []string) *ExportManifest {
+	m := &ExportManifest{
+		FileKey:          fileKey,
+		FileName:         file.Name,
+		FileVersion:      file.Version,
+		MainFileKey:      file.MainFileKey,
+		ExportedAt:       exportedAt,
+		Generator:        "figma-query " + version,
+		RemoteComponents: []ManifestComponent{},
+		RemoteStyles:     []ManifestStyle{},
+		Fonts:            []ManifestFont{},
+		ImageHosts:       []string{},
+	}
+
+	for _, c := range file.Components {
+		if c.Remote {
+			m.RemoteComponents = append(m.RemoteComponents, ManifestComponent{Key: c.Key, Name: c.Name})
+		}
+	}
+	sort.Slice(m.RemoteComponents, func(i, j int) bool { return m.RemoteComponents[i].Name < m.RemoteComponents[j].Name })
+
+	for _, s := range file.Styles {
+		if s.Remote {
+			m.RemoteStyles = append(m.RemoteStyles, ManifestStyle{Key: s.Key, Name: s.Name, Type: string(s.StyleType)})
+		}
+	}
+	sort.Slice(m.RemoteStyles, func(i, j int) bool { return m.RemoteStyles[i].Name < m.RemoteStyles[j].Name })
+
+	if file.Document != nil {
+		m.Fonts = collectFonts(flattenNodes(file.Document))
+	}
+
+	hosts := make(map[string]bool)
+	for _, raw := range imageURLs {
+		if u, err := url.Parse(raw); err == nil && u.Host != "" {
+			hosts[u.Host] = true
+		}
+	}
+	for h := range hosts {
+		m.ImageHosts = append(m.ImageHosts, h)
+	}
+	sort.Strings(m.ImageHosts)
+
+	return m
+}
+
+// collectFonts gathers the font families and weights used by text nodes,
+// including per-character style overrides.
+func collectFonts(nodes []*figma.Node) []ManifestFont {
+	type fontUse struct {
+		weights     map[float64]bool
+		postScripts map[string]bool
+	}
+	families := make(map[string]*fontUse)
+
+	add := func(s *figma.TypeStyle) {
+		if s == nil || s.FontFamily == "" {
+			return
+		}
+		f := families[s.FontFamily]
+		if f == nil {
+			f = &fontUse{weights: make(map[float64]bool), postScripts: make(map[string]bool)}
+			families[s.FontFamily] = f
+		}
+		if s.FontWeight > 0 {
+			f.weights[s.FontWeight] = true
+		}
+		if s.FontPostScriptName != "" {
+			f.postScripts[s.FontPostScriptName] = true
+		}
+	}
+
+	for _, n := range nodes {
+		add(n.Style)
+		for _, s := range n.StyleOverrideTable {
+			add(s)
+		}
+	}
+
+	fonts := make([]ManifestFont, 0, len(families))
+	for family, use := range families {
+		font := ManifestFont{Family: family, Weights: []float64{}}
+		for w := range use.weights {
+			font.Weights = append(font.Weights, w)
+		}
+		sort.Float64s(font.Weights)
+		for ps := range use.postScripts {
+			font.PostScriptNames = append(font.PostScriptNames, ps)
+		}
+		sort.Strings(font.PostScriptNames)
+		fonts = append(fonts, font)
+	}
+	sort.Slice(fonts, func(i, j int) bool { return fonts[i].Family < fonts[j].Family })
+	return fonts
+}
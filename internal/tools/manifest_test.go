@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestBuildExportManifest(t *testing.T) {
+	file := &figma.File{
+		Name:        "Site",
+		Version:     "123",
+		MainFileKey: "MAIN",
+		Components: map[string]*figma.Component{
+			"1:1": {Key: "local", Name: "Card"},
+			"9:1": {Key: "lib-btn", Name: "Button", Remote: true},
+		},
+		Styles: map[string]*figma.Style{
+			"S:1": {Key: "lib-brand", Name: "Brand/Primary", Remote: true, StyleType: figma.StyleTypeFill},
+			"S:2": {Key: "local", Name: "Local"},
+		},
+		Document: &figma.DocumentNode{Children: []*figma.Node{{
+			ID:   "0:1",
+			Type: figma.NodeTypeCanvas,
+			Children: []*figma.Node{
+				{ID: "1:2", Type: figma.NodeTypeText, Style: &figma.TypeStyle{FontFamily: "Inter", FontWeight: 400, FontPostScriptName: "Inter-Regular"},
+					StyleOverrideTable: map[string]*figma.TypeStyle{"1": {FontFamily: "Inter", FontWeight: 700}}},
+				{ID: "1:3", Type: figma.NodeTypeText, Style: &figma.TypeStyle{FontFamily: "Brand Serif", FontWeight: 400}},
+			},
+		}}},
+	}
+	urls := []string{
+		"https://s3-alpha-sig.figma.com/img/aa/bb?Expires=1",
+		"https://s3-alpha-sig.figma.com/img/cc/dd",
+		"https://figma-alpha-api.s3.us-west-2.amazonaws.com/images/x",
+	}
+
+	m := buildExportManifest(file, "KEY", "2026-01-01T00:00:00Z", "1.2.3", urls)
+
+	if m.Generator != "figma-query 1.2.3" || m.MainFileKey != "MAIN" || m.FileVersion != "123" {
+		t.Errorf("unexpected header: %+v", m)
+	}
+	if !reflect.DeepEqual(m.RemoteComponents, []ManifestComponent{{Key: "lib-btn", Name: "Button"}}) {
+		t.Errorf("RemoteComponents = %+v", m.RemoteComponents)
+	}
+	if !reflect.DeepEqual(m.RemoteStyles, []ManifestStyle{{Key: "lib-brand", Name: "Brand/Primary", Type: "FILL"}}) {
+		t.Errorf("RemoteStyles = %+v", m.RemoteStyles)
+	}
+	wantFonts := []ManifestFont{
+		{Family: "Brand Serif", Weights: []float64{400}},
+		{Family: "Inter", Weights: []float64{400, 700}, PostScriptNames: []string{"Inter-Regular"}},
+	}
+	if !reflect.DeepEqual(m.Fonts, wantFonts) {
+		t.Errorf("Fonts = %+v, want %+v", m.Fonts, wantFonts)
+	}
+	wantHosts := []string{"figma-alpha-api.s3.us-west-2.amazonaws.com", "s3-alpha-sig.figma.com"}
+	if !reflect.DeepEqual(m.ImageHosts, wantHosts) {
+		t.Errorf("ImageHosts = %v, want %v", m.ImageHosts, wantHosts)
+	}
+}
@@ -0,0 +1,287 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MergeExportsArgs contains arguments for the merge_exports tool.
+type MergeExportsArgs struct {
+	SourceDir string `json:"source_dir" jsonschema:"sync_file export to merge from (directory containing _meta.json)"`
+	TargetDir string `json:"target_dir" jsonschema:"sync_file export of the same file to merge into"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Report what would change without writing files"`
+	Format    string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// MergeExportsResult contains the result of merge_exports.
+type MergeExportsResult struct {
+	SourceDir      string   `json:"source_dir"`
+	TargetDir      string   `json:"target_dir"`
+	SourceExported string   `json:"source_exported_at"`
+	TargetExported string   `json:"target_exported_at"`
+	Added          int      `json:"added"`
+	Updated        int      `json:"updated"`
+	Skipped        int      `json:"skipped"` // conflicts where the target export is newer
+	DryRun         bool     `json:"dry_run,omitempty"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+func registerMergeExportsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "merge_exports",
+		Description: "Merge one sync_file export of a file into another. New nodes are copied; nodes in both keep the copy from the newer export.",
+		InputSchema: inputSchema[MergeExportsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args MergeExportsArgs) (*mcp.CallToolResult, *MergeExportsResult, error) {
+		if args.SourceDir == "" || args.TargetDir == "" {
+			return nil, nil, fmt.Errorf("source_dir and target_dir are required. Pass two sync_file export directories of the same file (each contains _meta.json)")
+		}
+
+		sourceDir := resolveExportPath(r.ExportDir(), args.SourceDir)
+		targetDir := resolveExportPath(r.ExportDir(), args.TargetDir)
+		if filepath.Clean(sourceDir) == filepath.Clean(targetDir) {
+			return nil, nil, fmt.Errorf("source_dir and target_dir are the same export")
+		}
+
+		result, err := mergeExports(&syncWriter{dryRun: args.DryRun}, sourceDir, targetDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.DryRun = args.DryRun
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatMergeExportsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// resolveExportPath returns dir as given when it exists, otherwise relative
+// to the export directory.
+func resolveExportPath(exportDir, dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return dir
+	}
+	return filepath.Join(exportDir, dir)
+}
+
+// exportMeta is the part of _meta.json needed to merge exports and to key
+// styles read from an export by version.
+type exportMeta struct {
+	FileKey    string `json:"fileKey"`
+	Version    string `json:"version"`
+	ExportedAt string `json:"exportedAt"`
+}
+
+func readExportMeta(dir string) (*exportMeta, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "_meta.json"))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	var meta exportMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("parsing _meta.json in %s: %w", dir, err)
+	}
+	return &meta, nil
+}
+
+func readExportIndex(dir string) (map[string]IndexEntry, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "_index.json"))
+	if err != nil {
+		return nil, fmt.Errorf("reading index in %s: %w", dir, err)
+	}
+	index := make(map[string]IndexEntry)
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parsing _index.json in %s: %w", dir, err)
+	}
+	return index, nil
+}
+
+// indexRelPath returns an index entry's path relative to its export root.
+// Index paths include whatever output directory the export was written to,
+// so they are cut at the last "pages" element: every element below it is
+// either "children" or a name with an ID suffix.
+func indexRelPath(p string) string {
+	parts := strings.Split(filepath.ToSlash(p), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == "pages" {
+			return filepath.Join(parts[i:]...)
+		}
+	}
+	return ""
+}
+
+// mergeExports copies the nodes of the source export into the target export.
+// Nodes only in the source are added; nodes in both are replaced only when
+// the source was exported more recently.
+func mergeExports(w *syncWriter, sourceDir, targetDir string) (*MergeExportsResult, error) {
+	sourceMeta, err := readExportMeta(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	targetMeta, err := readExportMeta(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	if sourceMeta.FileKey != targetMeta.FileKey {
+		return nil, fmt.Errorf("exports are of different files (%s and %s)", sourceMeta.FileKey, targetMeta.FileKey)
+	}
+
+	sourceIndex, err := readExportIndex(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	targetIndex, err := readExportIndex(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceTime, _ := time.Parse(time.RFC3339, sourceMeta.ExportedAt)
+	targetTime, _ := time.Parse(time.RFC3339, targetMeta.ExportedAt)
+	sourceNewer := sourceTime.After(targetTime)
+
+	result := &MergeExportsResult{
+		SourceDir:      sourceDir,
+		TargetDir:      targetDir,
+		SourceExported: sourceMeta.ExportedAt,
+		TargetExported: targetMeta.ExportedAt,
+	}
+
+	// Parents are merged before their children so a new child can be placed
+	// under its parent's directory in the target.
+	ids := make([]string, 0, len(sourceIndex))
+	for id := range sourceIndex {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := sourceIndex[ids[i]], sourceIndex[ids[j]]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		entry := sourceIndex[id]
+		rel := indexRelPath(entry.Path)
+		if rel == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("node %s: unrecognized index path %s", id, entry.Path))
+			continue
+		}
+		from := filepath.Join(sourceDir, rel)
+
+		existing, conflict := targetIndex[id]
+		if conflict && !sourceNewer {
+			result.Skipped++
+			continue
+		}
+
+		// Updated nodes keep their target directory; new nodes go under their
+		// parent's target directory, which may be named differently if the
+		// parent was renamed between exports.
+		to := filepath.Join(targetDir, rel)
+		if conflict {
+			if existingRel := indexRelPath(existing.Path); existingRel != "" {
+				to = filepath.Join(targetDir, existingRel)
+			}
+		} else if parent, ok := targetIndex[entry.ParentID]; ok {
+			if parentRel := indexRelPath(parent.Path); parentRel != "" {
+				to = filepath.Join(targetDir, parentRel, "children", filepath.Base(rel))
+			}
+		}
+
+		if err := copyNodeFiles(w, from, to); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("node %s: %v", id, err))
+			continue
+		}
+
+		if conflict {
+			result.Updated++
+		} else {
+			result.Added++
+		}
+		entry.Path = to
+		targetIndex[id] = entry
+	}
+
+	if result.Added > 0 || result.Updated > 0 {
+		if err := w.WriteJSON(filepath.Join(targetDir, "_index.json"), targetIndex); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("writing index: %v", err))
+		}
+		if !w.dryRun {
+			if err := removeNodeSnapshot(targetDir); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("removing stale node snapshot: %v", err))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// copyNodeFiles copies the files describing one node (_node.json, _css.json
+// and so on) without descending into its children directory.
+func copyNodeFiles(w *syncWriter, from, to string) error {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	if err := w.MkdirAll(to, 0755); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(from, e.Name()))
+		if err != nil {
+			return err
+		}
+		if err := w.WriteFile(filepath.Join(to, e.Name()), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatMergeExportsResult(r *MergeExportsResult) string {
+	var sb strings.Builder
+
+	if r.DryRun {
+		sb.WriteString("[DRY RUN] ")
+	}
+	sb.WriteString(fmt.Sprintf("Merged %s into %s\n", r.SourceDir, r.TargetDir))
+	sb.WriteString(fmt.Sprintf("Exported: source %s, target %s\n\n", r.SourceExported, r.TargetExported))
+	sb.WriteString(fmt.Sprintf("Added:   %d nodes\n", r.Added))
+	sb.WriteString(fmt.Sprintf("Updated: %d nodes (source newer)\n", r.Updated))
+	sb.WriteString(fmt.Sprintf("Skipped: %d nodes (target newer)\n", r.Skipped))
+
+	if len(r.Errors) > 0 {
+		sb.WriteString(fmt.Sprintf("\nErrors (%d):\n", len(r.Errors)))
+		for _, e := range r.Errors {
+			sb.WriteString(fmt.Sprintf("  %s\n", e))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// writeTestExport syncs page into dir the way sync_file lays it out.
+func writeTestExport(t *testing.T, dir, exportedAt string, page *figma.Node) {
+	t.Helper()
+	var treeLines []string
+	index := make(map[string]IndexEntry)
+	w := &syncWriter{}
+	pagePath := filepath.Join(dir, "pages", sanitizeName(page.Name)+"-"+sanitizeID(page.ID))
+	if _, errs := exportNode(context.Background(), w, page, pagePath, nil, page.Name, &treeLines, index, NewImageCollector()); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if err := w.WriteJSON(filepath.Join(dir, "_index.json"), index); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteFile(filepath.Join(dir, "_tree.txt"), []byte(strings.Join(treeLines, "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := map[string]any{"fileKey": "KEY", "exportedAt": exportedAt}
+	if err := w.WriteJSON(filepath.Join(dir, "_meta.json"), meta); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeExports(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "source")
+	target := filepath.Join(t.TempDir(), "target")
+
+	newer := testSyncPage()
+	newer.Children[0].Name = "Frame v2"
+	newer.Children[0].Children = append(newer.Children[0].Children, &figma.Node{ID: "1:4", Name: "Badge", Type: figma.NodeTypeFrame})
+	writeTestExport(t, source, "2026-02-01T00:00:00Z", newer)
+
+	older := testSyncPage()
+	older.Children[0].Children = older.Children[0].Children[:1]
+	writeTestExport(t, target, "2026-01-01T00:00:00Z", older)
+	if err := writeNodeSnapshot(target, []*figma.Node{older}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := mergeExports(&syncWriter{}, source, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The target's node snapshot no longer matches its node files.
+	if _, err := os.Stat(filepath.Join(target, nodeSnapshotFile)); !os.IsNotExist(err) {
+		t.Errorf("stale node snapshot kept after merge: %v", err)
+	}
+	if result.Added != 2 || result.Updated != 3 || result.Skipped != 0 || len(result.Errors) != 0 {
+		t.Errorf("result = %+v, want 2 added, 3 updated", result)
+	}
+
+	index, err := readExportIndex(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1:3", "1:4"} {
+		entry, ok := index[id]
+		if !ok {
+			t.Fatalf("node %s missing from merged index", id)
+		}
+		if _, err := os.Stat(filepath.Join(entry.Path, "_node.json")); err != nil {
+			t.Errorf("node %s not copied: %v", id, err)
+		}
+		// New children land under the target's directory for their parent.
+		if filepath.Dir(filepath.Dir(entry.Path)) != index["1:1"].Path {
+			t.Errorf("node %s copied to %s, want under %s", id, entry.Path, index["1:1"].Path)
+		}
+	}
+
+	// The updated frame keeps the target's directory name.
+	data, err := os.ReadFile(filepath.Join(index["1:1"].Path, "_node.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Frame v2") {
+		t.Errorf("frame was not updated: %s", data)
+	}
+
+	// Merging the other way keeps the newer target copy.
+	result, err = mergeExports(&syncWriter{dryRun: true}, target, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Skipped != 5 || result.Added != 0 || result.Updated != 0 {
+		t.Errorf("reverse result = %+v, want 5 skipped", result)
+	}
+}
+
+func TestIndexRelPath(t *testing.T) {
+	tests := map[string]string{
+		"/tmp/export/pages/pages/page-1-0-1/children/frame-1-1": "pages/page-1-0-1/children/frame-1-1",
+		"figma-export/site/pages/home-0-1":                      "pages/home-0-1",
+		"/tmp/export/site":                                      "",
+	}
+	for in, want := range tests {
+		if got := indexRelPath(in); got != filepath.FromSlash(want) {
+			t.Errorf("indexRelPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
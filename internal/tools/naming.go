@@ -0,0 +1,292 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// lintConfigFileName holds naming rules in the export directory, used when
+// check_naming_conventions is called without rules.
+const lintConfigFileName = ".figma-query-lint.json"
+
+var namingScopes = []string{"components", "variables", "pages", "frames"}
+
+// NamingRule requires the names in a scope to match a pattern.
+type NamingRule struct {
+	Scope   string `json:"scope" jsonschema:"Names to check: components, variables, pages or frames"`
+	Pattern string `json:"pattern" jsonschema:"Regular expression every name must match, e.g. ^[A-Z][a-z]+/[A-Z]"`
+	Level   string `json:"level,omitempty" jsonschema:"Severity of a mismatch: error (default) or warning"`
+}
+
+// CheckNamingConventionsArgs contains arguments for the check_naming_conventions tool.
+type CheckNamingConventionsArgs struct {
+	FileKey string       `json:"file_key" jsonschema:"Figma file key"`
+	Rules   []NamingRule `json:"rules,omitempty" jsonschema:"Naming rules (default: the rules in .figma-query-lint.json in the export directory)"`
+	Format  string       `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// NamingViolation is a name that does not match a rule's pattern.
+type NamingViolation struct {
+	Scope   string `json:"scope"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// CheckNamingConventionsResult contains the result of check_naming_conventions.
+type CheckNamingConventionsResult struct {
+	RulesFrom string            `json:"rules_from"` // "arguments" or the config file path
+	Errors    []NamingViolation `json:"errors"`
+	Warnings  []NamingViolation `json:"warnings"`
+	Checked   map[string]int    `json:"checked"` // names checked per scope
+	Notes     []string          `json:"notes,omitempty"`
+	Cached    bool              `json:"cached"`
+}
+
+func registerCheckNamingConventionsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "check_naming_conventions",
+		Description: "Check component, variable, page and frame names against regex naming rules, e.g. components in Category/Name form or camelCase variables. Rules come from the rules argument or from .figma-query-lint.json in the export directory. Violations are grouped into errors and warnings.",
+		InputSchema: inputSchema[CheckNamingConventionsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CheckNamingConventionsArgs) (*mcp.CallToolResult, *CheckNamingConventionsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		rules, rulesFrom := args.Rules, "arguments"
+		if len(rules) == 0 {
+			path := filepath.Join(r.ExportDir(), lintConfigFileName)
+			loaded, err := loadNamingRules(path)
+			if err != nil {
+				return nil, nil, err
+			}
+			rules, rulesFrom = loaded, path
+		}
+		compiled, err := compileNamingRules(rules)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		names := namesByScope(source.Nodes)
+		var notes []string
+		if usesScope(rules, "variables") {
+			variables, err := loadNamingVariables(ctx, r, args.FileKey)
+			if err != nil {
+				notes = append(notes, fmt.Sprintf("variables not checked: %v", err))
+			}
+			for _, v := range variables {
+				names["variables"] = append(names["variables"], namedItem{ID: v.ID, Name: v.Name})
+			}
+		}
+
+		result := checkNamingRules(compiled, names)
+		result.RulesFrom = rulesFrom
+		result.Notes = notes
+		result.Cached = source.Cached
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatCheckNamingConventionsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// loadNamingRules reads the rules of a lint config file:
+//
+//	{"rules": [{"scope": "components", "pattern": "^[A-Z]\\w*/", "level": "error"}]}
+func loadNamingRules(path string) ([]NamingRule, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no naming rules given and %s not found", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var config struct {
+		Rules []NamingRule `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(config.Rules) == 0 {
+		return nil, fmt.Errorf("%s has no rules", path)
+	}
+	return config.Rules, nil
+}
+
+type namingRule struct {
+	NamingRule
+	re *regexp.Regexp
+}
+
+// compileNamingRules validates rules and compiles their patterns.
+func compileNamingRules(rules []NamingRule) ([]namingRule, error) {
+	compiled := make([]namingRule, 0, len(rules))
+	for i, rule := range rules {
+		if !containsString(namingScopes, rule.Scope) {
+			return nil, fmt.Errorf("rule %d: scope must be one of %s, got %q", i+1, strings.Join(namingScopes, ", "), rule.Scope)
+		}
+		switch rule.Level {
+		case "":
+			rule.Level = "error"
+		case "error", "warning":
+		default:
+			return nil, fmt.Errorf("rule %d: level must be error or warning, got %q", i+1, rule.Level)
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: invalid pattern: %w", i+1, err)
+		}
+		compiled = append(compiled, namingRule{NamingRule: rule, re: re})
+	}
+	return compiled, nil
+}
+
+func usesScope(rules []NamingRule, scope string) bool {
+	for _, rule := range rules {
+		if rule.Scope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// namedItem is a node or variable whose name is checked.
+type namedItem struct {
+	ID   string
+	Name string
+}
+
+// namesByScope groups node names into the components, pages and frames
+// scopes. Component sets count as components.
+func namesByScope(nodes []*figma.Node) map[string][]namedItem {
+	names := make(map[string][]namedItem)
+	for _, node := range nodes {
+		var scope string
+		switch node.Type {
+		case figma.NodeTypeComponent, figma.NodeTypeComponentSet:
+			scope = "components"
+		case figma.NodeTypeCanvas:
+			scope = "pages"
+		case figma.NodeTypeFrame:
+			scope = "frames"
+		default:
+			continue
+		}
+		names[scope] = append(names[scope], namedItem{ID: node.ID, Name: node.Name})
+	}
+	return names
+}
+
+// loadNamingVariables returns the file's variables from the sync_file cache,
+// or from the API when the cache has none.
+func loadNamingVariables(ctx context.Context, r *Registry, fileKey string) ([]*figma.Variable, error) {
+	var variables map[string]*figma.Variable
+	if cacheDir, err := findCacheDir(r.ExportDir(), fileKey); err == nil {
+		if snapshot, _, err := readVariableSnapshot(cacheDir); err == nil {
+			variables = snapshot.variables
+		}
+	}
+	if variables == nil {
+		if !r.HasClient() {
+			return nil, errNoCacheNoClient
+		}
+		vars, err := r.Client().GetLocalVariables(ctx, fileKey)
+		if err != nil {
+			return nil, fmt.Errorf("fetching variables: %w", err)
+		}
+		if vars.Meta != nil {
+			variables = vars.Meta.Variables
+		}
+	}
+	return sortedVariables(variables), nil
+}
+
+// checkNamingRules matches every name in a rule's scope against its pattern.
+func checkNamingRules(rules []namingRule, names map[string][]namedItem) *CheckNamingConventionsResult {
+	result := &CheckNamingConventionsResult{
+		Errors:   []NamingViolation{},
+		Warnings: []NamingViolation{},
+		Checked:  make(map[string]int),
+	}
+
+	for _, rule := range rules {
+		items := names[rule.Scope]
+		result.Checked[rule.Scope] = len(items)
+		for _, item := range items {
+			if rule.re.MatchString(item.Name) {
+				continue
+			}
+			v := NamingViolation{Scope: rule.Scope, ID: item.ID, Name: item.Name, Pattern: rule.Pattern}
+			if rule.Level == "warning" {
+				result.Warnings = append(result.Warnings, v)
+			} else {
+				result.Errors = append(result.Errors, v)
+			}
+		}
+	}
+
+	return result
+}
+
+func formatCheckNamingConventionsResult(r *CheckNamingConventionsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Naming conventions: %d errors, %d warnings\n", len(r.Errors), len(r.Warnings)))
+	sb.WriteString(fmt.Sprintf("Rules from: %s\n", r.RulesFrom))
+
+	scopes := make([]string, 0, len(r.Checked))
+	for scope := range r.Checked {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	var checked []string
+	for _, scope := range scopes {
+		checked = append(checked, fmt.Sprintf("%d %s", r.Checked[scope], scope))
+	}
+	sb.WriteString(fmt.Sprintf("Checked: %s\n", strings.Join(checked, ", ")))
+
+	writeViolations := func(title string, violations []NamingViolation) {
+		if len(violations) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("\n%s (%d):\n", title, len(violations)))
+		for _, v := range violations {
+			sb.WriteString(fmt.Sprintf("  [%s] %s '%s' does not match %s\n", v.ID, strings.TrimSuffix(v.Scope, "s"), v.Name, v.Pattern))
+		}
+	}
+	writeViolations("Errors", r.Errors)
+	writeViolations("Warnings", r.Warnings)
+
+	for _, note := range r.Notes {
+		sb.WriteString(fmt.Sprintf("\nNote: %s\n", note))
+	}
+
+	return sb.String()
+}
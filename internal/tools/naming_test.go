@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCheckNamingRules(t *testing.T) {
+	nodes := []*figma.Node{
+		{ID: "0:1", Name: "01 Cover", Type: figma.NodeTypeCanvas},
+		{ID: "0:2", Name: "Scratch", Type: figma.NodeTypeCanvas},
+		{ID: "1:1", Name: "Button/Primary", Type: figma.NodeTypeComponent},
+		{ID: "1:2", Name: "card", Type: figma.NodeTypeComponentSet},
+		{ID: "1:3", Name: "Frame 12", Type: figma.NodeTypeFrame},
+		{ID: "1:4", Name: "Label", Type: figma.NodeTypeText},
+	}
+	names := namesByScope(nodes)
+	names["variables"] = []namedItem{{ID: "v:1", Name: "colorPrimary"}, {ID: "v:2", Name: "Color Primary"}}
+
+	rules, err := compileNamingRules([]NamingRule{
+		{Scope: "components", Pattern: `^[A-Z]\w*/\w+`},
+		{Scope: "variables", Pattern: `^[a-z][a-zA-Z0-9]*$`},
+		{Scope: "pages", Pattern: `^\d`, Level: "warning"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := checkNamingRules(rules, names)
+	if len(result.Errors) != 2 || result.Errors[0].ID != "1:2" || result.Errors[1].ID != "v:2" {
+		t.Errorf("errors = %+v", result.Errors)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Name != "Scratch" {
+		t.Errorf("warnings = %+v", result.Warnings)
+	}
+	if result.Checked["components"] != 2 || result.Checked["pages"] != 2 || result.Checked["variables"] != 2 {
+		t.Errorf("checked = %v", result.Checked)
+	}
+	if _, ok := result.Checked["frames"]; ok {
+		t.Error("frames checked without a frames rule")
+	}
+}
+
+func TestCompileNamingRulesErrors(t *testing.T) {
+	tests := []struct {
+		rule    NamingRule
+		errText string
+	}{
+		{NamingRule{Scope: "layers", Pattern: "x"}, "scope"},
+		{NamingRule{Scope: "pages", Pattern: "x", Level: "info"}, "level"},
+		{NamingRule{Scope: "pages", Pattern: "("}, "invalid pattern"},
+	}
+	for _, tt := range tests {
+		if _, err := compileNamingRules([]NamingRule{tt.rule}); err == nil || !strings.Contains(err.Error(), tt.errText) {
+			t.Errorf("%+v: expected error containing %q, got %v", tt.rule, tt.errText, err)
+		}
+	}
+}
+
+func TestLoadNamingRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), lintConfigFileName)
+	if _, err := loadNamingRules(path); err == nil {
+		t.Error("expected error for missing config file")
+	}
+
+	config := `{"rules": [{"scope": "frames", "pattern": "^[A-Z]", "level": "warning"}]}`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := loadNamingRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Scope != "frames" || rules[0].Level != "warning" {
+		t.Errorf("rules = %+v", rules)
+	}
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// maxCachedVersions bounds each session cache. A diff holds two versions of
+// a file, so a few entries cover the files a session is working on while
+// keeping long-running servers from accumulating every version they fetch.
+const maxCachedVersions = 8
+
+// versionKey identifies one version of a file.
+type versionKey struct {
+	fileKey string
+	version string
+}
+
+// versionCache holds per-version values for the lifetime of a session,
+// evicting the least recently used version once it holds maxCachedVersions.
+// File versions are immutable, so entries never need invalidating.
+type versionCache[V any] struct {
+	mu      sync.Mutex
+	entries map[versionKey]V
+	order   []versionKey // least recently used first
+}
+
+func newVersionCache[V any]() *versionCache[V] {
+	return &versionCache[V]{entries: make(map[versionKey]V)}
+}
+
+func (c *versionCache[V]) get(fileKey, version string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := versionKey{fileKey, version}
+	v, ok := c.entries[key]
+	if ok {
+		c.touch(key)
+	}
+	return v, ok
+}
+
+func (c *versionCache[V]) put(fileKey, version string, v V) {
+	if version == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := versionKey{fileKey, version}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxCachedVersions {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = v
+	c.touch(key)
+}
+
+// touch moves key to the most recently used end of order.
+func (c *versionCache[V]) touch(key versionKey) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, key)
+}
+
+// nodeCache holds the flattened nodes of recently used file versions.
+type nodeCache = versionCache[[]*figma.Node]
+
+func newNodeCache() *nodeCache {
+	return newVersionCache[[]*figma.Node]()
+}
+
+// fileNodes returns the flattened nodes of the current version of a file.
+// A shallow request resolves the version; the full document is only fetched
+// when that version has not been flattened yet in this session.
+func (r *Registry) fileNodes(ctx context.Context, fileKey string) ([]*figma.Node, error) {
+	head, err := r.Client().GetFile(ctx, fileKey, &figma.GetFileOptions{Depth: 1})
+	if err != nil {
+		return nil, fmt.Errorf("fetching file: %w", err)
+	}
+	if nodes, ok := r.nodes.get(fileKey, head.Version); ok {
+		return nodes, nil
+	}
+
+	file, err := r.Client().GetFile(ctx, fileKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching file: %w", err)
+	}
+
+	nodes := flattenNodes(file.Document)
+	r.nodes.put(fileKey, file.Version, nodes)
+	return nodes, nil
+}
+
+// versionNodes returns the flattened nodes of a specific file version.
+func (r *Registry) versionNodes(ctx context.Context, fileKey, version string) ([]*figma.Node, error) {
+	if nodes, ok := r.nodes.get(fileKey, version); ok {
+		return nodes, nil
+	}
+
+	file, err := r.Client().GetFile(ctx, fileKey, &figma.GetFileOptions{Version: version})
+	if err != nil {
+		return nil, fmt.Errorf("fetching version %s: %w", version, err)
+	}
+
+	nodes := flattenNodes(file.Document)
+	r.nodes.put(fileKey, version, nodes)
+	return nodes, nil
+}
+
+// styleCache holds the style ID → style maps of recently used file
+// versions, so style names can be resolved without rereading the export.
+type styleCache = versionCache[map[string]*figma.Style]
+
+func newStyleCache() *styleCache {
+	return newVersionCache[map[string]*figma.Style]()
+}
+
+// fileStyles returns the styles of a file keyed by style ID, from the
+// sync_file export or a shallow file request, in that order. Styles read from
+// an export are cached under the version it was synced at, so a later
+// sync_file of a newer version is picked up.
+func (r *Registry) fileStyles(ctx context.Context, fileKey string) (map[string]*figma.Style, error) {
+	if cacheDir, err := findCacheDir(r.ExportDir(), fileKey); err == nil {
+		var version string
+		if meta, err := readExportMeta(cacheDir); err == nil {
+			version = meta.Version
+		}
+		if styles, ok := r.styles.get(fileKey, version); ok {
+			return styles, nil
+		}
+		styles := readStylesFromCache(cacheDir)
+		r.styles.put(fileKey, version, styles)
+		return styles, nil
+	}
+
+	if !r.HasClient() {
+		return nil, errNoCacheNoClient
+	}
+	// The shallow request that would resolve the version for a cache lookup
+	// already carries the styles, so they are not cached.
+	file, err := r.Client().GetFile(ctx, fileKey, &figma.GetFileOptions{Depth: 1})
+	if err != nil {
+		return nil, fmt.Errorf("fetching styles: %w", err)
+	}
+	return file.Styles, nil
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestNodeCache(t *testing.T) {
+	c := newNodeCache()
+	nodes := []*figma.Node{{ID: "1:1"}}
+
+	if _, ok := c.get("abc", "v1"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	c.put("abc", "v1", nodes)
+	got, ok := c.get("abc", "v1")
+	if !ok || len(got) != 1 || got[0].ID != "1:1" {
+		t.Errorf("get(abc, v1) = %v, %v; want cached nodes", got, ok)
+	}
+
+	if _, ok := c.get("abc", "v2"); ok {
+		t.Error("expected miss for a different version")
+	}
+
+	c.put("abc", "", nodes)
+	if _, ok := c.get("abc", ""); ok {
+		t.Error("expected entries without a version to be skipped")
+	}
+}
+
+func TestNodeCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newNodeCache()
+	for i := 0; i < maxCachedVersions; i++ {
+		c.put("abc", fmt.Sprintf("v%d", i), nil)
+	}
+	// Using v0 keeps it; v1 is now the least recently used.
+	if _, ok := c.get("abc", "v0"); !ok {
+		t.Fatal("expected v0 to be cached")
+	}
+	c.put("abc", "new", nil)
+
+	if _, ok := c.get("abc", "v1"); ok {
+		t.Error("expected v1 to be evicted")
+	}
+	for _, v := range []string{"v0", "v2", "new"} {
+		if _, ok := c.get("abc", v); !ok {
+			t.Errorf("expected %s to be cached", v)
+		}
+	}
+	if len(c.entries) != maxCachedVersions || len(c.order) != maxCachedVersions {
+		t.Errorf("cache holds %d entries (%d ordered), want %d", len(c.entries), len(c.order), maxCachedVersions)
+	}
+}
+
+func TestFileStyles_KeyedByExportVersion(t *testing.T) {
+	exportDir := t.TempDir()
+	dir := filepath.Join(exportDir, "site")
+	sync := func(version, name string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, "styles"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeJSON(filepath.Join(dir, "_meta.json"), map[string]any{"fileKey": "KEY", "version": version}); err != nil {
+			t.Fatal(err)
+		}
+		colors := []map[string]any{{"id": "S:1", "name": name}}
+		if err := writeJSON(filepath.Join(dir, "styles", "colors.json"), colors); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := NewRegistry(nil, exportDir)
+
+	sync("v1", "Primary")
+	styles, err := r.fileStyles(context.Background(), "KEY")
+	if err != nil || styles["S:1"] == nil || styles["S:1"].Name != "Primary" {
+		t.Fatalf("fileStyles = %v, %v; want Primary", styles, err)
+	}
+
+	// A later sync_file of a newer version replaces the cached styles.
+	sync("v2", "Brand")
+	styles, err = r.fileStyles(context.Background(), "KEY")
+	if err != nil || styles["S:1"] == nil || styles["S:1"].Name != "Brand" {
+		t.Errorf("fileStyles after resync = %v, %v; want Brand", styles, err)
+	}
+}
@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+	bolt "go.etcd.io/bbolt"
+)
+
+// nodeSnapshotFile is a cached snapshot of every node that sync_file writes
+// next to _index.json. It lets cache reads load the whole tree from one file
+// instead of walking the export and decoding each _node.json, whose subtrees
+// repeat every descendant. It is not an index: nodes are always read in full
+// and filtered afterwards, so any change to the _node.json files must remove
+// it.
+const nodeSnapshotFile = "_nodes.db"
+
+// nodeSnapshotBucket holds one nodeRecord per node keyed by its position in
+// document order, so pages precede their descendants.
+var nodeSnapshotBucket = []byte("nodes")
+
+// nodeRecord is a node stored without its children, which are rebuilt from
+// ParentID when the snapshot is read.
+type nodeRecord struct {
+	ParentID string      `json:"parent_id,omitempty"`
+	Node     *figma.Node `json:"node"`
+}
+
+// writeNodeSnapshot replaces the node snapshot of an export with the nodes of
+// pages.
+func writeNodeSnapshot(exportPath string, pages []*figma.Node) error {
+	path := filepath.Join(exportPath, nodeSnapshotFile)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket(nodeSnapshotBucket)
+		if err != nil {
+			return err
+		}
+
+		var put func(node *figma.Node, parentID string) error
+		put = func(node *figma.Node, parentID string) error {
+			shallow := *node
+			shallow.Children = nil
+			data, err := json.Marshal(nodeRecord{ParentID: parentID, Node: &shallow})
+			if err != nil {
+				return fmt.Errorf("encoding node %s: %w", node.ID, err)
+			}
+			seq, _ := b.NextSequence()
+			key := make([]byte, 8)
+			binary.BigEndian.PutUint64(key, seq)
+			if err := b.Put(key, data); err != nil {
+				return err
+			}
+			for _, child := range node.Children {
+				if err := put(child, node.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for _, page := range pages {
+			if err := put(page, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// readNodeSnapshot loads every node of an export's snapshot in document
+// order, with children linked as in _node.json. It fails when the export has
+// no snapshot.
+func readNodeSnapshot(exportPath string) ([]*figma.Node, error) {
+	path := filepath.Join(exportPath, nodeSnapshotFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0444, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var nodes []*figma.Node
+	byID := make(map[string]*figma.Node)
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(nodeSnapshotBucket)
+		if b == nil {
+			return fmt.Errorf("%s has no nodes bucket", path)
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var rec nodeRecord
+			if err := json.Unmarshal(v, &rec); err != nil || rec.Node == nil {
+				return fmt.Errorf("parsing %s: bad record", path)
+			}
+			if parent := byID[rec.ParentID]; parent != nil {
+				parent.Children = append(parent.Children, rec.Node)
+			}
+			byID[rec.Node.ID] = rec.Node
+			nodes = append(nodes, rec.Node)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// removeNodeSnapshot deletes an export's node snapshot after its node files
+// change, so reads fall back to the files until the next sync_file.
+func removeNodeSnapshot(exportPath string) error {
+	err := os.Remove(filepath.Join(exportPath, nodeSnapshotFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
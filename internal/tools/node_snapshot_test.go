@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestNodeSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	if err := writeNodeSnapshot(dir, []*figma.Node{testSyncPage()}); err != nil {
+		t.Fatal(err)
+	}
+	// Rewriting replaces the previous snapshot.
+	if err := writeNodeSnapshot(dir, []*figma.Node{testSyncPage()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// There are no _node.json files, so these nodes come from the snapshot.
+	nodes, err := readNodesFromExport(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+	}
+	if want := []string{"0:1", "1:1", "1:2", "1:3"}; !equalStrings(ids, want) {
+		t.Fatalf("ids = %v, want %v in document order", ids, want)
+	}
+
+	page, frame := nodes[0], nodes[1]
+	if len(page.Children) != 1 || page.Children[0] != frame {
+		t.Errorf("page children = %v, want the frame", page.Children)
+	}
+	if len(frame.Children) != 2 || frame.Children[0].Name != "Label" || frame.Children[1].Type != figma.NodeTypeVector {
+		t.Errorf("frame children = %v", frame.Children)
+	}
+	if pages := nodePages(nodes); pages["1:3"] != "Page 1" {
+		t.Errorf("page of 1:3 = %q", pages["1:3"])
+	}
+
+	if err := removeNodeSnapshot(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, nodeSnapshotFile)); !os.IsNotExist(err) {
+		t.Errorf("node snapshot not removed: %v", err)
+	}
+	if err := removeNodeSnapshot(dir); err != nil {
+		t.Errorf("removing a missing snapshot: %v", err)
+	}
+	if _, err := readNodeSnapshot(dir); err == nil {
+		t.Error("expected error reading a missing snapshot")
+	}
+}
@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// GetOverridesArgs contains arguments for the get_overrides tool.
+type GetOverridesArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	NodeID  string `json:"node_id" jsonschema:"ID of an INSTANCE node"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// FieldOverride is one property an instance layer changes from its main
+// component.
+type FieldOverride struct {
+	NodeID   string          `json:"node_id"`
+	NodeName string          `json:"node_name"`
+	MasterID string          `json:"master_id,omitempty"`
+	Field    string          `json:"field"`
+	Master   json.RawMessage `json:"master,omitempty"`
+	Instance json.RawMessage `json:"instance,omitempty"`
+}
+
+// GetOverridesResult contains the result of get_overrides.
+type GetOverridesResult struct {
+	InstanceID    string          `json:"instance_id"`
+	InstanceName  string          `json:"instance_name"`
+	ComponentID   string          `json:"component_id"`
+	ComponentName string          `json:"component_name,omitempty"`
+	Overrides     []FieldOverride `json:"overrides"`
+	Note          string          `json:"note,omitempty"`
+}
+
+func registerGetOverridesTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_overrides",
+		Description: "List the properties a component instance overrides, with the main component's value next to the instance's value for each field.",
+		InputSchema: inputSchema[GetOverridesArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetOverridesArgs) (*mcp.CallToolResult, *GetOverridesResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.NodeID == "" {
+			return nil, nil, errNodeIDRequired
+		}
+		args.NodeID = r.ResolveNodeID(args.NodeID)
+
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		nodes, err := r.Client().GetFileNodes(ctx, args.FileKey, []string{args.NodeID}, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching node: %w", err)
+		}
+		wrapper, ok := nodes.Nodes[args.NodeID]
+		if !ok || wrapper.Document == nil {
+			return nil, nil, fmt.Errorf("node %s not found", args.NodeID)
+		}
+		instance := wrapper.Document
+		if instance.Type != figma.NodeTypeInstance {
+			return nil, nil, fmt.Errorf("node %s is a %s, not an INSTANCE", args.NodeID, instance.Type)
+		}
+
+		// Components from libraries are not nodes of this file.
+		var master *figma.Node
+		if instance.ComponentID != "" {
+			masters, err := r.Client().GetFileNodes(ctx, args.FileKey, []string{instance.ComponentID}, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("fetching main component: %w", err)
+			}
+			if w := masters.Nodes[instance.ComponentID]; w != nil {
+				master = w.Document
+			}
+		}
+
+		result := compareOverrides(instance, master)
+		if c := wrapper.Components[instance.ComponentID]; c != nil && result.ComponentName == "" {
+			result.ComponentName = c.Name
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatOverridesResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// compareOverrides reads the fields listed in an instance's overrides from
+// the overridden layer and from its counterpart in master. Master may be nil
+// when the main component is not in the file.
+func compareOverrides(instance, master *figma.Node) *GetOverridesResult {
+	result := &GetOverridesResult{
+		InstanceID:   instance.ID,
+		InstanceName: instance.Name,
+		ComponentID:  instance.ComponentID,
+		Overrides:    []FieldOverride{},
+	}
+	if master != nil {
+		result.ComponentName = master.Name
+	} else {
+		result.Note = "main component is not in this file (library component?); master values are unavailable"
+	}
+
+	instanceNodes := make(map[string]*figma.Node)
+	indexSubtree(instance, instanceNodes)
+	masterNodes := make(map[string]*figma.Node)
+	if master != nil {
+		indexSubtree(master, masterNodes)
+	}
+
+	for _, o := range instance.Overrides {
+		layer := instanceNodes[o.ID]
+		masterID := instanceLayerMasterID(instance.ID, o.ID, instance.ComponentID)
+		counterpart := masterNodes[masterID]
+
+		layerFields, masterFields := nodeFieldsJSON(layer), nodeFieldsJSON(counterpart)
+		for _, field := range o.OverriddenFields {
+			fo := FieldOverride{
+				NodeID:   o.ID,
+				MasterID: masterID,
+				Field:    field,
+				Master:   masterFields[field],
+				Instance: layerFields[field],
+			}
+			if layer != nil {
+				fo.NodeName = layer.Name
+			}
+			result.Overrides = append(result.Overrides, fo)
+		}
+	}
+
+	return result
+}
+
+func indexSubtree(node *figma.Node, index map[string]*figma.Node) {
+	index[node.ID] = node
+	for _, child := range node.Children {
+		indexSubtree(child, index)
+	}
+}
+
+// instanceLayerMasterID returns the ID of the main component layer an
+// instance layer was created from. Layers inside an instance have IDs like
+// I10:1;3:4, where the last segment is the layer in the component.
+func instanceLayerMasterID(instanceID, layerID, componentID string) string {
+	if layerID == instanceID {
+		return componentID
+	}
+	if i := strings.LastIndex(layerID, ";"); i >= 0 {
+		return layerID[i+1:]
+	}
+	return ""
+}
+
+// nodeFieldsJSON returns a node's properties keyed by their API names, e.g.
+// fills or characters. Children are left out.
+func nodeFieldsJSON(node *figma.Node) map[string]json.RawMessage {
+	if node == nil {
+		return nil
+	}
+	shallow := *node
+	shallow.Children = nil
+	b, err := json.Marshal(&shallow)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+func formatOverridesResult(r *GetOverridesResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Instance: %s [%s]\n", r.InstanceName, r.InstanceID))
+	if r.ComponentName != "" {
+		sb.WriteString(fmt.Sprintf("Component: %s [%s]\n", r.ComponentName, r.ComponentID))
+	} else {
+		sb.WriteString(fmt.Sprintf("Component: [%s]\n", r.ComponentID))
+	}
+	if r.Note != "" {
+		sb.WriteString(fmt.Sprintf("Note: %s\n", r.Note))
+	}
+
+	if len(r.Overrides) == 0 {
+		sb.WriteString("\nNo overrides\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\nOverrides (%d):\n", len(r.Overrides)))
+	lastNode := ""
+	for _, o := range r.Overrides {
+		if o.NodeID != lastNode {
+			sb.WriteString(fmt.Sprintf("  %s [%s]\n", o.NodeName, o.NodeID))
+			lastNode = o.NodeID
+		}
+		sb.WriteString(fmt.Sprintf("    %s: %s → %s\n", o.Field, overrideValueText(o.Master), overrideValueText(o.Instance)))
+	}
+
+	return sb.String()
+}
+
+func overrideValueText(v json.RawMessage) string {
+	if len(v) == 0 {
+		return "(unset)"
+	}
+	return truncateText(string(v), 80)
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCompareOverrides(t *testing.T) {
+	hidden := false
+	master := &figma.Node{
+		ID: "3:1", Name: "Button", Type: figma.NodeTypeComponent,
+		Fills: []figma.Paint{{Type: "SOLID", Color: &figma.Color{B: 1, A: 1}}},
+		Children: []*figma.Node{
+			{ID: "3:2", Name: "Label", Type: figma.NodeTypeText, Characters: "Button"},
+			{ID: "3:3", Name: "Icon", Type: figma.NodeTypeVector},
+		},
+	}
+	instance := &figma.Node{
+		ID: "10:1", Name: "Buy button", Type: figma.NodeTypeInstance, ComponentID: "3:1",
+		Fills: []figma.Paint{{Type: "SOLID", Color: &figma.Color{R: 1, A: 1}}},
+		Children: []*figma.Node{
+			{ID: "I10:1;3:2", Name: "Label", Type: figma.NodeTypeText, Characters: "Buy now"},
+			{ID: "I10:1;3:3", Name: "Icon", Type: figma.NodeTypeVector, Visible: &hidden},
+		},
+		Overrides: []figma.Override{
+			{ID: "10:1", OverriddenFields: []string{"fills"}},
+			{ID: "I10:1;3:2", OverriddenFields: []string{"characters"}},
+			{ID: "I10:1;3:3", OverriddenFields: []string{"visible"}},
+		},
+	}
+
+	result := compareOverrides(instance, master)
+	if result.ComponentName != "Button" || len(result.Overrides) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	byField := make(map[string]FieldOverride)
+	for _, o := range result.Overrides {
+		byField[o.Field] = o
+	}
+	if o := byField["fills"]; o.MasterID != "3:1" || !strings.Contains(string(o.Master), `"b":1`) || !strings.Contains(string(o.Instance), `"r":1`) {
+		t.Errorf("fills override = %+v", o)
+	}
+	if o := byField["characters"]; o.MasterID != "3:2" || string(o.Master) != `"Button"` || string(o.Instance) != `"Buy now"` {
+		t.Errorf("characters override = %s → %s", o.Master, o.Instance)
+	}
+	if o := byField["visible"]; o.Master != nil || string(o.Instance) != "false" {
+		t.Errorf("visible override = %s → %s", o.Master, o.Instance)
+	}
+
+	text := formatOverridesResult(result)
+	if !strings.Contains(text, `characters: "Button" → "Buy now"`) || !strings.Contains(text, "visible: (unset) → false") {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+
+	if result := compareOverrides(instance, nil); result.Note == "" || result.Overrides[1].Master != nil {
+		t.Errorf("expected a note and no master values without the main component: %+v", result)
+	}
+}
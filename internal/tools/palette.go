@@ -0,0 +1,290 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// GenerateColorPaletteArgs contains arguments for the generate_color_palette tool.
+type GenerateColorPaletteArgs struct {
+	FileKey string  `json:"file_key" jsonschema:"Figma file key"`
+	DeltaE  float64 `json:"delta_e,omitempty" jsonschema:"Max RGB distance (0-441) for colors to share a cluster (default: 12)"`
+	Format  string  `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// PaletteColor is a cluster of similar hard-coded colors.
+type PaletteColor struct {
+	Name     string   `json:"name"`           // suggested token name, e.g. blue-500
+	Role     string   `json:"role,omitempty"` // primary or secondary for the most used hues
+	Hex      string   `json:"hex"`            // most used color in the cluster
+	Count    int      `json:"count"`
+	Members  []string `json:"members"` // every color in the cluster
+	Examples []string `json:"examples"`
+}
+
+// GenerateColorPaletteResult contains the result of generate_color_palette.
+type GenerateColorPaletteResult struct {
+	Colors       []PaletteColor `json:"colors"`
+	UniqueColors int            `json:"unique_colors"`
+	DeltaE       float64        `json:"delta_e"`
+	Cached       bool           `json:"cached"`
+}
+
+func registerGenerateColorPaletteTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "generate_color_palette",
+		Description: "Cluster solid fill colors that are not bound to variables and suggest token names (primary, blue-500, gray-100...).",
+		InputSchema: inputSchema[GenerateColorPaletteArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GenerateColorPaletteArgs) (*mcp.CallToolResult, *GenerateColorPaletteResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		deltaE := args.DeltaE
+		if deltaE <= 0 {
+			deltaE = 12
+		}
+
+		source, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := generateColorPalette(source.Nodes, deltaE)
+		result.Cached = source.Cached
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatColorPaletteResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// paletteEntry is one unique hard-coded color and where it is used.
+type paletteEntry struct {
+	color    figma.Color
+	hex      string
+	count    int
+	examples []string
+}
+
+func generateColorPalette(nodes []*figma.Node, deltaE float64) *GenerateColorPaletteResult {
+	entries := make(map[string]*paletteEntry)
+	for _, node := range nodes {
+		for _, fill := range node.Fills {
+			if fill.Type != "SOLID" || fill.Color == nil || (fill.Visible != nil && !*fill.Visible) {
+				continue
+			}
+			if _, bound := fill.BoundVariables["color"]; bound {
+				continue
+			}
+			c := *fill.Color
+			if fill.Opacity != nil {
+				c.A *= *fill.Opacity
+			}
+			hex := colorKey(&c)
+			e := entries[hex]
+			if e == nil {
+				e = &paletteEntry{color: c, hex: hex}
+				entries[hex] = e
+			}
+			e.count++
+			if len(e.examples) < maxAuditExamples {
+				e.examples = append(e.examples, node.ID)
+			}
+		}
+	}
+
+	sorted := make([]*paletteEntry, 0, len(entries))
+	for _, e := range entries {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return sorted[i].hex < sorted[j].hex
+	})
+
+	// Greedy clustering: each color joins the first cluster whose most used
+	// color is within deltaE, so clusters are seeded by the most used colors.
+	type cluster struct {
+		seed    *paletteEntry
+		members []*paletteEntry
+	}
+	var clusters []*cluster
+	for _, e := range sorted {
+		var home *cluster
+		for _, c := range clusters {
+			if rgbDistance(c.seed.color, e.color) <= deltaE {
+				home = c
+				break
+			}
+		}
+		if home == nil {
+			home = &cluster{seed: e}
+			clusters = append(clusters, home)
+		}
+		home.members = append(home.members, e)
+	}
+
+	result := &GenerateColorPaletteResult{
+		Colors:       []PaletteColor{},
+		UniqueColors: len(entries),
+		DeltaE:       deltaE,
+	}
+	names := make(map[string]int)
+	roles := []string{"primary", "secondary"}
+
+	for _, c := range clusters {
+		pc := PaletteColor{Hex: c.seed.hex}
+		for _, m := range c.members {
+			pc.Count += m.count
+			pc.Members = append(pc.Members, m.hex)
+			for _, ex := range m.examples {
+				if len(pc.Examples) < maxAuditExamples {
+					pc.Examples = append(pc.Examples, ex)
+				}
+			}
+		}
+
+		family, shade := colorFamily(c.seed.color)
+		name := family
+		if shade > 0 {
+			name = fmt.Sprintf("%s-%d", family, shade)
+		}
+		names[name]++
+		if n := names[name]; n > 1 {
+			name = fmt.Sprintf("%s-%d", name, n)
+		}
+		pc.Name = name
+
+		if len(roles) > 0 && family != "gray" && family != "black" && family != "white" {
+			pc.Role, roles = roles[0], roles[1:]
+		}
+
+		result.Colors = append(result.Colors, pc)
+	}
+
+	sort.SliceStable(result.Colors, func(i, j int) bool { return result.Colors[i].Count > result.Colors[j].Count })
+	return result
+}
+
+// rgbDistance is the Euclidean distance between two colors on a 0-255 scale.
+func rgbDistance(a, b figma.Color) float64 {
+	dr, dg, db := (a.R-b.R)*255, (a.G-b.G)*255, (a.B-b.B)*255
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
+// colorFamily names a color by hue and a 50-900 shade from its lightness.
+// Black and white have no shade.
+func colorFamily(c figma.Color) (string, int) {
+	h, s, l := rgbToHSL(c)
+
+	switch {
+	case l <= 0.05:
+		return "black", 0
+	case l >= 0.97:
+		return "white", 0
+	}
+
+	shade := int(math.Round((1-l)*10)) * 100
+	shade = max(50, min(900, shade))
+
+	if s < 0.12 {
+		return "gray", shade
+	}
+
+	var family string
+	switch {
+	case h < 15 || h >= 335:
+		family = "red"
+	case h < 45:
+		family = "orange"
+	case h < 70:
+		family = "yellow"
+	case h < 165:
+		family = "green"
+	case h < 195:
+		family = "cyan"
+	case h < 255:
+		family = "blue"
+	case h < 290:
+		family = "purple"
+	default:
+		family = "pink"
+	}
+	return family, shade
+}
+
+// rgbToHSL converts a color to hue (0-360), saturation and lightness (0-1).
+func rgbToHSL(c figma.Color) (h, s, l float64) {
+	maxC := math.Max(c.R, math.Max(c.G, c.B))
+	minC := math.Min(c.R, math.Min(c.G, c.B))
+	l = (maxC + minC) / 2
+
+	d := maxC - minC
+	if d == 0 {
+		return 0, 0, l
+	}
+	s = d / (1 - math.Abs(2*l-1))
+
+	switch maxC {
+	case c.R:
+		h = math.Mod((c.G-c.B)/d, 6)
+	case c.G:
+		h = (c.B-c.R)/d + 2
+	default:
+		h = (c.R-c.G)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+func formatColorPaletteResult(r *GenerateColorPaletteResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Palette: %d clusters from %d unbound colors (delta_e %.0f)\n\n", len(r.Colors), r.UniqueColors, r.DeltaE))
+
+	if len(r.Colors) == 0 {
+		sb.WriteString("No unbound solid fills found.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	for _, c := range r.Colors {
+		name := c.Name
+		if c.Role != "" {
+			name = fmt.Sprintf("%s (%s)", c.Role, c.Name)
+		}
+		sb.WriteString(fmt.Sprintf("%-24s %-10s %4dx", name, c.Hex, c.Count))
+		if len(c.Members) > 1 {
+			sb.WriteString(fmt.Sprintf("  also: %s", strings.Join(c.Members[1:], ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestGenerateColorPalette(t *testing.T) {
+	blue := solid(0.15, 0.39, 0.92)
+	nearBlue := solid(0.16, 0.40, 0.93)
+	red := solid(0.86, 0.15, 0.15)
+	gray := solid(0.5, 0.5, 0.5)
+	bound := solid(0.86, 0.15, 0.15)
+	bound[0].BoundVariables = map[string]*figma.VariableAlias{"color": {Type: "VARIABLE_ALIAS", ID: "VariableID:1"}}
+
+	nodes := []*figma.Node{
+		{ID: "1:1", Fills: blue},
+		{ID: "1:2", Fills: blue},
+		{ID: "1:3", Fills: nearBlue},
+		{ID: "1:4", Fills: red},
+		{ID: "1:5", Fills: gray},
+		{ID: "1:6", Fills: bound},
+	}
+
+	result := generateColorPalette(nodes, 12)
+
+	if result.UniqueColors != 4 {
+		t.Errorf("UniqueColors = %d, want 4", result.UniqueColors)
+	}
+	if len(result.Colors) != 3 {
+		t.Fatalf("got %d clusters, want 3: %+v", len(result.Colors), result.Colors)
+	}
+
+	first := result.Colors[0]
+	if first.Name != "blue-500" || first.Role != "primary" || first.Count != 3 || len(first.Members) != 2 {
+		t.Errorf("first cluster = %+v, want primary blue-500 with 2 members and 3 uses", first)
+	}
+
+	roles := map[string]string{}
+	for _, c := range result.Colors {
+		roles[c.Name] = c.Role
+	}
+	if roles["red-500"] != "secondary" {
+		t.Errorf("red role = %q, want secondary", roles["red-500"])
+	}
+	if role, ok := roles["gray-500"]; !ok || role != "" {
+		t.Errorf("gray cluster missing or given role %q: %v", role, roles)
+	}
+}
+
+func TestColorFamily(t *testing.T) {
+	tests := []struct {
+		color  figma.Color
+		family string
+		shade  int
+	}{
+		{figma.Color{R: 0, G: 0, B: 0, A: 1}, "black", 0},
+		{figma.Color{R: 1, G: 1, B: 1, A: 1}, "white", 0},
+		{figma.Color{R: 0.9, G: 0.9, B: 0.9, A: 1}, "gray", 100},
+		{figma.Color{R: 0, G: 0.6, B: 0, A: 1}, "green", 700},
+		{figma.Color{R: 1, G: 0.5, B: 0, A: 1}, "orange", 500},
+		{figma.Color{R: 0.5, G: 0, B: 0.8, A: 1}, "purple", 600},
+	}
+
+	for _, tt := range tests {
+		family, shade := colorFamily(tt.color)
+		if family != tt.family || shade != tt.shade {
+			t.Errorf("colorFamily(%+v) = %s %d, want %s %d", tt.color, family, shade, tt.family, tt.shade)
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// designPatterns lists the patterns detect_design_patterns recognizes.
+var designPatterns = []string{"navbar", "card", "form"}
+
+var (
+	navbarNameRegex = regexp.MustCompile(`(?i)nav|header|menu|top ?bar|tab ?bar`)
+	cardNameRegex   = regexp.MustCompile(`(?i)card|tile`)
+	formNameRegex   = regexp.MustCompile(`(?i)form|login|log in|sign ?in|sign ?up|register|checkout|contact`)
+	inputNameRegex  = regexp.MustCompile(`(?i)input|field|text ?box|select|dropdown`)
+	buttonNameRegex = regexp.MustCompile(`(?i)button|btn|submit|cta`)
+)
+
+// DetectDesignPatternsArgs contains arguments for the detect_design_patterns tool.
+type DetectDesignPatternsArgs struct {
+	FileKey       string   `json:"file_key" jsonschema:"Figma file key"`
+	Patterns      []string `json:"patterns,omitempty" jsonschema:"Patterns to detect: navbar, card, form (default: all)"`
+	MinConfidence float64  `json:"min_confidence,omitempty" jsonschema:"Minimum confidence from 0 to 1 (default 0.5)"`
+	Format        string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// DetectedPattern is a node that looks like a common UI pattern.
+type DetectedPattern struct {
+	Pattern    string   `json:"pattern"`
+	NodeID     string   `json:"node_id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Confidence float64  `json:"confidence"`
+	Reasons    []string `json:"reasons"`
+}
+
+// DetectDesignPatternsResult contains the result of detect_design_patterns.
+type DetectDesignPatternsResult struct {
+	Patterns []DetectedPattern `json:"patterns"`
+	Counts   map[string]int    `json:"counts"`
+	Scanned  int               `json:"scanned"`
+	Cached   bool              `json:"cached,omitempty"`
+}
+
+func registerDetectDesignPatternsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "detect_design_patterns",
+		Description: "Find frames that look like common UI patterns (navbar, card, form) from their structure, with a confidence score and the evidence for each match. Useful for choosing framework components such as an MUI Card before implementing.",
+		InputSchema: inputSchema[DetectDesignPatternsArgs](map[string][]string{
+			"patterns": designPatterns,
+			"format":   responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DetectDesignPatternsArgs) (*mcp.CallToolResult, *DetectDesignPatternsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		patterns := args.Patterns
+		if len(patterns) == 0 {
+			patterns = designPatterns
+		}
+		for _, p := range patterns {
+			if !containsString(designPatterns, p) {
+				return nil, nil, fmt.Errorf("unknown pattern %q (valid: %s)", p, strings.Join(designPatterns, ", "))
+			}
+		}
+		minConfidence := args.MinConfidence
+		if minConfidence <= 0 {
+			minConfidence = 0.5
+		}
+
+		src, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := detectDesignPatterns(src.Nodes, patterns, minConfidence)
+		result.Cached = src.Cached
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatDetectDesignPatternsResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// patternDetector scores how much a node looks like a pattern, from 0 to 1,
+// and explains the score.
+type patternDetector func(node *figma.Node) (float64, []string)
+
+var patternDetectors = map[string]patternDetector{
+	"navbar": detectNavbar,
+	"card":   detectCard,
+	"form":   detectForm,
+}
+
+// detectDesignPatterns runs the detectors for patterns over every visible
+// container node and keeps the matches scoring at least minConfidence,
+// best first.
+func detectDesignPatterns(nodes []*figma.Node, patterns []string, minConfidence float64) *DetectDesignPatternsResult {
+	result := &DetectDesignPatternsResult{Patterns: []DetectedPattern{}, Counts: make(map[string]int)}
+
+	for _, node := range nodes {
+		switch node.Type {
+		case figma.NodeTypeFrame, figma.NodeTypeComponent, figma.NodeTypeInstance, figma.NodeTypeGroup:
+		default:
+			continue
+		}
+		if isHidden(node) {
+			continue
+		}
+		result.Scanned++
+
+		for _, p := range patterns {
+			confidence, reasons := patternDetectors[p](node)
+			confidence = math.Round(math.Min(confidence, 1)*100) / 100
+			if confidence < minConfidence {
+				continue
+			}
+			result.Patterns = append(result.Patterns, DetectedPattern{
+				Pattern:    p,
+				NodeID:     node.ID,
+				Name:       node.Name,
+				Type:       string(node.Type),
+				Confidence: confidence,
+				Reasons:    reasons,
+			})
+			result.Counts[p]++
+		}
+	}
+
+	sort.SliceStable(result.Patterns, func(i, j int) bool {
+		if result.Patterns[i].Confidence != result.Patterns[j].Confidence {
+			return result.Patterns[i].Confidence > result.Patterns[j].Confidence
+		}
+		return result.Patterns[i].Name < result.Patterns[j].Name
+	})
+
+	return result
+}
+
+// detectNavbar looks for a horizontal row of at least three text or
+// instance items, ideally in a wide, short strip.
+func detectNavbar(node *figma.Node) (float64, []string) {
+	children := visibleChildren(node)
+	items := 0
+	for _, c := range children {
+		if c.Type == figma.NodeTypeText || c.Type == figma.NodeTypeInstance {
+			items++
+		}
+	}
+	if items < 3 || !arrangedHorizontally(node, children) {
+		return 0, nil
+	}
+
+	score := 0.5
+	reasons := []string{fmt.Sprintf("horizontal row of %d text/instance items", items)}
+	if w, h := nodeSize(node); h > 0 && w >= 4*h {
+		score += 0.2
+		reasons = append(reasons, fmt.Sprintf("wide strip (%.0fx%.0f)", w, h))
+		if h <= 120 {
+			score += 0.15
+			reasons = append(reasons, "bar height")
+		}
+	}
+	if navbarNameRegex.MatchString(node.Name) {
+		score += 0.15
+		reasons = append(reasons, "name suggests navigation")
+	}
+	return score, reasons
+}
+
+// detectCard looks for a vertical stack with text, ideally led by an image
+// and set apart by rounded corners, a shadow or a border.
+func detectCard(node *figma.Node) (float64, []string) {
+	children := visibleChildren(node)
+	if len(children) < 2 || !arrangedVertically(node, children) {
+		return 0, nil
+	}
+	texts := countDescendants(node, 2, func(n *figma.Node) bool { return n.Type == figma.NodeTypeText })
+	if texts == 0 {
+		return 0, nil
+	}
+
+	score := 0.3
+	reasons := []string{fmt.Sprintf("vertical stack with %d text layers", texts)}
+	if countDescendants(node, 2, hasImageFill) > 0 || hasImageFill(node) {
+		score += 0.3
+		reasons = append(reasons, "image fill")
+	}
+	if node.CornerRadius > 0 {
+		score += 0.1
+		reasons = append(reasons, "rounded corners")
+	}
+	if len(node.Effects) > 0 || len(node.Strokes) > 0 {
+		score += 0.1
+		reasons = append(reasons, "shadow or border")
+	}
+	if cardNameRegex.MatchString(node.Name) {
+		score += 0.2
+		reasons = append(reasons, "name suggests a card")
+	}
+	return score, reasons
+}
+
+// detectForm looks for two or more input-like boxes, ideally with a button.
+func detectForm(node *figma.Node) (float64, []string) {
+	inputs := 0
+	button := false
+	var walk func(n *figma.Node, depth int)
+	walk = func(n *figma.Node, depth int) {
+		for _, c := range visibleChildren(n) {
+			if isInputLike(c) {
+				inputs++
+				continue
+			}
+			if buttonNameRegex.MatchString(c.Name) {
+				button = true
+				continue
+			}
+			if depth < 3 {
+				walk(c, depth+1)
+			}
+		}
+	}
+	walk(node, 1)
+	if inputs < 2 {
+		return 0, nil
+	}
+
+	score := 0.4 + math.Min(float64(inputs-2)*0.1, 0.2)
+	reasons := []string{fmt.Sprintf("%d input-like fields", inputs)}
+	if button {
+		score += 0.2
+		reasons = append(reasons, "button")
+	}
+	if formNameRegex.MatchString(node.Name) {
+		score += 0.2
+		reasons = append(reasons, "name suggests a form")
+	}
+	return score, reasons
+}
+
+// isInputLike reports whether a node looks like a text input: a bordered or
+// filled box of field height, at least three times as wide as tall, or a
+// layer named like one.
+func isInputLike(n *figma.Node) bool {
+	switch n.Type {
+	case figma.NodeTypeRectangle, figma.NodeTypeFrame, figma.NodeTypeInstance:
+	default:
+		return false
+	}
+	if inputNameRegex.MatchString(n.Name) {
+		return true
+	}
+	w, h := nodeSize(n)
+	return h >= 24 && h <= 64 && w >= 3*h && len(n.Strokes) > 0
+}
+
+func hasImageFill(n *figma.Node) bool {
+	for _, f := range n.Fills {
+		if f.Type == "IMAGE" && (f.Visible == nil || *f.Visible) {
+			return true
+		}
+	}
+	return false
+}
+
+func visibleChildren(n *figma.Node) []*figma.Node {
+	var children []*figma.Node
+	for _, c := range n.Children {
+		if !isHidden(c) {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// countDescendants counts the nodes within depth levels below n that match.
+func countDescendants(n *figma.Node, depth int, match func(*figma.Node) bool) int {
+	count := 0
+	for _, c := range visibleChildren(n) {
+		if match(c) {
+			count++
+		}
+		if depth > 1 {
+			count += countDescendants(c, depth-1, match)
+		}
+	}
+	return count
+}
+
+func nodeSize(n *figma.Node) (float64, float64) {
+	if b := n.AbsoluteBoundingBox; b != nil {
+		return b.Width, b.Height
+	}
+	return 0, 0
+}
+
+// arrangedHorizontally reports whether children form a row: auto layout
+// says so, or without auto layout their vertical centers line up.
+func arrangedHorizontally(n *figma.Node, children []*figma.Node) bool {
+	switch n.LayoutMode {
+	case "HORIZONTAL":
+		return true
+	case "VERTICAL":
+		return false
+	}
+	return alignedCenters(children, func(b *figma.Rectangle) (float64, float64) { return b.Y + b.Height/2, b.Height })
+}
+
+// arrangedVertically reports whether children form a column.
+func arrangedVertically(n *figma.Node, children []*figma.Node) bool {
+	switch n.LayoutMode {
+	case "VERTICAL":
+		return true
+	case "HORIZONTAL":
+		return false
+	}
+	return alignedCenters(children, func(b *figma.Rectangle) (float64, float64) { return b.X + b.Width/2, b.Width }) ||
+		stackedVertically(children)
+}
+
+// alignedCenters reports whether every child's center on one axis lies
+// within half the smallest child extent of the first child's center.
+func alignedCenters(children []*figma.Node, axis func(*figma.Rectangle) (center, extent float64)) bool {
+	var first, tolerance float64
+	seen := 0
+	for _, c := range children {
+		if c.AbsoluteBoundingBox == nil {
+			continue
+		}
+		center, extent := axis(c.AbsoluteBoundingBox)
+		if seen == 0 || extent/2 < tolerance {
+			tolerance = extent / 2
+		}
+		if seen == 0 {
+			first = center
+		} else if math.Abs(center-first) > tolerance {
+			return false
+		}
+		seen++
+	}
+	return seen >= 2
+}
+
+// stackedVertically reports whether children do not overlap vertically, in
+// some order: each one starts below where the previous one ends.
+func stackedVertically(children []*figma.Node) bool {
+	var boxes []*figma.Rectangle
+	for _, c := range children {
+		if c.AbsoluteBoundingBox != nil {
+			boxes = append(boxes, c.AbsoluteBoundingBox)
+		}
+	}
+	if len(boxes) < 2 {
+		return false
+	}
+	sort.Slice(boxes, func(i, j int) bool { return boxes[i].Y < boxes[j].Y })
+	for i := 1; i < len(boxes); i++ {
+		if boxes[i].Y < boxes[i-1].Y+boxes[i-1].Height-1 {
+			return false
+		}
+	}
+	return true
+}
+
+func formatDetectDesignPatternsResult(r *DetectDesignPatternsResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Design patterns: %d found in %d frames\n", len(r.Patterns), r.Scanned))
+	if len(r.Patterns) == 0 {
+		sb.WriteString("\nNo patterns detected.\n")
+		return sb.String()
+	}
+	var counts []string
+	for _, p := range designPatterns {
+		if n := r.Counts[p]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%s %d", p, n))
+		}
+	}
+	sb.WriteString(strings.Join(counts, ", ") + "\n\n")
+
+	sb.WriteString("Pattern | Conf | ID         | Name\n")
+	sb.WriteString("------- | ---- | ---------- | ----\n")
+	for _, p := range r.Patterns {
+		sb.WriteString(fmt.Sprintf("%-7s | %.2f | %-10s | %s\n", p.Pattern, p.Confidence, p.NodeID, p.Name))
+		sb.WriteString(fmt.Sprintf("        |      |            |   %s\n", strings.Join(p.Reasons, "; ")))
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
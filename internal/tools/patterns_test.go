@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func box(x, y, w, h float64) *figma.Rectangle {
+	return &figma.Rectangle{X: x, Y: y, Width: w, Height: h}
+}
+
+func TestDetectDesignPatterns(t *testing.T) {
+	navbar := &figma.Node{
+		ID: "1:1", Name: "Header", Type: figma.NodeTypeFrame, LayoutMode: "HORIZONTAL",
+		AbsoluteBoundingBox: box(0, 0, 1440, 64),
+		Children: []*figma.Node{
+			{ID: "1:2", Type: figma.NodeTypeInstance, Name: "Logo"},
+			{ID: "1:3", Type: figma.NodeTypeText, Name: "Home"},
+			{ID: "1:4", Type: figma.NodeTypeText, Name: "Pricing"},
+			{ID: "1:5", Type: figma.NodeTypeText, Name: "Docs"},
+		},
+	}
+	card := &figma.Node{
+		ID: "2:1", Name: "Product", Type: figma.NodeTypeFrame, CornerRadius: 8,
+		Effects: []figma.Effect{{Type: "DROP_SHADOW"}},
+		Children: []*figma.Node{
+			{ID: "2:2", Type: figma.NodeTypeRectangle, AbsoluteBoundingBox: box(0, 0, 300, 200), Fills: []figma.Paint{{Type: "IMAGE"}}},
+			{ID: "2:3", Type: figma.NodeTypeText, AbsoluteBoundingBox: box(16, 216, 200, 24)},
+			{ID: "2:4", Type: figma.NodeTypeText, AbsoluteBoundingBox: box(16, 248, 268, 40)},
+		},
+	}
+	field := func(id string, y float64) *figma.Node {
+		return &figma.Node{ID: id, Type: figma.NodeTypeFrame, Name: "Box", AbsoluteBoundingBox: box(0, y, 320, 40), Strokes: []figma.Paint{{Type: "SOLID"}}}
+	}
+	form := &figma.Node{
+		ID: "3:1", Name: "Sign in", Type: figma.NodeTypeFrame, LayoutMode: "VERTICAL",
+		Children: []*figma.Node{
+			{ID: "3:2", Type: figma.NodeTypeFrame, Name: "Email", Children: []*figma.Node{field("3:3", 0)}},
+			field("3:4", 60),
+			{ID: "3:5", Type: figma.NodeTypeInstance, Name: "Button/Primary"},
+		},
+	}
+	plain := &figma.Node{
+		ID: "4:1", Name: "Divider", Type: figma.NodeTypeFrame, LayoutMode: "HORIZONTAL",
+		Children: []*figma.Node{{ID: "4:2", Type: figma.NodeTypeRectangle}},
+	}
+
+	result := detectDesignPatterns([]*figma.Node{navbar, card, form, plain}, designPatterns, 0.5)
+
+	found := make(map[string]DetectedPattern)
+	for _, p := range result.Patterns {
+		found[p.NodeID+" "+p.Pattern] = p
+	}
+	for _, want := range []struct {
+		key string
+		min float64
+	}{
+		{"1:1 navbar", 1},
+		{"2:1 card", 0.8},
+		{"3:1 form", 0.8},
+	} {
+		p, ok := found[want.key]
+		if !ok {
+			t.Errorf("%s not detected in %+v", want.key, result.Patterns)
+			continue
+		}
+		if p.Confidence < want.min || len(p.Reasons) == 0 {
+			t.Errorf("%s = %+v, want confidence >= %.2f with reasons", want.key, p, want.min)
+		}
+	}
+	if len(result.Patterns) != 3 {
+		t.Errorf("got %d patterns, want 3: %+v", len(result.Patterns), result.Patterns)
+	}
+	if result.Scanned != 4 || result.Counts["card"] != 1 {
+		t.Errorf("scanned %d, counts %v", result.Scanned, result.Counts)
+	}
+	if result.Patterns[0].NodeID != "1:1" {
+		t.Errorf("first pattern = %s, want the highest confidence first", result.Patterns[0].NodeID)
+	}
+
+	only := detectDesignPatterns([]*figma.Node{navbar, card, form}, []string{"form"}, 0.5)
+	if len(only.Patterns) != 1 || only.Patterns[0].Pattern != "form" {
+		t.Errorf("patterns filter: got %+v", only.Patterns)
+	}
+}
+
+func TestArrangedVertically_NoAutoLayout(t *testing.T) {
+	stacked := []*figma.Node{
+		{AbsoluteBoundingBox: box(0, 100, 50, 20)},
+		{AbsoluteBoundingBox: box(0, 0, 200, 90)},
+	}
+	if !arrangedVertically(&figma.Node{}, stacked) {
+		t.Error("expected stacked children to be vertical")
+	}
+	row := []*figma.Node{
+		{AbsoluteBoundingBox: box(0, 0, 50, 20)},
+		{AbsoluteBoundingBox: box(60, 2, 50, 20)},
+	}
+	if arrangedVertically(&figma.Node{}, row) || !arrangedHorizontally(&figma.Node{}, row) {
+		t.Error("expected side-by-side children to be horizontal")
+	}
+}
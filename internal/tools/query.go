@@ -15,21 +15,22 @@ import (
 
 // Query represents a query DSL object.
 type Query struct {
-	From   []string               `json:"from,omitempty" jsonschema:"Node types to query (e.g. FRAME, TEXT, COMPONENT)"`
+	From   StringList             `json:"from,omitempty" jsonschema:"Node type(s) or #node_id to query (e.g. FRAME or [FRAME, TEXT])"`
 	Where  map[string]any         `json:"where,omitempty" jsonschema:"Filter conditions"`
 	Select []string               `json:"select,omitempty" jsonschema:"Properties or @projections to return"`
-	Path   string                 `json:"path,omitempty" jsonschema:"CSS-like path expression"`
-	Depth  int                    `json:"depth,omitempty" jsonschema:"Child traversal depth"`
+	Path   string                 `json:"path,omitempty" jsonschema:"CSS-like path expression, e.g. FRAME > TEXT, PAGE FRAME[name=Card*] or FRAME + TEXT"`
+	Depth  int                    `json:"depth,omitempty" jsonschema:"Child traversal depth of each result (0: node only, -1: all descendants)"`
 	Limit  int                    `json:"limit,omitempty" jsonschema:"Max results to return"`
 	Offset int                    `json:"offset,omitempty" jsonschema:"Pagination offset"`
 }
 
 // QueryArgs contains arguments for the query tool.
 type QueryArgs struct {
-	FileKey   string `json:"file_key" jsonschema:"Figma file key"`
-	Q         Query  `json:"q" jsonschema:"Query object with from/where/select/depth/limit"`
-	FromCache bool   `json:"from_cache,omitempty" jsonschema:"Read from local export if available (default: true)"`
-	Format    string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	FileKey       string `json:"file_key" jsonschema:"Figma file key"`
+	Q             Query  `json:"q" jsonschema:"Query object with from/where/select/depth/limit"`
+	FromCache     bool   `json:"from_cache,omitempty" jsonschema:"Read from local export if available (default: true)"`
+	IncludeHidden bool   `json:"include_hidden,omitempty" jsonschema:"Include invisible nodes (default: false)"`
+	Format        string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
 }
 
 // QueryResult contains the result of a query.
@@ -46,9 +47,12 @@ func registerQueryTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "query",
 		Description: "Query nodes using JSON DSL with data shaping. Reads from cache or API.",
+		InputSchema: inputSchema[QueryArgs](map[string][]string{
+			"format": responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args QueryArgs) (*mcp.CallToolResult, *QueryResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 
 		// Set defaults
@@ -73,22 +77,25 @@ func registerQueryTool(server *mcp.Server, r *Registry) {
 			}
 		}
 
-		// Fall back to API
+		// Fall back to API. The whole file is fetched without a depth
+		// parameter, so any q.depth, including -1, can be served.
 		if len(nodes) == 0 {
 			if !r.HasClient() {
-				return nil, nil, fmt.Errorf("no cache found and Figma API not configured")
+				return nil, nil, errNoCacheNoClient
 			}
 
-			file, err := r.Client().GetFile(ctx, args.FileKey, nil)
+			fileNodes, err := r.fileNodes(ctx, args.FileKey)
 			if err != nil {
-				return nil, nil, fmt.Errorf("fetching file: %w", err)
+				return nil, nil, err
 			}
-
-			nodes = flattenNodes(file.Document)
+			nodes = fileNodes
 		}
 
 		// Apply query filters
-		filtered := filterNodes(nodes, &args.Q)
+		filtered, err := filterNodes(nodes, &args.Q, args.IncludeHidden)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		// Apply pagination
 		total := len(filtered)
@@ -106,7 +113,7 @@ func registerQueryTool(server *mcp.Server, r *Registry) {
 		// Project selected properties
 		results := make([]map[string]interface{}, 0, len(pageNodes))
 		for _, node := range pageNodes {
-			projected := projectNode(node, args.Q.Select)
+			projected := projectNodeTree(node, args.Q.Select, args.Q.Depth)
 			results = append(results, projected)
 		}
 
@@ -140,12 +147,45 @@ func registerQueryTool(server *mcp.Server, r *Registry) {
 }
 
 func readNodesFromCache(exportDir, fileKey string) ([]*figma.Node, error) {
+	cacheDir, err := findCacheDir(exportDir, fileKey)
+	if err != nil {
+		return nil, err
+	}
+	return readNodesFromExport(cacheDir)
+}
+
+// findCacheDir returns the sync_file export directory for a file key.
+func findCacheDir(exportDir, fileKey string) (string, error) {
 	// Find export directory for this file
+	caches, err := listCachedFiles(exportDir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range caches {
+		if c.FileKey == fileKey {
+			return c.Dir, nil
+		}
+	}
+
+	return "", errNoCache(fileKey)
+}
+
+// cachedFile is a sync_file export found under the export directory.
+type cachedFile struct {
+	Dir     string
+	FileKey string
+	Name    string
+}
+
+// listCachedFiles returns every export directory with a readable _meta.json.
+func listCachedFiles(exportDir string) ([]cachedFile, error) {
 	entries, err := os.ReadDir(exportDir)
 	if err != nil {
 		return nil, err
 	}
 
+	var caches []cachedFile
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -157,21 +197,30 @@ func readNodesFromCache(exportDir, fileKey string) ([]*figma.Node, error) {
 			continue
 		}
 
-		var meta map[string]interface{}
-		if err := json.Unmarshal(metaData, &meta); err != nil {
+		var meta struct {
+			FileKey string `json:"fileKey"`
+			Name    string `json:"name"`
+		}
+		if err := json.Unmarshal(metaData, &meta); err != nil || meta.FileKey == "" {
 			continue
 		}
 
-		if meta["fileKey"] == fileKey {
-			// Found matching export, read index and nodes
-			return readNodesFromExport(filepath.Join(exportDir, entry.Name()))
-		}
+		caches = append(caches, cachedFile{
+			Dir:     filepath.Join(exportDir, entry.Name()),
+			FileKey: meta.FileKey,
+			Name:    meta.Name,
+		})
 	}
 
-	return nil, fmt.Errorf("no cache found for file %s", fileKey)
+	return caches, nil
 }
 
 func readNodesFromExport(exportPath string) ([]*figma.Node, error) {
+	// The node snapshot written by sync_file avoids decoding every _node.json
+	if nodes, err := readNodeSnapshot(exportPath); err == nil && len(nodes) > 0 {
+		return nodes, nil
+	}
+
 	var nodes []*figma.Node
 
 	// Walk the export directory and read _node.json files
@@ -218,16 +267,41 @@ func flattenNodes(doc *figma.DocumentNode) []*figma.Node {
 	return nodes
 }
 
-func filterNodes(nodes []*figma.Node, q *Query) []*figma.Node {
+func filterNodes(nodes []*figma.Node, q *Query, includeHidden bool) ([]*figma.Node, error) {
+	var matcher *pathMatcher
+	if q.Path != "" {
+		m, err := newPathMatcher(q.Path, nodes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %w", q.Path, err)
+		}
+		matcher = m
+	}
+
 	var result []*figma.Node
 
 	for _, node := range nodes {
+		if !includeHidden && isHidden(node) {
+			continue
+		}
+		if matcher != nil && !matcher.matches(node) {
+			continue
+		}
 		if matchesQuery(node, q) {
 			result = append(result, node)
 		}
 	}
 
-	return result
+	return result, nil
+}
+
+// isHidden reports whether a node has been hidden in the layers panel.
+func isHidden(node *figma.Node) bool {
+	return node.Visible != nil && !*node.Visible
+}
+
+// isLocked reports whether a node has been locked against edits.
+func isLocked(node *figma.Node) bool {
+	return node.Locked != nil && *node.Locked
 }
 
 func matchesQuery(node *figma.Node, q *Query) bool {
@@ -282,6 +356,10 @@ func matchesCondition(node *figma.Node, field string, condition interface{}) boo
 	// Handle operator objects
 	if condMap, ok := condition.(map[string]interface{}); ok {
 		for op, operand := range condMap {
+			// $fuzzy reads its sibling $threshold from the same condition
+			if op == "$fuzzy" {
+				operand = condMap
+			}
 			if !applyOperator(value, op, operand) {
 				return false
 			}
@@ -335,6 +413,8 @@ func getNodeField(node *figma.Node, field string) interface{} {
 		return node.CornerRadius
 	case "layoutMode":
 		return node.LayoutMode
+	case "locked":
+		return isLocked(node)
 	default:
 		return nil
 	}
@@ -415,11 +495,41 @@ func applyOperator(value interface{}, op string, operand interface{}) bool {
 	case "$not":
 		return !applyOperator(value, "$eq", operand)
 
+	case "$fuzzy":
+		term, threshold, ok := fuzzyOperand(operand)
+		if !ok || value == nil {
+			return false
+		}
+		return fuzzySimilarity(term, fmt.Sprintf("%v", value)) >= threshold
+
 	default:
 		return true
 	}
 }
 
+// fuzzyOperand extracts the search term and threshold for $fuzzy. The operand
+// is either the term itself or the whole condition, e.g.
+// {"$fuzzy": "Button", "$threshold": 0.8}.
+func fuzzyOperand(operand interface{}) (string, float64, bool) {
+	threshold := defaultFuzzyThreshold
+
+	switch v := operand.(type) {
+	case string:
+		return v, threshold, true
+	case map[string]interface{}:
+		term, ok := v["$fuzzy"].(string)
+		if !ok {
+			return "", 0, false
+		}
+		if t, ok := v["$threshold"]; ok {
+			threshold = toFloat(t)
+		}
+		return term, threshold, true
+	}
+
+	return "", 0, false
+}
+
 func compareNumbers(a, b interface{}) int {
 	af := toFloat(a)
 	bf := toFloat(b)
@@ -483,6 +593,9 @@ func applyProjection(node *figma.Node, projection string, result map[string]inte
 		if node.Visible != nil {
 			result["visible"] = *node.Visible
 		}
+		if isLocked(node) {
+			result["locked"] = true
+		}
 
 	case "@bounds":
 		if node.AbsoluteBoundingBox != nil {
@@ -542,6 +655,9 @@ func applyProjection(node *figma.Node, projection string, result map[string]inte
 			result["exportSettings"] = node.ExportSettings
 		}
 
+	case "@summary":
+		result["summary"] = nodeSummary(node)
+
 	case "@all":
 		// Include everything
 		applyProjection(node, "@structure", result)
@@ -554,6 +670,48 @@ func applyProjection(node *figma.Node, projection string, result map[string]inte
 	}
 }
 
+// nodeSummary describes a node on one line, e.g.
+// [FRAME] 'Button/Primary' (120x40) [3 children] [fills: rgb(0, 102, 204)].
+func nodeSummary(node *figma.Node) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s] '%s'", node.Type, node.Name))
+
+	if b := node.AbsoluteBoundingBox; b != nil {
+		w := strings.TrimSuffix(formatCSSValue(b.Width), "px")
+		h := strings.TrimSuffix(formatCSSValue(b.Height), "px")
+		sb.WriteString(fmt.Sprintf(" (%sx%s)", w, h))
+	}
+	if n := len(node.Children); n == 1 {
+		sb.WriteString(" [1 child]")
+	} else if n > 1 {
+		sb.WriteString(fmt.Sprintf(" [%d children]", n))
+	}
+
+	var fills []string
+	for _, f := range node.Fills {
+		if f.Visible != nil && !*f.Visible {
+			continue
+		}
+		switch f.Type {
+		case "SOLID":
+			fills = append(fills, colorToCSS(f.Color, f.Opacity))
+		case "IMAGE":
+			fills = append(fills, "image")
+		default:
+			fills = append(fills, strings.ToLower(f.Type))
+		}
+	}
+	if len(fills) > 0 {
+		sb.WriteString(fmt.Sprintf(" [fills: %s]", strings.Join(fills, ", ")))
+	}
+
+	if node.Characters != "" {
+		sb.WriteString(fmt.Sprintf(" [text: %s]", truncateText(node.Characters, 40)))
+	}
+
+	return sb.String()
+}
+
 // ImageRef represents a reference to an image used in a node.
 type ImageRef struct {
 	Ref    string `json:"ref"`              // The image reference ID
@@ -647,5 +805,80 @@ func formatQueryResult(r *QueryResult) string {
 		sb.WriteString("\n(from cache)\n")
 	}
 
+	sb.WriteString(suggestFollowUpTools(r.Results))
+
 	return sb.String()
 }
+
+// followUpSampleSize is how many node IDs a follow-up tip lists.
+const followUpSampleSize = 3
+
+// suggestFollowUpTools returns tips naming the tools that usually come next
+// for the kinds of nodes in results: wireframe and get_css for components,
+// get_css for text typography, and download_image for image fills. Results
+// are inspected through their type, fills and imageRefs fields, so tips
+// depend on the selected properties.
+func suggestFollowUpTools(results []map[string]any) string {
+	var components, texts, images, imageRefs []string
+	for _, res := range results {
+		id := fmt.Sprint(res["id"])
+		switch fmt.Sprint(res["type"]) {
+		case string(figma.NodeTypeComponent), string(figma.NodeTypeComponentSet):
+			components = append(components, id)
+		case string(figma.NodeTypeText):
+			texts = append(texts, id)
+		}
+
+		hasImage := false
+		refs, _ := res["imageRefs"].([]ImageRef)
+		for _, ref := range refs {
+			if ref.Source == "fill" {
+				hasImage = true
+				imageRefs = append(imageRefs, ref.Ref)
+			}
+		}
+		if fills, ok := res["fills"].([]figma.Paint); ok {
+			for _, fill := range fills {
+				if fill.Type == "IMAGE" {
+					hasImage = true
+				}
+			}
+		}
+		if hasImage {
+			images = append(images, id)
+		}
+	}
+
+	idList := func(ids []string) string {
+		if len(ids) > followUpSampleSize {
+			ids = ids[:followUpSampleSize]
+		}
+		quoted := make([]string, len(ids))
+		for i, id := range ids {
+			quoted[i] = fmt.Sprintf("%q", id)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	}
+
+	var tips []string
+	if len(components) > 0 {
+		tips = append(tips, fmt.Sprintf("Tip: Run wireframe(file_key=..., node_id=%q) to see the visual layout or get_css(node_ids=%s) to get CSS",
+			components[0], idList(components)))
+	}
+	if len(texts) > 0 {
+		tips = append(tips, fmt.Sprintf("Tip: Run get_css(node_ids=%s, include=[\"typography\"]) to get typography CSS for the text nodes",
+			idList(texts)))
+	}
+	if len(imageRefs) > 0 {
+		tips = append(tips, fmt.Sprintf("Tip: Run download_image(file_key=..., image_refs=%s, output_dir=...) to download the image fills",
+			idList(imageRefs)))
+	} else if len(images) > 0 {
+		tips = append(tips, fmt.Sprintf("Tip: Run download_image(file_key=..., node_ids=%s, output_dir=...) to render the nodes with image fills",
+			idList(images)))
+	}
+
+	if len(tips) == 0 {
+		return ""
+	}
+	return "\n" + strings.Join(tips, "\n") + "\n"
+}
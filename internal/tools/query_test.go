@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/standardbeagle/figma-query/internal/figma"
@@ -36,6 +37,22 @@ func TestMatchesFrom(t *testing.T) {
 	}
 }
 
+func TestFilterNodesHidden(t *testing.T) {
+	hidden := false
+	nodes := []*figma.Node{
+		{ID: "1:1", Type: figma.NodeTypeFrame},
+		{ID: "1:2", Type: figma.NodeTypeFrame, Visible: &hidden},
+	}
+	q := &Query{From: []string{"FRAME"}}
+
+	if got, _ := filterNodes(nodes, q, false); len(got) != 1 || got[0].ID != "1:1" {
+		t.Errorf("filterNodes without hidden = %v, want only 1:1", got)
+	}
+	if got, _ := filterNodes(nodes, q, true); len(got) != 2 {
+		t.Errorf("filterNodes with hidden returned %d nodes, want 2", len(got))
+	}
+}
+
 func TestApplyOperator(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -57,6 +74,11 @@ func TestApplyOperator(t *testing.T) {
 		{"$exists false", nil, "$exists", true, false},
 		{"$in match", "FRAME", "$in", []interface{}{"FRAME", "GROUP"}, true},
 		{"$in no match", "TEXT", "$in", []interface{}{"FRAME", "GROUP"}, false},
+		{"$fuzzy match", "button-primary", "$fuzzy", "Button Primary", true},
+		{"$fuzzy typo", "Buton", "$fuzzy", "Button", true},
+		{"$fuzzy no match", "Card", "$fuzzy", "Button", false},
+		{"$fuzzy threshold", "BtnPrimary", "$fuzzy", map[string]interface{}{"$fuzzy": "ButtonPrimary", "$threshold": 0.7}, true},
+		{"$fuzzy strict threshold", "BtnPrimary", "$fuzzy", map[string]interface{}{"$fuzzy": "ButtonPrimary", "$threshold": 0.9}, false},
 	}
 
 	for _, tt := range tests {
@@ -99,14 +121,40 @@ func TestProjectNode(t *testing.T) {
 	}
 }
 
+func TestNodeSummary(t *testing.T) {
+	hidden := false
+	button := &figma.Node{
+		ID:                  "1:2",
+		Name:                "Button/Primary",
+		Type:                figma.NodeTypeFrame,
+		AbsoluteBoundingBox: &figma.Rectangle{Width: 120, Height: 40.5},
+		Fills: []figma.Paint{
+			{Type: "SOLID", Color: &figma.Color{R: 0, G: 0.4, B: 0.8, A: 1}},
+			{Type: "SOLID", Visible: &hidden, Color: &figma.Color{A: 1}},
+		},
+		Children: []*figma.Node{{ID: "1:3"}, {ID: "1:4"}, {ID: "1:5"}},
+	}
+	want := "[FRAME] 'Button/Primary' (120x40.50) [3 children] [fills: rgb(0, 102, 204)]"
+	if got := projectNode(button, []string{"@summary"})["summary"]; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+
+	label := &figma.Node{Name: "Label", Type: figma.NodeTypeText, Characters: "Sign in\nto continue"}
+	if got, want := nodeSummary(label), "[TEXT] 'Label' [text: Sign in]"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+}
+
 func TestGetNodeField(t *testing.T) {
 	visible := true
+	locked := true
 	opacity := 0.5
 	node := &figma.Node{
 		ID:      "1:2",
 		Name:    "Test",
 		Type:    figma.NodeTypeFrame,
 		Visible: &visible,
+		Locked:  &locked,
 		Opacity: &opacity,
 		AbsoluteBoundingBox: &figma.Rectangle{
 			Width: 100, Height: 200,
@@ -126,6 +174,7 @@ func TestGetNodeField(t *testing.T) {
 		{"height", 200.0},
 		{"opacity", 0.5},
 		{"layoutMode", "HORIZONTAL"},
+		{"locked", true},
 	}
 
 	for _, tt := range tests {
@@ -137,3 +186,33 @@ func TestGetNodeField(t *testing.T) {
 		})
 	}
 }
+
+func TestSuggestFollowUpTools(t *testing.T) {
+	results := []map[string]any{
+		{"id": "1:1", "type": figma.NodeTypeComponent},
+		{"id": "1:2", "type": figma.NodeTypeText},
+		{"id": "1:3", "type": figma.NodeTypeRectangle, "imageRefs": []ImageRef{{Ref: "abc", Source: "fill"}}},
+		{"id": "1:4", "type": figma.NodeTypeFrame, "fills": []figma.Paint{{Type: "IMAGE"}}},
+	}
+	tips := suggestFollowUpTools(results)
+	for _, want := range []string{
+		`wireframe(file_key=..., node_id="1:1")`,
+		`get_css(node_ids=["1:1"])`,
+		`get_css(node_ids=["1:2"], include=["typography"])`,
+		`download_image(file_key=..., image_refs=["abc"]`,
+	} {
+		if !strings.Contains(tips, want) {
+			t.Errorf("tips missing %q:\n%s", want, tips)
+		}
+	}
+
+	// Without refs, nodes with image fills are rendered by ID.
+	tips = suggestFollowUpTools(results[3:])
+	if !strings.Contains(tips, `download_image(file_key=..., node_ids=["1:4"]`) {
+		t.Errorf("expected node_ids download tip:\n%s", tips)
+	}
+
+	if tips := suggestFollowUpTools([]map[string]any{{"id": "2:1", "type": figma.NodeTypeFrame}}); tips != "" {
+		t.Errorf("expected no tips for plain frames, got %q", tips)
+	}
+}
@@ -2,7 +2,13 @@
 package tools
 
 import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/analytics"
 	"github.com/standardbeagle/figma-query/internal/figma"
 )
 
@@ -10,6 +16,22 @@ import (
 type Registry struct {
 	client    *figma.Client
 	exportDir string
+	analytics analytics.Writer
+	aliases   *AliasStore
+	bookmarks *BookmarkStore
+	compMap   *ComponentMap
+	nodes     *nodeCache
+	styles    *styleCache
+	version   string
+	progress  ProgressCallback
+	discovery FileDiscovery
+	confined  bool // path arguments must stay inside exportDir
+
+	toolInputsOnce sync.Once
+	toolInputs     map[string]toolInput // input schema of each tool, by name
+
+	userMu sync.Mutex
+	user   *figma.User // token owner, cached once looked up
 }
 
 // NewRegistry creates a new tool registry.
@@ -17,37 +39,88 @@ func NewRegistry(client *figma.Client, exportDir string) *Registry {
 	return &Registry{
 		client:    client,
 		exportDir: exportDir,
+		aliases:   NewAliasStore(defaultAliasPath()),
+		bookmarks: NewBookmarkStore(defaultBookmarkPath()),
+		nodes:     newNodeCache(),
+		styles:    newStyleCache(),
 	}
 }
 
 // RegisterTools registers all tools with the MCP server.
 func (r *Registry) RegisterTools(server *mcp.Server) {
+	server.AddReceivingMiddleware(r.analyticsMiddleware, r.bookmarkMiddleware, r.fileKeyMiddleware, r.pathMiddleware)
+
 	// Discovery tools
 	registerInfoTool(server, r)
+	registerCreateAliasTool(server, r)
+	registerBookmarkTool(server, r)
 
 	// Export tools
 	registerSyncFileTool(server, r)
+	registerBatchSyncTool(server, r)
 	registerExportAssetsTool(server, r)
+	registerExportSpriteTool(server, r)
 	registerExportTokensTool(server, r)
+	registerTextStylesToCSSTool(server, r)
 	registerDownloadImageTool(server, r)
+	registerExportComponentDocsTool(server, r)
+	registerExportStoriesTool(server, r)
+	registerMergeExportsTool(server, r)
+	registerCleanNodeJSONTool(server, r)
+	registerValidateExportTool(server, r)
+	registerCaptureBaselineTool(server, r)
 
 	// Query tools
 	registerQueryTool(server, r)
 	registerSearchTool(server, r)
+	registerFindTextTool(server, r)
+	registerGetCommentsTool(server, r)
+	registerCacheSearchTool(server, r)
 	registerGetTreeTool(server, r)
+	registerGetNodePathTool(server, r)
 	registerListComponentsTool(server, r)
+	registerComponentMapTool(server, r)
+	registerGetComponentGraphTool(server, r)
 	registerListStylesTool(server, r)
+	registerGetGridStylesTool(server, r)
+	registerFrameInventoryTool(server, r)
+	registerGetResponsiveBreakpointsTool(server, r)
 
 	// Detail tools
 	registerGetNodeTool(server, r)
 	registerGetCSSTool(server, r)
+	registerCompareToCodeTool(server, r)
 	registerGetTokensTool(server, r)
+	registerGetOverridesTool(server, r)
 
 	// Render tools
 	registerWireframeTool(server, r)
+	registerRenderAllPagesTool(server, r)
 
 	// Analysis tools
 	registerDiffTool(server, r)
+	registerListVersionsTool(server, r)
+	registerWatchQueryTool(server, r)
+	registerTokenDiffTool(server, r)
+	registerResolveTokenChainTool(server, r)
+	registerGetSpacingScaleTool(server, r)
+	registerListEffectsTool(server, r)
+	registerTokenAuditTool(server, r)
+	registerFindDuplicatesTool(server, r)
+	registerCheckAccessibilityTool(server, r)
+	registerGetContrastPairsTool(server, r)
+	registerFindOrphanStylesTool(server, r)
+	registerGenerateColorPaletteTool(server, r)
+	registerGetContributorsTool(server, r)
+	registerGetAnalyticsTool(server, r)
+	registerDetectDesignPatternsTool(server, r)
+	registerCheckNamingConventionsTool(server, r)
+	registerLayoutAuditTool(server, r)
+
+	// Write tools
+	registerUpdateVariablesTool(server, r)
+	registerSearchAndReplaceTool(server, r)
+	registerPostCommentTool(server, r)
 }
 
 // HasClient returns true if a Figma client is configured.
@@ -60,7 +133,157 @@ func (r *Registry) Client() *figma.Client {
 	return r.client
 }
 
+// WithClient returns a registry for a session with its own token: it uses
+// client for Figma requests and keeps everything it reads or writes in
+// exportDir. Its node and style caches are its own, its aliases and
+// bookmarks persist to exportDir, it has no component mapping until one is
+// loaded, and path arguments of its tools must stay inside exportDir. With an
+// export directory per token, nothing from one token reaches a session using
+// another. Analytics and settings are shared with r.
+func (r *Registry) WithClient(client *figma.Client, exportDir string) *Registry {
+	return &Registry{
+		client:    client,
+		exportDir: exportDir,
+		analytics: r.analytics,
+		aliases:   NewAliasStore(filepath.Join(exportDir, aliasFileName)),
+		bookmarks: NewBookmarkStore(filepath.Join(exportDir, bookmarkFileName)),
+		nodes:     newNodeCache(),
+		styles:    newStyleCache(),
+		version:   r.version,
+		progress:  r.progress,
+		discovery: r.discovery,
+		confined:  true,
+	}
+}
+
 // ExportDir returns the export directory.
 func (r *Registry) ExportDir() string {
 	return r.exportDir
 }
+
+// Aliases returns the node alias store.
+func (r *Registry) Aliases() *AliasStore {
+	return r.aliases
+}
+
+// SetAliases replaces the node alias store.
+func (r *Registry) SetAliases(s *AliasStore) {
+	r.aliases = s
+}
+
+// Bookmarks returns the bookmark store.
+func (r *Registry) Bookmarks() *BookmarkStore {
+	return r.bookmarks
+}
+
+// SetBookmarks replaces the bookmark store.
+func (r *Registry) SetBookmarks(s *BookmarkStore) {
+	r.bookmarks = s
+}
+
+// SetComponentMap sets the component key → code file mapping.
+func (r *Registry) SetComponentMap(m *ComponentMap) {
+	r.compMap = m
+}
+
+// componentMapping returns the loaded component key → code file table, or
+// nil if no mapping was loaded or it can no longer be read.
+func (r *Registry) componentMapping() map[string]string {
+	if r.compMap == nil {
+		return nil
+	}
+	mapping, err := r.compMap.All()
+	if err != nil {
+		return nil
+	}
+	return mapping
+}
+
+// ResolveNodeID returns the node ID for an alias, or id unchanged.
+func (r *Registry) ResolveNodeID(id string) string {
+	if id == "" {
+		return id
+	}
+	return r.aliases.Resolve(id)
+}
+
+// ResolveNodeIDs resolves each alias in ids.
+func (r *Registry) ResolveNodeIDs(ids []string) []string {
+	resolved := make([]string, len(ids))
+	for i, id := range ids {
+		resolved[i] = r.ResolveNodeID(id)
+	}
+	return resolved
+}
+
+// CurrentUser returns the Figma user the token belongs to. A successful
+// lookup is cached for the life of the process; failures are retried.
+func (r *Registry) CurrentUser(ctx context.Context) (*figma.User, error) {
+	r.userMu.Lock()
+	defer r.userMu.Unlock()
+
+	if r.user != nil {
+		return r.user, nil
+	}
+	user, err := r.Client().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.user = user
+	return user, nil
+}
+
+// ProgressCallback receives progress from long-running exports: the page just
+// finished, nodes exported so far and the total number of nodes expected.
+type ProgressCallback func(page string, nodesExported, totalEstimate int)
+
+// SetProgressCallback sets a callback invoked as sync_file finishes each page.
+func (r *Registry) SetProgressCallback(fn ProgressCallback) {
+	r.progress = fn
+}
+
+// SetServerVersion records the server version written to export manifests.
+func (r *Registry) SetServerVersion(v string) {
+	r.version = v
+}
+
+// ServerVersion returns the server version, or "dev" if it was never set.
+func (r *Registry) ServerVersion() string {
+	if r.version == "" {
+		return "dev"
+	}
+	return r.version
+}
+
+// SetAnalytics sets the writer that records tool invocations.
+func (r *Registry) SetAnalytics(w analytics.Writer) {
+	r.analytics = w
+}
+
+// analyticsMiddleware records each tools/call request when analytics is enabled.
+func (r *Registry) analyticsMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if r.analytics == nil || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		start := time.Now()
+		result, err := next(ctx, method, req)
+
+		event := analytics.Event{
+			Timestamp:  start.UTC(),
+			DurationMS: time.Since(start).Milliseconds(),
+			Error:      err != nil,
+		}
+		if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+			event.Tool = params.Name
+		}
+		if res, ok := result.(*mcp.CallToolResult); ok && res.IsError {
+			event.Error = true
+		}
+		// Analytics must never break a tool call.
+		_ = r.analytics.Record(event)
+
+		return result, err
+	}
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestRegistryWithClient(t *testing.T) {
+	r := NewRegistry(nil, t.TempDir())
+	r.SetServerVersion("1.2.3")
+	r.SetComponentMap(&ComponentMap{})
+	client := figma.NewClient("session-token")
+
+	sessionDir := t.TempDir()
+	s := r.WithClient(client, sessionDir)
+	if s.Client() != client || r.Client() != nil {
+		t.Errorf("clients = %v, %v; want the session's client on the copy only", s.Client(), r.Client())
+	}
+	if s.ExportDir() != sessionDir || r.ExportDir() == sessionDir {
+		t.Errorf("export dirs = %q, %q; want the session's dir on the copy only", s.ExportDir(), r.ExportDir())
+	}
+	if s.ServerVersion() != "1.2.3" {
+		t.Error("copy does not share settings")
+	}
+	// Aliases and bookmarks of one token must not be seen by another.
+	if s.Aliases() == r.Aliases() || s.Bookmarks() == r.Bookmarks() {
+		t.Error("copy shares the alias and bookmark stores")
+	}
+	if filepath.Dir(s.Aliases().Path()) != sessionDir || filepath.Dir(s.Bookmarks().Path()) != sessionDir {
+		t.Errorf("session stores = %q, %q; want files in %q", s.Aliases().Path(), s.Bookmarks().Path(), sessionDir)
+	}
+	if !s.confined || r.confined {
+		t.Error("want path arguments confined on the copy only")
+	}
+	// Nodes fetched with one token must not be served to another.
+	if s.nodes == r.nodes || s.styles == r.styles {
+		t.Error("copy shares the node and style caches")
+	}
+	if s.compMap != nil {
+		t.Error("copy shares the component mapping")
+	}
+}
@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// SearchAndReplaceArgs contains arguments for the search_and_replace tool.
+type SearchAndReplaceArgs struct {
+	FileKey       string   `json:"file_key" jsonschema:"Figma file key"`
+	Pattern       string   `json:"pattern" jsonschema:"Text to find: glob (Lorem*) or /regex/"`
+	Replacement   string   `json:"replacement" jsonschema:"Replacement text. With a /regex/ pattern, $1 etc. refer to capture groups"`
+	DryRun        bool     `json:"dry_run,omitempty" jsonschema:"Only list the affected nodes; do not write a change file"`
+	IncludeHidden bool     `json:"include_hidden,omitempty" jsonschema:"Include hidden text nodes (default: false)"`
+	NodeIDs       []string `json:"node_ids,omitempty" jsonschema:"Limit the replacement to these nodes and their descendants"`
+	Format        string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	OutputFile    string   `json:"output_file,omitempty" jsonschema:"Path for the change file (default: auto-generated under the export dir)"`
+}
+
+// TextReplacement is the before/after content of one text node.
+type TextReplacement struct {
+	NodeID  string `json:"node_id"`
+	Name    string `json:"name"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Matches int    `json:"matches"`
+}
+
+// SearchAndReplaceResult contains the result of search_and_replace.
+type SearchAndReplaceResult struct {
+	FileKey      string            `json:"file_key"`
+	Pattern      string            `json:"pattern"`
+	Replacement  string            `json:"replacement"`
+	Changes      []TextReplacement `json:"changes"`
+	Nodes        int               `json:"nodes"`
+	Replacements int               `json:"replacements"`
+	DryRun       bool              `json:"dry_run"`
+	Applied      bool              `json:"applied"` // false: the REST API cannot edit text content
+	FilePath     string            `json:"file_path,omitempty"`
+	Cached       bool              `json:"cached"`
+}
+
+func registerSearchAndReplaceTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "search_and_replace",
+		Description: `Find text nodes whose content matches a pattern and compute the replaced copy.
+
+Returns each affected node with its before/after text. The Figma REST API cannot edit text content, so nothing is written to Figma: unless dry_run is set, the changes are saved as a JSON change file that a Figma plugin or a designer can apply.`,
+		InputSchema: inputSchema[SearchAndReplaceArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SearchAndReplaceArgs) (*mcp.CallToolResult, *SearchAndReplaceResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.Pattern == "" {
+			return nil, nil, errPatternRequired
+		}
+
+		re, err := buildSearchRegex(args.Pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+
+		src, err := loadAuditSource(ctx, r, args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes := src.Nodes
+		if len(args.NodeIDs) > 0 {
+			args.NodeIDs = r.ResolveNodeIDs(args.NodeIDs)
+			nodes = flattenNodeSubtrees(nodes, args.NodeIDs)
+		}
+
+		changes := replaceText(nodes, re, args.Replacement, isRegexPattern(args.Pattern), args.IncludeHidden)
+		result := &SearchAndReplaceResult{
+			FileKey:     args.FileKey,
+			Pattern:     args.Pattern,
+			Replacement: args.Replacement,
+			Changes:     changes,
+			Nodes:       len(changes),
+			DryRun:      args.DryRun,
+			Cached:      src.Cached,
+		}
+		for _, c := range changes {
+			result.Replacements += c.Matches
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatSearchAndReplaceResult(result)
+		}
+
+		outputCfg := OutputConfig{
+			MaxOutputSize: DefaultMaxOutputSize,
+			OutputFile:    args.OutputFile,
+			OutputDir:     r.ExportDir(),
+			ToolName:      "search_and_replace",
+			FileKey:       args.FileKey,
+		}
+		// Without dry_run the change file is the deliverable, so always write it.
+		if !args.DryRun && len(changes) > 0 && outputCfg.OutputFile == "" {
+			outputCfg.OutputFile = generateOutputPath(outputCfg)
+		}
+
+		outputResult, err := ProcessOutput(textOutput, result, outputCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("processing output: %w", err)
+		}
+
+		if outputResult.WasWrittenToFile {
+			result.FilePath = outputResult.FilePath
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: outputResult.Text},
+			},
+		}, result, nil
+	})
+}
+
+// flattenNodeSubtrees returns the nodes with the given IDs and all of their
+// descendants.
+func flattenNodeSubtrees(nodes []*figma.Node, ids []string) []*figma.Node {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+
+	var out []*figma.Node
+	seen := make(map[string]bool)
+	var walk func(n *figma.Node)
+	walk = func(n *figma.Node) {
+		if seen[n.ID] {
+			return
+		}
+		seen[n.ID] = true
+		out = append(out, n)
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	for _, n := range nodes {
+		if want[n.ID] {
+			walk(n)
+		}
+	}
+	return out
+}
+
+// replaceText applies re to the characters of every TEXT node and returns
+// the nodes whose content changes. Capture-group references in replacement
+// are only expanded for /regex/ patterns; glob replacements are literal.
+func replaceText(nodes []*figma.Node, re *regexp.Regexp, replacement string, expand, includeHidden bool) []TextReplacement {
+	changes := []TextReplacement{}
+	for _, node := range nodes {
+		if node.Type != figma.NodeTypeText || node.Characters == "" {
+			continue
+		}
+		if !includeHidden && isHidden(node) {
+			continue
+		}
+
+		matches := len(re.FindAllStringIndex(node.Characters, -1))
+		if matches == 0 {
+			continue
+		}
+
+		var after string
+		if expand {
+			after = re.ReplaceAllString(node.Characters, replacement)
+		} else {
+			after = re.ReplaceAllLiteralString(node.Characters, replacement)
+		}
+		if after == node.Characters {
+			continue
+		}
+
+		changes = append(changes, TextReplacement{
+			NodeID:  node.ID,
+			Name:    node.Name,
+			Before:  node.Characters,
+			After:   after,
+			Matches: matches,
+		})
+	}
+	return changes
+}
+
+func formatSearchAndReplaceResult(r *SearchAndReplaceResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Search and replace: %q -> %q\n", r.Pattern, r.Replacement))
+	sb.WriteString(fmt.Sprintf("%d replacements in %d text nodes\n", r.Replacements, r.Nodes))
+	if r.DryRun {
+		sb.WriteString("Dry run: no change file written\n")
+	} else if r.Nodes > 0 {
+		sb.WriteString("Not applied: the Figma REST API cannot edit text. Apply the change file with a plugin or by hand.\n")
+	}
+	sb.WriteString("\n")
+
+	if len(r.Changes) == 0 {
+		sb.WriteString("No matching text nodes.\n")
+		writeCachedNote(&sb, r.Cached)
+		return sb.String()
+	}
+
+	for _, c := range r.Changes {
+		sb.WriteString(fmt.Sprintf("%s %q\n", c.NodeID, c.Name))
+		sb.WriteString(fmt.Sprintf("  - %s\n", truncateText(c.Before, 100)))
+		sb.WriteString(fmt.Sprintf("  + %s\n", truncateText(c.After, 100)))
+	}
+
+	writeCachedNote(&sb, r.Cached)
+
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestReplaceText(t *testing.T) {
+	hidden := false
+	nodes := []*figma.Node{
+		{ID: "1:1", Name: "Title", Type: figma.NodeTypeText, Characters: "Lorem ipsum dolor"},
+		{ID: "1:2", Name: "Body", Type: figma.NodeTypeText, Characters: "lorem and LOREM"},
+		{ID: "1:3", Name: "Other", Type: figma.NodeTypeText, Characters: "Real copy"},
+		{ID: "1:4", Name: "Hidden", Type: figma.NodeTypeText, Characters: "Lorem", Visible: &hidden},
+		{ID: "1:5", Name: "Lorem", Type: figma.NodeTypeFrame},
+	}
+
+	re, err := buildSearchRegex("lorem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := replaceText(nodes, re, "Hello", false, false)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2: %+v", len(changes), changes)
+	}
+	if changes[0].After != "Hello ipsum dolor" {
+		t.Errorf("1:1 after = %q", changes[0].After)
+	}
+	if changes[1].After != "Hello and Hello" || changes[1].Matches != 2 {
+		t.Errorf("1:2 = %+v", changes[1])
+	}
+
+	if got := replaceText(nodes, re, "Hello", false, true); len(got) != 3 {
+		t.Errorf("with hidden: got %d changes, want 3", len(got))
+	}
+
+	// Capture groups expand for regex patterns only.
+	re, _ = buildSearchRegex(`/(\w+) copy/`)
+	changes = replaceText(nodes, re, "$1 text", true, false)
+	if len(changes) != 1 || changes[0].After != "Real text" {
+		t.Errorf("regex changes = %+v", changes)
+	}
+	re, _ = buildSearchRegex("Real*")
+	changes = replaceText(nodes, re, "$1", false, false)
+	if len(changes) != 1 || changes[0].After != "$1" {
+		t.Errorf("glob changes = %+v", changes)
+	}
+}
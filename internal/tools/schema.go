@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// responseFormats are the values accepted by every tool's format argument.
+var responseFormats = []string{"text", "json"}
+
+// tableFormats are accepted by list tools that can also emit GFM tables.
+var tableFormats = []string{"text", "json", "markdown"}
+
+// StringList is a list of strings that also accepts a single string in JSON,
+// so {"from": "FRAME"} and {"from": ["FRAME"]} are equivalent.
+type StringList []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = StringList{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected string or array of strings: %w", err)
+	}
+	*l = list
+	return nil
+}
+
+// schemaTypes overrides the inferred schema for types with custom JSON decoding.
+var schemaTypes = map[reflect.Type]*jsonschema.Schema{
+	reflect.TypeFor[StringList](): {
+		Types: []string{"string", "array"},
+		Items: &jsonschema.Schema{Type: "string"},
+	},
+}
+
+// inputSchema infers the JSON Schema for a tool's argument type.
+//
+// Fields without omitempty in their json tag are marked required. The enums
+// map constrains string properties (or the items of string arrays) to a fixed
+// set of values; nested properties are addressed with dotted paths such as
+// "assets.formats". It panics on an invalid path, like mcp.AddTool does for
+// invalid types, since both are programming errors caught at startup.
+//
+// Schemas with a file_key property also get a bookmark property, which
+// bookmarkMiddleware expands into file_key and node_id before the call.
+func inputSchema[T any](enums map[string][]string) *jsonschema.Schema {
+	schema, err := jsonschema.For[T](&jsonschema.ForOptions{TypeSchemas: schemaTypes})
+	if err != nil {
+		panic(fmt.Sprintf("inferring schema for %T: %v", *new(T), err))
+	}
+
+	for path, values := range enums {
+		prop := schema
+		for _, name := range strings.Split(path, ".") {
+			prop = prop.Properties[name]
+			if prop == nil {
+				panic(fmt.Sprintf("schema for %T has no property %q", *new(T), path))
+			}
+		}
+
+		enum := make([]any, len(values))
+		for i, v := range values {
+			enum[i] = v
+		}
+
+		if prop.Type == "array" && prop.Items != nil {
+			prop.Items.Enum = enum
+		} else {
+			prop.Enum = enum
+		}
+	}
+
+	if _, ok := schema.Properties["file_key"]; ok {
+		schema.Properties["bookmark"] = &jsonschema.Schema{
+			Type:        "string",
+			Description: "Bookmark name (see the bookmark tool) to use in place of file_key and node_id",
+		}
+	}
+
+	return schema
+}
+
+// operatorOperandTypes gives the JSON type each WHERE operator accepts.
+// Operators missing here accept any value.
+var operatorOperandTypes = map[string]string{
+	"$match":     "string",
+	"$regex":     "string",
+	"$contains":  "string",
+	"$fuzzy":     "string",
+	"$in":        "array",
+	"$gt":        "number",
+	"$gte":       "number",
+	"$lt":        "number",
+	"$lte":       "number",
+	"$exists":    "boolean",
+	"$threshold": "number",
+}
+
+// querySchema returns the JSON Schema for the query tool's q argument. It
+// starts from the schema inferred for Query and tightens from, where and
+// select with the node types, operators and projections the engine supports.
+func querySchema() *jsonschema.Schema {
+	schema := inputSchema[Query](nil)
+	schema.Description = "Query DSL object for the query tool"
+
+	// from: node type, "#node_id", or a list of those
+	nodeTypes := make([]any, len(figma.NodeTypes))
+	for i, t := range figma.NodeTypes {
+		nodeTypes[i] = string(t)
+	}
+	fromItem := func() *jsonschema.Schema {
+		return &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+			{Type: "string", Enum: nodeTypes},
+			{Type: "string", Pattern: "^#.+", Description: "Specific node, e.g. #1:234"},
+		}}
+	}
+	schema.Properties["from"] = &jsonschema.Schema{
+		Description: schema.Properties["from"].Description,
+		AnyOf:       []*jsonschema.Schema{fromItem(), {Type: "array", Items: fromItem()}},
+	}
+
+	// where: field name -> literal value or operator object
+	opNames := make([]string, 0, len(queryOperators)+1)
+	for op := range queryOperators {
+		opNames = append(opNames, op)
+	}
+	opNames = append(opNames, "$threshold")
+	sort.Strings(opNames)
+
+	operators := make(map[string]*jsonschema.Schema, len(opNames))
+	for _, op := range opNames {
+		operand := &jsonschema.Schema{Type: operatorOperandTypes[op], Description: queryOperators[op]}
+		if op == "$threshold" {
+			lo, hi := 0.0, 1.0
+			operand.Description = "Similarity required by $fuzzy, 0.0-1.0 (default 0.8)"
+			operand.Minimum, operand.Maximum = &lo, &hi
+		}
+		operators[op] = operand
+	}
+	schema.Properties["where"] = &jsonschema.Schema{
+		Type:        "object",
+		Description: "Conditions keyed by node field; all must match",
+		AdditionalProperties: &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+			{Types: []string{"string", "number", "boolean", "null"}, Description: "Exact match"},
+			{Type: "object", Properties: operators, AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}}},
+		}},
+	}
+
+	// select: property names or known @projections
+	projections := make([]string, 0, len(queryProjections))
+	for name := range queryProjections {
+		projections = append(projections, name)
+	}
+	sort.Strings(projections)
+	projectionEnum := make([]any, len(projections))
+	for i, p := range projections {
+		projectionEnum[i] = p
+	}
+	schema.Properties["select"].Items = &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+		{Type: "string", Enum: projectionEnum},
+		{Type: "string", Pattern: "^[^@]", Description: "Node property name"},
+	}}
+
+	return schema
+}
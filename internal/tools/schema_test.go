@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStringListUnmarshal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{`"FRAME"`, []string{"FRAME"}, false},
+		{`["FRAME", "TEXT"]`, []string{"FRAME", "TEXT"}, false},
+		{`42`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var l StringList
+			err := json.Unmarshal([]byte(tt.input), &l)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(l) != len(tt.expected) {
+				t.Fatalf("got %v, want %v", l, tt.expected)
+			}
+			for i := range l {
+				if l[i] != tt.expected[i] {
+					t.Errorf("got %v, want %v", l, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestQuerySchema(t *testing.T) {
+	resolved, err := querySchema().Resolve(nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		valid bool
+	}{
+		{"type string", `{"from": "FRAME"}`, true},
+		{"type list", `{"from": ["FRAME", "TEXT"]}`, true},
+		{"node id", `{"from": "#1:234"}`, true},
+		{"unknown type", `{"from": "BUTTON"}`, false},
+		{"operator", `{"where": {"name": {"$match": "Button*"}}}`, true},
+		{"literal", `{"where": {"visible": true}}`, true},
+		{"fuzzy threshold", `{"where": {"name": {"$fuzzy": "Btn", "$threshold": 0.7}}}`, true},
+		{"unknown operator", `{"where": {"name": {"$like": "Button"}}}`, false},
+		{"bad operand", `{"where": {"width": {"$gt": "100"}}}`, false},
+		{"projection", `{"select": ["@css", "name"]}`, true},
+		{"unknown projection", `{"select": ["@colors"]}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var instance map[string]any
+			if err := json.Unmarshal([]byte(tt.query), &instance); err != nil {
+				t.Fatalf("bad test input: %v", err)
+			}
+			err := resolved.Validate(instance)
+			if tt.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
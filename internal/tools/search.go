@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -13,20 +14,25 @@ import (
 
 // SearchArgs contains arguments for the search tool.
 type SearchArgs struct {
-	FileKey   string   `json:"file_key" jsonschema:"Figma file key"`
+	FileKey   string   `json:"file_key" jsonschema:"Figma file key, or * to search every synced file in the export directory"`
 	Pattern   string   `json:"pattern" jsonschema:"Search pattern (supports glob * and regex /pattern/)"`
 	Scope     []string `json:"scope,omitempty" jsonschema:"Where to search: names text properties styles variables"`
 	NodeTypes []string `json:"node_types,omitempty" jsonschema:"Filter by node type"`
 	Select    []string `json:"select,omitempty" jsonschema:"Properties to return for matches"`
 	Limit     int      `json:"limit,omitempty" jsonschema:"Max results (default: 50)"`
+	Cursor    string   `json:"cursor,omitempty" jsonschema:"next_cursor from a previous search with the same arguments, to continue after its last result"`
 	Format    string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+
+	IncludeHidden bool `json:"include_hidden,omitempty" jsonschema:"Include invisible nodes (default: false)"`
 }
 
 // SearchResult contains the result of a search.
 type SearchResult struct {
-	Results []SearchMatch `json:"results"`
-	Total   int           `json:"total"`
-	HasMore bool          `json:"has_more"`
+	Results    []SearchMatch `json:"results"`
+	Total      int           `json:"total"`
+	HasMore    bool          `json:"has_more"`
+	NextCursor string        `json:"next_cursor,omitempty"` // pass as cursor to get the next page
+	Fuzzy      bool          `json:"fuzzy,omitempty"`
 }
 
 // SearchMatch represents a single search match.
@@ -37,23 +43,30 @@ type SearchMatch struct {
 	Path         string `json:"path"`
 	MatchContext string `json:"match_context"`
 	MatchField   string `json:"match_field"`
+	Locked       bool   `json:"locked,omitempty"`
+	FileKey      string `json:"file_key,omitempty"`  // set when searching all cached files
+	FileName     string `json:"file_name,omitempty"` // set when searching all cached files
 }
 
 func registerSearchTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search",
-		Description: "Full-text search across node names, text content, and properties.",
+		Description: "Full-text search across node names, text content, and properties. Use file_key=\"*\" to search every synced file.",
+		InputSchema: inputSchema[SearchArgs](map[string][]string{
+			"scope":  {"names", "text", "properties", "styles", "variables"},
+			"format": responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args SearchArgs) (*mcp.CallToolResult, *SearchResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 		if args.Pattern == "" {
-			return nil, nil, fmt.Errorf("pattern is required")
+			return nil, nil, errPatternRequired
 		}
 
 		// Set defaults
 		limit := args.Limit
-		if limit == 0 {
+		if limit <= 0 {
 			limit = 50
 		}
 		scope := args.Scope
@@ -61,54 +74,25 @@ func registerSearchTool(server *mcp.Server, r *Registry) {
 			scope = []string{"names", "text"}
 		}
 
-		// Try cache first, then API
-		var nodes []*figma.Node
-		cachedNodes, err := readNodesFromCache(r.ExportDir(), args.FileKey)
-		if err == nil && len(cachedNodes) > 0 {
-			nodes = cachedNodes
-		} else if r.HasClient() {
-			file, err := r.Client().GetFile(ctx, args.FileKey, nil)
-			if err != nil {
-				return nil, nil, fmt.Errorf("fetching file: %w", err)
-			}
-			nodes = flattenNodes(file.Document)
-		} else {
-			return nil, nil, fmt.Errorf("no cache found and Figma API not configured")
-		}
-
 		// Build regex from pattern
 		re, err := buildSearchRegex(args.Pattern)
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid pattern: %w", err)
 		}
 
-		// Search nodes
-		var matches []SearchMatch
-		for _, node := range nodes {
-			// Filter by node type if specified
-			if len(args.NodeTypes) > 0 && !containsString(args.NodeTypes, string(node.Type)) {
-				continue
-			}
-
-			// Search in each scope
-			for _, s := range scope {
-				if match := searchInScope(node, s, re); match != nil {
-					matches = append(matches, *match)
-					break // Only add once per node
-				}
+		var result *SearchResult
+		if args.FileKey == "*" {
+			result, err = searchAllCaches(r.ExportDir(), args, re, scope, limit)
+			if err != nil {
+				return nil, nil, err
 			}
-
-			if len(matches) >= limit {
-				break
+		} else {
+			result, err = searchFile(ctx, r, args, re, scope, limit)
+			if err != nil {
+				return nil, nil, err
 			}
 		}
 
-		result := &SearchResult{
-			Results: matches,
-			Total:   len(matches),
-			HasMore: len(nodes) > limit,
-		}
-
 		// Format output
 		var textOutput string
 		if args.Format == "json" {
@@ -126,9 +110,203 @@ func registerSearchTool(server *mcp.Server, r *Registry) {
 	})
 }
 
+// searchFile searches one file, from its cache or the API.
+func searchFile(ctx context.Context, r *Registry, args SearchArgs, re *regexp.Regexp, scope []string, limit int) (*SearchResult, error) {
+	// Try cache first, then API
+	var nodes []*figma.Node
+	cachedNodes, err := readNodesFromCache(r.ExportDir(), args.FileKey)
+	if err == nil && len(cachedNodes) > 0 {
+		nodes = cachedNodes
+	} else if r.HasClient() {
+		nodes, err = r.fileNodes(ctx, args.FileKey)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, errNoCacheNoClient
+	}
+
+	var styles map[string]*figma.Style
+	if containsString(scope, "styles") {
+		styles, err = r.fileStyles(ctx, args.FileKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if args.Cursor != "" {
+		nodes, err = nodesAfterCursor(nodes, args.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Ask for one extra match to learn whether another page exists
+	matches := searchNodes(nodes, styles, re, scope, args.NodeTypes, args.IncludeHidden, limit+1)
+	hasMore := len(matches) > limit
+	if hasMore {
+		matches = matches[:limit]
+	}
+
+	// Fall back to approximate name matching for plain patterns
+	fuzzy := false
+	if len(matches) == 0 && args.Cursor == "" && !isRegexPattern(args.Pattern) && containsString(scope, "names") {
+		matches = fuzzySearchNames(nodes, args.Pattern, args.NodeTypes, limit)
+		fuzzy = len(matches) > 0
+	}
+
+	result := &SearchResult{
+		Results: matches,
+		Total:   len(matches),
+		HasMore: hasMore,
+		Fuzzy:   fuzzy,
+	}
+	if hasMore {
+		result.NextCursor = matches[len(matches)-1].NodeID
+	}
+	return result, nil
+}
+
+// nodesAfterCursor returns the nodes after the one whose ID is cursor, so a
+// search can resume where the previous page ended.
+func nodesAfterCursor(nodes []*figma.Node, cursor string) ([]*figma.Node, error) {
+	for i, node := range nodes {
+		if node.ID == cursor {
+			return nodes[i+1:], nil
+		}
+	}
+	return nil, fmt.Errorf("cursor %q not found in file. The file may have changed; search again without cursor", cursor)
+}
+
+// searchAllCaches searches every sync_file export under exportDir and tags
+// each match with the file it came from. The API is not used.
+func searchAllCaches(exportDir string, args SearchArgs, re *regexp.Regexp, scope []string, limit int) (*SearchResult, error) {
+	caches, err := listCachedFiles(exportDir)
+	if err != nil || len(caches) == 0 {
+		return nil, fmt.Errorf("no synced files found in %s (run sync_file first)", exportDir)
+	}
+
+	// A cursor names the file and node the previous page ended on
+	startFile, startNode := "", ""
+	if args.Cursor != "" {
+		var ok bool
+		startFile, startNode, ok = strings.Cut(args.Cursor, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid cursor %q: expected <file_key>/<node_id> from next_cursor", args.Cursor)
+		}
+	}
+	skipping := startFile != ""
+
+	fileNodes := make([][]*figma.Node, len(caches))
+	var matches []SearchMatch
+	for i, c := range caches {
+		if len(matches) > limit {
+			break
+		}
+		if skipping && c.FileKey != startFile {
+			continue
+		}
+		nodes, err := readNodesFromExport(c.Dir)
+		if err != nil {
+			continue
+		}
+		fileNodes[i] = nodes
+		if skipping {
+			skipping = false
+			if nodes, err = nodesAfterCursor(nodes, startNode); err != nil {
+				return nil, err
+			}
+		}
+
+		var styles map[string]*figma.Style
+		if containsString(scope, "styles") {
+			styles = readStylesFromCache(c.Dir)
+		}
+		// Ask for one extra match to learn whether another page exists
+		found := searchNodes(nodes, styles, re, scope, args.NodeTypes, args.IncludeHidden, limit+1-len(matches))
+		matches = append(matches, tagMatches(found, c)...)
+	}
+	if skipping {
+		return nil, fmt.Errorf("cursor file %s not found in %s; search again without cursor", startFile, exportDir)
+	}
+
+	hasMore := len(matches) > limit
+	if hasMore {
+		matches = matches[:limit]
+	}
+
+	// Fall back to approximate name matching for plain patterns
+	fuzzy := false
+	if len(matches) == 0 && args.Cursor == "" && !isRegexPattern(args.Pattern) && containsString(scope, "names") {
+		for i, c := range caches {
+			if len(matches) >= limit {
+				break
+			}
+			found := fuzzySearchNames(fileNodes[i], args.Pattern, args.NodeTypes, limit-len(matches))
+			matches = append(matches, tagMatches(found, c)...)
+		}
+		fuzzy = len(matches) > 0
+	}
+
+	result := &SearchResult{
+		Results: matches,
+		Total:   len(matches),
+		HasMore: hasMore,
+		Fuzzy:   fuzzy,
+	}
+	if hasMore {
+		last := matches[len(matches)-1]
+		result.NextCursor = last.FileKey + "/" + last.NodeID
+	}
+	return result, nil
+}
+
+func tagMatches(matches []SearchMatch, c cachedFile) []SearchMatch {
+	for i := range matches {
+		matches[i].FileKey = c.FileKey
+		matches[i].FileName = c.Name
+	}
+	return matches
+}
+
+// searchNodes returns up to limit nodes matching re in any of scope. styles
+// maps style IDs to styles for the "styles" scope. Invisible nodes are
+// skipped unless includeHidden is set.
+func searchNodes(nodes []*figma.Node, styles map[string]*figma.Style, re *regexp.Regexp, scope, nodeTypes []string, includeHidden bool, limit int) []SearchMatch {
+	var matches []SearchMatch
+	for _, node := range nodes {
+		if !includeHidden && isHidden(node) {
+			continue
+		}
+
+		// Filter by node type if specified
+		if len(nodeTypes) > 0 && !containsString(nodeTypes, string(node.Type)) {
+			continue
+		}
+
+		// Search in each scope
+		for _, s := range scope {
+			if match := searchInScope(node, s, re, styles); match != nil {
+				match.Locked = isLocked(node)
+				matches = append(matches, *match)
+				break // Only add once per node
+			}
+		}
+
+		if len(matches) >= limit {
+			break
+		}
+	}
+	return matches
+}
+
+func isRegexPattern(pattern string) bool {
+	return len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/")
+}
+
 func buildSearchRegex(pattern string) (*regexp.Regexp, error) {
 	// Check if it's a regex pattern
-	if strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+	if isRegexPattern(pattern) {
 		return regexp.Compile(pattern[1 : len(pattern)-1])
 	}
 
@@ -140,7 +318,7 @@ func buildSearchRegex(pattern string) (*regexp.Regexp, error) {
 	return regexp.Compile("(?i)" + escaped)
 }
 
-func searchInScope(node *figma.Node, scope string, re *regexp.Regexp) *SearchMatch {
+func searchInScope(node *figma.Node, scope string, re *regexp.Regexp, styles map[string]*figma.Style) *SearchMatch {
 	switch scope {
 	case "names":
 		if re.MatchString(node.Name) {
@@ -179,11 +357,77 @@ func searchInScope(node *figma.Node, scope string, re *regexp.Regexp) *SearchMat
 				MatchField:   "componentId",
 			}
 		}
+
+	case "styles":
+		// Match the names of applied styles rather than their raw IDs
+		applied := nodeStyleIDs(node)
+		for _, kind := range []string{"fill", "stroke", "text", "effect", "grid"} {
+			id := applied[kind]
+			if id == "" {
+				continue
+			}
+			if style := styles[id]; style != nil && re.MatchString(style.Name) {
+				return &SearchMatch{
+					NodeID:       node.ID,
+					Name:         node.Name,
+					Type:         string(node.Type),
+					MatchContext: style.Name,
+					MatchField:   kind + "Style",
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
+// fuzzySearchNames returns nodes whose names are similar to pattern, best
+// matches first. Glob wildcards are ignored.
+func fuzzySearchNames(nodes []*figma.Node, pattern string, nodeTypes []string, limit int) []SearchMatch {
+	term := strings.NewReplacer("*", "", "?", "").Replace(pattern)
+	if normalizeForFuzzy(term) == "" {
+		return nil
+	}
+
+	type scored struct {
+		match SearchMatch
+		score float64
+	}
+	var candidates []scored
+	for _, node := range nodes {
+		if len(nodeTypes) > 0 && !containsString(nodeTypes, string(node.Type)) {
+			continue
+		}
+		score := fuzzySimilarity(term, node.Name)
+		if score < defaultFuzzyThreshold {
+			continue
+		}
+		candidates = append(candidates, scored{
+			match: SearchMatch{
+				NodeID:       node.ID,
+				Name:         node.Name,
+				Type:         string(node.Type),
+				MatchContext: fmt.Sprintf("%s (%.0f%% similar)", node.Name, score*100),
+				MatchField:   "name",
+			},
+			score: score,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	var matches []SearchMatch
+	for _, c := range candidates {
+		if len(matches) >= limit {
+			break
+		}
+		matches = append(matches, c.match)
+	}
+	return matches
+}
+
 func containsString(slice []string, item string) bool {
 	for _, s := range slice {
 		if strings.EqualFold(s, item) {
@@ -196,15 +440,24 @@ func containsString(slice []string, item string) bool {
 func formatSearchResult(r *SearchResult) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("Found %d matches\n\n", r.Total))
+	sb.WriteString(fmt.Sprintf("Found %d matches\n", r.Total))
+	if r.Fuzzy {
+		sb.WriteString("No exact matches; showing fuzzy name matches\n")
+	}
+	sb.WriteString("\n")
 
 	if len(r.Results) == 0 {
 		sb.WriteString("No matches found.\n")
 		return sb.String()
 	}
 
-	sb.WriteString("ID       | Name                           | Type      | Match\n")
-	sb.WriteString("-------- | ------------------------------ | --------- | -----\n")
+	if r.Results[0].FileKey != "" {
+		sb.WriteString("File                 | ID       | Name                           | Type      | Match\n")
+		sb.WriteString("-------------------- | -------- | ------------------------------ | --------- | -----\n")
+	} else {
+		sb.WriteString("ID       | Name                           | Type      | Match\n")
+		sb.WriteString("-------- | ------------------------------ | --------- | -----\n")
+	}
 
 	for _, m := range r.Results {
 		name := m.Name
@@ -216,8 +469,25 @@ func formatSearchResult(r *SearchResult) string {
 			context = context[:27] + "..."
 		}
 
+		if m.FileKey != "" {
+			file := m.FileName
+			if file == "" {
+				file = m.FileKey
+			}
+			if len(file) > 20 {
+				file = file[:17] + "..."
+			}
+			sb.WriteString(fmt.Sprintf("%-20s | ", file))
+		}
+		if m.Locked {
+			context += " [L]"
+		}
 		sb.WriteString(fmt.Sprintf("%-8s | %-30s | %-9s | %s\n", m.NodeID, name, m.Type, context))
 	}
 
+	if r.HasMore {
+		sb.WriteString(fmt.Sprintf("\nMore results: search again with cursor=%q\n", r.NextCursor))
+	}
+
 	return sb.String()
 }
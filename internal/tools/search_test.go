@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestSearchInScopeStyles(t *testing.T) {
+	styles := map[string]*figma.Style{
+		"S:fill":  {Name: "Brand/Primary", StyleType: figma.StyleTypeFill},
+		"S:text":  {Name: "Heading/H1", StyleType: figma.StyleTypeText},
+		"S:other": {Name: "Unused", StyleType: figma.StyleTypeFill},
+	}
+	nodes := []*figma.Node{
+		{ID: "1:1", Name: "Button", Type: figma.NodeTypeFrame, Styles: map[string]string{"fill": "S:fill"}},
+		{ID: "1:2", Name: "Title", Type: figma.NodeTypeText, Styles: map[string]string{"text": "S:text"}},
+		{ID: "1:3", Name: "Raw", Type: figma.NodeTypeFrame, Styles: map[string]string{"fill": "S:missing"}},
+	}
+
+	re, _ := buildSearchRegex("brand/*")
+	matches := searchNodes(nodes, styles, re, []string{"styles"}, nil, false, 10)
+	if len(matches) != 1 || matches[0].NodeID != "1:1" || matches[0].MatchField != "fillStyle" || matches[0].MatchContext != "Brand/Primary" {
+		t.Errorf("brand/* matches = %+v", matches)
+	}
+
+	re, _ = buildSearchRegex("/^Heading/")
+	matches = searchNodes(nodes, styles, re, []string{"styles"}, nil, false, 10)
+	if len(matches) != 1 || matches[0].NodeID != "1:2" || matches[0].MatchField != "textStyle" {
+		t.Errorf("/^Heading/ matches = %+v", matches)
+	}
+
+	// Raw style IDs are not matched
+	re, _ = buildSearchRegex("S:*")
+	if matches := searchNodes(nodes, styles, re, []string{"styles"}, nil, false, 10); len(matches) != 0 {
+		t.Errorf("style IDs should not match: %+v", matches)
+	}
+}
+
+func TestNodesAfterCursor(t *testing.T) {
+	nodes := []*figma.Node{{ID: "1:1"}, {ID: "1:2"}, {ID: "1:3"}}
+
+	rest, err := nodesAfterCursor(nodes, "1:2")
+	if err != nil || len(rest) != 1 || rest[0].ID != "1:3" {
+		t.Errorf("nodesAfterCursor(1:2) = %v, %v", rest, err)
+	}
+	if _, err := nodesAfterCursor(nodes, "9:9"); err == nil {
+		t.Error("expected error for unknown cursor")
+	}
+}
@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// pathStep is one compound selector in a path expression, such as
+// FRAME[name=Card*] or #1:23, with the combinator joining it to the step
+// before it.
+type pathStep struct {
+	combinator byte   // ' ' descendant, '>' child, '+' adjacent sibling; 0 for the first step
+	nodeType   string // empty or "*" matches any type
+	id         string
+	name       string // glob matched case-insensitively against the node name
+}
+
+func (s pathStep) matches(node *figma.Node) bool {
+	if s.nodeType != "" && s.nodeType != "*" && string(node.Type) != s.nodeType {
+		return false
+	}
+	if s.id != "" && node.ID != s.id {
+		return false
+	}
+	if s.name != "" {
+		ok, _ := path.Match(strings.ToLower(s.name), strings.ToLower(node.Name))
+		return ok
+	}
+	return true
+}
+
+// pathMatcher evaluates a CSS-like path expression such as
+// "PAGE > FRAME TEXT" against nodes, using parent links to walk ancestors
+// and siblings. It supports the descendant (space), child (>) and adjacent
+// sibling (+) combinators.
+type pathMatcher struct {
+	steps   []pathStep
+	parents map[string]*figma.Node
+}
+
+// newPathMatcher parses expr and indexes the parents of nodes.
+func newPathMatcher(expr string, nodes []*figma.Node) (*pathMatcher, error) {
+	steps, err := parsePathExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &pathMatcher{steps: steps, parents: buildParentMap(nodes)}, nil
+}
+
+// parsePathExpr splits a path expression into compound selectors and
+// combinators. Names in brackets may be quoted to include spaces.
+func parsePathExpr(expr string) ([]pathStep, error) {
+	var steps []pathStep
+	var combinator byte
+
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			if len(steps) > 0 && combinator == 0 {
+				combinator = ' '
+			}
+			i++
+			continue
+		case c == '>' || c == '+':
+			if len(steps) == 0 || (combinator != 0 && combinator != ' ') {
+				return nil, fmt.Errorf("unexpected %q at position %d", c, i)
+			}
+			combinator = c
+			i++
+			continue
+		}
+
+		step, n, err := parsePathStep(expr[i:])
+		if err != nil {
+			return nil, fmt.Errorf("%v at position %d", err, i)
+		}
+		step.combinator = combinator
+		steps = append(steps, step)
+		combinator = 0
+		i += n
+	}
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("empty path expression")
+	}
+	if combinator != 0 && combinator != ' ' {
+		return nil, fmt.Errorf("path ends with %q", combinator)
+	}
+	return steps, nil
+}
+
+// parsePathStep parses one compound selector at the start of s and returns
+// it with the number of bytes consumed.
+func parsePathStep(s string) (pathStep, int, error) {
+	var step pathStep
+	i := 0
+
+	start := i
+	for i < len(s) && (isSelectorChar(s[i]) || s[i] == '*') {
+		i++
+	}
+	step.nodeType = strings.ToUpper(s[start:i])
+	if step.nodeType == "PAGE" {
+		step.nodeType = string(figma.NodeTypeCanvas)
+	}
+
+	for i < len(s) {
+		switch s[i] {
+		case '#':
+			i++
+			start := i
+			for i < len(s) && (isSelectorChar(s[i]) || s[i] == ':' || s[i] == ';') {
+				i++
+			}
+			step.id = s[start:i]
+			if step.id == "" {
+				return step, i, fmt.Errorf("empty #id")
+			}
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return step, i, fmt.Errorf("unclosed [")
+			}
+			key, value, ok := strings.Cut(s[i+1:i+end], "=")
+			if !ok || strings.TrimSpace(key) != "name" {
+				return step, i, fmt.Errorf("only [name=pattern] attributes are supported")
+			}
+			step.name = strings.Trim(strings.TrimSpace(value), `"'`)
+			i += end + 1
+		default:
+			if i == 0 {
+				return step, i, fmt.Errorf("unexpected %q", s[i])
+			}
+			return step, i, nil
+		}
+	}
+	return step, i, nil
+}
+
+func isSelectorChar(c byte) bool {
+	return c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// matches reports whether node is selected by the last step and its
+// ancestors and siblings satisfy the steps before it.
+func (m *pathMatcher) matches(node *figma.Node) bool {
+	return m.matchFrom(node, len(m.steps)-1)
+}
+
+func (m *pathMatcher) matchFrom(node *figma.Node, i int) bool {
+	step := m.steps[i]
+	if !step.matches(node) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	switch step.combinator {
+	case '>':
+		parent := m.parents[node.ID]
+		return parent != nil && m.matchFrom(parent, i-1)
+	case '+':
+		prev := m.previousSibling(node)
+		return prev != nil && m.matchFrom(prev, i-1)
+	default:
+		for p := m.parents[node.ID]; p != nil; p = m.parents[p.ID] {
+			if m.matchFrom(p, i-1) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (m *pathMatcher) previousSibling(node *figma.Node) *figma.Node {
+	parent := m.parents[node.ID]
+	if parent == nil {
+		return nil
+	}
+	for i, child := range parent.Children {
+		if child.ID == node.ID {
+			if i == 0 {
+				return nil
+			}
+			return parent.Children[i-1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestPathMatcher(t *testing.T) {
+	page := &figma.Node{
+		ID:   "0:1",
+		Name: "Page 1",
+		Type: figma.NodeTypeCanvas,
+		Children: []*figma.Node{
+			{
+				ID:   "1:1",
+				Name: "Card Large",
+				Type: figma.NodeTypeFrame,
+				Children: []*figma.Node{
+					{ID: "1:2", Name: "Title", Type: figma.NodeTypeText},
+					{ID: "1:3", Name: "Body", Type: figma.NodeTypeText},
+					{
+						ID:   "1:4",
+						Name: "Footer",
+						Type: figma.NodeTypeGroup,
+						Children: []*figma.Node{
+							{ID: "1:5", Name: "Link", Type: figma.NodeTypeText},
+						},
+					},
+				},
+			},
+		},
+	}
+	nodes := flattenNodes(&figma.DocumentNode{Children: []*figma.Node{page}})
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"FRAME > TEXT", []string{"1:2", "1:3"}},
+		{"FRAME TEXT", []string{"1:2", "1:3", "1:5"}},
+		{"PAGE > FRAME", []string{"1:1"}},
+		{"TEXT + TEXT", []string{"1:3"}},
+		{"TEXT+GROUP > TEXT", []string{"1:5"}},
+		{`FRAME[name="card *"] > *`, []string{"1:2", "1:3", "1:4"}},
+		{"#1:4 TEXT", []string{"1:5"}},
+		{"GROUP > FRAME", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			m, err := newPathMatcher(tt.expr, nodes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, n := range nodes {
+				if m.matches(n) {
+					got = append(got, n.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePathExprErrors(t *testing.T) {
+	for _, expr := range []string{"", "> TEXT", "FRAME >", "FRAME > > TEXT", "FRAME[id=1]", "FRAME[name=x", "#"} {
+		if _, err := parsePathExpr(expr); err == nil {
+			t.Errorf("parsePathExpr(%q) succeeded, want error", expr)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// pathArgs are the tool arguments naming files or directories to read or
+// write.
+var pathArgs = []string{
+	"export_dir",
+	"output_dir",
+	"output_path",
+	"output_file",
+	"css_file",
+	"mapping_file",
+	"source_dir",
+	"target_dir",
+}
+
+// confinePath resolves p inside root: relative paths are joined to root, and
+// paths that end up outside it are rejected.
+func confinePath(root, p string) (string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(root, p)
+	}
+	p = filepath.Clean(p)
+	rel, err := filepath.Rel(root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the session export directory %s", p, root)
+	}
+	return p, nil
+}
+
+// pathMiddleware confines the path arguments of tools/call requests to the
+// export directory of registries that serve a per-token session, so a client
+// cannot read or write files elsewhere on the server. Relative paths are
+// resolved inside the export directory.
+func (r *Registry) pathMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || !r.confined {
+			return next(ctx, method, req)
+		}
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok || len(params.Arguments) == 0 {
+			return next(ctx, method, req)
+		}
+
+		args := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			return next(ctx, method, req)
+		}
+		changed := false
+		for _, key := range pathArgs {
+			if !hasArg(args, key) {
+				continue
+			}
+			var p string
+			if err := json.Unmarshal(args[key], &p); err != nil {
+				continue // left to the tool's own argument validation
+			}
+			confined, err := confinePath(r.exportDir, p)
+			if err != nil {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("%s: %v. Pass a path relative to the export directory", key, err)}},
+				}, nil
+			}
+			args[key], _ = json.Marshal(confined)
+			changed = true
+		}
+
+		if changed {
+			params.Arguments, _ = json.Marshal(args)
+		}
+		return next(ctx, method, req)
+	}
+}
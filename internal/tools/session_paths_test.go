@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestConfinePath(t *testing.T) {
+	root := t.TempDir()
+
+	tests := []struct {
+		path string
+		want string // "" for rejected paths
+	}{
+		{"", root},
+		{"tokens.css", filepath.Join(root, "tokens.css")},
+		{"assets/../icons", filepath.Join(root, "icons")},
+		{filepath.Join(root, "site"), filepath.Join(root, "site")},
+		{"..", ""},
+		{"../other/tokens.css", ""},
+		{"/etc/passwd", ""},
+		{root + "-other", ""},
+	}
+	for _, tt := range tests {
+		got, err := confinePath(root, tt.path)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("confinePath(%q) = %q, want error", tt.path, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("confinePath(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+		}
+	}
+}
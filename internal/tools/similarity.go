@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"strings"
+	"unicode"
+)
+
+// defaultFuzzyThreshold is the minimum similarity for $fuzzy and fuzzy search.
+const defaultFuzzyThreshold = 0.8
+
+// levenshtein returns the edit distance between a and b, counted in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// similarity returns 1 - distance/maxLen, so identical strings score 1.0.
+func similarity(a, b string) float64 {
+	maxLen := max(len([]rune(a)), len([]rune(b)))
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(maxLen)
+}
+
+// normalizeForFuzzy lowercases s and drops separators, so "Button Primary",
+// "button-primary" and "ButtonPrimary" all compare equal.
+func normalizeForFuzzy(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return sb.String()
+}
+
+// fuzzySimilarity scores how closely candidate matches term. The whole
+// candidate is compared, as well as each of its words, so "Buton" scores
+// highly against "Button / Primary".
+func fuzzySimilarity(term, candidate string) float64 {
+	t := normalizeForFuzzy(term)
+	best := similarity(t, normalizeForFuzzy(candidate))
+
+	words := strings.FieldsFunc(candidate, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 1 {
+		for _, w := range words {
+			if s := similarity(t, normalizeForFuzzy(w)); s > best {
+				best = s
+			}
+		}
+	}
+
+	return best
+}
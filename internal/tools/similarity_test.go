@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"button", "buton", 1},
+		{"héllo", "hello", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := levenshtein(tt.a, tt.b); got != tt.expected {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFuzzySimilarity(t *testing.T) {
+	tests := []struct {
+		term      string
+		candidate string
+		minScore  float64
+		maxScore  float64
+	}{
+		{"Button Primary", "button-primary", 1, 1},
+		{"ButtonPrimary", "BtnPrimary", 0.7, 0.8},
+		{"Buton", "Button / Primary", 0.8, 1},
+		{"Card", "Button", 0, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term+"/"+tt.candidate, func(t *testing.T) {
+			got := fuzzySimilarity(tt.term, tt.candidate)
+			if got < tt.minScore || got > tt.maxScore {
+				t.Errorf("fuzzySimilarity(%q, %q) = %.2f, want between %.2f and %.2f",
+					tt.term, tt.candidate, got, tt.minScore, tt.maxScore)
+			}
+		})
+	}
+}
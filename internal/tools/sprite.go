@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+var (
+	svgAttrRegex  = regexp.MustCompile(`([\w:-]+)\s*=\s*"([^"]*)"`)
+	svgIDRegex    = regexp.MustCompile(`\bid="([^"]+)"`)
+	svgIDRefRegex = regexp.MustCompile(`(url\(#|href="#)([^)"]+)`)
+)
+
+// ExportSpriteArgs contains arguments for the export_sprite tool.
+type ExportSpriteArgs struct {
+	FileKey    string   `json:"file_key" jsonschema:"Figma file key"`
+	NodeIDs    []string `json:"node_ids" jsonschema:"Icon node IDs to include in the sprite"`
+	OutputPath string   `json:"output_path" jsonschema:"Sprite file to write, e.g. assets/icons.svg. Its manifest is written next to it, e.g. assets/icons.manifest.json"`
+	Prefix     string   `json:"prefix,omitempty" jsonschema:"Prefix for symbol IDs (default: icon), e.g. Arrow -> icon-arrow"`
+	Format     string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// SpriteIcon is one symbol of an SVG sprite, as listed in its manifest.
+type SpriteIcon struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	NodeID  string  `json:"nodeId"`
+	Width   float64 `json:"width"`
+	Height  float64 `json:"height"`
+	ViewBox string  `json:"viewBox"`
+}
+
+// ExportSpriteResult contains the result of export_sprite.
+type ExportSpriteResult struct {
+	Path         string       `json:"path"`
+	ManifestPath string       `json:"manifest_path"`
+	Icons        []SpriteIcon `json:"icons"`
+	Failed       []string     `json:"failed,omitempty"`
+}
+
+func registerExportSpriteTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_sprite",
+		Description: "Combine icon nodes into a single SVG sprite of <symbol> elements, referenced with <use href=\"#icon-arrow\"/>. Writes <sprite name>.manifest.json next to the sprite (icons.svg → icons.manifest.json) listing each symbol's id, name, node ID, size and viewBox.",
+		InputSchema: inputSchema[ExportSpriteArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExportSpriteArgs) (*mcp.CallToolResult, *ExportSpriteResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if len(args.NodeIDs) == 0 {
+			return nil, nil, errNodeIDsRequired
+		}
+		args.NodeIDs = r.ResolveNodeIDs(args.NodeIDs)
+		if args.OutputPath == "" {
+			return nil, nil, fmt.Errorf("output_path is required. Pass the sprite file to write (e.g. \"assets/icons.svg\")")
+		}
+		prefix := args.Prefix
+		if prefix == "" {
+			prefix = "icon"
+		}
+
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		nodes, err := r.Client().GetFileNodes(ctx, args.FileKey, args.NodeIDs, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching nodes: %w", err)
+		}
+		images, err := r.Client().GetImages(ctx, args.FileKey, args.NodeIDs, &figma.ImageExportOptions{Format: "svg"})
+		if err != nil {
+			return nil, nil, fmt.Errorf("exporting svg: %w", err)
+		}
+
+		result := &ExportSpriteResult{Icons: []SpriteIcon{}}
+		var symbols []string
+		used := make(map[string]bool)
+
+		for _, id := range args.NodeIDs {
+			var node *figma.Node
+			if w := nodes.Nodes[id]; w != nil {
+				node = w.Document
+			}
+			if node == nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: node not found", id))
+				continue
+			}
+			imageURL := images.Images[id]
+			if imageURL == "" {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %s", id, imageFailureReason("svg")))
+				continue
+			}
+			data, err := r.Client().DownloadImage(ctx, imageURL)
+			if err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("download %s: %v", id, err))
+				continue
+			}
+
+			symbolID := cssIdentifier(node.Name, prefix)
+			for n := 2; used[symbolID]; n++ {
+				symbolID = fmt.Sprintf("%s-%d", cssIdentifier(node.Name, prefix), n)
+			}
+			used[symbolID] = true
+
+			icon := SpriteIcon{ID: symbolID, Name: node.Name, NodeID: id}
+			if b := node.AbsoluteBoundingBox; b != nil {
+				icon.Width, icon.Height = b.Width, b.Height
+			}
+			symbol, err := svgSymbol(string(data), &icon)
+			if err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+			symbols = append(symbols, symbol)
+			result.Icons = append(result.Icons, icon)
+		}
+
+		if len(result.Icons) == 0 {
+			return nil, nil, fmt.Errorf("no icons exported: %s", strings.Join(result.Failed, "; "))
+		}
+
+		if err := os.MkdirAll(filepath.Dir(args.OutputPath), 0755); err != nil {
+			return nil, nil, fmt.Errorf("creating directory: %w", err)
+		}
+		sprite := "<svg xmlns=\"http://www.w3.org/2000/svg\" xmlns:xlink=\"http://www.w3.org/1999/xlink\" style=\"display: none\">\n" +
+			strings.Join(symbols, "\n") + "\n</svg>\n"
+		if err := os.WriteFile(args.OutputPath, []byte(sprite), 0644); err != nil {
+			return nil, nil, fmt.Errorf("writing sprite: %w", err)
+		}
+		result.Path = args.OutputPath
+
+		result.ManifestPath = spriteManifestPath(args.OutputPath)
+		if err := writeJSON(result.ManifestPath, result.Icons); err != nil {
+			return nil, nil, fmt.Errorf("writing sprite manifest: %w", err)
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatExportSpriteResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// svgSymbol turns an exported SVG document into a <symbol> with icon.ID,
+// filling in the icon's viewBox and size from the root element. IDs inside
+// the document are prefixed with the symbol ID so gradients and clip paths
+// of different icons do not collide in the sprite.
+func svgSymbol(svg string, icon *SpriteIcon) (string, error) {
+	start := strings.Index(svg, "<svg")
+	if start < 0 {
+		return "", fmt.Errorf("not an SVG document")
+	}
+	open := strings.Index(svg[start:], ">")
+	end := strings.LastIndex(svg, "</svg>")
+	if open < 0 || end < start+open {
+		return "", fmt.Errorf("malformed SVG document")
+	}
+	tag := svg[start : start+open]
+	inner := strings.TrimSpace(svg[start+open+1 : end])
+
+	attrs := make(map[string]string)
+	for _, m := range svgAttrRegex.FindAllStringSubmatch(tag, -1) {
+		attrs[m[1]] = m[2]
+	}
+	if w, err := strconv.ParseFloat(strings.TrimSuffix(attrs["width"], "px"), 64); err == nil {
+		icon.Width = w
+	}
+	if h, err := strconv.ParseFloat(strings.TrimSuffix(attrs["height"], "px"), 64); err == nil {
+		icon.Height = h
+	}
+	icon.ViewBox = attrs["viewBox"]
+	if icon.ViewBox == "" {
+		icon.ViewBox = fmt.Sprintf("0 0 %g %g", icon.Width, icon.Height)
+	}
+
+	inner = svgIDRegex.ReplaceAllString(inner, `id="`+icon.ID+`-$1"`)
+	inner = svgIDRefRegex.ReplaceAllString(inner, `${1}`+icon.ID+`-$2`)
+
+	return fmt.Sprintf("<symbol id=\"%s\" viewBox=\"%s\">\n%s\n</symbol>", icon.ID, icon.ViewBox, inner), nil
+}
+
+func formatExportSpriteResult(r *ExportSpriteResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Exported %d icons to %s\n", len(r.Icons), r.Path))
+	sb.WriteString(fmt.Sprintf("Manifest: %s\n\n", r.ManifestPath))
+
+	for _, icon := range r.Icons {
+		sb.WriteString(fmt.Sprintf("  #%s  %s [%s] %gx%g\n", icon.ID, icon.Name, icon.NodeID, icon.Width, icon.Height))
+	}
+
+	if len(r.Failed) > 0 {
+		sb.WriteString(fmt.Sprintf("\nFailed: %d\n", len(r.Failed)))
+		for _, f := range r.Failed {
+			sb.WriteString(fmt.Sprintf("  - %s\n", f))
+		}
+	}
+
+	if len(r.Icons) > 0 {
+		sb.WriteString(fmt.Sprintf("\nUsage: <svg><use href=\"#%s\"/></svg>\n", r.Icons[0].ID))
+	}
+
+	return sb.String()
+}
+
+// spriteManifestPath names the manifest after the sprite, so several sprites
+// written to one directory keep separate manifests.
+func spriteManifestPath(spritePath string) string {
+	return strings.TrimSuffix(spritePath, filepath.Ext(spritePath)) + ".manifest.json"
+}
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSVGSymbol(t *testing.T) {
+	svg := `<svg width="24" height="24" viewBox="0 0 24 24" fill="none" xmlns="http://www.w3.org/2000/svg">
+<g clip-path="url(#clip0_1_2)">
+<path d="M4 12h16" stroke="url(#paint0_linear)"/>
+</g>
+<defs>
+<clipPath id="clip0_1_2"><rect width="24" height="24"/></clipPath>
+<linearGradient id="paint0_linear"/>
+</defs>
+</svg>
+`
+	icon := SpriteIcon{ID: "icon-arrow", Name: "Arrow", NodeID: "1:2", Width: 10, Height: 10}
+	symbol, err := svgSymbol(svg, &icon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if icon.Width != 24 || icon.Height != 24 || icon.ViewBox != "0 0 24 24" {
+		t.Errorf("icon = %+v, want 24x24 with viewBox 0 0 24 24", icon)
+	}
+	if !strings.HasPrefix(symbol, `<symbol id="icon-arrow" viewBox="0 0 24 24">`) || !strings.HasSuffix(symbol, "</symbol>") {
+		t.Errorf("symbol = %s", symbol)
+	}
+	for _, want := range []string{`clip-path="url(#icon-arrow-clip0_1_2)"`, `id="icon-arrow-clip0_1_2"`, `stroke="url(#icon-arrow-paint0_linear)"`, `id="icon-arrow-paint0_linear"`} {
+		if !strings.Contains(symbol, want) {
+			t.Errorf("symbol missing %s:\n%s", want, symbol)
+		}
+	}
+	if strings.Contains(symbol, "<svg") || strings.Contains(symbol, "xmlns") {
+		t.Errorf("symbol kept the root element:\n%s", symbol)
+	}
+}
+
+func TestSVGSymbol_NoViewBox(t *testing.T) {
+	icon := SpriteIcon{ID: "icon-dot", Width: 8, Height: 8}
+	if _, err := svgSymbol(`<svg xmlns="http://www.w3.org/2000/svg"><circle r="4"/></svg>`, &icon); err != nil {
+		t.Fatal(err)
+	}
+	if icon.ViewBox != "0 0 8 8" {
+		t.Errorf("viewBox = %q, want size from the node bounds", icon.ViewBox)
+	}
+
+	if _, err := svgSymbol("<html></html>", &icon); err == nil {
+		t.Error("expected error for non-SVG data")
+	}
+}
+
+func TestSpriteManifestPath(t *testing.T) {
+	tests := map[string]string{
+		"assets/icons.svg":  "assets/icons.manifest.json",
+		"assets/arrows.svg": "assets/arrows.manifest.json",
+		"sprite":            "sprite.manifest.json",
+	}
+	for in, want := range tests {
+		if got := spriteManifestPath(in); got != want {
+			t.Errorf("spriteManifestPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
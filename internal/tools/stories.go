@@ -0,0 +1,377 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// ExportStoriesArgs contains arguments for the export_stories tool.
+type ExportStoriesArgs struct {
+	FileKey     string `json:"file_key" jsonschema:"Figma file key"`
+	OutputDir   string `json:"output_dir" jsonschema:"Directory to write .stories.tsx and .mdx files to"`
+	ImportPath  string `json:"import_path,omitempty" jsonschema:"Module path the components are imported from, joined with the component name (default: ../components)"`
+	TitlePrefix string `json:"title_prefix,omitempty" jsonschema:"Storybook sidebar folder for the stories (default: Components)"`
+	Format      string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// ComponentStory describes the stories generated for one component.
+type ComponentStory struct {
+	Name      string   `json:"name"`
+	Component string   `json:"component"` // identifier the component is imported as
+	FigmaURL  string   `json:"figma_url"`
+	Stories   []string `json:"stories"`
+	StoryPath string   `json:"story_path"`
+	DocsPath  string   `json:"docs_path"`
+}
+
+// ExportStoriesResult contains the result of export_stories.
+type ExportStoriesResult struct {
+	Components []ComponentStory `json:"components"`
+	OutputDir  string           `json:"output_dir"`
+	Errors     []string         `json:"errors,omitempty"`
+}
+
+// storyComponent is a local component set, or a component outside any set,
+// with the variants it has stories for.
+type storyComponent struct {
+	ID          string
+	Name        string
+	Description string
+	Variants    []*figma.Component
+}
+
+// storyArg is a variant property passed to the component as a prop.
+type storyArg struct {
+	Prop  string
+	Value string
+}
+
+func registerExportStoriesTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_stories",
+		Description: "Generate Storybook 7+ stories for each component: a CSF .stories.tsx file with a story per variant, and an MDX 2 docs page with an <AutoDocs /> block, the Figma embed in a <Canvas> block for design/code comparison, and code examples in <Source> blocks.",
+		InputSchema: inputSchema[ExportStoriesArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ExportStoriesArgs) (*mcp.CallToolResult, *ExportStoriesResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.OutputDir == "" {
+			return nil, nil, errOutputDirRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+		importPath := strings.TrimSuffix(args.ImportPath, "/")
+		if importPath == "" {
+			importPath = "../components"
+		}
+		titlePrefix := args.TitlePrefix
+		if titlePrefix == "" {
+			titlePrefix = "Components"
+		}
+
+		file, err := r.Client().GetFile(ctx, args.FileKey, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+
+		if err := os.MkdirAll(args.OutputDir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("creating output dir: %w", err)
+		}
+
+		result := &ExportStoriesResult{
+			Components: []ComponentStory{},
+			OutputDir:  args.OutputDir,
+		}
+
+		used := make(map[string]bool)
+		for _, comp := range storyComponents(file) {
+			story := ComponentStory{
+				Name:      comp.Name,
+				Component: componentIdentifier(comp.Name),
+				FigmaURL:  figmaNodeURL(args.FileKey, comp.ID),
+			}
+			if used[story.Component] {
+				story.Component += "_" + strings.ReplaceAll(sanitizeID(comp.ID), "-", "_")
+			}
+			used[story.Component] = true
+
+			stories, names := renderComponentStories(comp, &story, importPath, titlePrefix)
+			story.Stories = names
+			story.StoryPath = filepath.Join(args.OutputDir, story.Component+".stories.tsx")
+			story.DocsPath = filepath.Join(args.OutputDir, story.Component+".mdx")
+
+			if err := os.WriteFile(story.StoryPath, []byte(stories), 0644); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("writing %s: %v", story.StoryPath, err))
+				continue
+			}
+			mdx := renderStoriesMDX(comp, &story, args.FileKey)
+			if err := os.WriteFile(story.DocsPath, []byte(mdx), 0644); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("writing %s: %v", story.DocsPath, err))
+				continue
+			}
+
+			result.Components = append(result.Components, story)
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatExportStoriesResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// storyComponents returns the file's local component sets and the local
+// components outside any set, sorted by name. Variants are sorted by name.
+func storyComponents(file *figma.File) []storyComponent {
+	variants := make(map[string][]*figma.Component)
+	var comps []storyComponent
+	for id, comp := range file.Components {
+		if comp.Remote {
+			continue
+		}
+		if comp.ComponentSetID != "" {
+			variants[comp.ComponentSetID] = append(variants[comp.ComponentSetID], comp)
+			continue
+		}
+		comps = append(comps, storyComponent{ID: id, Name: comp.Name, Description: comp.Description})
+	}
+
+	for id, set := range file.ComponentSets {
+		if set.Remote {
+			continue
+		}
+		vs := variants[id]
+		sort.Slice(vs, func(i, j int) bool { return vs[i].Name < vs[j].Name })
+		comps = append(comps, storyComponent{ID: id, Name: set.Name, Description: set.Description, Variants: vs})
+	}
+
+	sort.Slice(comps, func(i, j int) bool {
+		if comps[i].Name != comps[j].Name {
+			return comps[i].Name < comps[j].Name
+		}
+		return comps[i].ID < comps[j].ID
+	})
+	return comps
+}
+
+// componentIdentifier converts a component name such as "icon/arrow-left" to
+// a PascalCase identifier such as IconArrowLeft.
+func componentIdentifier(name string) string {
+	id := swiftIdentifier(name, "")
+	if strings.HasPrefix(id, "_") {
+		return "Component" + id
+	}
+	return strings.ToUpper(id[:1]) + id[1:]
+}
+
+// variantArgs converts a variant name such as "Size=Large, State=Hover" into
+// props.
+func variantArgs(name string) []storyArg {
+	var args []storyArg
+	for _, pair := range parseVariantName(name) {
+		args = append(args, storyArg{Prop: swiftIdentifier(pair[0], ""), Value: pair[1]})
+	}
+	return args
+}
+
+// storyName names a variant's story after its values, e.g. LargeHover.
+func storyName(args []storyArg) string {
+	var values []string
+	for _, a := range args {
+		values = append(values, a.Value)
+	}
+	if len(values) == 0 {
+		return "Default"
+	}
+	return componentIdentifier(strings.Join(values, " "))
+}
+
+// renderComponentStories renders the CSF stories file of a component and
+// returns it with the story names.
+func renderComponentStories(comp storyComponent, story *ComponentStory, importPath, titlePrefix string) (string, []string) {
+	var sb strings.Builder
+	name := story.Component
+
+	sb.WriteString("// Generated by figma-query\n")
+	sb.WriteString("import type { Meta, StoryObj } from '@storybook/react';\n")
+	sb.WriteString(fmt.Sprintf("import { %s } from %s;\n\n", name, jsString(importPath+"/"+name)))
+
+	sb.WriteString(fmt.Sprintf("const meta: Meta<typeof %s> = {\n", name))
+	sb.WriteString(fmt.Sprintf("  title: %s,\n", jsString(titlePrefix+"/"+comp.Name)))
+	sb.WriteString(fmt.Sprintf("  component: %s,\n", name))
+	sb.WriteString("  parameters: {\n")
+	sb.WriteString(fmt.Sprintf("    design: { type: 'figma', url: %s },\n", jsString(story.FigmaURL)))
+	if desc := strings.TrimSpace(comp.Description); desc != "" {
+		sb.WriteString(fmt.Sprintf("    docs: { description: { component: %s } },\n", jsString(desc)))
+	}
+	sb.WriteString("  },\n")
+
+	order := propertyOrder(comp.Variants)
+	props := variantProperties(comp.Variants)
+	if len(order) > 0 {
+		sb.WriteString("  argTypes: {\n")
+		for _, prop := range order {
+			options := make([]string, len(props[prop]))
+			for i, v := range props[prop] {
+				options[i] = jsString(v)
+			}
+			sb.WriteString(fmt.Sprintf("    %s: { control: 'select', options: [%s] },\n", swiftIdentifier(prop, ""), strings.Join(options, ", ")))
+		}
+		sb.WriteString("  },\n")
+	}
+	sb.WriteString("};\n\n")
+	sb.WriteString("export default meta;\n")
+	sb.WriteString(fmt.Sprintf("type Story = StoryObj<typeof %s>;\n", name))
+
+	variants := comp.Variants
+	if len(variants) == 0 {
+		variants = []*figma.Component{{}}
+	}
+	var names []string
+	seen := make(map[string]int)
+	for _, v := range variants {
+		args := variantArgs(v.Name)
+		sn := storyName(args)
+		if seen[sn]++; seen[sn] > 1 {
+			sn += strconv.Itoa(seen[sn])
+		}
+		names = append(names, sn)
+
+		sb.WriteString(fmt.Sprintf("\nexport const %s: Story = {", sn))
+		if len(args) == 0 {
+			sb.WriteString("};\n")
+			continue
+		}
+		sb.WriteString("\n  args: {\n")
+		for _, a := range args {
+			sb.WriteString(fmt.Sprintf("    %s: %s,\n", a.Prop, jsString(a.Value)))
+		}
+		sb.WriteString("  },\n};\n")
+	}
+
+	return sb.String(), names
+}
+
+// figmaEmbedURL returns the URL of the Figma embed of a node.
+func figmaEmbedURL(fileKey, nodeID string) string {
+	return "https://www.figma.com/embed?embed_host=share&url=" + url.QueryEscape(figmaNodeURL(fileKey, nodeID))
+}
+
+// renderStoriesMDX renders the MDX 2 docs page attached to a component's
+// stories. AutoDocs is defined in the page from the blocks Storybook's
+// autodocs template renders.
+func renderStoriesMDX(comp storyComponent, story *ComponentStory, fileKey string) string {
+	var sb strings.Builder
+	name := story.Component
+
+	sb.WriteString("import { Meta, Title, Description, Primary, Controls, Stories, Canvas, Source } from '@storybook/blocks';\n")
+	sb.WriteString(fmt.Sprintf("import * as %sStories from './%s.stories';\n\n", name, name))
+
+	sb.WriteString("export const AutoDocs = () => (\n")
+	sb.WriteString("  <>\n")
+	sb.WriteString("    <Title />\n")
+	sb.WriteString("    <Description />\n")
+	sb.WriteString("    <Primary />\n")
+	sb.WriteString("    <Controls />\n")
+	sb.WriteString("    <Stories />\n")
+	sb.WriteString("  </>\n")
+	sb.WriteString(");\n\n")
+
+	sb.WriteString(fmt.Sprintf("<Meta of={%sStories} />\n\n", name))
+	sb.WriteString("<AutoDocs />\n\n")
+
+	sb.WriteString("## Design\n\n")
+	sb.WriteString("<Canvas>\n")
+	sb.WriteString("  <iframe\n")
+	sb.WriteString(fmt.Sprintf("    title=%s\n", jsxAttr(comp.Name+" in Figma")))
+	sb.WriteString(fmt.Sprintf("    src=%s\n", jsxAttr(figmaEmbedURL(fileKey, comp.ID))))
+	sb.WriteString("    width=\"100%\"\n")
+	sb.WriteString("    height=\"450\"\n")
+	sb.WriteString("    allowFullScreen\n")
+	sb.WriteString("  />\n")
+	sb.WriteString("</Canvas>\n\n")
+
+	sb.WriteString("## Usage\n\n")
+	variants := comp.Variants
+	if len(variants) == 0 {
+		variants = []*figma.Component{{}}
+	}
+	var examples []string
+	for _, v := range variants {
+		examples = append(examples, jsxExample(name, variantArgs(v.Name)))
+	}
+	code := strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${").Replace(strings.Join(examples, "\n"))
+	sb.WriteString("<Source\n")
+	sb.WriteString("  language=\"tsx\"\n")
+	sb.WriteString(fmt.Sprintf("  code={`%s`}\n", code))
+	sb.WriteString("/>\n")
+
+	return sb.String()
+}
+
+// jsxExample renders an element of the component with args as props.
+func jsxExample(component string, args []storyArg) string {
+	var sb strings.Builder
+	sb.WriteString("<" + component)
+	for _, a := range args {
+		sb.WriteString(fmt.Sprintf(" %s=%s", a.Prop, jsxAttr(a.Value)))
+	}
+	sb.WriteString(" />")
+	return sb.String()
+}
+
+// jsxAttr quotes s as a JSX attribute value. JSX strings have no escapes,
+// so values containing quotes or backslashes become expressions.
+func jsxAttr(s string) string {
+	if strings.ContainsAny(s, "\"\\") {
+		return "{" + jsString(s) + "}"
+	}
+	return `"` + s + `"`
+}
+
+// jsString quotes s as a JavaScript string literal.
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func formatExportStoriesResult(r *ExportStoriesResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Wrote stories for %d components to %s\n\n", len(r.Components), r.OutputDir))
+
+	for _, c := range r.Components {
+		sb.WriteString(fmt.Sprintf("  %s (%d stories) → %s, %s\n", c.Name, len(c.Stories), filepath.Base(c.StoryPath), filepath.Base(c.DocsPath)))
+	}
+
+	if len(r.Errors) > 0 {
+		sb.WriteString("\nErrors:\n")
+		for _, e := range r.Errors {
+			sb.WriteString(fmt.Sprintf("  - %s\n", e))
+		}
+	}
+
+	return sb.String()
+}
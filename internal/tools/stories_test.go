@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestStoryComponents(t *testing.T) {
+	file := &figma.File{
+		Components: map[string]*figma.Component{
+			"1:3": {Name: "Size=Small", ComponentSetID: "1:2"},
+			"1:4": {Name: "Size=Large", ComponentSetID: "1:2"},
+			"2:1": {Name: "icon/arrow-left"},
+			"3:1": {Name: "Remote", Remote: true},
+		},
+		ComponentSets: map[string]*figma.ComponentSet{
+			"1:2": {Name: "Button"},
+		},
+	}
+
+	comps := storyComponents(file)
+	if len(comps) != 2 {
+		t.Fatalf("got %d components, want 2: %+v", len(comps), comps)
+	}
+	if comps[0].Name != "Button" || comps[1].Name != "icon/arrow-left" {
+		t.Errorf("components = %s, %s, want Button, icon/arrow-left", comps[0].Name, comps[1].Name)
+	}
+	if len(comps[0].Variants) != 2 || comps[0].Variants[0].Name != "Size=Large" {
+		t.Errorf("Button variants not sorted by name: %+v", comps[0].Variants)
+	}
+	if got := componentIdentifier(comps[1].Name); got != "IconArrowLeft" {
+		t.Errorf("componentIdentifier = %s, want IconArrowLeft", got)
+	}
+}
+
+func TestRenderStories(t *testing.T) {
+	comp := storyComponent{
+		ID:          "1:2",
+		Name:        "Button",
+		Description: "Primary actions.",
+		Variants: []*figma.Component{
+			{Name: "Size=Large, Icon Position=Left"},
+			{Name: "Size=Small, Icon Position=Left"},
+		},
+	}
+	story := &ComponentStory{Component: "Button", FigmaURL: figmaNodeURL("FILE", "1:2")}
+
+	csf, names := renderComponentStories(comp, story, "../components", "Components")
+	if strings.Join(names, ",") != "LargeLeft,SmallLeft" {
+		t.Errorf("story names = %v, want [LargeLeft SmallLeft]", names)
+	}
+	for _, want := range []string{
+		`import { Button } from "../components/Button";`,
+		`title: "Components/Button",`,
+		`design: { type: 'figma', url: "https://www.figma.com/design/FILE?node-id=1-2" },`,
+		`docs: { description: { component: "Primary actions." } },`,
+		`iconPosition: { control: 'select', options: ["Left"] },`,
+		"export const LargeLeft: Story = {\n  args: {\n    size: \"Large\",\n    iconPosition: \"Left\",\n  },\n};",
+	} {
+		if !strings.Contains(csf, want) {
+			t.Errorf("expected stories to contain %q, got:\n%s", want, csf)
+		}
+	}
+
+	mdx := renderStoriesMDX(comp, story, "FILE")
+	for _, want := range []string{
+		"import * as ButtonStories from './Button.stories';",
+		"export const AutoDocs = () => (",
+		"<Meta of={ButtonStories} />",
+		"<AutoDocs />",
+		`src="https://www.figma.com/embed?embed_host=share&url=https%3A%2F%2Fwww.figma.com%2Fdesign%2FFILE%3Fnode-id%3D1-2"`,
+		"code={`<Button size=\"Large\" iconPosition=\"Left\" />\n<Button size=\"Small\" iconPosition=\"Left\" />`}",
+	} {
+		if !strings.Contains(mdx, want) {
+			t.Errorf("expected MDX to contain %q, got:\n%s", want, mdx)
+		}
+	}
+}
+
+func TestJSXAttr(t *testing.T) {
+	if got := jsxAttr("Large"); got != `"Large"` {
+		t.Errorf("jsxAttr(Large) = %s", got)
+	}
+	if got := jsxAttr(`Say "hi"`); got != `{"Say \"hi\""}` {
+		t.Errorf("jsxAttr with quotes = %s", got)
+	}
+}
@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// textRun is a span of characters sharing one style override.
+type textRun struct {
+	override int
+	text     string
+}
+
+// splitTextRuns run-length encodes characterStyleOverrides over characters.
+// Figma indexes overrides by UTF-16 code unit and omits trailing zeros.
+func splitTextRuns(characters string, overrides []int) []textRun {
+	units := utf16.Encode([]rune(characters))
+	if len(units) == 0 {
+		return nil
+	}
+
+	overrideAt := func(i int) int {
+		if i < len(overrides) {
+			return overrides[i]
+		}
+		return 0
+	}
+
+	var runs []textRun
+	start := 0
+	for i := 1; i <= len(units); i++ {
+		if i < len(units) && overrideAt(i) == overrideAt(start) {
+			continue
+		}
+		runs = append(runs, textRun{
+			override: overrideAt(start),
+			text:     string(utf16.Decode(units[start:i])),
+		})
+		start = i
+	}
+	return runs
+}
+
+// svgTextAttrs returns SVG presentation attributes for a text style, in a
+// stable order. fills is used when the style carries no fills of its own.
+func svgTextAttrs(style *figma.TypeStyle, fills []figma.Paint) [][2]string {
+	var attrs [][2]string
+	if style == nil {
+		style = &figma.TypeStyle{}
+	}
+
+	if style.FontFamily != "" {
+		attrs = append(attrs, [2]string{"font-family", style.FontFamily})
+	}
+	if style.FontSize > 0 {
+		attrs = append(attrs, [2]string{"font-size", fmt.Sprintf("%g", style.FontSize)})
+	}
+	if style.FontWeight > 0 {
+		attrs = append(attrs, [2]string{"font-weight", fmt.Sprintf("%g", style.FontWeight)})
+	}
+	if style.Italic {
+		attrs = append(attrs, [2]string{"font-style", "italic"})
+	}
+	if style.LetterSpacing != 0 {
+		attrs = append(attrs, [2]string{"letter-spacing", fmt.Sprintf("%g", style.LetterSpacing)})
+	}
+	switch style.TextDecoration {
+	case "UNDERLINE":
+		attrs = append(attrs, [2]string{"text-decoration", "underline"})
+	case "STRIKETHROUGH":
+		attrs = append(attrs, [2]string{"text-decoration", "line-through"})
+	}
+
+	if len(style.Fills) > 0 {
+		fills = style.Fills
+	}
+	for _, fill := range fills {
+		if fill.Type == "SOLID" && fill.Color != nil && (fill.Visible == nil || *fill.Visible) {
+			attrs = append(attrs, [2]string{"fill", colorToCSS(fill.Color, fill.Opacity)})
+			break
+		}
+	}
+
+	return attrs
+}
+
+// diffAttrs returns the attributes in attrs whose values differ from base.
+func diffAttrs(base, attrs [][2]string) [][2]string {
+	baseValues := make(map[string]string, len(base))
+	for _, a := range base {
+		baseValues[a[0]] = a[1]
+	}
+
+	var diff [][2]string
+	for _, a := range attrs {
+		if baseValues[a[0]] != a[1] {
+			diff = append(diff, a)
+		}
+	}
+	return diff
+}
+
+func writeSVGAttrs(sb *strings.Builder, attrs [][2]string) {
+	for _, a := range attrs {
+		sb.WriteString(fmt.Sprintf(" %s=\"%s\"", a[0], xmlEscaper.Replace(a[1])))
+	}
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;")
+
+// generateSVGText renders a TEXT node as an SVG <text> element, with a
+// <tspan> for each run of characters that uses a style override.
+func generateSVGText(node *figma.Node) string {
+	var sb strings.Builder
+
+	base := svgTextAttrs(node.Style, node.Fills)
+	sb.WriteString("<text")
+	writeSVGAttrs(&sb, base)
+	sb.WriteString(">")
+
+	for _, run := range splitTextRuns(node.Characters, node.CharacterStyleOverrides) {
+		override := node.StyleOverrideTable[fmt.Sprintf("%d", run.override)]
+		if run.override == 0 || override == nil {
+			sb.WriteString(xmlEscaper.Replace(run.text))
+			continue
+		}
+
+		merged := mergeTypeStyle(node.Style, override)
+		attrs := diffAttrs(base, svgTextAttrs(merged, node.Fills))
+		if len(attrs) == 0 {
+			sb.WriteString(xmlEscaper.Replace(run.text))
+			continue
+		}
+
+		sb.WriteString("<tspan")
+		writeSVGAttrs(&sb, attrs)
+		sb.WriteString(">")
+		sb.WriteString(xmlEscaper.Replace(run.text))
+		sb.WriteString("</tspan>")
+	}
+
+	sb.WriteString("</text>")
+	return sb.String()
+}
+
+// mergeTypeStyle applies the fields set in override on top of base.
+func mergeTypeStyle(base, override *figma.TypeStyle) *figma.TypeStyle {
+	merged := figma.TypeStyle{}
+	if base != nil {
+		merged = *base
+	}
+
+	if override.FontFamily != "" {
+		merged.FontFamily = override.FontFamily
+	}
+	if override.FontSize > 0 {
+		merged.FontSize = override.FontSize
+	}
+	if override.FontWeight > 0 {
+		merged.FontWeight = override.FontWeight
+	}
+	if override.Italic {
+		merged.Italic = true
+	}
+	if override.LetterSpacing != 0 {
+		merged.LetterSpacing = override.LetterSpacing
+	}
+	if override.TextDecoration != "" {
+		merged.TextDecoration = override.TextDecoration
+	}
+	if len(override.Fills) > 0 {
+		merged.Fills = override.Fills
+	}
+
+	return &merged
+}
+
+// generateSVGTextSnippets renders node, or every TEXT node beneath it, as SVG.
+func generateSVGTextSnippets(node *figma.Node) string {
+	var sb strings.Builder
+
+	var walk func(*figma.Node)
+	walk = func(n *figma.Node) {
+		if n.Type == figma.NodeTypeText {
+			sb.WriteString(fmt.Sprintf("<!-- %s [%s] -->\n", n.Name, n.ID))
+			sb.WriteString(generateSVGText(n))
+			sb.WriteString("\n")
+			return
+		}
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	if sb.Len() == 0 {
+		return fmt.Sprintf("<!-- %s: no text layers -->\n", node.Name)
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestSplitTextRuns(t *testing.T) {
+	tests := []struct {
+		name       string
+		characters string
+		overrides  []int
+		expected   []textRun
+	}{
+		{"no overrides", "Hello", nil, []textRun{{0, "Hello"}}},
+		{"trailing zeros omitted", "Hello world", []int{1, 1, 1, 1, 1}, []textRun{{1, "Hello"}, {0, " world"}}},
+		{"middle run", "a bold b", []int{0, 0, 2, 2, 2, 2, 0, 0}, []textRun{{0, "a "}, {2, "bold"}, {0, " b"}}},
+		// The emoji is two UTF-16 code units
+		{"surrogate pair", "🎉ok", []int{3, 3, 0, 0}, []textRun{{3, "🎉"}, {0, "ok"}}},
+		{"empty", "", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := splitTextRuns(tt.characters, tt.overrides)
+			if len(runs) != len(tt.expected) {
+				t.Fatalf("got %d runs %v, want %v", len(runs), runs, tt.expected)
+			}
+			for i := range runs {
+				if runs[i] != tt.expected[i] {
+					t.Errorf("run %d = %+v, want %+v", i, runs[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSVGText(t *testing.T) {
+	node := &figma.Node{
+		Type:                    figma.NodeTypeText,
+		Characters:              "Save <now>",
+		Style:                   &figma.TypeStyle{FontFamily: "Inter", FontSize: 16, FontWeight: 400},
+		Fills:                   []figma.Paint{{Type: "SOLID", Color: &figma.Color{A: 1}}},
+		CharacterStyleOverrides: []int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1},
+		StyleOverrideTable: map[string]*figma.TypeStyle{
+			"1": {FontWeight: 700, FontFamily: "Inter"},
+		},
+	}
+
+	expected := `<text font-family="Inter" font-size="16" font-weight="400" fill="rgb(0, 0, 0)">Save <tspan font-weight="700">&lt;now&gt;</tspan></text>`
+	if got := generateSVGText(node); got != expected {
+		t.Errorf("generateSVGText() =\n%s\nwant\n%s", got, expected)
+	}
+}
@@ -21,7 +21,14 @@ type SyncFileArgs struct {
 	Include     []string     `json:"include,omitempty" jsonschema:"What to export: pages components styles variables assets"`
 	Assets      AssetOptions `json:"assets,omitempty" jsonschema:"Asset export options"`
 	Incremental bool         `json:"incremental,omitempty" jsonschema:"Only update changed nodes (default: true)"`
-	Format      string       `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	DryRun      bool         `json:"dry_run,omitempty" jsonschema:"Walk the file and report stats without writing files or downloading assets"`
+	PluginData  string       `json:"plugin_data,omitempty" jsonschema:"Comma-separated plugin IDs or 'shared' to include plugin data (written to _plugin.json)"`
+
+	DownloadThumbnail bool   `json:"download_thumbnail,omitempty" jsonschema:"Download the file thumbnail to _thumbnail.png (default: false)"`
+	Stream            bool   `json:"stream,omitempty" jsonschema:"Report page_start, page_done and done events as JSON Lines: sent as progress notifications while the sync runs and returned as the text output"`
+	Format            string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+
+	keyedDir bool // suffix the export directory with the file key (batch_sync)
 }
 
 // AssetOptions contains options for asset export.
@@ -33,10 +40,21 @@ type AssetOptions struct {
 
 // SyncFileResult contains the result of the sync_file tool.
 type SyncFileResult struct {
-	ExportPath  string           `json:"export_path"`
-	Stats       SyncStats        `json:"stats"`
-	TreePreview string           `json:"tree_preview,omitempty"`
-	Errors      []string         `json:"errors,omitempty"`
+	ExportPath  string      `json:"export_path"`
+	Stats       SyncStats   `json:"stats"`
+	TreePreview string      `json:"tree_preview,omitempty"`
+	Errors      []string    `json:"errors,omitempty"`
+	Events      []SyncEvent `json:"events,omitempty"` // with stream
+}
+
+// SyncEvent is a step of a streaming sync, written as one JSON line.
+type SyncEvent struct {
+	Event     string     `json:"event"` // page_start, page_done or done
+	Page      string     `json:"page,omitempty"`
+	Timestamp string     `json:"timestamp"`
+	Nodes     *int       `json:"nodes,omitempty"`  // page_done: nodes exported from the page
+	Assets    *int       `json:"assets,omitempty"` // page_done: images and exportable nodes found on the page
+	Stats     *SyncStats `json:"stats,omitempty"`  // done
 }
 
 // SyncStats contains export statistics.
@@ -85,227 +103,399 @@ func registerSyncFileTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "sync_file",
 		Description: "Export entire Figma file to nested folder structure for grep/jq access. Creates local cache.",
+		InputSchema: inputSchema[SyncFileArgs](map[string][]string{
+			"include":        {"pages", "components", "styles", "variables", "assets"},
+			"assets.formats": {"png", "svg", "pdf", "jpg"},
+			"format":         responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args SyncFileArgs) (*mcp.CallToolResult, *SyncFileResult, error) {
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured. Set FIGMA_ACCESS_TOKEN environment variable")
+			return nil, nil, errNoClient
 		}
 
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
 
-		startTime := time.Now()
-
-		// Set defaults
-		outputDir := args.OutputDir
-		if outputDir == "" {
-			outputDir = r.ExportDir()
+		result, err := r.syncFile(ctx, req, args)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		include := args.Include
-		if len(include) == 0 {
-			include = []string{"pages", "components", "styles", "variables", "assets"}
+		// Format output
+		var textOutput string
+		switch {
+		case args.Format == "json":
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		case args.Stream:
+			textOutput = formatSyncEvents(result.Events)
+		default:
+			textOutput = formatSyncResult(result)
 		}
 
-		// Fetch the file
-		file, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{
-			Geometry: "paths",
-		})
-		if err != nil {
-			return nil, nil, fmt.Errorf("fetching file: %w", err)
-		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// unsafeKeyChars matches characters of a file key not kept in a directory name.
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+// syncExportPath returns the directory an export of a file is written to. With
+// keyed set the file key is appended, so files that share a name get separate
+// directories. File keys are case-sensitive, so the key keeps its case.
+func syncExportPath(outputDir, fileName, fileKey string, keyed bool) string {
+	name := sanitizeName(fileName)
+	if keyed {
+		name += "-" + unsafeKeyChars.ReplaceAllString(fileKey, "_")
+	}
+	return filepath.Join(outputDir, name)
+}
+
+// syncFile exports a file to args.OutputDir (default: the export directory).
+// Progress is reported to the client when req carries a progress token; req
+// may be nil.
+func (r *Registry) syncFile(ctx context.Context, req *mcp.CallToolRequest, args SyncFileArgs) (*SyncFileResult, error) {
+	startTime := time.Now()
+
+	var stream *syncStream
+	if args.Stream {
+		// Stream events replace the per-page progress notifications
+		stream = &syncStream{ctx: ctx, req: req}
+		req = nil
+	}
+
+	// Set defaults
+	outputDir := args.OutputDir
+	if outputDir == "" {
+		outputDir = r.ExportDir()
+	}
 
-		// Create export directory
-		exportPath := filepath.Join(outputDir, sanitizeName(file.Name))
-		if err := os.MkdirAll(exportPath, 0755); err != nil {
-			return nil, nil, fmt.Errorf("creating export directory: %w", err)
+	include := args.Include
+	if len(include) == 0 {
+		include = []string{"pages", "components", "styles", "variables", "assets"}
+	}
+
+	// Fetch the file
+	file, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{
+		Geometry:   "paths",
+		PluginData: args.PluginData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching file: %w", err)
+	}
+
+	// Create export directory
+	w := &syncWriter{dryRun: args.DryRun}
+	exportPath := syncExportPath(outputDir, file.Name, args.FileKey, args.keyedDir)
+	if err := w.MkdirAll(exportPath, 0755); err != nil {
+		return nil, fmt.Errorf("creating export directory: %w", err)
+	}
+
+	stats := SyncStats{}
+	var errors []string
+	var treeLines []string
+	var downloadedURLs []string
+	imageCollector := NewImageCollector()
+
+	// Export metadata
+	exportedAt := time.Now().UTC().Format(time.RFC3339)
+	meta := map[string]interface{}{
+		"name":          file.Name,
+		"version":       file.Version,
+		"lastModified":  file.LastModified,
+		"exportedAt":    exportedAt,
+		"fileKey":       args.FileKey,
+		"schemaVersion": file.SchemaVersion,
+	}
+
+	// Thumbnail (opt-in; the URL expires, so the PNG is kept locally)
+	if args.DownloadThumbnail && file.ThumbnailURL != "" && !args.DryRun {
+		data, err := r.Client().DownloadImage(ctx, file.ThumbnailURL)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("downloading thumbnail: %v", err))
+		} else if err := w.WriteFile(filepath.Join(exportPath, "_thumbnail.png"), data, 0644); err != nil {
+			errors = append(errors, fmt.Sprintf("writing thumbnail: %v", err))
+		} else {
+			meta["thumbnail"] = "_thumbnail.png"
+			downloadedURLs = append(downloadedURLs, file.ThumbnailURL)
 		}
+	}
 
-		stats := SyncStats{}
-		var errors []string
-		var treeLines []string
-		imageCollector := NewImageCollector()
+	// Build node index
+	nodeIndex := make(map[string]IndexEntry)
 
-		// Export metadata
-		meta := map[string]interface{}{
-			"name":          file.Name,
-			"version":       file.Version,
-			"lastModified":  file.LastModified,
-			"exportedAt":    time.Now().UTC().Format(time.RFC3339),
-			"fileKey":       args.FileKey,
-			"schemaVersion": file.SchemaVersion,
+	// Export pages
+	if contains(include, "pages") && file.Document != nil {
+		pagesDir := filepath.Join(exportPath, "pages")
+		if err := w.MkdirAll(pagesDir, 0755); err != nil {
+			errors = append(errors, fmt.Sprintf("creating pages dir: %v", err))
 		}
-		if err := writeJSON(filepath.Join(exportPath, "_meta.json"), meta); err != nil {
-			errors = append(errors, fmt.Sprintf("writing meta: %v", err))
+
+		var exportedPages []*figma.Node
+		totalNodes := 0
+		for _, page := range file.Document.Children {
+			totalNodes += countNodes(page)
 		}
 
-		// Build node index
-		nodeIndex := make(map[string]string) // node_id -> path
+		for _, page := range file.Document.Children {
+			if page.Type == figma.NodeTypeCanvas {
+				stats.Pages++
+				pagePath := filepath.Join(pagesDir, sanitizeName(page.Name)+"-"+sanitizeID(page.ID))
+				treeLines = append(treeLines, fmt.Sprintf("Page: %s [%s]", page.Name, page.ID))
 
-		// Export pages
-		if contains(include, "pages") && file.Document != nil {
-			pagesDir := filepath.Join(exportPath, "pages")
-			if err := os.MkdirAll(pagesDir, 0755); err != nil {
-				errors = append(errors, fmt.Sprintf("creating pages dir: %v", err))
-			}
+				stream.emit(SyncEvent{Event: "page_start", Page: page.Name})
+				assetsBefore := len(imageCollector.ImageRefs) + len(imageCollector.ExportNodes)
 
-			for _, page := range file.Document.Children {
-				if page.Type == figma.NodeTypeCanvas {
-					stats.Pages++
-					pagePath := filepath.Join(pagesDir, sanitizeName(page.Name)+"-"+sanitizeID(page.ID))
-					treeLines = append(treeLines, fmt.Sprintf("Page: %s [%s]", page.Name, page.ID))
+				nodeCount, pageErrors := exportNode(ctx, w, page, pagePath, nil, page.Name, &treeLines, nodeIndex, imageCollector)
+				stats.Nodes += nodeCount
+				errors = append(errors, pageErrors...)
 
-					nodeCount, pageErrors := exportNode(ctx, page, pagePath, 1, &treeLines, nodeIndex, imageCollector)
-					stats.Nodes += nodeCount
-					errors = append(errors, pageErrors...)
-				}
+				assets := len(imageCollector.ImageRefs) + len(imageCollector.ExportNodes) - assetsBefore
+				stream.emit(SyncEvent{Event: "page_done", Page: page.Name, Nodes: &nodeCount, Assets: &assets})
+
+				reportSyncProgress(ctx, r, req, page.Name, stats.Nodes, totalNodes)
+				exportedPages = append(exportedPages, page)
 			}
 		}
 
-		// Export components
-		if contains(include, "components") && len(file.Components) > 0 {
-			componentsDir := filepath.Join(exportPath, "components")
-			if err := os.MkdirAll(componentsDir, 0755); err != nil {
-				errors = append(errors, fmt.Sprintf("creating components dir: %v", err))
+		if !args.DryRun {
+			if err := writeNodeSnapshot(exportPath, exportedPages); err != nil {
+				errors = append(errors, fmt.Sprintf("writing node snapshot: %v", err))
 			}
+		}
+	}
+
+	// Export components
+	if contains(include, "components") && len(file.Components) > 0 {
+		componentsDir := filepath.Join(exportPath, "components")
+		if err := w.MkdirAll(componentsDir, 0755); err != nil {
+			errors = append(errors, fmt.Sprintf("creating components dir: %v", err))
+		}
 
-			componentList := make([]map[string]interface{}, 0, len(file.Components))
-			for id, comp := range file.Components {
-				stats.Components++
-				componentList = append(componentList, map[string]interface{}{
-					"id":          id,
-					"key":         comp.Key,
-					"name":        comp.Name,
-					"description": comp.Description,
-				})
+		componentList := make([]map[string]interface{}, 0, len(file.Components))
+		for id, comp := range file.Components {
+			stats.Components++
+			componentList = append(componentList, map[string]interface{}{
+				"id":          id,
+				"key":         comp.Key,
+				"name":        comp.Name,
+				"description": comp.Description,
+			})
+		}
+
+		if err := w.WriteJSON(filepath.Join(componentsDir, "_components.json"), componentList); err != nil {
+			errors = append(errors, fmt.Sprintf("writing components: %v", err))
+		}
+	}
+
+	// Export styles
+	if contains(include, "styles") && len(file.Styles) > 0 {
+		stylesDir := filepath.Join(exportPath, "styles")
+		if err := w.MkdirAll(stylesDir, 0755); err != nil {
+			errors = append(errors, fmt.Sprintf("creating styles dir: %v", err))
+		}
+
+		// Group styles by type
+		colorStyles := make([]map[string]interface{}, 0)
+		textStyles := make([]map[string]interface{}, 0)
+		effectStyles := make([]map[string]interface{}, 0)
+		gridStyles := make([]map[string]interface{}, 0)
+
+		for id, style := range file.Styles {
+			stats.Styles++
+			styleData := map[string]interface{}{
+				"id":          id,
+				"key":         style.Key,
+				"name":        style.Name,
+				"description": style.Description,
 			}
 
-			if err := writeJSON(filepath.Join(componentsDir, "_components.json"), componentList); err != nil {
-				errors = append(errors, fmt.Sprintf("writing components: %v", err))
+			switch style.StyleType {
+			case figma.StyleTypeFill:
+				colorStyles = append(colorStyles, styleData)
+			case figma.StyleTypeText:
+				textStyles = append(textStyles, styleData)
+			case figma.StyleTypeEffect:
+				effectStyles = append(effectStyles, styleData)
+			case figma.StyleTypeGrid:
+				gridStyles = append(gridStyles, styleData)
 			}
 		}
 
-		// Export styles
-		if contains(include, "styles") && len(file.Styles) > 0 {
-			stylesDir := filepath.Join(exportPath, "styles")
-			if err := os.MkdirAll(stylesDir, 0755); err != nil {
-				errors = append(errors, fmt.Sprintf("creating styles dir: %v", err))
+		if len(colorStyles) > 0 {
+			w.WriteJSON(filepath.Join(stylesDir, "colors.json"), colorStyles)
+		}
+		if len(textStyles) > 0 {
+			w.WriteJSON(filepath.Join(stylesDir, "typography.json"), textStyles)
+		}
+		if len(effectStyles) > 0 {
+			w.WriteJSON(filepath.Join(stylesDir, "effects.json"), effectStyles)
+		}
+		if len(gridStyles) > 0 {
+			w.WriteJSON(filepath.Join(stylesDir, "grids.json"), gridStyles)
+		}
+	}
+
+	// Export variables
+	if contains(include, "variables") {
+		vars, err := r.Client().GetLocalVariables(ctx, args.FileKey)
+		if err == nil && vars.Meta != nil {
+			varsDir := filepath.Join(exportPath, "variables")
+			if err := w.MkdirAll(varsDir, 0755); err != nil {
+				errors = append(errors, fmt.Sprintf("creating variables dir: %v", err))
 			}
 
-			// Group styles by type
-			colorStyles := make([]map[string]interface{}, 0)
-			textStyles := make([]map[string]interface{}, 0)
-			effectStyles := make([]map[string]interface{}, 0)
-			gridStyles := make([]map[string]interface{}, 0)
-
-			for id, style := range file.Styles {
-				stats.Styles++
-				styleData := map[string]interface{}{
-					"id":          id,
-					"key":         style.Key,
-					"name":        style.Name,
-					"description": style.Description,
-				}
+			stats.Variables = len(vars.Meta.Variables)
+
+			// Export collections
+			collectionsDir := filepath.Join(varsDir, "collections")
+			w.MkdirAll(collectionsDir, 0755)
 
-				switch style.StyleType {
-				case figma.StyleTypeFill:
-					colorStyles = append(colorStyles, styleData)
-				case figma.StyleTypeText:
-					textStyles = append(textStyles, styleData)
-				case figma.StyleTypeEffect:
-					effectStyles = append(effectStyles, styleData)
-				case figma.StyleTypeGrid:
-					gridStyles = append(gridStyles, styleData)
+			for _, coll := range vars.Meta.VariableCollections {
+				collData := map[string]interface{}{
+					"id":            coll.ID,
+					"name":          coll.Name,
+					"key":           coll.Key,
+					"modes":         coll.Modes,
+					"defaultModeId": coll.DefaultModeID,
+					"variableIds":   coll.VariableIDs,
 				}
+				w.WriteJSON(filepath.Join(collectionsDir, sanitizeName(coll.Name)+".json"), collData)
 			}
 
-			if len(colorStyles) > 0 {
-				writeJSON(filepath.Join(stylesDir, "colors.json"), colorStyles)
-			}
-			if len(textStyles) > 0 {
-				writeJSON(filepath.Join(stylesDir, "typography.json"), textStyles)
-			}
-			if len(effectStyles) > 0 {
-				writeJSON(filepath.Join(stylesDir, "effects.json"), effectStyles)
-			}
-			if len(gridStyles) > 0 {
-				writeJSON(filepath.Join(stylesDir, "grids.json"), gridStyles)
-			}
+			// Export all variables
+			w.WriteJSON(filepath.Join(varsDir, "tokens.json"), vars.Meta.Variables)
 		}
+	}
 
-		// Export variables
-		if contains(include, "variables") {
-			vars, err := r.Client().GetLocalVariables(ctx, args.FileKey)
-			if err == nil && vars.Meta != nil {
-				varsDir := filepath.Join(exportPath, "variables")
-				if err := os.MkdirAll(varsDir, 0755); err != nil {
-					errors = append(errors, fmt.Sprintf("creating variables dir: %v", err))
-				}
+	// Export assets (image fills and node renders)
+	if contains(include, "assets") {
+		assetsDir := filepath.Join(exportPath, "assets")
+		if err := w.MkdirAll(assetsDir, 0755); err != nil {
+			errors = append(errors, fmt.Sprintf("creating assets dir: %v", err))
+		}
+
+		// Set default formats and scales
+		formats := args.Assets.Formats
+		if len(formats) == 0 {
+			formats = []string{"png"}
+		}
+		scales := args.Assets.Scales
+		if len(scales) == 0 {
+			scales = []float64{1}
+		}
 
-				stats.Variables = len(vars.Meta.Variables)
+		if args.DryRun {
+			// Report what would be downloaded without calling the image APIs
+			stats.ImageFills = len(imageCollector.ImageRefs)
+			stats.Assets = len(imageCollector.ExportNodes) * len(formats) * len(scales)
+		}
 
-				// Export collections
-				collectionsDir := filepath.Join(varsDir, "collections")
-				os.MkdirAll(collectionsDir, 0755)
+		// Export image fills (backgrounds, fill images, etc.)
+		if !args.DryRun && len(imageCollector.ImageRefs) > 0 {
+			imageFillsDir := filepath.Join(assetsDir, "fills")
+			if err := w.MkdirAll(imageFillsDir, 0755); err != nil {
+				errors = append(errors, fmt.Sprintf("creating fills dir: %v", err))
+			}
 
-				for _, coll := range vars.Meta.VariableCollections {
-					collData := map[string]interface{}{
-						"id":            coll.ID,
-						"name":          coll.Name,
-						"key":           coll.Key,
-						"modes":         coll.Modes,
-						"defaultModeId": coll.DefaultModeID,
-						"variableIds":   coll.VariableIDs,
+			// Get image fill URLs from Figma
+			imageFillURLs, err := r.Client().GetImageFills(ctx, args.FileKey)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("fetching image fills: %v", err))
+			} else {
+				// Download each image fill
+				for imageRef, nodeIDs := range imageCollector.ImageRefs {
+					imageURL, ok := imageFillURLs[imageRef]
+					if !ok || imageURL == "" {
+						errors = append(errors, fmt.Sprintf("no URL for image ref %s (used in %v)", imageRef, nodeIDs))
+						continue
+					}
+
+					// Download the image
+					data, err := r.Client().DownloadImage(ctx, imageURL)
+					if err != nil {
+						errors = append(errors, fmt.Sprintf("downloading image %s: %v", imageRef, err))
+						continue
+					}
+
+					// Skip if over size limit
+					if args.Assets.MaxSize > 0 && len(data) > args.Assets.MaxSize {
+						continue
 					}
-					writeJSON(filepath.Join(collectionsDir, sanitizeName(coll.Name)+".json"), collData)
-				}
 
-				// Export all variables
-				writeJSON(filepath.Join(varsDir, "tokens.json"), vars.Meta.Variables)
+					// Determine file extension from URL or default to png
+					ext := "png"
+					if strings.Contains(imageURL, ".jpg") || strings.Contains(imageURL, ".jpeg") {
+						ext = "jpg"
+					} else if strings.Contains(imageURL, ".svg") {
+						ext = "svg"
+					} else if strings.Contains(imageURL, ".gif") {
+						ext = "gif"
+					} else if strings.Contains(imageURL, ".webp") {
+						ext = "webp"
+					}
+
+					filename := fmt.Sprintf("%s.%s", sanitizeID(imageRef), ext)
+					filePath := filepath.Join(imageFillsDir, filename)
+
+					if err := w.WriteFile(filePath, data, 0644); err != nil {
+						errors = append(errors, fmt.Sprintf("writing image %s: %v", imageRef, err))
+						continue
+					}
+					downloadedURLs = append(downloadedURLs, imageURL)
+
+					stats.ImageFills++
+				}
 			}
 		}
 
-		// Export assets (image fills and node renders)
-		if contains(include, "assets") {
-			assetsDir := filepath.Join(exportPath, "assets")
-			if err := os.MkdirAll(assetsDir, 0755); err != nil {
-				errors = append(errors, fmt.Sprintf("creating assets dir: %v", err))
+		// Export nodes with export settings (icons, rendered images)
+		if !args.DryRun && len(imageCollector.ExportNodes) > 0 {
+			rendersDir := filepath.Join(assetsDir, "renders")
+			if err := w.MkdirAll(rendersDir, 0755); err != nil {
+				errors = append(errors, fmt.Sprintf("creating renders dir: %v", err))
 			}
 
-			// Set default formats and scales
-			formats := args.Assets.Formats
-			if len(formats) == 0 {
-				formats = []string{"png"}
-			}
-			scales := args.Assets.Scales
-			if len(scales) == 0 {
-				scales = []float64{1}
+			// Collect node IDs
+			nodeIDs := make([]string, 0, len(imageCollector.ExportNodes))
+			for id := range imageCollector.ExportNodes {
+				nodeIDs = append(nodeIDs, id)
 			}
 
-			// Export image fills (backgrounds, fill images, etc.)
-			if len(imageCollector.ImageRefs) > 0 {
-				imageFillsDir := filepath.Join(assetsDir, "fills")
-				if err := os.MkdirAll(imageFillsDir, 0755); err != nil {
-					errors = append(errors, fmt.Sprintf("creating fills dir: %v", err))
-				}
+			// Export in each format and scale
+			for _, format := range formats {
+				exported := make(map[float64]bool)
+				for _, scale := range scales {
+					opts := &figma.ImageExportOptions{
+						Format: format,
+						Scale:  scale,
+					}
+					errors = append(errors, figma.NormalizeImageExportOptions(opts)...)
+					if exported[opts.Scale] {
+						continue
+					}
+					exported[opts.Scale] = true
+					scale = opts.Scale
+
+					images, err := r.Client().GetImages(ctx, args.FileKey, nodeIDs, opts)
+					if err != nil {
+						errors = append(errors, fmt.Sprintf("exporting images: %v", err))
+						continue
+					}
 
-				// Get image fill URLs from Figma
-				imageFillURLs, err := r.Client().GetImageFills(ctx, args.FileKey)
-				if err != nil {
-					errors = append(errors, fmt.Sprintf("fetching image fills: %v", err))
-				} else {
-					// Download each image fill
-					for imageRef, nodeIDs := range imageCollector.ImageRefs {
-						imageURL, ok := imageFillURLs[imageRef]
-						if !ok || imageURL == "" {
-							errors = append(errors, fmt.Sprintf("no URL for image ref %s (used in %v)", imageRef, nodeIDs))
+					for id, imageURL := range images.Images {
+						if imageURL == "" {
 							continue
 						}
 
-						// Download the image
 						data, err := r.Client().DownloadImage(ctx, imageURL)
 						if err != nil {
-							errors = append(errors, fmt.Sprintf("downloading image %s: %v", imageRef, err))
+							errors = append(errors, fmt.Sprintf("downloading render %s: %v", id, err))
 							continue
 						}
 
@@ -314,150 +504,234 @@ func registerSyncFileTool(server *mcp.Server, r *Registry) {
 							continue
 						}
 
-						// Determine file extension from URL or default to png
-						ext := "png"
-						if strings.Contains(imageURL, ".jpg") || strings.Contains(imageURL, ".jpeg") {
-							ext = "jpg"
-						} else if strings.Contains(imageURL, ".svg") {
-							ext = "svg"
-						} else if strings.Contains(imageURL, ".gif") {
-							ext = "gif"
-						} else if strings.Contains(imageURL, ".webp") {
-							ext = "webp"
+						// Build filename using node name
+						node := imageCollector.ExportNodes[id]
+						name := sanitizeName(node.Name)
+						if scale != 1 {
+							name = fmt.Sprintf("%s@%dx", name, int(scale))
 						}
+						filename := fmt.Sprintf("%s.%s", name, format)
+						filePath := filepath.Join(rendersDir, filename)
 
-						filename := fmt.Sprintf("%s.%s", sanitizeID(imageRef), ext)
-						filePath := filepath.Join(imageFillsDir, filename)
-
-						if err := os.WriteFile(filePath, data, 0644); err != nil {
-							errors = append(errors, fmt.Sprintf("writing image %s: %v", imageRef, err))
+						if err := w.WriteFile(filePath, data, 0644); err != nil {
+							errors = append(errors, fmt.Sprintf("writing render %s: %v", id, err))
 							continue
 						}
+						downloadedURLs = append(downloadedURLs, imageURL)
 
-						stats.ImageFills++
+						stats.Assets++
 					}
 				}
 			}
+		}
+	}
 
-			// Export nodes with export settings (icons, rendered images)
-			if len(imageCollector.ExportNodes) > 0 {
-				rendersDir := filepath.Join(assetsDir, "renders")
-				if err := os.MkdirAll(rendersDir, 0755); err != nil {
-					errors = append(errors, fmt.Sprintf("creating renders dir: %v", err))
-				}
+	// Write manifest of external references
+	manifest := buildExportManifest(file, args.FileKey, exportedAt, r.ServerVersion(), downloadedURLs)
+	if err := w.WriteJSON(filepath.Join(exportPath, "_manifest.json"), manifest); err != nil {
+		errors = append(errors, fmt.Sprintf("writing manifest: %v", err))
+	}
 
-				// Collect node IDs
-				nodeIDs := make([]string, 0, len(imageCollector.ExportNodes))
-				for id := range imageCollector.ExportNodes {
-					nodeIDs = append(nodeIDs, id)
-				}
+	// Write tree file
+	treeContent := strings.Join(treeLines, "\n")
+	if err := w.WriteFile(filepath.Join(exportPath, "_tree.txt"), []byte(treeContent), 0644); err != nil {
+		errors = append(errors, fmt.Sprintf("writing tree: %v", err))
+	}
 
-				// Export in each format and scale
-				for _, format := range formats {
-					for _, scale := range scales {
-						images, err := r.Client().GetImages(ctx, args.FileKey, nodeIDs, &figma.ImageExportOptions{
-							Format: format,
-							Scale:  scale,
-						})
-						if err != nil {
-							errors = append(errors, fmt.Sprintf("exporting images: %v", err))
-							continue
-						}
+	// Write index file
+	if err := w.WriteJSON(filepath.Join(exportPath, "_index.json"), nodeIndex); err != nil {
+		errors = append(errors, fmt.Sprintf("writing index: %v", err))
+	}
 
-						for id, imageURL := range images.Images {
-							if imageURL == "" {
-								continue
-							}
-
-							data, err := r.Client().DownloadImage(ctx, imageURL)
-							if err != nil {
-								errors = append(errors, fmt.Sprintf("downloading render %s: %v", id, err))
-								continue
-							}
-
-							// Skip if over size limit
-							if args.Assets.MaxSize > 0 && len(data) > args.Assets.MaxSize {
-								continue
-							}
-
-							// Build filename using node name
-							node := imageCollector.ExportNodes[id]
-							name := sanitizeName(node.Name)
-							if scale != 1 {
-								name = fmt.Sprintf("%s@%dx", name, int(scale))
-							}
-							filename := fmt.Sprintf("%s.%s", name, format)
-							filePath := filepath.Join(rendersDir, filename)
-
-							if err := os.WriteFile(filePath, data, 0644); err != nil {
-								errors = append(errors, fmt.Sprintf("writing render %s: %v", id, err))
-								continue
-							}
-
-							stats.Assets++
-						}
-					}
-				}
-			}
-		}
+	// Write metadata last so the history records this sync's counts
+	metaPath := filepath.Join(exportPath, "_meta.json")
+	meta["history"] = appendSyncHistory(readSyncHistory(metaPath), SyncSnapshot{
+		ExportedAt:   exportedAt,
+		Version:      file.Version,
+		LastModified: file.LastModified,
+		Nodes:        stats.Nodes,
+		Components:   stats.Components,
+		Styles:       stats.Styles,
+	})
+	if err := w.WriteJSON(metaPath, meta); err != nil {
+		errors = append(errors, fmt.Sprintf("writing meta: %v", err))
+	}
 
-		// Write tree file
-		treeContent := strings.Join(treeLines, "\n")
-		if err := os.WriteFile(filepath.Join(exportPath, "_tree.txt"), []byte(treeContent), 0644); err != nil {
-			errors = append(errors, fmt.Sprintf("writing tree: %v", err))
-		}
+	stats.DurationMS = time.Since(startTime).Milliseconds()
 
-		// Write index file
-		if err := writeJSON(filepath.Join(exportPath, "_index.json"), nodeIndex); err != nil {
-			errors = append(errors, fmt.Sprintf("writing index: %v", err))
-		}
+	// Build result
+	result := &SyncFileResult{
+		ExportPath: exportPath,
+		Stats:      stats,
+		Errors:     errors,
+	}
+	if args.DryRun {
+		result.ExportPath = "[DRY RUN] " + exportPath
+	}
+	if stream != nil {
+		stream.emit(SyncEvent{Event: "done", Stats: &stats})
+		result.Events = stream.events
+	}
 
-		stats.DurationMS = time.Since(startTime).Milliseconds()
+	// Tree preview (first 50 lines)
+	previewLines := treeLines
+	if len(previewLines) > 50 {
+		previewLines = previewLines[:50]
+		previewLines = append(previewLines, fmt.Sprintf("... and %d more", len(treeLines)-50))
+	}
+	result.TreePreview = strings.Join(previewLines, "\n")
 
-		// Build result
-		result := &SyncFileResult{
-			ExportPath: exportPath,
-			Stats:      stats,
-			Errors:     errors,
-		}
+	return result, nil
+}
 
-		// Tree preview (first 50 lines)
-		previewLines := treeLines
-		if len(previewLines) > 50 {
-			previewLines = previewLines[:50]
-			previewLines = append(previewLines, fmt.Sprintf("... and %d more", len(treeLines)-50))
-		}
-		result.TreePreview = strings.Join(previewLines, "\n")
+// syncStream collects the events of a streaming sync and sends each as a
+// JSON line in an MCP progress notification when the client sent a progress
+// token. A nil stream ignores events.
+type syncStream struct {
+	ctx    context.Context
+	req    *mcp.CallToolRequest
+	events []SyncEvent
+}
 
-		// Format output
-		var textOutput string
-		if args.Format == "json" {
-			b, _ := json.MarshalIndent(result, "", "  ")
-			textOutput = string(b)
-		} else {
-			textOutput = formatSyncResult(result)
-		}
+func (s *syncStream) emit(e SyncEvent) {
+	if s == nil {
+		return
+	}
+	e.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	s.events = append(s.events, e)
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: textOutput},
-			},
-		}, result, nil
+	if s.req == nil || s.req.Session == nil || s.req.Params == nil {
+		return
+	}
+	token := s.req.Params.GetProgressToken()
+	if token == nil {
+		return
+	}
+	line, _ := json.Marshal(e)
+	s.req.Session.NotifyProgress(s.ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: token,
+		Message:       string(line),
+		Progress:      float64(len(s.events)),
 	})
 }
 
-func exportNode(ctx context.Context, node *figma.Node, basePath string, depth int, treeLines *[]string, nodeIndex map[string]string, imageCollector *ImageCollector) (int, []string) {
+// formatSyncEvents writes events as JSON Lines.
+func formatSyncEvents(events []SyncEvent) string {
+	var sb strings.Builder
+	for _, e := range events {
+		line, _ := json.Marshal(e)
+		sb.Write(line)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// reportSyncProgress reports an exported page to the registry's progress
+// callback and, when the client sent a progress token, as an MCP progress
+// notification. Notification failures are ignored: progress is best effort.
+func reportSyncProgress(ctx context.Context, r *Registry, req *mcp.CallToolRequest, page string, nodesExported, totalEstimate int) {
+	if r.progress != nil {
+		r.progress(page, nodesExported, totalEstimate)
+	}
+
+	if req == nil || req.Session == nil || req.Params == nil {
+		return
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return
+	}
+	req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: token,
+		Message:       fmt.Sprintf("Exported page %s (%d/%d nodes)", page, nodesExported, totalEstimate),
+		Progress:      float64(nodesExported),
+		Total:         float64(totalEstimate),
+	})
+}
+
+// countNodes returns the number of nodes in the subtree rooted at node.
+func countNodes(node *figma.Node) int {
+	n := 1
+	for _, child := range node.Children {
+		n += countNodes(child)
+	}
+	return n
+}
+
+// Number of syncs kept in the history of _meta.json.
+const maxSyncHistory = 100
+
+// SyncSnapshot records one sync of a file in the history kept in _meta.json.
+type SyncSnapshot struct {
+	ExportedAt   string `json:"exportedAt"`
+	Version      string `json:"version"`
+	LastModified string `json:"lastModified"`
+	Nodes        int    `json:"nodes"`
+	Components   int    `json:"components"`
+	Styles       int    `json:"styles"`
+}
+
+// readSyncHistory returns the sync history of an existing _meta.json. An
+// export written before the history existed yields its single sync, without
+// counts.
+func readSyncHistory(metaPath string) []SyncSnapshot {
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil
+	}
+	var meta struct {
+		ExportedAt   string         `json:"exportedAt"`
+		Version      string         `json:"version"`
+		LastModified string         `json:"lastModified"`
+		History      []SyncSnapshot `json:"history"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil
+	}
+	if len(meta.History) == 0 && meta.ExportedAt != "" {
+		return []SyncSnapshot{{ExportedAt: meta.ExportedAt, Version: meta.Version, LastModified: meta.LastModified}}
+	}
+	return meta.History
+}
+
+// appendSyncHistory adds a sync to history, keeping the latest maxSyncHistory.
+func appendSyncHistory(history []SyncSnapshot, s SyncSnapshot) []SyncSnapshot {
+	history = append(history, s)
+	if len(history) > maxSyncHistory {
+		history = history[len(history)-maxSyncHistory:]
+	}
+	return history
+}
+
+// IndexEntry locates a node in the export and records its place in the hierarchy.
+type IndexEntry struct {
+	Path     string `json:"path"`
+	ParentID string `json:"parent_id,omitempty"`
+	Depth    int    `json:"depth"`
+	Page     string `json:"page"`
+	Plugin   bool   `json:"plugin,omitempty"` // node has a _plugin.json
+}
+
+// exportNode writes a node and its descendants under basePath. ancestors holds
+// the IDs of the node's ancestors from the page down to its direct parent.
+func exportNode(ctx context.Context, w *syncWriter, node *figma.Node, basePath string, ancestors []string, page string, treeLines *[]string, nodeIndex map[string]IndexEntry, imageCollector *ImageCollector) (int, []string) {
 	var errors []string
 	nodeCount := 1
+	depth := len(ancestors) + 1
 
 	// Create directory for this node
-	if err := os.MkdirAll(basePath, 0755); err != nil {
+	if err := w.MkdirAll(basePath, 0755); err != nil {
 		errors = append(errors, fmt.Sprintf("creating dir for %s: %v", node.ID, err))
 		return nodeCount, errors
 	}
 
 	// Add to index
-	nodeIndex[node.ID] = basePath
+	entry := IndexEntry{Path: basePath, Depth: depth, Page: page}
+	if len(ancestors) > 0 {
+		entry.ParentID = ancestors[len(ancestors)-1]
+	}
+	entry.Plugin = hasRawData(node.PluginData) || hasRawData(node.SharedPluginData)
+	nodeIndex[node.ID] = entry
 
 	// Add to tree
 	indent := strings.Repeat("│   ", depth-1) + "├── "
@@ -493,14 +767,14 @@ func exportNode(ctx context.Context, node *figma.Node, basePath string, depth in
 	}
 
 	// Write node data
-	if err := writeJSON(filepath.Join(basePath, "_node.json"), node); err != nil {
+	if err := w.WriteJSON(filepath.Join(basePath, "_node.json"), node); err != nil {
 		errors = append(errors, fmt.Sprintf("writing node %s: %v", node.ID, err))
 	}
 
 	// Extract and write CSS properties
 	cssProps := extractCSSProperties(node)
 	if len(cssProps) > 0 {
-		if err := writeJSON(filepath.Join(basePath, "_css.json"), cssProps); err != nil {
+		if err := w.WriteJSON(filepath.Join(basePath, "_css.json"), cssProps); err != nil {
 			errors = append(errors, fmt.Sprintf("writing css for %s: %v", node.ID, err))
 		}
 	}
@@ -508,17 +782,32 @@ func exportNode(ctx context.Context, node *figma.Node, basePath string, depth in
 	// Extract and write token references
 	tokens := extractTokenReferences(node)
 	if len(tokens) > 0 {
-		if err := writeJSON(filepath.Join(basePath, "_tokens.json"), tokens); err != nil {
+		if err := w.WriteJSON(filepath.Join(basePath, "_tokens.json"), tokens); err != nil {
 			errors = append(errors, fmt.Sprintf("writing tokens for %s: %v", node.ID, err))
 		}
 	}
 
+	// Write plugin data separately so it can be grepped without parsing nodes
+	if entry.Plugin {
+		plugin := map[string]json.RawMessage{}
+		if hasRawData(node.PluginData) {
+			plugin["pluginData"] = node.PluginData
+		}
+		if hasRawData(node.SharedPluginData) {
+			plugin["sharedPluginData"] = node.SharedPluginData
+		}
+		if err := w.WriteJSON(filepath.Join(basePath, "_plugin.json"), plugin); err != nil {
+			errors = append(errors, fmt.Sprintf("writing plugin data for %s: %v", node.ID, err))
+		}
+	}
+
 	// Export children
 	if len(node.Children) > 0 {
 		childrenDir := filepath.Join(basePath, "children")
+		childAncestors := append(ancestors[:len(ancestors):len(ancestors)], node.ID)
 		for _, child := range node.Children {
 			childPath := filepath.Join(childrenDir, sanitizeName(child.Name)+"-"+sanitizeID(child.ID))
-			childCount, childErrors := exportNode(ctx, child, childPath, depth+1, treeLines, nodeIndex, imageCollector)
+			childCount, childErrors := exportNode(ctx, w, child, childPath, childAncestors, page, treeLines, nodeIndex, imageCollector)
 			nodeCount += childCount
 			errors = append(errors, childErrors...)
 		}
@@ -527,6 +816,11 @@ func exportNode(ctx context.Context, node *figma.Node, basePath string, depth in
 	return nodeCount, errors
 }
 
+// hasRawData reports whether raw holds a non-null JSON value.
+func hasRawData(raw json.RawMessage) bool {
+	return len(raw) > 0 && string(raw) != "null"
+}
+
 func extractCSSProperties(node *figma.Node) map[string]interface{} {
 	css := make(map[string]interface{})
 
@@ -631,6 +925,9 @@ func extractCSSProperties(node *figma.Node) map[string]interface{} {
 		// Alignment
 		css["justifyContent"] = alignToCSS(node.PrimaryAxisAlignItems)
 		css["alignItems"] = alignToCSS(node.CounterAxisAlignItems)
+	} else {
+		// Layout grids (only when auto-layout doesn't already define the layout)
+		addLayoutGridCSS(css, node.LayoutGrids)
 	}
 
 	// Typography (for text nodes)
@@ -646,6 +943,57 @@ func extractCSSProperties(node *figma.Node) map[string]interface{} {
 	return css
 }
 
+// addLayoutGridCSS maps a frame's layout grids onto CSS grid properties.
+// COLUMNS and ROWS grids define the tracks on their axis; a uniform GRID
+// defines both. The first grid of each pattern wins.
+func addLayoutGridCSS(css map[string]interface{}, grids []figma.LayoutGrid) {
+	seen := make(map[string]bool)
+	for _, g := range grids {
+		if seen[g.Pattern] {
+			continue
+		}
+		seen[g.Pattern] = true
+
+		switch g.Pattern {
+		case "GRID":
+			css["display"] = "grid"
+			css["gridTemplateColumns"] = gridTracks(g)
+			if g.GutterSize > 0 {
+				css["gap"] = g.GutterSize
+			}
+
+		case "COLUMNS":
+			css["display"] = "grid"
+			css["gridTemplateColumns"] = gridTracks(g)
+			if g.GutterSize > 0 {
+				css["columnGap"] = g.GutterSize
+			}
+			if g.Offset > 0 {
+				css["paddingInline"] = g.Offset
+			}
+
+		case "ROWS":
+			css["display"] = "grid"
+			css["gridTemplateRows"] = gridTracks(g)
+			if g.GutterSize > 0 {
+				css["rowGap"] = g.GutterSize
+			}
+			if g.Offset > 0 {
+				css["paddingBlock"] = g.Offset
+			}
+		}
+	}
+}
+
+// gridTracks returns a track list for a layout grid: equal fractions when it
+// has a fixed count, otherwise as many section-sized tracks as fit.
+func gridTracks(g figma.LayoutGrid) string {
+	if g.Count > 0 {
+		return fmt.Sprintf("repeat(%d, 1fr)", g.Count)
+	}
+	return fmt.Sprintf("repeat(auto-fill, %gpx)", g.SectionSize)
+}
+
 func extractTokenReferences(node *figma.Node) map[string]interface{} {
 	tokens := make(map[string]interface{})
 
@@ -751,6 +1099,32 @@ func alignToCSS(align string) string {
 	}
 }
 
+// syncWriter performs sync_file's filesystem writes, or skips them in dry-run mode.
+type syncWriter struct {
+	dryRun bool
+}
+
+func (w *syncWriter) MkdirAll(path string, perm os.FileMode) error {
+	if w.dryRun {
+		return nil
+	}
+	return os.MkdirAll(path, perm)
+}
+
+func (w *syncWriter) WriteFile(path string, data []byte, perm os.FileMode) error {
+	if w.dryRun {
+		return nil
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+func (w *syncWriter) WriteJSON(path string, data interface{}) error {
+	if w.dryRun {
+		return nil
+	}
+	return writeJSON(path, data)
+}
+
 func writeJSON(path string, data interface{}) error {
 	b, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func testSyncPage() *figma.Node {
+	return &figma.Node{
+		ID:   "0:1",
+		Name: "Page 1",
+		Type: figma.NodeTypeCanvas,
+		Children: []*figma.Node{
+			{
+				ID:   "1:1",
+				Name: "Frame",
+				Type: figma.NodeTypeFrame,
+				Children: []*figma.Node{
+					{ID: "1:2", Name: "Label", Type: figma.NodeTypeText},
+					{ID: "1:3", Name: "Icon", Type: figma.NodeTypeVector},
+				},
+			},
+		},
+	}
+}
+
+func TestExportNodeIndexAncestry(t *testing.T) {
+	page := testSyncPage()
+
+	var treeLines []string
+	index := make(map[string]IndexEntry)
+	count, errs := exportNode(context.Background(), &syncWriter{}, page, t.TempDir(), nil, page.Name, &treeLines, index, NewImageCollector())
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 nodes, got %d", count)
+	}
+
+	tests := []struct {
+		id       string
+		parentID string
+		depth    int
+	}{
+		{"0:1", "", 1},
+		{"1:1", "0:1", 2},
+		{"1:2", "1:1", 3},
+		{"1:3", "1:1", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			entry, ok := index[tt.id]
+			if !ok {
+				t.Fatalf("node %s missing from index", tt.id)
+			}
+			if entry.ParentID != tt.parentID {
+				t.Errorf("parent_id = %q, want %q", entry.ParentID, tt.parentID)
+			}
+			if entry.Depth != tt.depth {
+				t.Errorf("depth = %d, want %d", entry.Depth, tt.depth)
+			}
+			if entry.Page != "Page 1" {
+				t.Errorf("page = %q, want %q", entry.Page, "Page 1")
+			}
+			if entry.Path == "" {
+				t.Error("expected non-empty path")
+			}
+		})
+	}
+}
+
+func TestExportNodeDryRun(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "page")
+
+	var treeLines []string
+	index := make(map[string]IndexEntry)
+	count, errs := exportNode(context.Background(), &syncWriter{dryRun: true}, testSyncPage(), basePath, nil, "Page 1", &treeLines, index, NewImageCollector())
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if count != 4 || len(index) != 4 || len(treeLines) != 4 {
+		t.Errorf("expected 4 nodes counted, got count=%d index=%d tree=%d", count, len(index), len(treeLines))
+	}
+
+	if _, err := os.Stat(basePath); !os.IsNotExist(err) {
+		t.Errorf("expected dry run to skip writing %s, stat err = %v", basePath, err)
+	}
+}
+
+func TestExportNodePluginData(t *testing.T) {
+	page := testSyncPage()
+	page.Children[0].PluginData = json.RawMessage(`{"tokens-studio":{"fill":"color.primary"}}`)
+	page.Children[0].Children[0].SharedPluginData = json.RawMessage(`null`)
+
+	basePath := filepath.Join(t.TempDir(), "page")
+	var treeLines []string
+	index := make(map[string]IndexEntry)
+	if _, errs := exportNode(context.Background(), &syncWriter{}, page, basePath, nil, "Page 1", &treeLines, index, NewImageCollector()); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	for id, want := range map[string]bool{"0:1": false, "1:1": true, "1:2": false} {
+		entry := index[id]
+		if entry.Plugin != want {
+			t.Errorf("index[%s].plugin = %v, want %v", id, entry.Plugin, want)
+		}
+		_, err := os.Stat(filepath.Join(entry.Path, "_plugin.json"))
+		if exists := err == nil; exists != want {
+			t.Errorf("_plugin.json for %s exists = %v, want %v", id, exists, want)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(index["1:1"].Path, "_plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plugin map[string]json.RawMessage
+	if err := json.Unmarshal(data, &plugin); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := plugin["pluginData"]; !ok {
+		t.Errorf("expected pluginData key in %s", data)
+	}
+}
+
+func TestExtractCSSPropertiesLayoutGrid(t *testing.T) {
+	node := &figma.Node{
+		ID:   "1:1",
+		Type: figma.NodeTypeFrame,
+		LayoutGrids: []figma.LayoutGrid{
+			{Pattern: "COLUMNS", Count: 12, GutterSize: 24, Offset: 32, Alignment: "STRETCH"},
+			{Pattern: "ROWS", SectionSize: 8},
+		},
+	}
+
+	css := extractCSSProperties(node)
+	expected := map[string]interface{}{
+		"display":             "grid",
+		"gridTemplateColumns": "repeat(12, 1fr)",
+		"columnGap":           24.0,
+		"paddingInline":       32.0,
+		"gridTemplateRows":    "repeat(auto-fill, 8px)",
+	}
+	for key, want := range expected {
+		if css[key] != want {
+			t.Errorf("%s = %v, want %v", key, css[key], want)
+		}
+	}
+
+	node.LayoutGrids = []figma.LayoutGrid{{Pattern: "GRID", Count: 4, GutterSize: 16}}
+	css = extractCSSProperties(node)
+	if css["gridTemplateColumns"] != "repeat(4, 1fr)" || css["gap"] != 16.0 {
+		t.Errorf("GRID pattern css = %v", css)
+	}
+
+	// Auto-layout takes precedence over layout grids.
+	node.LayoutMode = "HORIZONTAL"
+	if css = extractCSSProperties(node); css["display"] != "flex" || css["gridTemplateColumns"] != nil {
+		t.Errorf("auto-layout frame css = %v", css)
+	}
+}
+
+func TestReportSyncProgress(t *testing.T) {
+	if n := countNodes(testSyncPage()); n != 4 {
+		t.Errorf("countNodes = %d, want 4", n)
+	}
+
+	r := NewRegistry(nil, t.TempDir())
+	var gotPage string
+	var gotDone, gotTotal int
+	r.SetProgressCallback(func(page string, nodesExported, totalEstimate int) {
+		gotPage, gotDone, gotTotal = page, nodesExported, totalEstimate
+	})
+
+	// Without a request there is no session to notify; the callback still runs.
+	reportSyncProgress(context.Background(), r, nil, "Page 1", 4, 10)
+	if gotPage != "Page 1" || gotDone != 4 || gotTotal != 10 {
+		t.Errorf("callback got (%q, %d, %d), want (Page 1, 4, 10)", gotPage, gotDone, gotTotal)
+	}
+}
+
+func TestSyncStream(t *testing.T) {
+	// A nil stream ignores events.
+	var none *syncStream
+	none.emit(SyncEvent{Event: "page_start", Page: "Page 1"})
+
+	s := &syncStream{ctx: context.Background()}
+	nodes, assets := 4, 0
+	s.emit(SyncEvent{Event: "page_start", Page: "Page 1"})
+	s.emit(SyncEvent{Event: "page_done", Page: "Page 1", Nodes: &nodes, Assets: &assets})
+	s.emit(SyncEvent{Event: "done", Stats: &SyncStats{Pages: 1, Nodes: 4}})
+
+	lines := strings.Split(strings.TrimSuffix(formatSyncEvents(s.events), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	var events []map[string]any
+	for _, line := range lines {
+		var e map[string]any
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line %q is not JSON: %v", line, err)
+		}
+		if e["timestamp"] == "" || e["timestamp"] == nil {
+			t.Errorf("line %q has no timestamp", line)
+		}
+		events = append(events, e)
+	}
+
+	if events[0]["event"] != "page_start" || events[0]["page"] != "Page 1" || events[0]["nodes"] != nil {
+		t.Errorf("page_start = %v", events[0])
+	}
+	// Zero counts are still reported on page_done.
+	if events[1]["event"] != "page_done" || events[1]["nodes"] != float64(4) || events[1]["assets"] != float64(0) {
+		t.Errorf("page_done = %v", events[1])
+	}
+	stats, _ := events[2]["stats"].(map[string]any)
+	if events[2]["event"] != "done" || stats == nil || stats["nodes"] != float64(4) {
+		t.Errorf("done = %v", events[2])
+	}
+}
@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// TextStylesToCSSArgs contains arguments for the text_styles_to_css tool.
+type TextStylesToCSSArgs struct {
+	FileKey    string `json:"file_key" jsonschema:"Figma file key"`
+	Format     string `json:"format,omitempty" jsonschema:"Output format: css (default) classes with values, css-vars classes backed by custom properties, scss classes backed by SCSS variables, or json"`
+	Prefix     string `json:"prefix,omitempty" jsonschema:"Prefix for class and variable names"`
+	OutputPath string `json:"output_path,omitempty" jsonschema:"Write the stylesheet to this file instead of returning it"`
+}
+
+// CSSDeclaration is one property: value pair of a CSS rule.
+type CSSDeclaration struct {
+	Property string `json:"property"`
+	Value    string `json:"value"`
+}
+
+// TextStyleCSS is a text style and the CSS that reproduces it.
+type TextStyleCSS struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Class        string           `json:"class"`
+	Declarations []CSSDeclaration `json:"declarations"`
+}
+
+// TextStylesToCSSResult contains the result of text_styles_to_css.
+type TextStylesToCSSResult struct {
+	Styles  []TextStyleCSS `json:"styles"`
+	Missing []string       `json:"missing,omitempty"` // styles whose definition is not in the file
+	Path    string         `json:"path,omitempty"`
+}
+
+func registerTextStylesToCSSTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "text_styles_to_css",
+		Description: "Generate CSS typography classes from the text styles of a file (Heading/H1 -> .heading-h1), optionally backed by CSS custom properties or SCSS variables.",
+		InputSchema: inputSchema[TextStylesToCSSArgs](map[string][]string{
+			"format": {"css", "css-vars", "scss", "json"},
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args TextStylesToCSSArgs) (*mcp.CallToolResult, *TextStylesToCSSResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		format := args.Format
+		if format == "" {
+			format = "css"
+		}
+		if !containsString([]string{"css", "css-vars", "scss", "json"}, format) {
+			return nil, nil, fmt.Errorf("unsupported format: %s", format)
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		// The styles map covers every style the file uses, published or not.
+		file, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{Depth: 1})
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+
+		var ids []string
+		for id, style := range file.Styles {
+			if style.StyleType == figma.StyleTypeText {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+
+		// Style definitions are nodes; their TypeStyle holds the font values.
+		var nodes *figma.FileNodes
+		if len(ids) > 0 {
+			nodes, err = r.Client().GetFileNodes(ctx, args.FileKey, ids, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("fetching text style nodes: %w", err)
+			}
+		}
+
+		result := &TextStylesToCSSResult{Styles: []TextStyleCSS{}}
+		for _, id := range ids {
+			name := file.Styles[id].Name
+			w := nodes.Nodes[id]
+			if w == nil || w.Document == nil || w.Document.Style == nil {
+				result.Missing = append(result.Missing, name)
+				continue
+			}
+			result.Styles = append(result.Styles, TextStyleCSS{
+				ID:           id,
+				Name:         name,
+				Class:        cssIdentifier(name, args.Prefix),
+				Declarations: typographyDeclarations(w.Document.Style),
+			})
+		}
+		sort.SliceStable(result.Styles, func(i, j int) bool { return result.Styles[i].Name < result.Styles[j].Name })
+
+		var content string
+		if format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			content = string(b)
+		} else {
+			content = generateTypographyCSS(result.Styles, format)
+		}
+
+		textOutput := content
+		if args.OutputPath != "" {
+			if err := os.MkdirAll(filepath.Dir(args.OutputPath), 0755); err != nil {
+				return nil, nil, fmt.Errorf("creating directory: %w", err)
+			}
+			if err := os.WriteFile(args.OutputPath, []byte(content), 0644); err != nil {
+				return nil, nil, fmt.Errorf("writing file: %w", err)
+			}
+			result.Path = args.OutputPath
+			textOutput = fmt.Sprintf("Exported %d text styles to %s", len(result.Styles), args.OutputPath)
+		}
+		if len(result.Missing) > 0 && format != "json" {
+			textOutput += fmt.Sprintf("\n\nSkipped %d styles whose definition is not in this file: %s",
+				len(result.Missing), strings.Join(result.Missing, ", "))
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// cssIdentifier turns a style name into a CSS class or variable name:
+// Heading/H1 -> heading-h1. Runs of characters that are not letters or
+// digits become a single dash.
+func cssIdentifier(name, prefix string) string {
+	if prefix != "" {
+		name = prefix + "-" + name
+	}
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	id := sb.String()
+	if id == "" || (id[0] >= '0' && id[0] <= '9') {
+		id = "text-" + id
+	}
+	return id
+}
+
+// typographyDeclarations maps a TypeStyle onto CSS font properties.
+func typographyDeclarations(ts *figma.TypeStyle) []CSSDeclaration {
+	var decls []CSSDeclaration
+	add := func(prop, value string) {
+		decls = append(decls, CSSDeclaration{Property: prop, Value: value})
+	}
+
+	if ts.FontFamily != "" {
+		add("font-family", fmt.Sprintf("%q", ts.FontFamily))
+	}
+	if ts.FontSize > 0 {
+		add("font-size", formatCSSValue(ts.FontSize))
+	}
+	if ts.FontWeight > 0 {
+		add("font-weight", fmt.Sprintf("%g", ts.FontWeight))
+	}
+	if ts.Italic {
+		add("font-style", "italic")
+	}
+
+	switch ts.LineHeightUnit {
+	case "INTRINSIC_%":
+		add("line-height", "normal")
+	case "FONT_SIZE_%":
+		if ts.LineHeightPercentFontSize > 0 {
+			add("line-height", fmt.Sprintf("%g", math.Round(ts.LineHeightPercentFontSize*10)/1000))
+			break
+		}
+		fallthrough
+	default:
+		if ts.LineHeightPx > 0 {
+			add("line-height", formatCSSValue(ts.LineHeightPx))
+		}
+	}
+
+	if ts.LetterSpacing != 0 {
+		add("letter-spacing", formatCSSValue(ts.LetterSpacing))
+	}
+
+	switch ts.TextCase {
+	case "UPPER":
+		add("text-transform", "uppercase")
+	case "LOWER":
+		add("text-transform", "lowercase")
+	case "TITLE":
+		add("text-transform", "capitalize")
+	case "SMALL_CAPS", "SMALL_CAPS_FORCED":
+		add("font-variant", "small-caps")
+	}
+
+	switch ts.TextDecoration {
+	case "UNDERLINE":
+		add("text-decoration", "underline")
+	case "STRIKETHROUGH":
+		add("text-decoration", "line-through")
+	}
+
+	return decls
+}
+
+// generateTypographyCSS renders text styles as a stylesheet. css writes
+// values into the classes; css-vars and scss declare one variable per
+// property and have the classes reference them.
+func generateTypographyCSS(styles []TextStyleCSS, format string) string {
+	var sb strings.Builder
+
+	ref := func(name string) string { return name }
+	switch format {
+	case "css-vars":
+		sb.WriteString("/* Typography - Generated by figma-query */\n\n")
+		sb.WriteString(":root {\n")
+		for _, s := range styles {
+			for _, d := range s.Declarations {
+				sb.WriteString(fmt.Sprintf("  --%s-%s: %s;\n", s.Class, d.Property, d.Value))
+			}
+		}
+		sb.WriteString("}\n\n")
+		ref = func(name string) string { return "var(--" + name + ")" }
+	case "scss":
+		sb.WriteString("// Typography - Generated by figma-query\n\n")
+		for _, s := range styles {
+			for _, d := range s.Declarations {
+				sb.WriteString(fmt.Sprintf("$%s-%s: %s;\n", s.Class, d.Property, d.Value))
+			}
+		}
+		sb.WriteString("\n")
+		ref = func(name string) string { return "$" + name }
+	default:
+		sb.WriteString("/* Typography - Generated by figma-query */\n\n")
+	}
+
+	for i, s := range styles {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("/* %s */\n.%s {\n", s.Name, s.Class))
+		for _, d := range s.Declarations {
+			value := d.Value
+			if format != "css" {
+				value = ref(s.Class + "-" + d.Property)
+			}
+			sb.WriteString(fmt.Sprintf("  %s: %s;\n", d.Property, value))
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
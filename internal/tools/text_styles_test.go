@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCSSIdentifier(t *testing.T) {
+	tests := []struct{ name, prefix, want string }{
+		{"Heading/H1", "", "heading-h1"},
+		{"Body / Large (Bold)", "", "body-large-bold"},
+		{"Caption", "ds", "ds-caption"},
+		{"2XL", "", "text-2xl"},
+	}
+	for _, tt := range tests {
+		if got := cssIdentifier(tt.name, tt.prefix); got != tt.want {
+			t.Errorf("cssIdentifier(%q, %q) = %q, want %q", tt.name, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestTypographyDeclarations(t *testing.T) {
+	decls := typographyDeclarations(&figma.TypeStyle{
+		FontFamily:                "Inter",
+		FontSize:                  32,
+		FontWeight:                700,
+		LineHeightUnit:            "FONT_SIZE_%",
+		LineHeightPercentFontSize: 125,
+		LetterSpacing:             -0.5,
+		TextCase:                  "UPPER",
+	})
+
+	want := map[string]string{
+		"font-family":    `"Inter"`,
+		"font-size":      "32px",
+		"font-weight":    "700",
+		"line-height":    "1.25",
+		"letter-spacing": "-0.50px",
+		"text-transform": "uppercase",
+	}
+	if len(decls) != len(want) {
+		t.Fatalf("got %d declarations, want %d: %+v", len(decls), len(want), decls)
+	}
+	for _, d := range decls {
+		if want[d.Property] != d.Value {
+			t.Errorf("%s = %q, want %q", d.Property, d.Value, want[d.Property])
+		}
+	}
+
+	px := typographyDeclarations(&figma.TypeStyle{LineHeightUnit: "PIXELS", LineHeightPx: 24})
+	if len(px) != 1 || px[0].Value != "24px" {
+		t.Errorf("pixel line height = %+v", px)
+	}
+}
+
+func TestGenerateTypographyCSS(t *testing.T) {
+	styles := []TextStyleCSS{{
+		Name:         "Heading/H1",
+		Class:        "heading-h1",
+		Declarations: []CSSDeclaration{{Property: "font-size", Value: "32px"}},
+	}}
+
+	css := generateTypographyCSS(styles, "css")
+	if !strings.Contains(css, ".heading-h1 {\n  font-size: 32px;\n}") {
+		t.Errorf("css output:\n%s", css)
+	}
+
+	vars := generateTypographyCSS(styles, "css-vars")
+	if !strings.Contains(vars, "--heading-h1-font-size: 32px;") || !strings.Contains(vars, "font-size: var(--heading-h1-font-size);") {
+		t.Errorf("css-vars output:\n%s", vars)
+	}
+
+	scss := generateTypographyCSS(styles, "scss")
+	if !strings.Contains(scss, "$heading-h1-font-size: 32px;") || !strings.Contains(scss, "font-size: $heading-h1-font-size;") {
+		t.Errorf("scss output:\n%s", scss)
+	}
+}
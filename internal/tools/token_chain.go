@@ -0,0 +1,291 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// ResolveTokenChainArgs contains arguments for the resolve_token_chain tool.
+type ResolveTokenChainArgs struct {
+	FileKey      string `json:"file_key" jsonschema:"Figma file key"`
+	VariableID   string `json:"variable_id,omitempty" jsonschema:"Variable ID to start from, e.g. VariableID:1:23"`
+	VariableName string `json:"variable_name,omitempty" jsonschema:"Variable name to start from, e.g. button/background/default (used when variable_id is empty)"`
+	Mode         string `json:"mode,omitempty" jsonschema:"Mode name whose aliases are followed (default: the collection's default mode)"`
+	Format       string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// TokenChainStep is one variable of an alias chain.
+type TokenChainStep struct {
+	VariableID string            `json:"variable_id"`
+	Name       string            `json:"name"`
+	Collection string            `json:"collection"`
+	Type       string            `json:"type"`
+	Mode       string            `json:"mode"`   // mode followed at this step
+	Values     map[string]string `json:"values"` // mode → value, aliases as "→ <name>"
+}
+
+// ResolveTokenChainResult contains the result of resolve_token_chain.
+type ResolveTokenChainResult struct {
+	Chain    []TokenChainStep  `json:"chain"`
+	Value    string            `json:"value,omitempty"`    // concrete value at the end of the chain
+	Resolved map[string]string `json:"resolved,omitempty"` // mode of the first variable → concrete value
+	Error    string            `json:"error,omitempty"`    // cycle or missing alias target
+}
+
+func registerResolveTokenChainTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "resolve_token_chain",
+		Description: "Show how a variable resolves: the chain of aliases from a semantic token such as button/background/default through color/primary/500 to its raw value, with each step's collection and per-mode values. Reports alias cycles and missing targets.",
+		InputSchema: inputSchema[ResolveTokenChainArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ResolveTokenChainArgs) (*mcp.CallToolResult, *ResolveTokenChainResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.VariableID == "" && args.VariableName == "" {
+			return nil, nil, fmt.Errorf("variable_id or variable_name is required. Pass a variable ID (e.g. \"VariableID:1:23\") or name (e.g. \"button/background/default\"); sync_file writes them to variables/tokens.json")
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		vars, err := r.Client().GetLocalVariables(ctx, args.FileKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching variables: %w", err)
+		}
+		if vars.Meta == nil {
+			return nil, nil, fmt.Errorf("no variables found in file")
+		}
+
+		start, err := findVariable(vars.Meta.Variables, vars.Meta.VariableCollections, args.VariableID, args.VariableName)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := resolveTokenChain(start, vars.Meta.Variables, vars.Meta.VariableCollections, args.Mode)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatResolveTokenChainResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// findVariable looks a variable up by ID, or else by name. Names are matched
+// exactly first, then ignoring case; a name shared by several collections
+// is an error listing them.
+func findVariable(variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, id, name string) (*figma.Variable, error) {
+	if id != "" {
+		v := variables[id]
+		if v == nil {
+			return nil, fmt.Errorf("variable %s not found", id)
+		}
+		return v, nil
+	}
+
+	var matches []*figma.Variable
+	for _, fold := range []bool{false, true} {
+		for _, v := range sortedVariables(variables) {
+			if v.Name == name || (fold && strings.EqualFold(v.Name, name)) {
+				matches = append(matches, v)
+			}
+		}
+		if len(matches) > 0 {
+			break
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no variable named %q", name)
+	case 1:
+		return matches[0], nil
+	}
+	var candidates []string
+	for _, v := range matches {
+		candidates = append(candidates, fmt.Sprintf("%s (%s, %s)", v.Name, collectionName(collections, v), v.ID))
+	}
+	return nil, fmt.Errorf("%d variables named %q, pass variable_id: %s", len(matches), name, strings.Join(candidates, "; "))
+}
+
+// resolveTokenChain follows the aliases of start in the named mode, or the
+// default mode of its collection, recording every variable on the way. A
+// cycle or missing target ends the chain and is reported in Error. Resolved
+// holds the concrete value of start in each of its modes.
+func resolveTokenChain(start *figma.Variable, variables map[string]*figma.Variable, collections map[string]*figma.VariableCollection, mode string) (*ResolveTokenChainResult, error) {
+	names := modeNames(collections)
+	coll := collections[start.VariableCollectionID]
+
+	modeID := ""
+	if coll != nil {
+		modeID = coll.DefaultModeID
+		if mode != "" {
+			modeID = ""
+			for _, m := range coll.Modes {
+				if strings.EqualFold(m.Name, mode) {
+					modeID = m.ModeID
+				}
+			}
+			if modeID == "" {
+				return nil, fmt.Errorf("collection %s has no mode %q", coll.Name, mode)
+			}
+		}
+	}
+
+	result := &ResolveTokenChainResult{Chain: []TokenChainStep{}}
+	visited := make(map[string]bool)
+	for v := start; ; {
+		if visited[v.ID] {
+			result.Error = fmt.Sprintf("alias cycle: %s → %s", chainNames(result.Chain), v.Name)
+			break
+		}
+		visited[v.ID] = true
+
+		// Aliases into another collection use its default mode unless it
+		// shares the mode ID, as tokenResolver does.
+		value, ok := v.ValuesByMode[modeID]
+		if !ok {
+			if c := collections[v.VariableCollectionID]; c != nil {
+				modeID = c.DefaultModeID
+				value = v.ValuesByMode[modeID]
+			}
+		}
+
+		result.Chain = append(result.Chain, TokenChainStep{
+			VariableID: v.ID,
+			Name:       v.Name,
+			Collection: collectionName(collections, v),
+			Type:       v.ResolvedType,
+			Mode:       modeLabel(names, modeID),
+			Values:     chainStepValues(v, variables, names),
+		})
+
+		alias, ok := parseVariableAlias(value)
+		if !ok {
+			result.Value = formatTokenValue(v.ResolvedType, value)
+			break
+		}
+		target := variables[alias.ID]
+		if target == nil {
+			result.Error = fmt.Sprintf("alias target %s not found", alias.ID)
+			break
+		}
+		v = target
+	}
+
+	resolver := newTokenResolver(variables, collections)
+	result.Resolved = make(map[string]string, len(start.ValuesByMode))
+	for id := range start.ValuesByMode {
+		value, source, err := resolver.resolve(start, id)
+		if err != nil {
+			result.Resolved[modeLabel(names, id)] = err.Error()
+			continue
+		}
+		result.Resolved[modeLabel(names, id)] = formatTokenValue(source.ResolvedType, value)
+	}
+
+	return result, nil
+}
+
+// chainStepValues formats each mode value of v, naming alias targets.
+func chainStepValues(v *figma.Variable, variables map[string]*figma.Variable, names map[string]string) map[string]string {
+	values := make(map[string]string, len(v.ValuesByMode))
+	for id, value := range v.ValuesByMode {
+		if alias, ok := parseVariableAlias(value); ok {
+			target := alias.ID
+			if t := variables[alias.ID]; t != nil {
+				target = t.Name
+			}
+			values[modeLabel(names, id)] = "→ " + target
+			continue
+		}
+		values[modeLabel(names, id)] = formatTokenValue(v.ResolvedType, value)
+	}
+	return values
+}
+
+func collectionName(collections map[string]*figma.VariableCollection, v *figma.Variable) string {
+	if c := collections[v.VariableCollectionID]; c != nil {
+		return c.Name
+	}
+	return v.VariableCollectionID
+}
+
+// modeLabel returns the name of a mode, or its ID when unnamed.
+func modeLabel(names map[string]string, modeID string) string {
+	if name := names[modeID]; name != "" {
+		return name
+	}
+	return modeID
+}
+
+func chainNames(chain []TokenChainStep) string {
+	parts := make([]string, len(chain))
+	for i, step := range chain {
+		parts[i] = step.Name
+	}
+	return strings.Join(parts, " → ")
+}
+
+func formatResolveTokenChainResult(r *ResolveTokenChainResult) string {
+	var sb strings.Builder
+
+	if len(r.Chain) > 0 {
+		sb.WriteString(chainNames(r.Chain))
+		if r.Value != "" {
+			sb.WriteString(" → " + r.Value)
+		}
+		sb.WriteString("\n\n")
+	}
+
+	for i, step := range r.Chain {
+		sb.WriteString(fmt.Sprintf("%d. %s [%s] (%s, %s)\n", i+1, step.Name, step.VariableID, step.Collection, step.Type))
+		for _, mode := range sortedKeys(step.Values) {
+			marker := " "
+			if mode == step.Mode {
+				marker = "*"
+			}
+			sb.WriteString(fmt.Sprintf("   %s %s: %s\n", marker, mode, step.Values[mode]))
+		}
+	}
+
+	if r.Error != "" {
+		sb.WriteString(fmt.Sprintf("\nError: %s\n", r.Error))
+	}
+
+	if len(r.Resolved) > 0 {
+		sb.WriteString("\nResolved values:\n")
+		for _, mode := range sortedKeys(r.Resolved) {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", mode, r.Resolved[mode]))
+		}
+	}
+
+	return sb.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
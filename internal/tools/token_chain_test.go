@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveTokenChain(t *testing.T) {
+	variables, collections := testTokenVariables()
+
+	start, err := findVariable(variables, collections, "", "Color/Link")
+	if err != nil || start.ID != "v:link" {
+		t.Fatalf("findVariable by name = %v, %v", start, err)
+	}
+	if _, err := findVariable(variables, collections, "v:nope", ""); err == nil {
+		t.Error("expected error for unknown variable ID")
+	}
+
+	result, err := resolveTokenChain(start, variables, collections, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := chainNames(result.Chain); got != "color/link → color/primary → blue/500" {
+		t.Errorf("chain = %q", got)
+	}
+	if result.Value != "#0000ff" || result.Error != "" {
+		t.Errorf("value = %q, error = %q", result.Value, result.Error)
+	}
+	if step := result.Chain[1]; step.Collection != "Semantic" || step.Values["m:s"] != "→ blue/500" {
+		t.Errorf("step 2 = %+v", step)
+	}
+	// The alias into Primitives switches to its default mode.
+	if step := result.Chain[2]; step.Mode != "m:p" || step.Collection != "Primitives" {
+		t.Errorf("step 3 = %+v", step)
+	}
+	if result.Resolved["m:s"] != "#0000ff" {
+		t.Errorf("resolved = %v", result.Resolved)
+	}
+
+	// Cycles end the chain at the repeated variable.
+	result, err = resolveTokenChain(variables["v:a"], variables, collections, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Error != "alias cycle: loop/a → loop/b → loop/a" || len(result.Chain) != 2 {
+		t.Errorf("cycle: chain = %q, error = %q", chainNames(result.Chain), result.Error)
+	}
+
+	result, _ = resolveTokenChain(variables["v:dangling"], variables, collections, "")
+	if !strings.Contains(result.Error, "v:missing") {
+		t.Errorf("dangling error = %q", result.Error)
+	}
+
+	if _, err := resolveTokenChain(start, variables, collections, "Dark"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
@@ -0,0 +1,317 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// TokenDiffArgs contains arguments for the token_diff tool.
+type TokenDiffArgs struct {
+	FileKey   string `json:"file_key" jsonschema:"Figma file key"`
+	VersionID string `json:"version_id,omitempty" jsonschema:"File version the baseline snapshot must come from (default: whatever sync_file last exported)"`
+	Format    string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// TokenChange describes an added, removed or changed variable.
+type TokenChange struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Values map[string]string `json:"values,omitempty"` // mode → value, for added/removed
+	Modes  []ModeChange      `json:"modes,omitempty"`  // for changed
+}
+
+// ModeChange is a before/after value for one mode.
+type ModeChange struct {
+	Mode   string `json:"mode"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// TokenDiffResult contains the result of token_diff.
+type TokenDiffResult struct {
+	BaselineVersion string        `json:"baseline_version"`
+	Added           []TokenChange `json:"added"`
+	Removed         []TokenChange `json:"removed"`
+	Changed         []TokenChange `json:"changed"`
+	Summary         string        `json:"summary"`
+}
+
+// variableSnapshot is one side of a token diff.
+type variableSnapshot struct {
+	variables map[string]*figma.Variable
+	modeNames map[string]string // mode ID → name
+}
+
+func registerTokenDiffTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "token_diff",
+		Description: "Compare current local variables against the snapshot saved by sync_file and list added, removed and changed tokens per mode.",
+		InputSchema: inputSchema[TokenDiffArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args TokenDiffArgs) (*mcp.CallToolResult, *TokenDiffResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		// The variables endpoint has no version parameter, so the baseline
+		// comes from the variables snapshot written by sync_file.
+		cacheDir, err := findCacheDir(r.ExportDir(), args.FileKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("no previous sync found: %w", err)
+		}
+		baseline, baselineVersion, err := readVariableSnapshot(cacheDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		if args.VersionID != "" && args.VersionID != baselineVersion {
+			return nil, nil, fmt.Errorf("no variables snapshot for version %s (last sync is version %s); Figma only serves current variables, so run sync_file while the file is at that version", args.VersionID, baselineVersion)
+		}
+
+		vars, err := r.Client().GetLocalVariables(ctx, args.FileKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching variables: %w", err)
+		}
+		if vars.Meta == nil {
+			return nil, nil, fmt.Errorf("no variables found in file")
+		}
+		current := &variableSnapshot{
+			variables: vars.Meta.Variables,
+			modeNames: modeNames(vars.Meta.VariableCollections),
+		}
+
+		result := diffVariables(baseline, current)
+		result.BaselineVersion = baselineVersion
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatTokenDiffResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// readVariableSnapshot loads variables/ from a sync_file export along with
+// the file version it was taken at.
+func readVariableSnapshot(cacheDir string) (*variableSnapshot, string, error) {
+	var meta struct {
+		Version string `json:"version"`
+	}
+	if data, err := os.ReadFile(filepath.Join(cacheDir, "_meta.json")); err == nil {
+		json.Unmarshal(data, &meta)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cacheDir, "variables", "tokens.json"))
+	if err != nil {
+		return nil, "", fmt.Errorf("no variables in last sync (run sync_file with include=variables): %w", err)
+	}
+
+	snapshot := &variableSnapshot{modeNames: make(map[string]string)}
+	if err := json.Unmarshal(data, &snapshot.variables); err != nil {
+		return nil, "", fmt.Errorf("parsing variables/tokens.json: %w", err)
+	}
+
+	collFiles, _ := filepath.Glob(filepath.Join(cacheDir, "variables", "collections", "*.json"))
+	for _, path := range collFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var coll figma.VariableCollection
+		if json.Unmarshal(data, &coll) == nil {
+			for _, m := range coll.Modes {
+				snapshot.modeNames[m.ModeID] = m.Name
+			}
+		}
+	}
+
+	return snapshot, meta.Version, nil
+}
+
+func modeNames(collections map[string]*figma.VariableCollection) map[string]string {
+	names := make(map[string]string)
+	for _, coll := range collections {
+		for _, m := range coll.Modes {
+			names[m.ModeID] = m.Name
+		}
+	}
+	return names
+}
+
+// modeName returns the display name for a mode ID, preferring the current side.
+func (s *variableSnapshot) modeName(modeID string, other *variableSnapshot) string {
+	if name := s.modeNames[modeID]; name != "" {
+		return name
+	}
+	if name := other.modeNames[modeID]; name != "" {
+		return name
+	}
+	return modeID
+}
+
+// describeValue formats a mode value, naming alias targets.
+func (s *variableSnapshot) describeValue(v *figma.Variable, value json.RawMessage) string {
+	if len(value) == 0 {
+		return "(unset)"
+	}
+	if alias, ok := parseVariableAlias(value); ok {
+		if target := s.variables[alias.ID]; target != nil {
+			return "{" + target.Name + "}"
+		}
+		return "{" + alias.ID + "}"
+	}
+	return formatTokenValue(v.ResolvedType, value)
+}
+
+// byName indexes variables by collection and name, which survive re-creation
+// of a variable better than its ID.
+func (s *variableSnapshot) byName() map[string]*figma.Variable {
+	index := make(map[string]*figma.Variable, len(s.variables))
+	for _, v := range s.variables {
+		index[v.VariableCollectionID+"/"+v.Name] = v
+	}
+	return index
+}
+
+func diffVariables(before, after *variableSnapshot) *TokenDiffResult {
+	result := &TokenDiffResult{
+		Added:   []TokenChange{},
+		Removed: []TokenChange{},
+		Changed: []TokenChange{},
+	}
+
+	oldVars := before.byName()
+	newVars := after.byName()
+
+	allValues := func(s, other *variableSnapshot, v *figma.Variable) map[string]string {
+		values := make(map[string]string, len(v.ValuesByMode))
+		for modeID, value := range v.ValuesByMode {
+			values[s.modeName(modeID, other)] = s.describeValue(v, value)
+		}
+		return values
+	}
+
+	for key, v := range newVars {
+		old, ok := oldVars[key]
+		if !ok {
+			result.Added = append(result.Added, TokenChange{Name: v.Name, Type: v.ResolvedType, Values: allValues(after, before, v)})
+			continue
+		}
+
+		modeIDs := make(map[string]bool)
+		for id := range old.ValuesByMode {
+			modeIDs[id] = true
+		}
+		for id := range v.ValuesByMode {
+			modeIDs[id] = true
+		}
+
+		var modes []ModeChange
+		for modeID := range modeIDs {
+			oldValue, newValue := old.ValuesByMode[modeID], v.ValuesByMode[modeID]
+			if jsonEqual(oldValue, newValue) {
+				continue
+			}
+			modes = append(modes, ModeChange{
+				Mode:   after.modeName(modeID, before),
+				Before: before.describeValue(old, oldValue),
+				After:  after.describeValue(v, newValue),
+			})
+		}
+		if len(modes) > 0 {
+			sort.Slice(modes, func(i, j int) bool { return modes[i].Mode < modes[j].Mode })
+			result.Changed = append(result.Changed, TokenChange{Name: v.Name, Type: v.ResolvedType, Modes: modes})
+		}
+	}
+
+	for key, v := range oldVars {
+		if _, ok := newVars[key]; !ok {
+			result.Removed = append(result.Removed, TokenChange{Name: v.Name, Type: v.ResolvedType, Values: allValues(before, after, v)})
+		}
+	}
+
+	for _, list := range [][]TokenChange{result.Added, result.Removed, result.Changed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	}
+
+	result.Summary = fmt.Sprintf("%d added, %d removed, %d changed",
+		len(result.Added), len(result.Removed), len(result.Changed))
+
+	return result
+}
+
+// jsonEqual compares two JSON values ignoring formatting.
+func jsonEqual(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+func formatTokenDiffResult(r *TokenDiffResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Token Diff: %s (baseline version %s)\n\n", r.Summary, r.BaselineVersion))
+
+	formatValues := func(values map[string]string) string {
+		modes := make([]string, 0, len(values))
+		for mode := range values {
+			modes = append(modes, mode)
+		}
+		sort.Strings(modes)
+		parts := make([]string, len(modes))
+		for i, mode := range modes {
+			parts[i] = mode + "=" + values[mode]
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	if len(r.Added) > 0 {
+		sb.WriteString(fmt.Sprintf("Added (%d):\n", len(r.Added)))
+		for _, t := range r.Added {
+			sb.WriteString(fmt.Sprintf("  + %s (%s) %s\n", t.Name, t.Type, formatValues(t.Values)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(r.Removed) > 0 {
+		sb.WriteString(fmt.Sprintf("Removed (%d):\n", len(r.Removed)))
+		for _, t := range r.Removed {
+			sb.WriteString(fmt.Sprintf("  - %s (%s) %s\n", t.Name, t.Type, formatValues(t.Values)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(r.Changed) > 0 {
+		sb.WriteString(fmt.Sprintf("Changed (%d):\n", len(r.Changed)))
+		for _, t := range r.Changed {
+			sb.WriteString(fmt.Sprintf("  ~ %s (%s)\n", t.Name, t.Type))
+			for _, m := range t.Modes {
+				sb.WriteString(fmt.Sprintf("      %s: %s → %s\n", m.Mode, m.Before, m.After))
+			}
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestDiffVariables(t *testing.T) {
+	modes := map[string]string{"m1": "Light", "m2": "Dark"}
+	variable := func(id, name, typ string, values map[string]string) *figma.Variable {
+		v := &figma.Variable{ID: id, Name: name, VariableCollectionID: "c1", ResolvedType: typ, ValuesByMode: map[string]json.RawMessage{}}
+		for mode, value := range values {
+			v.ValuesByMode[mode] = json.RawMessage(value)
+		}
+		return v
+	}
+
+	before := &variableSnapshot{
+		modeNames: modes,
+		variables: map[string]*figma.Variable{
+			"v1": variable("v1", "space/sm", "FLOAT", map[string]string{"m1": "4", "m2": "4"}),
+			"v2": variable("v2", "space/old", "FLOAT", map[string]string{"m1": "2"}),
+			"v3": variable("v3", "color/bg", "COLOR", map[string]string{"m1": `{"r":1,"g":1,"b":1,"a":1}`, "m2": `{"r":0,"g":0,"b":0,"a":1}`}),
+		},
+	}
+	after := &variableSnapshot{
+		modeNames: modes,
+		variables: map[string]*figma.Variable{
+			"v1": variable("v1", "space/sm", "FLOAT", map[string]string{"m1": "4", "m2": "6"}),
+			"v3": variable("v3", "color/bg", "COLOR", map[string]string{"m1": `{ "r": 1, "g": 1, "b": 1, "a": 1 }`, "m2": `{"r":0,"g":0,"b":0,"a":1}`}),
+			"v4": variable("v4", "space/lg", "FLOAT", map[string]string{"m1": `{"type":"VARIABLE_ALIAS","id":"v1"}`}),
+		},
+	}
+
+	result := diffVariables(before, after)
+
+	if result.Summary != "1 added, 1 removed, 1 changed" {
+		t.Fatalf("summary = %q", result.Summary)
+	}
+	if added := result.Added[0]; added.Name != "space/lg" || added.Values["Light"] != "{space/sm}" {
+		t.Errorf("added = %+v", added)
+	}
+	if removed := result.Removed[0]; removed.Name != "space/old" || removed.Values["Light"] != "2px" {
+		t.Errorf("removed = %+v", removed)
+	}
+
+	changed := result.Changed[0]
+	if changed.Name != "space/sm" || len(changed.Modes) != 1 {
+		t.Fatalf("changed = %+v", changed)
+	}
+	if m := changed.Modes[0]; m.Mode != "Dark" || m.Before != "4px" || m.After != "6px" {
+		t.Errorf("mode change = %+v, want Dark 4px → 6px", m)
+	}
+}
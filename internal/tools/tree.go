@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -17,6 +20,7 @@ type GetTreeArgs struct {
 	Depth      int      `json:"depth,omitempty" jsonschema:"Max depth to show (default: 3)"`
 	MaxNodes   int      `json:"max_nodes,omitempty" jsonschema:"Maximum nodes to return (default: 500, max: 2000)"`
 	HideIDs    bool     `json:"hide_ids,omitempty" jsonschema:"Hide node IDs in tree (default: false, IDs shown)"`
+	HideHidden *bool    `json:"hide_hidden,omitempty" jsonschema:"Skip invisible nodes and their children (default: true)"`
 	NodeTypes  []string `json:"node_types,omitempty" jsonschema:"Only show these node types"`
 	Format     string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
 	OutputFile string   `json:"output_file,omitempty" jsonschema:"Write full output to file path (useful for large trees)"`
@@ -27,6 +31,7 @@ type TreeNode struct {
 	ID       string      `json:"id"`
 	Name     string      `json:"name"`
 	Type     string      `json:"type"`
+	Locked   bool        `json:"locked,omitempty"`
 	Children []*TreeNode `json:"children,omitempty"`
 }
 
@@ -39,16 +44,22 @@ type GetTreeResult struct {
 	MaxDepth  int         `json:"max_depth"`
 	Truncated bool        `json:"truncated"`
 	FilePath  string      `json:"file_path,omitempty"`
+
+	TypeCounts map[string]int `json:"type_counts"` // nodes per type among the Total nodes
 }
 
 func registerGetTreeTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_tree",
 		Description: "Get file structure as ASCII tree or JSON tree with node IDs.",
+		InputSchema: inputSchema[GetTreeArgs](map[string][]string{
+			"format": responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetTreeArgs) (*mcp.CallToolResult, any, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
 		}
+		args.RootNodeID = r.ResolveNodeID(args.RootNodeID)
 
 		// Set defaults
 		depth := args.Depth
@@ -74,7 +85,7 @@ func registerGetTreeTool(server *mcp.Server, r *Registry) {
 
 		if file == nil {
 			if !r.HasClient() {
-				return nil, nil, fmt.Errorf("no cache found and Figma API not configured")
+				return nil, nil, errNoCacheNoClient
 			}
 
 			file, err = r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{
@@ -91,12 +102,15 @@ func registerGetTreeTool(server *mcp.Server, r *Registry) {
 		totalNodes := 0
 		returnedNodes := 0
 		truncated := false
+		typeCounts := make(map[string]int)
 
 		// TreeBuilder context to track limits
 		buildCtx := &treeBuildContext{
 			maxNodes:      maxNodes,
 			returnedNodes: &returnedNodes,
 			truncated:     &truncated,
+			hideHidden:    args.HideHidden == nil || *args.HideHidden,
+			typeCounts:    typeCounts,
 		}
 
 		if file.Document != nil {
@@ -129,6 +143,8 @@ func registerGetTreeTool(server *mcp.Server, r *Registry) {
 			Returned:  returnedNodes,
 			MaxDepth:  depth,
 			Truncated: truncated,
+
+			TypeCounts: typeCounts,
 		}
 
 		// Format output
@@ -147,6 +163,9 @@ func registerGetTreeTool(server *mcp.Server, r *Registry) {
 			} else {
 				textOutput += fmt.Sprintf("\n\n[%d nodes, max depth %d]", totalNodes, depth)
 			}
+			if len(typeCounts) > 0 {
+				textOutput += "\nNode types: " + formatTypeCounts(typeCounts)
+			}
 		}
 
 		// Handle large output / file writing
@@ -180,11 +199,19 @@ type treeBuildContext struct {
 	maxNodes      int
 	returnedNodes *int
 	truncated     *bool
+	hideHidden    bool
+	typeCounts    map[string]int // optional; counts every node visited
 }
 
 // buildTreeNodeLimited builds a tree node with limit tracking.
 func buildTreeNodeLimited(node *figma.Node, currentDepth, maxDepth int, nodeTypes []string, showIDs bool, lines *[]string, total *int, ctx *treeBuildContext) *TreeNode {
+	if ctx.hideHidden && isHidden(node) {
+		return nil
+	}
 	*total++
+	if ctx.typeCounts != nil {
+		ctx.typeCounts[string(node.Type)]++
+	}
 
 	// Check if we've hit the limit
 	if *ctx.returnedNodes >= ctx.maxNodes {
@@ -203,9 +230,10 @@ func buildTreeNodeLimited(node *figma.Node, currentDepth, maxDepth int, nodeType
 	*ctx.returnedNodes++
 
 	treeNode := &TreeNode{
-		ID:   node.ID,
-		Name: node.Name,
-		Type: string(node.Type),
+		ID:     node.ID,
+		Name:   node.Name,
+		Type:   string(node.Type),
+		Locked: isLocked(node),
 	}
 
 	// Build line
@@ -219,6 +247,9 @@ func buildTreeNodeLimited(node *figma.Node, currentDepth, maxDepth int, nodeType
 		line += fmt.Sprintf(" [%s]", node.ID)
 	}
 	line += fmt.Sprintf(" (%s)", node.Type)
+	if isLocked(node) {
+		line += " [L]"
+	}
 	*lines = append(*lines, line)
 
 	// Process children
@@ -257,6 +288,25 @@ func buildTreeNodeLimited(node *figma.Node, currentDepth, maxDepth int, nodeType
 	return treeNode
 }
 
+// formatTypeCounts lists node types by frequency: TEXT: 380, FRAME: 142, ...
+func formatTypeCounts(counts map[string]int) string {
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if counts[types[i]] != counts[types[j]] {
+			return counts[types[i]] > counts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = fmt.Sprintf("%s: %d", t, counts[t])
+	}
+	return strings.Join(parts, ", ")
+}
+
 func findNode(root *figma.Node, id string) *figma.Node {
 	if root.ID == id {
 		return root
@@ -269,3 +319,165 @@ func findNode(root *figma.Node, id string) *figma.Node {
 	return nil
 }
 
+
+// findNodePath returns the nodes from root down to the node with id, or nil.
+func findNodePath(root *figma.Node, id string) []*figma.Node {
+	if root.ID == id {
+		return []*figma.Node{root}
+	}
+	for _, child := range root.Children {
+		if path := findNodePath(child, id); path != nil {
+			return append([]*figma.Node{root}, path...)
+		}
+	}
+	return nil
+}
+
+// GetNodePathArgs contains arguments for the get_node_path tool.
+type GetNodePathArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key"`
+	NodeID  string `json:"node_id" jsonschema:"Node ID to locate (e.g. 1:234)"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// PathNode is one step in a node's ancestor chain.
+type PathNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetNodePathResult contains the result of get_node_path.
+type GetNodePathResult struct {
+	Path     []PathNode `json:"path"`
+	CacheHit bool       `json:"cache_hit"`
+}
+
+func registerGetNodePathTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_node_path",
+		Description: "Get the ancestor chain of a node, from its page down to the node.",
+		InputSchema: inputSchema[GetNodePathArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetNodePathArgs) (*mcp.CallToolResult, *GetNodePathResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if args.NodeID == "" {
+			return nil, nil, errNodeIDRequired
+		}
+		nodeID := r.ResolveNodeID(args.NodeID)
+
+		paths, cacheHit, err := r.nodePaths(ctx, args.FileKey, []string{nodeID})
+		if err != nil {
+			return nil, nil, err
+		}
+		if paths[nodeID] == nil {
+			return nil, nil, fmt.Errorf("node %s not found", nodeID)
+		}
+		result := &GetNodePathResult{Path: paths[nodeID], CacheHit: cacheHit}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatNodePath(result.Path)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// nodePaths returns the ancestor chain of each node, page first. Parent
+// pointers in the sync_file index are used when available; nodes missing from
+// it are located in one full file fetch. cacheHit reports whether the index
+// answered for every node. Nodes that cannot be found are absent from paths.
+func (r *Registry) nodePaths(ctx context.Context, fileKey string, nodeIDs []string) (paths map[string][]PathNode, cacheHit bool, err error) {
+	paths = make(map[string][]PathNode, len(nodeIDs))
+
+	var missing []string
+	var index map[string]IndexEntry
+	if cacheDir, err := findCacheDir(r.ExportDir(), fileKey); err == nil {
+		index, _ = readExportIndex(cacheDir)
+	}
+	if index != nil {
+		for _, id := range nodeIDs {
+			if path, err := nodePathFromIndex(index, id); err == nil {
+				paths[id] = path
+			} else {
+				missing = append(missing, id)
+			}
+		}
+	} else {
+		missing = nodeIDs
+	}
+	if len(missing) == 0 {
+		return paths, true, nil
+	}
+
+	if !r.HasClient() {
+		return nil, false, errNoCacheNoClient
+	}
+
+	file, err := r.Client().GetFile(ctx, fileKey, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("fetching file: %w", err)
+	}
+
+	for _, id := range missing {
+		for _, page := range file.Document.Children {
+			if nodes := findNodePath(page, id); nodes != nil {
+				for _, n := range nodes {
+					paths[id] = append(paths[id], PathNode{ID: n.ID, Name: n.Name, Type: string(n.Type)})
+				}
+				break
+			}
+		}
+	}
+	return paths, false, nil
+}
+
+// nodePathFromIndex walks parent_id links of an export's _index.json up to
+// the page.
+func nodePathFromIndex(index map[string]IndexEntry, nodeID string) ([]PathNode, error) {
+	var path []PathNode
+	for id := nodeID; id != ""; {
+		entry, ok := index[id]
+		if !ok {
+			return nil, fmt.Errorf("node %s not in index", id)
+		}
+		if len(path) > len(index) {
+			return nil, fmt.Errorf("parent cycle at %s", id)
+		}
+
+		step := PathNode{ID: id}
+		if nodeData, err := os.ReadFile(filepath.Join(entry.Path, "_node.json")); err == nil {
+			var node struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			}
+			if json.Unmarshal(nodeData, &node) == nil {
+				step.Name, step.Type = node.Name, node.Type
+			}
+		}
+
+		path = append([]PathNode{step}, path...)
+		id = entry.ParentID
+	}
+
+	return path, nil
+}
+
+func formatNodePath(path []PathNode) string {
+	var sb strings.Builder
+	for i, n := range path {
+		sb.WriteString(fmt.Sprintf("%s%s [%s] %s\n", strings.Repeat("  ", i), n.Name, n.ID, n.Type))
+	}
+	return sb.String()
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestFindNodePath(t *testing.T) {
+	path := findNodePath(testSyncPage(), "1:3")
+
+	var ids []string
+	for _, n := range path {
+		ids = append(ids, n.ID)
+	}
+	if len(ids) != 3 || ids[0] != "0:1" || ids[1] != "1:1" || ids[2] != "1:3" {
+		t.Errorf("path = %v, want [0:1 1:1 1:3]", ids)
+	}
+
+	if findNodePath(testSyncPage(), "9:9") != nil {
+		t.Error("expected nil path for missing node")
+	}
+}
+
+func TestNodePathFromIndex(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	var treeLines []string
+	index := make(map[string]IndexEntry)
+	w := &syncWriter{}
+	if _, errs := exportNode(context.Background(), w, testSyncPage(), filepath.Join(cacheDir, "pages", "page-1"), nil, "Page 1", &treeLines, index, NewImageCollector()); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	path, err := nodePathFromIndex(index, "1:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []PathNode{
+		{ID: "0:1", Name: "Page 1", Type: "CANVAS"},
+		{ID: "1:1", Name: "Frame", Type: "FRAME"},
+		{ID: "1:2", Name: "Label", Type: "TEXT"},
+	}
+	if len(path) != len(expected) {
+		t.Fatalf("path = %+v, want %+v", path, expected)
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Errorf("path[%d] = %+v, want %+v", i, path[i], expected[i])
+		}
+	}
+
+	if _, err := nodePathFromIndex(index, "9:9"); err == nil {
+		t.Error("expected error for node missing from index")
+	}
+}
+
+func TestBuildTreeNodeHidesHidden(t *testing.T) {
+	page := testSyncPage()
+	hidden := false
+	page.Children[0].Children[1].Visible = &hidden
+
+	for _, hide := range []bool{true, false} {
+		var lines []string
+		total, returned, truncated := 0, 0, false
+		ctx := &treeBuildContext{maxNodes: 100, returnedNodes: &returned, truncated: &truncated, hideHidden: hide}
+		buildTreeNodeLimited(page, 0, 5, nil, true, &lines, &total, ctx)
+
+		want := 4
+		if hide {
+			want = 3
+		}
+		if total != want || len(lines) != want {
+			t.Errorf("hideHidden=%v: total %d, lines %d, want %d", hide, total, len(lines), want)
+		}
+	}
+}
+
+func TestBuildTreeNodeTypeCounts(t *testing.T) {
+	page := testSyncPage()
+	page.Children = append(page.Children, &figma.Node{ID: "2:1", Name: "Caption", Type: figma.NodeTypeText})
+
+	var lines []string
+	total, returned, truncated := 0, 0, false
+	counts := make(map[string]int)
+	ctx := &treeBuildContext{maxNodes: 100, returnedNodes: &returned, truncated: &truncated, typeCounts: counts}
+	buildTreeNodeLimited(page, 0, 5, nil, true, &lines, &total, ctx)
+
+	want := map[string]int{"CANVAS": 1, "FRAME": 1, "TEXT": 2, "VECTOR": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("type counts = %v, want %v", counts, want)
+	}
+	if got := formatTypeCounts(counts); got != "TEXT: 2, CANVAS: 1, FRAME: 1, VECTOR: 1" {
+		t.Errorf("formatTypeCounts = %q", got)
+	}
+}
+
+func TestBuildTreeNodeMarksLocked(t *testing.T) {
+	page := testSyncPage()
+	locked := true
+	page.Children[0].Locked = &locked
+
+	var lines []string
+	total, returned, truncated := 0, 0, false
+	ctx := &treeBuildContext{maxNodes: 100, returnedNodes: &returned, truncated: &truncated}
+	tree := buildTreeNodeLimited(page, 0, 5, nil, true, &lines, &total, ctx)
+
+	if !tree.Children[0].Locked || tree.Children[0].Children[0].Locked {
+		t.Errorf("locked flags = %v, %v; want only the frame locked", tree.Children[0].Locked, tree.Children[0].Children[0].Locked)
+	}
+	if !strings.HasSuffix(lines[1], "(FRAME) [L]") {
+		t.Errorf("frame line = %q, want [L] marker", lines[1])
+	}
+}
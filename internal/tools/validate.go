@@ -0,0 +1,267 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// exportMetaFields lists the _meta.json fields written by sync_file.
+var exportMetaFields = []string{"name", "version", "lastModified", "exportedAt", "fileKey", "schemaVersion"}
+
+// Number of problems listed per check; the rest are counted.
+const maxValidateProblems = 20
+
+// ValidateExportArgs contains arguments for the validate_export tool.
+type ValidateExportArgs struct {
+	FileKey string `json:"file_key" jsonschema:"Figma file key of a sync_file export"`
+	Format  string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// ExportCheck is the outcome of one validate_export check.
+type ExportCheck struct {
+	Name     string   `json:"name"`
+	Passed   bool     `json:"passed"`
+	Detail   string   `json:"detail"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// ValidateExportResult contains the result of validate_export.
+type ValidateExportResult struct {
+	ExportDir  string        `json:"export_dir"`
+	Valid      bool          `json:"valid"`
+	Checks     []ExportCheck `json:"checks"`
+	Suggestion string        `json:"suggestion,omitempty"`
+}
+
+func registerValidateExportTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "validate_export",
+		Description: "Check the integrity of a sync_file export: every node in _index.json has a _node.json, every _node.json parses, _meta.json is complete and _tree.txt lists as many nodes as the index.",
+		InputSchema: inputSchema[ValidateExportArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ValidateExportArgs) (*mcp.CallToolResult, *ValidateExportResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		cacheDir, err := findCacheDir(r.ExportDir(), args.FileKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := validateExport(cacheDir)
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatValidateExportResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// validateExport runs every check against the export in dir.
+func validateExport(dir string) *ValidateExportResult {
+	result := &ValidateExportResult{ExportDir: dir, Valid: true}
+
+	index, indexErr := readExportIndex(dir)
+	result.Checks = []ExportCheck{
+		checkIndexNodeFiles(dir, index, indexErr),
+		checkNodeJSON(dir),
+		checkExportMeta(dir),
+		checkTreeCount(dir, index, indexErr),
+	}
+
+	for _, c := range result.Checks {
+		if !c.Passed {
+			result.Valid = false
+		}
+	}
+	if !result.Valid {
+		result.Suggestion = fmt.Sprintf("Run sync_file again to rewrite the export. If the problems remain, delete %s and sync into an empty directory.", dir)
+	}
+
+	return result
+}
+
+// checkIndexNodeFiles verifies that every node in _index.json has a _node.json.
+func checkIndexNodeFiles(dir string, index map[string]IndexEntry, indexErr error) ExportCheck {
+	check := ExportCheck{Name: "index_node_files"}
+	if indexErr != nil {
+		check.Detail = indexErr.Error()
+		return check
+	}
+
+	ids := make([]string, 0, len(index))
+	for id := range index {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var missing []string
+	for _, id := range ids {
+		rel := indexRelPath(index[id].Path)
+		if rel == "" {
+			missing = append(missing, fmt.Sprintf("%s: unrecognized index path %s", id, index[id].Path))
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, rel, "_node.json")); err != nil {
+			missing = append(missing, fmt.Sprintf("%s: %s/_node.json missing", id, filepath.ToSlash(rel)))
+		}
+	}
+
+	check.Passed = len(missing) == 0
+	check.Detail = fmt.Sprintf("%d of %d indexed nodes have a _node.json", len(ids)-len(missing), len(ids))
+	check.Problems = limitProblems(missing)
+	return check
+}
+
+// checkNodeJSON parses every _node.json under dir.
+func checkNodeJSON(dir string) ExportCheck {
+	check := ExportCheck{Name: "node_json"}
+
+	files := 0
+	var invalid []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() != "_node.json" {
+			return nil
+		}
+		files++
+
+		rel, _ := filepath.Rel(dir, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", filepath.ToSlash(rel), err))
+			return nil
+		}
+		var node map[string]interface{}
+		if err := json.Unmarshal(data, &node); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", filepath.ToSlash(rel), err))
+		}
+		return nil
+	})
+	if err != nil {
+		check.Detail = fmt.Sprintf("walking %s: %v", dir, err)
+		return check
+	}
+
+	check.Passed = len(invalid) == 0
+	check.Detail = fmt.Sprintf("%d of %d _node.json files parse", files-len(invalid), files)
+	check.Problems = limitProblems(invalid)
+	return check
+}
+
+// checkExportMeta verifies that _meta.json has every field sync_file writes.
+func checkExportMeta(dir string) ExportCheck {
+	check := ExportCheck{Name: "meta"}
+
+	data, err := os.ReadFile(filepath.Join(dir, "_meta.json"))
+	if err != nil {
+		check.Detail = fmt.Sprintf("reading _meta.json: %v", err)
+		return check
+	}
+	var meta map[string]interface{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		check.Detail = fmt.Sprintf("parsing _meta.json: %v", err)
+		return check
+	}
+
+	var missing []string
+	for _, field := range exportMetaFields {
+		v := meta[field]
+		if s, isString := v.(string); v == nil || (isString && s == "") {
+			missing = append(missing, field)
+		}
+	}
+
+	check.Passed = len(missing) == 0
+	if check.Passed {
+		check.Detail = fmt.Sprintf("all %d fields present", len(exportMetaFields))
+	} else {
+		check.Detail = "missing or empty: " + strings.Join(missing, ", ")
+	}
+	return check
+}
+
+// checkTreeCount compares the nodes listed in _tree.txt with the size of
+// _index.json. Page headers are not counted; each page is also listed as a
+// node of the tree.
+func checkTreeCount(dir string, index map[string]IndexEntry, indexErr error) ExportCheck {
+	check := ExportCheck{Name: "tree_count"}
+	if indexErr != nil {
+		check.Detail = indexErr.Error()
+		return check
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "_tree.txt"))
+	if err != nil {
+		check.Detail = fmt.Sprintf("reading _tree.txt: %v", err)
+		return check
+	}
+
+	nodes := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.Contains(line, "├── ") {
+			nodes++
+		}
+	}
+
+	check.Passed = nodes == len(index)
+	check.Detail = fmt.Sprintf("_tree.txt lists %d nodes, _index.json has %d", nodes, len(index))
+	return check
+}
+
+// limitProblems keeps the first maxValidateProblems entries and counts the rest.
+func limitProblems(problems []string) []string {
+	if len(problems) <= maxValidateProblems {
+		return problems
+	}
+	rest := len(problems) - maxValidateProblems
+	return append(problems[:maxValidateProblems:maxValidateProblems], fmt.Sprintf("... and %d more", rest))
+}
+
+func formatValidateExportResult(r *ValidateExportResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Export: %s\n", r.ExportDir))
+	if r.Valid {
+		sb.WriteString("Status: valid\n\n")
+	} else {
+		sb.WriteString("Status: INVALID\n\n")
+	}
+
+	for _, c := range r.Checks {
+		status := "PASS"
+		if !c.Passed {
+			status = "FAIL"
+		}
+		sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", status, c.Name, c.Detail))
+		for _, p := range c.Problems {
+			sb.WriteString(fmt.Sprintf("  - %s\n", p))
+		}
+	}
+
+	if r.Suggestion != "" {
+		sb.WriteString("\n" + r.Suggestion + "\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateExport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "export")
+	writeTestExport(t, dir, "2026-01-01T00:00:00Z", testSyncPage())
+	writeTestMeta(t, dir, map[string]any{
+		"name": "File", "version": "1", "lastModified": "2026-01-01T00:00:00Z",
+		"exportedAt": "2026-01-01T00:00:00Z", "fileKey": "KEY", "schemaVersion": 0,
+	})
+
+	if result := validateExport(dir); !result.Valid || result.Suggestion != "" {
+		t.Fatalf("expected a valid export, got %s", formatValidateExportResult(result))
+	}
+
+	// A truncated write, a missing node file and an incomplete _meta.json
+	labelPath := filepath.Join(dir, "pages", "page-1-0-1", "children", "frame-1-1", "children", "label-1-2", "_node.json")
+	if err := os.WriteFile(labelPath, []byte(`{"id": "1:2", "na`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	iconDir := filepath.Join(dir, "pages", "page-1-0-1", "children", "frame-1-1", "children", "icon-1-3")
+	if err := os.Remove(filepath.Join(iconDir, "_node.json")); err != nil {
+		t.Fatal(err)
+	}
+	writeTestMeta(t, dir, map[string]any{"fileKey": "KEY", "exportedAt": "2026-01-01T00:00:00Z"})
+	if err := os.WriteFile(filepath.Join(dir, "_tree.txt"), []byte("Page: Page 1 [0:1]\n├── Page 1 [0:1] CANVAS\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := validateExport(dir)
+	if result.Valid || !strings.Contains(result.Suggestion, "sync_file") {
+		t.Fatalf("expected an invalid export with a suggestion, got %+v", result)
+	}
+
+	checks := make(map[string]ExportCheck)
+	for _, c := range result.Checks {
+		checks[c.Name] = c
+	}
+	if c := checks["index_node_files"]; c.Passed || len(c.Problems) != 1 || !strings.Contains(c.Problems[0], "1:3") {
+		t.Errorf("index_node_files = %+v", c)
+	}
+	if c := checks["node_json"]; c.Passed || len(c.Problems) != 1 || !strings.Contains(c.Problems[0], "label-1-2") {
+		t.Errorf("node_json = %+v", c)
+	}
+	if c := checks["meta"]; c.Passed || !strings.Contains(c.Detail, "name, version, lastModified, schemaVersion") {
+		t.Errorf("meta = %+v", c)
+	}
+	if c := checks["tree_count"]; c.Passed || c.Detail != "_tree.txt lists 1 nodes, _index.json has 4" {
+		t.Errorf("tree_count = %+v", c)
+	}
+}
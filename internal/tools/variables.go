@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// VariableChange sets the value of a variable in one mode.
+type VariableChange struct {
+	VariableID string `json:"variableId" jsonschema:"Variable ID (e.g. VariableID:1:23)"`
+	ModeID     string `json:"modeId" jsonschema:"Mode ID within the variable's collection"`
+	Value      any    `json:"value" jsonschema:"New value: number, string, boolean, {r,g,b,a} color, or {type: VARIABLE_ALIAS, id} alias"`
+}
+
+// UpdateVariablesArgs contains arguments for the update_variables tool.
+type UpdateVariablesArgs struct {
+	FileKey string           `json:"file_key" jsonschema:"Figma file key"`
+	Changes []VariableChange `json:"changes" jsonschema:"Values to write"`
+	Format  string           `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// UpdateVariablesResult contains the result of update_variables.
+type UpdateVariablesResult struct {
+	Updated int `json:"updated"`
+}
+
+func registerUpdateVariablesTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_variables",
+		Description: "EXPERIMENTAL: writes to the Figma file. Set variable values per mode via the variables REST API. Requires a token with the file_variables:write scope (Enterprise plans).",
+		InputSchema: inputSchema[UpdateVariablesArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UpdateVariablesArgs) (*mcp.CallToolResult, *UpdateVariablesResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if len(args.Changes) == 0 {
+			return nil, nil, fmt.Errorf("changes is required. Pass [{\"variableId\": \"VariableID:1:23\", \"modeId\": \"1:0\", \"value\": ...}]; sync_file writes variable and mode IDs under variables/")
+		}
+		for i, c := range args.Changes {
+			if c.VariableID == "" || c.ModeID == "" || c.Value == nil {
+				return nil, nil, fmt.Errorf("changes[%d]: variableId, modeId and value are required. sync_file writes variable and mode IDs under variables/", i)
+			}
+		}
+
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		payload := map[string]any{"variableModeValues": args.Changes}
+		if err := r.Client().PostVariables(ctx, args.FileKey, payload); err != nil {
+			return nil, nil, fmt.Errorf("updating variables: %w", err)
+		}
+
+		result := &UpdateVariablesResult{Updated: len(args.Changes)}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			var sb strings.Builder
+			sb.WriteString(fmt.Sprintf("Updated %d variable values\n", result.Updated))
+			for _, c := range args.Changes {
+				value, _ := json.Marshal(c.Value)
+				sb.WriteString(fmt.Sprintf("  %s [%s] = %s\n", c.VariableID, c.ModeID, value))
+			}
+			textOutput = sb.String()
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
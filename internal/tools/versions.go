@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// maxVersionScan bounds how much history list_versions pages through when
+// labeled_only skips autosaves.
+const maxVersionScan = 1000
+
+// ListVersionsArgs contains arguments for the list_versions tool.
+type ListVersionsArgs struct {
+	FileKey     string `json:"file_key" jsonschema:"Figma file key"`
+	Limit       int    `json:"limit,omitempty" jsonschema:"Max versions to return (default: 20)"`
+	Before      string `json:"before,omitempty" jsonschema:"Only versions older than this version ID; pass next_before of a previous call for the next page"`
+	LabeledOnly bool   `json:"labeled_only,omitempty" jsonschema:"Only named versions, skipping autosaves (default: false)"`
+	Format      string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// VersionEntry is a saved version of a file.
+type VersionEntry struct {
+	ID          string `json:"id"`
+	CreatedAt   string `json:"created_at"`
+	Label       string `json:"label,omitempty"`
+	Description string `json:"description,omitempty"`
+	User        string `json:"user,omitempty"`
+}
+
+// ListVersionsResult contains the result of list_versions.
+type ListVersionsResult struct {
+	Versions   []VersionEntry `json:"versions"`
+	Total      int            `json:"total"`
+	NextBefore string         `json:"next_before,omitempty"` // empty at the start of history
+}
+
+func registerListVersionsTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_versions",
+		Description: "List a file's version history, newest first, with version ID, date, label and author. Pass a version ID to diff with compare=version.",
+		InputSchema: inputSchema[ListVersionsArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListVersionsArgs) (*mcp.CallToolResult, *ListVersionsResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+		limit := args.Limit
+		if limit == 0 {
+			limit = 20
+		}
+
+		versions, next, err := collectVersions(func(opts *figma.GetVersionsOptions) (*figma.FileVersions, error) {
+			return r.Client().GetFileVersions(ctx, args.FileKey, opts)
+		}, args.Before, limit, args.LabeledOnly)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching versions: %w", err)
+		}
+
+		result := &ListVersionsResult{Versions: []VersionEntry{}, NextBefore: next}
+		for _, v := range versions {
+			entry := VersionEntry{ID: v.ID, CreatedAt: v.CreatedAt, Label: v.Label, Description: v.Description}
+			if v.User != nil {
+				entry.User = v.User.Handle
+			}
+			result.Versions = append(result.Versions, entry)
+		}
+		result.Total = len(result.Versions)
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatListVersionsResult(result, args.FileKey)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// collectVersions pages through version history older than before, newest
+// first, until it has limit versions, skipping unlabeled ones when
+// labeledOnly is set. It also returns the ID to pass as before for the next
+// page, or "" when the history is exhausted.
+func collectVersions(fetch func(*figma.GetVersionsOptions) (*figma.FileVersions, error), before string, limit int, labeledOnly bool) ([]figma.Version, string, error) {
+	var versions []figma.Version
+	opts := &figma.GetVersionsOptions{PageSize: 50, Before: before}
+
+	for scanned := 0; scanned < maxVersionScan; {
+		page, err := fetch(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, v := range page.Versions {
+			scanned++
+			if labeledOnly && v.Label == "" {
+				continue
+			}
+			versions = append(versions, v)
+			if len(versions) == limit {
+				return versions, v.ID, nil
+			}
+		}
+		if len(page.Versions) == 0 || page.Pagination == nil || page.Pagination.NextPage == "" {
+			return versions, "", nil
+		}
+		opts.Before = page.Versions[len(page.Versions)-1].ID
+	}
+
+	return versions, opts.Before, nil
+}
+
+func formatListVersionsResult(r *ListVersionsResult, fileKey string) string {
+	var sb strings.Builder
+
+	if r.Total == 0 {
+		sb.WriteString("No versions found\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%d versions (newest first)\n\n", r.Total))
+	for _, v := range r.Versions {
+		label := v.Label
+		if label == "" {
+			label = "(autosave)"
+		}
+		sb.WriteString(fmt.Sprintf("%s  %s  %s", v.ID, v.CreatedAt, label))
+		if v.User != "" {
+			sb.WriteString(" by " + v.User)
+		}
+		sb.WriteString("\n")
+		if v.Description != "" {
+			sb.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(v.Description, "\n", "\n    ")))
+		}
+	}
+
+	if r.NextBefore != "" {
+		sb.WriteString(fmt.Sprintf("\nMore history: list_versions(before=%q)\n", r.NextBefore))
+	}
+	sb.WriteString(fmt.Sprintf("Compare: diff(file_key=%q, compare=\"version\", version_id=\"%s\")\n", fileKey, r.Versions[0].ID))
+
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestCollectVersions(t *testing.T) {
+	// 120 versions, newest first: IDs 120..1, every tenth labeled.
+	fetch := func(opts *figma.GetVersionsOptions) (*figma.FileVersions, error) {
+		start := 120
+		if opts.Before != "" {
+			fmt.Sscanf(opts.Before, "%d", &start)
+			start--
+		}
+		page := &figma.FileVersions{}
+		for id := start; id > 0 && len(page.Versions) < opts.PageSize; id-- {
+			v := figma.Version{ID: fmt.Sprint(id)}
+			if id%10 == 0 {
+				v.Label = fmt.Sprintf("v%d", id/10)
+			}
+			page.Versions = append(page.Versions, v)
+		}
+		if n := len(page.Versions); n > 0 && page.Versions[n-1].ID != "1" {
+			page.Pagination = &figma.VersionsPagination{NextPage: "next"}
+		}
+		return page, nil
+	}
+
+	versions, next, err := collectVersions(fetch, "", 3, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 || versions[0].ID != "120" || next != "118" {
+		t.Errorf("first page = %v, next %q", versions, next)
+	}
+
+	versions, next, _ = collectVersions(fetch, next, 2, false)
+	if len(versions) != 2 || versions[0].ID != "117" || next != "116" {
+		t.Errorf("second page = %v, next %q", versions, next)
+	}
+
+	// Labeled versions span several API pages.
+	versions, next, _ = collectVersions(fetch, "", 7, true)
+	if len(versions) != 7 || versions[0].Label != "v12" || versions[6].Label != "v6" || next != "60" {
+		t.Errorf("labeled = %v, next %q", versions, next)
+	}
+
+	// Running out of history leaves no cursor.
+	versions, next, _ = collectVersions(fetch, "", 50, true)
+	if len(versions) != 12 || next != "" {
+		t.Errorf("all labeled = %d versions, next %q", len(versions), next)
+	}
+}
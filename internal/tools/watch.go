@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+// Polling bounds for watch_query. Each poll is one API request, so the
+// interval is kept well above Figma's rate limits.
+const (
+	defaultWatchInterval = 30
+	minWatchInterval     = 5
+	defaultWatchWait     = 300
+)
+
+// WatchQueryArgs contains arguments for the watch_query tool.
+type WatchQueryArgs struct {
+	FileKey             string `json:"file_key" jsonschema:"Figma file key"`
+	Q                   Query  `json:"q" jsonschema:"Query selecting the nodes to watch, as for the query tool"`
+	PollIntervalSeconds int    `json:"poll_interval_seconds,omitempty" jsonschema:"Seconds between checks of the file's lastModified (default: 30, minimum: 5)"`
+	MaxWaitSeconds      int    `json:"max_wait_seconds,omitempty" jsonschema:"Stop watching after this many seconds without a matching change (default: 300)"`
+	IncludeHidden       bool   `json:"include_hidden,omitempty" jsonschema:"Include invisible nodes (default: false)"`
+	Format              string `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// WatchQueryResult contains the result of watch_query.
+type WatchQueryResult struct {
+	FileKey      string      `json:"file_key"`
+	Changed      bool        `json:"changed"`
+	Polls        int         `json:"polls"`
+	LastModified string      `json:"last_modified"`
+	Matched      int         `json:"matched"` // nodes matching the query in the latest version
+	Diff         *DiffResult `json:"diff,omitempty"`
+}
+
+func registerWatchQueryTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "watch_query",
+		Description: "Wait for nodes matching a query to change. Polls the file's lastModified and, when the file is edited, re-runs the query; returns the added, removed and modified matches as soon as there are any, or when max_wait_seconds passes without one.",
+		InputSchema: inputSchema[WatchQueryArgs](map[string][]string{
+			"format": responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args WatchQueryArgs) (*mcp.CallToolResult, *WatchQueryResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		interval := args.PollIntervalSeconds
+		if interval == 0 {
+			interval = defaultWatchInterval
+		}
+		if interval < minWatchInterval {
+			interval = minWatchInterval
+		}
+		wait := args.MaxWaitSeconds
+		if wait <= 0 {
+			wait = defaultWatchWait
+		}
+
+		result, err := watchQuery(ctx, r, req, &args, time.Duration(interval)*time.Second, time.Duration(wait)*time.Second)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatWatchQueryResult(result)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: textOutput},
+			},
+		}, result, nil
+	})
+}
+
+// watchQuery polls the file until the nodes matching the query change or
+// wait has passed.
+func watchQuery(ctx context.Context, r *Registry, req *mcp.CallToolRequest, args *WatchQueryArgs, interval, wait time.Duration) (*WatchQueryResult, error) {
+	matches, lastModified, err := r.queryMatches(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WatchQueryResult{
+		FileKey:      args.FileKey,
+		LastModified: lastModified,
+		Matched:      len(matches),
+	}
+
+	deadline := time.Now().Add(wait)
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		result.Polls++
+		reportWatchProgress(ctx, req, result)
+
+		head, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{Depth: 1})
+		if err != nil {
+			return nil, fmt.Errorf("fetching file: %w", err)
+		}
+		if head.LastModified != result.LastModified {
+			current, _, err := r.queryMatches(ctx, args)
+			if err != nil {
+				return nil, err
+			}
+			result.LastModified = head.LastModified
+			result.Matched = len(current)
+
+			if diff := diffQueryMatches(matches, current); diff != nil {
+				result.Changed = true
+				result.Diff = diff
+				return result, nil
+			}
+			matches = current
+		}
+
+		next := time.Until(deadline)
+		if next > interval {
+			next = interval
+		}
+		timer.Reset(next)
+	}
+
+	return result, nil
+}
+
+// queryMatches fetches the file and returns the nodes that match the watched
+// query, with the file's last modified time. Pagination is ignored so every
+// match is compared. The version-keyed node cache is bypassed: edits change
+// lastModified without creating a version, so it could return nodes from
+// before the edit.
+func (r *Registry) queryMatches(ctx context.Context, args *WatchQueryArgs) ([]*figma.Node, string, error) {
+	file, err := r.Client().GetFile(ctx, args.FileKey, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching file: %w", err)
+	}
+	matches, err := filterNodes(flattenNodes(file.Document), &args.Q, args.IncludeHidden)
+	if err != nil {
+		return nil, "", err
+	}
+	return matches, file.LastModified, nil
+}
+
+// diffQueryMatches compares two runs of a query. It returns nil if the
+// matches are the same.
+func diffQueryMatches(previous, current []*figma.Node) *DiffResult {
+	result := compareNodes(nodesByID(previous), nodesByID(current), []string{"structure", "properties"}, 0)
+	if result.Counts == (DiffCounts{}) {
+		return nil
+	}
+	result.Summary = formatDiffCounts(result.Counts)
+	return result
+}
+
+func reportWatchProgress(ctx context.Context, req *mcp.CallToolRequest, result *WatchQueryResult) {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return
+	}
+	req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: token,
+		Message:       fmt.Sprintf("Poll %d: %d matching nodes, last modified %s", result.Polls, result.Matched, result.LastModified),
+		Progress:      float64(result.Polls),
+	})
+}
+
+func formatWatchQueryResult(r *WatchQueryResult) string {
+	var sb strings.Builder
+
+	if !r.Changed {
+		sb.WriteString(fmt.Sprintf("No changes to the %d matching nodes after %d polls (last modified %s)\n", r.Matched, r.Polls, r.LastModified))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Matching nodes changed after %d polls (last modified %s, %d now match)\n\n", r.Polls, r.LastModified, r.Matched))
+	sb.WriteString(formatDiffResult(r.Diff))
+
+	return sb.String()
+}
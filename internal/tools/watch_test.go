@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestDiffQueryMatches(t *testing.T) {
+	q := &Query{From: StringList{"COMPONENT"}, Where: map[string]any{"name": map[string]any{"$match": "Button/*"}}}
+	before := []*figma.Node{
+		{ID: "1:1", Name: "Button/Primary", Type: figma.NodeTypeComponent},
+		{ID: "1:2", Name: "Button/Secondary", Type: figma.NodeTypeComponent},
+		{ID: "1:3", Name: "Card", Type: figma.NodeTypeComponent},
+	}
+	previous, err := filterNodes(before, q, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(previous) != 2 {
+		t.Fatalf("query matched %d nodes, want 2", len(previous))
+	}
+
+	// An edit outside the query does not count as a change.
+	unrelated := []*figma.Node{before[0], before[1], {ID: "1:3", Name: "Card v2", Type: figma.NodeTypeComponent}}
+	current, _ := filterNodes(unrelated, q, false)
+	if diff := diffQueryMatches(previous, current); diff != nil {
+		t.Errorf("expected no change, got %s", diff.Summary)
+	}
+
+	edited := []*figma.Node{
+		{ID: "1:1", Name: "Button/Primary", Type: figma.NodeTypeComponent, Fills: []figma.Paint{{Type: "SOLID"}}},
+		{ID: "1:4", Name: "Button/Ghost", Type: figma.NodeTypeComponent},
+		before[2],
+	}
+	current, _ = filterNodes(edited, q, false)
+	diff := diffQueryMatches(previous, current)
+	if diff == nil {
+		t.Fatal("expected a change")
+	}
+	if diff.Counts != (DiffCounts{Added: 1, Removed: 1, Modified: 1}) {
+		t.Errorf("counts = %+v", diff.Counts)
+	}
+
+	text := formatWatchQueryResult(&WatchQueryResult{Changed: true, Polls: 3, Matched: 2, Diff: diff})
+	if !strings.Contains(text, "1 added, 1 removed, 1 modified") || !strings.Contains(text, "Button/Ghost") {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+}
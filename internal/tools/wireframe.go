@@ -3,7 +3,12 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -12,16 +17,18 @@ import (
 
 // WireframeArgs contains arguments for the wireframe tool.
 type WireframeArgs struct {
-	FileKey      string   `json:"file_key" jsonschema:"Figma file key"`
-	NodeID       string   `json:"node_id" jsonschema:"Node to render"`
-	Style        string   `json:"style,omitempty" jsonschema:"Output format: ascii (default), svg, or png"`
-	Annotations  []string `json:"annotations,omitempty" jsonschema:"What to annotate: ids names dimensions spacing"`
-	Depth        int      `json:"depth,omitempty" jsonschema:"How deep to render children (default: 2)"`
-	MaxChildren  int      `json:"max_children,omitempty" jsonschema:"Max children per node (default: 20, max: 50)"`
-	MaxLegend    int      `json:"max_legend,omitempty" jsonschema:"Max legend entries (default: 50)"`
-	OutputPath   string   `json:"output_path,omitempty" jsonschema:"Save to file (for svg/png)"`
-	OutputFile   string   `json:"output_file,omitempty" jsonschema:"Write full text output to file path"`
-	Format       string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+	FileKey       string   `json:"file_key" jsonschema:"Figma file key"`
+	NodeID        string   `json:"node_id,omitempty" jsonschema:"Node to render"`
+	NodeName      string   `json:"node_name,omitempty" jsonschema:"Name of the node to render, used when node_id is not known. Must match a single node"`
+	Style         string   `json:"style,omitempty" jsonschema:"Output format: ascii (default), svg, or png"`
+	Annotations   []string `json:"annotations,omitempty" jsonschema:"What to annotate: ids names dimensions spacing text"`
+	Depth         int      `json:"depth,omitempty" jsonschema:"How deep to render children (default: 2)"`
+	MaxChildren   int      `json:"max_children,omitempty" jsonschema:"Max children per node (default: 20, max: 50)"`
+	MaxLegend     int      `json:"max_legend,omitempty" jsonschema:"Max legend entries (default: 50)"`
+	TerminalWidth int      `json:"terminal_width,omitempty" jsonschema:"Terminal columns to fill with the ascii canvas (default: $COLUMNS, else 60 columns)"`
+	OutputPath    string   `json:"output_path,omitempty" jsonschema:"Save to file (for svg/png)"`
+	OutputFile    string   `json:"output_file,omitempty" jsonschema:"Write full text output to file path"`
+	Format        string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
 }
 
 // WireframeResult contains the result of wireframe rendering.
@@ -32,6 +39,7 @@ type WireframeResult struct {
 	TotalNodes    int               `json:"total_nodes"`
 	RenderedNodes int               `json:"rendered_nodes"`
 	Truncated     bool              `json:"truncated"`
+	CodeFiles     map[string]string `json:"code_files,omitempty"` // node ID → implementing file, from component_map
 	FilePath      string            `json:"file_path,omitempty"`
 }
 
@@ -41,17 +49,82 @@ type Bounds struct {
 	Height float64 `json:"height"`
 }
 
+// nodeNameCandidates is how many name matches findNodeIDByName considers.
+const nodeNameCandidates = 5
+
+// findNodeIDByName searches node names for name and returns the ID of the
+// single match. A name that matches several nodes is an error listing them,
+// unless exactly one of them has that name in full.
+func findNodeIDByName(ctx context.Context, r *Registry, fileKey, name string) (string, error) {
+	re, err := buildSearchRegex(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid node_name: %w", err)
+	}
+	scope := []string{"names"}
+	found, err := searchFile(ctx, r, SearchArgs{FileKey: fileKey, Pattern: name, Scope: scope}, re, scope, nodeNameCandidates)
+	if err != nil {
+		return "", err
+	}
+
+	matches := found.Results
+	if len(matches) == 1 && !found.Fuzzy {
+		return matches[0].NodeID, nil
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no node named %q. Find node IDs with search, query or get_tree", name)
+	}
+
+	if !found.Fuzzy {
+		var exact []SearchMatch
+		for _, m := range matches {
+			if strings.EqualFold(m.Name, name) {
+				exact = append(exact, m)
+			}
+		}
+		if len(exact) == 1 {
+			return exact[0].NodeID, nil
+		}
+	}
+
+	var sb strings.Builder
+	if found.Fuzzy {
+		sb.WriteString(fmt.Sprintf("no node named %q. Similar nodes, pass one as node_id:", name))
+	} else {
+		sb.WriteString(fmt.Sprintf("node_name %q matches several nodes. Pass one as node_id:", name))
+	}
+	for _, m := range matches {
+		sb.WriteString(fmt.Sprintf("\n  %s  %s (%s)", m.NodeID, m.Name, m.Type))
+	}
+	if found.HasMore {
+		sb.WriteString("\n  ... more matches; use a more specific name")
+	}
+	return "", errors.New(sb.String())
+}
+
 func registerWireframeTool(server *mcp.Server, r *Registry) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "wireframe",
-		Description: "Generate annotated wireframe with node IDs for visual reference.",
+		Description: "Generate annotated wireframe with node IDs for visual reference. Select the node by node_id, or by node_name when the ID is not known.",
+		InputSchema: inputSchema[WireframeArgs](map[string][]string{
+			"style":       {"ascii", "svg", "png"},
+			"annotations": {"ids", "names", "dimensions", "spacing", "text"},
+			"format":      responseFormats,
+		}),
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args WireframeArgs) (*mcp.CallToolResult, *WireframeResult, error) {
 		if args.FileKey == "" {
-			return nil, nil, fmt.Errorf("file_key is required")
+			return nil, nil, errFileKeyRequired
+		}
+		if args.NodeID == "" && args.NodeName != "" {
+			id, err := findNodeIDByName(ctx, r, args.FileKey, args.NodeName)
+			if err != nil {
+				return nil, nil, err
+			}
+			args.NodeID = id
 		}
 		if args.NodeID == "" {
-			return nil, nil, fmt.Errorf("node_id is required")
+			return nil, nil, errNodeIDRequired
 		}
+		args.NodeID = r.ResolveNodeID(args.NodeID)
 
 		// Set defaults
 		style := args.Style
@@ -79,7 +152,7 @@ func registerWireframeTool(server *mcp.Server, r *Registry) {
 		}
 
 		if !r.HasClient() {
-			return nil, nil, fmt.Errorf("Figma API not configured")
+			return nil, nil, errNoClient
 		}
 
 		// Fetch node
@@ -105,6 +178,7 @@ func registerWireframeTool(server *mcp.Server, r *Registry) {
 			totalNodes:    0,
 			truncated:     false,
 		}
+		renderCtx.canvasWidth, renderCtx.canvasHeight = terminalCanvas(args.TerminalWidth, os.Getenv("COLUMNS"), os.Getenv("LINES"))
 
 		result := &WireframeResult{
 			Legend: make(map[string]string),
@@ -130,6 +204,11 @@ func registerWireframeTool(server *mcp.Server, r *Registry) {
 		result.TotalNodes = renderCtx.totalNodes
 		result.RenderedNodes = renderCtx.renderedNodes
 		result.Truncated = renderCtx.truncated
+		if mapping := r.componentMapping(); mapping != nil {
+			if files := codeFiles(node, wrapper.Components, mapping, depth); len(files) > 0 {
+				result.CodeFiles = files
+			}
+		}
 
 		// Format output
 		var textOutput string
@@ -153,6 +232,18 @@ func registerWireframeTool(server *mcp.Server, r *Registry) {
 				}
 			}
 
+			if len(result.CodeFiles) > 0 {
+				ids := make([]string, 0, len(result.CodeFiles))
+				for id := range result.CodeFiles {
+					ids = append(ids, id)
+				}
+				sort.Strings(ids)
+				textOutput += "\n\nImplemented components:\n"
+				for _, id := range ids {
+					textOutput += fmt.Sprintf("  [%s] %s\n", id, result.CodeFiles[id])
+				}
+			}
+
 			if renderCtx.truncated {
 				textOutput += fmt.Sprintf("\n[Rendered %d of %d nodes - use smaller node_id or reduce depth]\n", renderCtx.renderedNodes, renderCtx.totalNodes)
 			}
@@ -184,6 +275,223 @@ func registerWireframeTool(server *mcp.Server, r *Registry) {
 	})
 }
 
+// RenderAllPagesArgs contains arguments for the render_all_pages tool.
+type RenderAllPagesArgs struct {
+	FileKey     string   `json:"file_key" jsonschema:"Figma file key"`
+	Annotations []string `json:"annotations,omitempty" jsonschema:"What to annotate: ids names dimensions spacing text"`
+	Depth       int      `json:"depth,omitempty" jsonschema:"How deep to render children of each root frame (default: 2)"`
+	MaxChildren int      `json:"max_children,omitempty" jsonschema:"Max children per node, including root frames per page (default: 20, max: 50)"`
+	OutputDir   string   `json:"output_dir,omitempty" jsonschema:"Write each page's wireframe to <output_dir>/<page>.txt"`
+	Format      string   `json:"format,omitempty" jsonschema:"Response format: text (default) or json"`
+}
+
+// RenderAllPagesResult contains wireframes for every page in a file.
+type RenderAllPagesResult struct {
+	Pages         []PageWireframe `json:"pages"`
+	TotalNodes    int             `json:"total_nodes"`
+	RenderedNodes int             `json:"rendered_nodes"`
+	Truncated     bool            `json:"truncated"`
+	FilePath      string          `json:"file_path,omitempty"`
+}
+
+// PageWireframe is the rendered wireframe for a single page.
+type PageWireframe struct {
+	PageID    string            `json:"page_id"`
+	PageName  string            `json:"page_name"`
+	Frames    int               `json:"frames"`
+	Wireframe string            `json:"wireframe"`
+	Legend    map[string]string `json:"legend,omitempty"`
+	FilePath  string            `json:"file_path,omitempty"`
+}
+
+func registerRenderAllPagesTool(server *mcp.Server, r *Registry) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "render_all_pages",
+		Description: "Render ASCII wireframes for the root frames of every page in a file.",
+		InputSchema: inputSchema[RenderAllPagesArgs](map[string][]string{
+			"annotations": {"ids", "names", "dimensions", "spacing", "text"},
+			"format":      responseFormats,
+		}),
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args RenderAllPagesArgs) (*mcp.CallToolResult, *RenderAllPagesResult, error) {
+		if args.FileKey == "" {
+			return nil, nil, errFileKeyRequired
+		}
+
+		// Set defaults
+		depth := args.Depth
+		if depth == 0 {
+			depth = 2
+		}
+		maxChildren := args.MaxChildren
+		if maxChildren == 0 {
+			maxChildren = 20
+		}
+		if maxChildren > 50 {
+			maxChildren = 50
+		}
+		annotations := args.Annotations
+		if len(annotations) == 0 {
+			annotations = []string{"ids", "names"}
+		}
+
+		if !r.HasClient() {
+			return nil, nil, errNoClient
+		}
+
+		// List pages without their contents
+		file, err := r.Client().GetFile(ctx, args.FileKey, &figma.GetFileOptions{Depth: 1})
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching file: %w", err)
+		}
+		if file.Document == nil {
+			return nil, nil, fmt.Errorf("file %s has no document", args.FileKey)
+		}
+
+		var pageIDs []string
+		for _, page := range file.Document.Children {
+			if page.Type == figma.NodeTypeCanvas {
+				pageIDs = append(pageIDs, page.ID)
+			}
+		}
+		if len(pageIDs) == 0 {
+			return nil, nil, fmt.Errorf("file %s has no pages", args.FileKey)
+		}
+
+		// Fetch pages with root frames plus the requested render depth
+		nodes, err := r.Client().GetFileNodes(ctx, args.FileKey, pageIDs, &figma.GetFileOptions{
+			Depth: depth + 1,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching pages: %w", err)
+		}
+
+		if args.OutputDir != "" {
+			if err := os.MkdirAll(args.OutputDir, 0755); err != nil {
+				return nil, nil, fmt.Errorf("creating output dir: %w", err)
+			}
+		}
+
+		renderCtx := &wireframeRenderContext{
+			maxChildren: maxChildren,
+			maxLegend:   50,
+		}
+		result := &RenderAllPagesResult{}
+
+		for _, pageID := range pageIDs {
+			wrapper, ok := nodes.Nodes[pageID]
+			if !ok || wrapper.Document == nil {
+				continue
+			}
+			page := wrapper.Document
+
+			pw := PageWireframe{
+				PageID:   page.ID,
+				PageName: page.Name,
+				Legend:   make(map[string]string),
+			}
+
+			var sb strings.Builder
+			for i, frame := range page.Children {
+				if i >= maxChildren {
+					renderCtx.truncated = true
+					sb.WriteString(fmt.Sprintf("... and %d more root frames\n", len(page.Children)-i))
+					break
+				}
+				if i > 0 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(renderASCIIWireframeLimited(frame, annotations, depth, pw.Legend, renderCtx))
+				pw.Frames++
+			}
+			if pw.Frames == 0 {
+				sb.WriteString("(empty page)\n")
+			}
+			pw.Wireframe = sb.String()
+
+			if args.OutputDir != "" {
+				path := filepath.Join(args.OutputDir, sanitizeName(page.Name)+"-"+sanitizeID(page.ID)+".txt")
+				if err := os.WriteFile(path, []byte(formatPageWireframe(pw, annotations)), 0644); err != nil {
+					return nil, nil, fmt.Errorf("writing %s: %w", path, err)
+				}
+				pw.FilePath = path
+			}
+
+			result.Pages = append(result.Pages, pw)
+		}
+
+		result.TotalNodes = renderCtx.totalNodes
+		result.RenderedNodes = renderCtx.renderedNodes
+		result.Truncated = renderCtx.truncated
+
+		// Format output
+		var textOutput string
+		if args.Format == "json" {
+			b, _ := json.MarshalIndent(result, "", "  ")
+			textOutput = string(b)
+		} else {
+			textOutput = formatRenderAllPagesResult(result, annotations)
+		}
+
+		outputCfg := OutputConfig{
+			MaxOutputSize: DefaultMaxOutputSize,
+			OutputDir:     r.ExportDir(),
+			ToolName:      "render_all_pages",
+			FileKey:       args.FileKey,
+		}
+
+		outputResult, err := ProcessOutput(textOutput, result, outputCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("processing output: %w", err)
+		}
+
+		if outputResult.WasWrittenToFile {
+			result.FilePath = outputResult.FilePath
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: outputResult.Text},
+			},
+		}, result, nil
+	})
+}
+
+func formatPageWireframe(pw PageWireframe, annotations []string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("=== Page: %s [%s] ===\n\n", pw.PageName, pw.PageID))
+	sb.WriteString(pw.Wireframe)
+
+	if containsStr(annotations, "ids") && len(pw.Legend) > 0 {
+		sb.WriteString("\nLegend:\n")
+		for id, name := range pw.Legend {
+			sb.WriteString(fmt.Sprintf("  [%s] %s\n", id, name))
+		}
+	}
+
+	return sb.String()
+}
+
+func formatRenderAllPagesResult(r *RenderAllPagesResult, annotations []string) string {
+	var sb strings.Builder
+
+	for i, pw := range r.Pages {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(formatPageWireframe(pw, annotations))
+		if pw.FilePath != "" {
+			sb.WriteString(fmt.Sprintf("Saved to: %s\n", pw.FilePath))
+		}
+	}
+
+	if r.Truncated {
+		sb.WriteString(fmt.Sprintf("\n[Rendered %d of %d nodes - reduce depth or raise max_children]\n", r.RenderedNodes, r.TotalNodes))
+	}
+
+	return sb.String()
+}
+
 // wireframeRenderContext tracks state during wireframe rendering.
 type wireframeRenderContext struct {
 	maxChildren   int
@@ -191,6 +499,26 @@ type wireframeRenderContext struct {
 	renderedNodes int
 	totalNodes    int
 	truncated     bool
+
+	// ASCII canvas size in characters; zero means the 60x30 default.
+	canvasWidth  int
+	canvasHeight int
+}
+
+// terminalCanvas sizes the ASCII canvas from the terminal: width minus 4 for
+// the border and padding, height minus 10 for the header and legend. Unknown
+// dimensions are returned as zero.
+func terminalCanvas(terminalWidth int, columns, lines string) (width, height int) {
+	if terminalWidth <= 0 {
+		terminalWidth, _ = strconv.Atoi(columns)
+	}
+	if terminalWidth > 0 {
+		width = max(terminalWidth-4, 20)
+	}
+	if rows, _ := strconv.Atoi(lines); rows > 0 {
+		height = max(rows-10, 10)
+	}
+	return width, height
 }
 
 func containsStr(slice []string, item string) bool {
@@ -208,11 +536,18 @@ func renderASCIIWireframeLimited(node *figma.Node, annotations []string, maxDept
 	ctx.totalNodes++
 	ctx.renderedNodes++
 
-	// Calculate scale factor to fit in reasonable terminal width
-	width := 60.0
+	// Calculate scale factor to fit in the terminal
+	canvasWidth, canvasHeight := 60.0, 30.0
+	if ctx.canvasWidth > 0 {
+		canvasWidth = float64(ctx.canvasWidth)
+	}
+	if ctx.canvasHeight > 0 {
+		canvasHeight = float64(ctx.canvasHeight)
+	}
+	width := canvasWidth
 	if node.AbsoluteBoundingBox != nil {
-		scaleX := 60.0 / node.AbsoluteBoundingBox.Width
-		scaleY := 30.0 / node.AbsoluteBoundingBox.Height
+		scaleX := canvasWidth / node.AbsoluteBoundingBox.Width
+		scaleY := canvasHeight / node.AbsoluteBoundingBox.Height
 		scale := scaleX
 		if scaleY < scaleX {
 			scale = scaleY
@@ -225,6 +560,7 @@ func renderASCIIWireframeLimited(node *figma.Node, annotations []string, maxDept
 	showDimensions := containsStr(annotations, "dimensions")
 	showNames := containsStr(annotations, "names")
 	showIDs := containsStr(annotations, "ids")
+	showText := containsStr(annotations, "text")
 
 	headerParts := []string{node.Name}
 	if showIDs {
@@ -237,33 +573,42 @@ func renderASCIIWireframeLimited(node *figma.Node, annotations []string, maxDept
 	sb.WriteString(strings.Join(headerParts, " "))
 	sb.WriteString("\n")
 
+	box := boxCharsFor(node.Type)
+
 	// Top border
-	sb.WriteString("┌")
-	sb.WriteString(strings.Repeat("─", int(width)))
-	sb.WriteString("┐\n")
+	sb.WriteString(box.topLeft)
+	sb.WriteString(strings.Repeat(box.horizontal, int(width)))
+	sb.WriteString(box.topRight + "\n")
 
 	// Render children as boxes within
-	childLines := renderChildrenASCIILimited(node, showIDs, showNames, showDimensions, 0, maxDepth, legend, int(width)-2, ctx)
+	childLines := renderChildrenASCIILimited(node, showIDs, showNames, showDimensions, showText, 0, maxDepth, legend, int(width)-2, ctx)
+
+	// Keep tall nodes within the terminal height
+	if ctx.canvasHeight > 0 && len(childLines) > ctx.canvasHeight {
+		hidden := len(childLines) - ctx.canvasHeight + 1
+		childLines = append(childLines[:ctx.canvasHeight-1], fmt.Sprintf("... %d more lines", hidden))
+		ctx.truncated = true
+	}
 
 	for _, line := range childLines {
-		sb.WriteString("│ ")
+		sb.WriteString(box.vertical + " ")
 		sb.WriteString(line)
 		padding := int(width) - 2 - len(line)
 		if padding > 0 {
 			sb.WriteString(strings.Repeat(" ", padding))
 		}
-		sb.WriteString(" │\n")
+		sb.WriteString(" " + box.vertical + "\n")
 	}
 
 	// Bottom border
-	sb.WriteString("└")
-	sb.WriteString(strings.Repeat("─", int(width)))
-	sb.WriteString("┘\n")
+	sb.WriteString(box.bottomLeft)
+	sb.WriteString(strings.Repeat(box.horizontal, int(width)))
+	sb.WriteString(box.bottomRight + "\n")
 
 	return sb.String()
 }
 
-func renderChildrenASCIILimited(node *figma.Node, showIDs, showNames, showDimensions bool, depth, maxDepth int, legend map[string]string, maxWidth int, ctx *wireframeRenderContext) []string {
+func renderChildrenASCIILimited(node *figma.Node, showIDs, showNames, showDimensions, showText bool, depth, maxDepth int, legend map[string]string, maxWidth int, ctx *wireframeRenderContext) []string {
 	var lines []string
 
 	if depth >= maxDepth || len(node.Children) == 0 {
@@ -308,7 +653,7 @@ func renderChildrenASCIILimited(node *figma.Node, showIDs, showNames, showDimens
 		label := strings.Join(parts, " ")
 
 		// Determine box style based on node type
-		boxStyle := "─"
+		box := boxCharsFor(child.Type)
 		if child.Type == figma.NodeTypeText {
 			// Text node - just show content
 			text := child.Characters
@@ -328,7 +673,7 @@ func renderChildrenASCIILimited(node *figma.Node, showIDs, showNames, showDimens
 		indent := strings.Repeat("  ", depth)
 
 		// Top of child box
-		lines = append(lines, indent+"┌"+strings.Repeat(boxStyle, boxWidth-2)+"┐")
+		lines = append(lines, indent+box.topLeft+strings.Repeat(box.horizontal, boxWidth-2)+box.topRight)
 
 		// Label line
 		labelLine := " " + label
@@ -336,25 +681,70 @@ func renderChildrenASCIILimited(node *figma.Node, showIDs, showNames, showDimens
 			labelLine = labelLine[:boxWidth-5] + "..."
 		}
 		labelLine += strings.Repeat(" ", boxWidth-2-len(labelLine))
-		lines = append(lines, indent+"│"+labelLine+"│")
+		lines = append(lines, indent+box.vertical+labelLine+box.vertical)
+
+		// Text content line (e.g. a button's label)
+		if showText {
+			if text := firstTextLine(child); text != "" {
+				textLine := fmt.Sprintf(" \"%s\"", text)
+				if len(textLine) > boxWidth-2 {
+					textLine = textLine[:boxWidth-6] + "...\""
+				}
+				textLine += strings.Repeat(" ", boxWidth-2-len(textLine))
+				lines = append(lines, indent+box.vertical+textLine+box.vertical)
+			}
+		}
 
 		// Nested children
 		if depth+1 < maxDepth && len(child.Children) > 0 {
-			childContent := renderChildrenASCIILimited(child, showIDs, showNames, showDimensions, depth+1, maxDepth, legend, boxWidth-4, ctx)
+			childContent := renderChildrenASCIILimited(child, showIDs, showNames, showDimensions, showText, depth+1, maxDepth, legend, boxWidth-4, ctx)
 			for _, cl := range childContent {
-				lines = append(lines, indent+"│ "+cl+strings.Repeat(" ", boxWidth-4-len(cl))+" │")
+				lines = append(lines, indent+box.vertical+" "+cl+strings.Repeat(" ", boxWidth-4-len(cl))+" "+box.vertical)
 			}
 		} else if len(child.Children) > 0 {
-			lines = append(lines, indent+"│ "+fmt.Sprintf("... %d children", len(child.Children))+strings.Repeat(" ", boxWidth-16)+"│")
+			lines = append(lines, indent+box.vertical+" "+fmt.Sprintf("... %d children", len(child.Children))+strings.Repeat(" ", boxWidth-16)+box.vertical)
 		}
 
 		// Bottom of child box
-		lines = append(lines, indent+"└"+strings.Repeat(boxStyle, boxWidth-2)+"┘")
+		lines = append(lines, indent+box.bottomLeft+strings.Repeat(box.horizontal, boxWidth-2)+box.bottomRight)
 	}
 
 	return lines
 }
 
+// boxChars are the characters used to draw an ASCII box.
+type boxChars struct {
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical string
+}
+
+var (
+	singleBox = boxChars{"┌", "┐", "└", "┘", "─", "│"}
+	doubleBox = boxChars{"╔", "╗", "╚", "╝", "═", "║"}
+)
+
+// boxCharsFor draws sections with double lines so they stand apart from frames.
+func boxCharsFor(t figma.NodeType) boxChars {
+	if t == figma.NodeTypeSection {
+		return doubleBox
+	}
+	return singleBox
+}
+
+// firstTextLine returns the first line of text shown inside node: its own
+// characters, or those of its first descendant with text content.
+func firstTextLine(node *figma.Node) string {
+	if node.Characters != "" {
+		line, _, _ := strings.Cut(strings.TrimSpace(node.Characters), "\n")
+		return strings.TrimSpace(line)
+	}
+	for _, child := range node.Children {
+		if text := firstTextLine(child); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
 func renderSVGWireframeLimited(node *figma.Node, annotations []string, maxDepth int, legend map[string]string, ctx *wireframeRenderContext) string {
 	width := 800.0
 	height := 600.0
@@ -371,11 +761,16 @@ func renderSVGWireframeLimited(node *figma.Node, annotations []string, maxDepth
 	sb.WriteString("\n<style>")
 	sb.WriteString(".frame { fill: none; stroke: #333; stroke-width: 1; }")
 	sb.WriteString(".text { fill: none; stroke: #666; stroke-width: 1; stroke-dasharray: 4; }")
+	sb.WriteString(".section { fill: none; stroke: #999; stroke-width: 2; stroke-dasharray: 8 4; }")
 	sb.WriteString(".label { font-family: monospace; font-size: 10px; fill: #666; }")
 	sb.WriteString("</style>\n")
 
 	// Root frame
-	sb.WriteString(fmt.Sprintf(`<rect class="frame" x="0" y="0" width="%.0f" height="%.0f"/>`, width, height))
+	rootClass := "frame"
+	if node.Type == figma.NodeTypeSection {
+		rootClass = "section"
+	}
+	sb.WriteString(fmt.Sprintf(`<rect class="%s" x="0" y="0" width="%.0f" height="%.0f"/>`, rootClass, width, height))
 	sb.WriteString("\n")
 
 	// Render children
@@ -420,8 +815,11 @@ func renderChildrenSVGLimited(sb *strings.Builder, node *figma.Node, annotations
 		h := child.AbsoluteBoundingBox.Height
 
 		class := "frame"
-		if child.Type == figma.NodeTypeText {
+		switch child.Type {
+		case figma.NodeTypeText:
 			class = "text"
+		case figma.NodeTypeSection:
+			class = "section"
 		}
 
 		sb.WriteString(fmt.Sprintf(`<rect class="%s" x="%.0f" y="%.0f" width="%.0f" height="%.0f"/>`, class, x, y, w, h))
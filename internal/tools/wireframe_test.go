@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/standardbeagle/figma-query/internal/figma"
+)
+
+func TestRenderASCIIWireframeTextAnnotation(t *testing.T) {
+	frame := &figma.Node{
+		ID:   "1:1",
+		Name: "Card",
+		Type: figma.NodeTypeFrame,
+		Children: []*figma.Node{
+			{
+				ID:   "1:2",
+				Name: "Primary Button",
+				Type: figma.NodeTypeInstance,
+				Children: []*figma.Node{
+					{ID: "1:3", Name: "Label", Type: figma.NodeTypeText, Characters: "Sign up\nfree"},
+				},
+			},
+		},
+	}
+
+	newCtx := func() *wireframeRenderContext {
+		return &wireframeRenderContext{maxChildren: 20, maxLegend: 50}
+	}
+
+	without := renderASCIIWireframeLimited(frame, []string{"names"}, 1, map[string]string{}, newCtx())
+	if strings.Contains(without, `"Sign up"`) {
+		t.Errorf("text should not be shown without the text annotation:\n%s", without)
+	}
+
+	with := renderASCIIWireframeLimited(frame, []string{"names", "text"}, 1, map[string]string{}, newCtx())
+	if !strings.Contains(with, `"Sign up"`) {
+		t.Errorf("expected button label inside the box:\n%s", with)
+	}
+	if strings.Contains(with, "free") {
+		t.Errorf("expected only the first line of text:\n%s", with)
+	}
+}
+
+func TestTerminalCanvas(t *testing.T) {
+	tests := []struct {
+		arg           int
+		columns       string
+		lines         string
+		width, height int
+	}{
+		{0, "", "", 0, 0},
+		{0, "120", "50", 116, 40},
+		{100, "120", "", 96, 0},
+		{0, "abc", "12", 0, 10},
+	}
+	for _, tt := range tests {
+		w, h := terminalCanvas(tt.arg, tt.columns, tt.lines)
+		if w != tt.width || h != tt.height {
+			t.Errorf("terminalCanvas(%d, %q, %q) = %d, %d; want %d, %d", tt.arg, tt.columns, tt.lines, w, h, tt.width, tt.height)
+		}
+	}
+}
+
+func TestRenderASCIIWireframeTerminalSize(t *testing.T) {
+	frame := &figma.Node{
+		ID:                  "1:1",
+		Name:                "Page",
+		Type:                figma.NodeTypeFrame,
+		AbsoluteBoundingBox: &figma.Rectangle{Width: 1000, Height: 50},
+	}
+	for i := 0; i < 30; i++ {
+		frame.Children = append(frame.Children, &figma.Node{ID: "2:1", Name: "Row", Type: figma.NodeTypeFrame})
+	}
+
+	ctx := &wireframeRenderContext{maxChildren: 50, maxLegend: 50, canvasWidth: 116, canvasHeight: 10}
+	out := renderASCIIWireframeLimited(frame, []string{"names"}, 1, map[string]string{}, ctx)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+
+	if got := len([]rune(lines[1])); got != 118 {
+		t.Errorf("border width = %d, want 118:\n%s", got, out)
+	}
+	if len(lines) != 13 {
+		t.Errorf("expected header, borders and 10 body lines, got %d:\n%s", len(lines), out)
+	}
+	if !ctx.truncated || !strings.Contains(out, "... 81 more lines") {
+		t.Errorf("expected truncation marker:\n%s", out)
+	}
+}
+
+func TestWireframeSectionStyle(t *testing.T) {
+	section := &figma.Node{
+		ID:                  "1:1",
+		Name:                "Onboarding",
+		Type:                figma.NodeTypeSection,
+		AbsoluteBoundingBox: &figma.Rectangle{Width: 400, Height: 200},
+		Children: []*figma.Node{
+			{ID: "1:2", Name: "Welcome", Type: figma.NodeTypeFrame, AbsoluteBoundingBox: &figma.Rectangle{Width: 100, Height: 100}},
+			{ID: "1:3", Name: "Nested", Type: figma.NodeTypeSection, AbsoluteBoundingBox: &figma.Rectangle{X: 200, Width: 100, Height: 100}},
+		},
+	}
+
+	ascii := renderASCIIWireframeLimited(section, []string{"names"}, 1, map[string]string{}, &wireframeRenderContext{maxChildren: 20, maxLegend: 50})
+	if strings.Count(ascii, "╔") != 2 || strings.Count(ascii, "┌") != 1 {
+		t.Errorf("expected double borders for the two sections and a single border for the frame:\n%s", ascii)
+	}
+
+	svg := renderSVGWireframeLimited(section, []string{"names"}, 1, map[string]string{}, &wireframeRenderContext{maxChildren: 20, maxLegend: 50})
+	if strings.Count(svg, `class="section"`) != 2 || !strings.Contains(svg, "stroke-dasharray: 8 4") {
+		t.Errorf("expected dashed section outlines:\n%s", svg)
+	}
+}
+
+func TestFindNodeIDByName(t *testing.T) {
+	exportDir := t.TempDir()
+	page := &figma.Node{ID: "0:1", Name: "Screens", Type: figma.NodeTypeCanvas, Children: []*figma.Node{
+		{ID: "1:1", Name: "Checkout", Type: figma.NodeTypeFrame},
+		{ID: "1:2", Name: "Checkout Header", Type: figma.NodeTypeFrame},
+		{ID: "2:1", Name: "Card", Type: figma.NodeTypeFrame},
+		{ID: "2:2", Name: "Card", Type: figma.NodeTypeFrame},
+		{ID: "3:1", Name: "Login Screen", Type: figma.NodeTypeFrame},
+	}}
+	writeTestExport(t, filepath.Join(exportDir, "app"), "2026-01-01T00:00:00Z", page)
+	r := NewRegistry(nil, exportDir)
+	ctx := context.Background()
+
+	for name, want := range map[string]string{
+		"login":    "3:1", // single match
+		"checkout": "1:1", // several matches, one exact
+	} {
+		id, err := findNodeIDByName(ctx, r, "KEY", name)
+		if err != nil || id != want {
+			t.Errorf("findNodeIDByName(%q) = %q, %v, want %q", name, id, err, want)
+		}
+	}
+
+	_, err := findNodeIDByName(ctx, r, "KEY", "card")
+	if err == nil || !strings.Contains(err.Error(), "2:1  Card (FRAME)") || !strings.Contains(err.Error(), "2:2  Card (FRAME)") {
+		t.Errorf("ambiguous name error = %v", err)
+	}
+
+	_, err = findNodeIDByName(ctx, r, "KEY", "Logn Screen")
+	if err == nil || !strings.Contains(err.Error(), "Similar nodes") || !strings.Contains(err.Error(), "3:1") {
+		t.Errorf("fuzzy name error = %v", err)
+	}
+}